@@ -94,6 +94,11 @@ func (b *Builder) Run(ctx context.Context, ui packer.Ui, hook packer.Hook) (pack
 
 	// Build the steps
 	steps = []multistep.Step{
+		&stepForceCleanup{
+			ForceCleanup: b.config.ForceCleanup,
+			Age:          b.config.ForceCleanupAge,
+			RegionId:     b.config.AlicloudRegion,
+		},
 		&stepPreValidate{
 			AlicloudDestImageName: b.config.AlicloudImageName,
 			ForceDelete:           b.config.AlicloudImageForceDelete,
@@ -138,6 +143,7 @@ func (b *Builder) Run(ctx context.Context, ui packer.Ui, hook packer.Hook) (pack
 			InternetMaxBandwidthOut: b.config.InternetMaxBandwidthOut,
 			InstanceName:            b.config.InstanceName,
 			ZoneId:                  b.config.ZoneId,
+			HttpTokens:              b.config.InstanceMetadataHttpTokens,
 		})
 	if b.chooseNetworkType() == InstanceNetworkVpc {
 		steps = append(steps, &stepConfigAlicloudEIP{
@@ -145,8 +151,13 @@ func (b *Builder) Run(ctx context.Context, ui packer.Ui, hook packer.Hook) (pack
 			RegionId:                 b.config.AlicloudRegion,
 			InternetChargeType:       b.config.InternetChargeType,
 			InternetMaxBandwidthOut:  b.config.InternetMaxBandwidthOut,
+			EipBandwidth:             b.config.EipBandwidth,
+			EipISP:                   b.config.EipISP,
 			SSHPrivateIp:             b.config.SSHPrivateIp,
-		})
+		},
+			&stepAssignAlicloudIpv6{
+				EnableIpv6: b.config.EnableIpv6,
+			})
 	} else {
 		steps = append(steps, &stepConfigAlicloudPublicIP{
 			RegionId:     b.config.AlicloudRegion,
@@ -156,6 +167,11 @@ func (b *Builder) Run(ctx context.Context, ui packer.Ui, hook packer.Hook) (pack
 	steps = append(steps,
 		&stepAttachKeyPair{},
 		&stepRunAlicloudInstance{},
+		&stepCheckMetadataOptions{
+			HttpTokens: b.config.InstanceMetadataHttpTokens,
+			Verify:     b.config.InstanceMetadataVerify,
+			RegionId:   b.config.AlicloudRegion,
+		},
 		&communicator.StepConnect{
 			Config: &b.config.RunConfig.Comm,
 			Host: SSHHost(
@@ -194,9 +210,11 @@ func (b *Builder) Run(ctx context.Context, ui packer.Ui, hook packer.Hook) (pack
 			Tags: b.config.AlicloudImageTags,
 		},
 		&stepRegionCopyAlicloudImage{
-			AlicloudImageDestinationRegions: b.config.AlicloudImageDestinationRegions,
-			AlicloudImageDestinationNames:   b.config.AlicloudImageDestinationNames,
-			RegionId:                        b.config.AlicloudRegion,
+			AlicloudImageDestinationRegions:    b.config.AlicloudImageDestinationRegions,
+			AlicloudImageDestinationNames:      b.config.AlicloudImageDestinationNames,
+			AlicloudImageDestinationRegionTags: b.config.AlicloudImageDestinationRegionTags,
+			AlicloudImageTags:                  b.config.AlicloudImageTags,
+			RegionId:                           b.config.AlicloudRegion,
 		},
 		&stepShareAlicloudImage{
 			AlicloudImageShareAccounts:   b.config.AlicloudImageShareAccounts,
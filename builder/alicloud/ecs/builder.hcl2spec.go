@@ -44,104 +44,139 @@ func (*FlatAlicloudDiskDevice) HCL2Spec() map[string]hcldec.Spec {
 	return s
 }
 
+// FlatAlicloudSourceImageFilter is an auto-generated flat version of AlicloudSourceImageFilter.
+// Where the contents of a field with a `mapstructure:,squash` tag are bubbled up.
+type FlatAlicloudSourceImageFilter struct {
+	Filters    map[string]string `mapstructure:"filters" required:"false" cty:"filters"`
+	MostRecent *bool             `mapstructure:"most_recent" required:"false" cty:"most_recent"`
+}
+
+// FlatMapstructure returns a new FlatAlicloudSourceImageFilter.
+// FlatAlicloudSourceImageFilter is an auto-generated flat version of AlicloudSourceImageFilter.
+// Where the contents a fields with a `mapstructure:,squash` tag are bubbled up.
+func (*AlicloudSourceImageFilter) FlatMapstructure() interface{ HCL2Spec() map[string]hcldec.Spec } {
+	return new(FlatAlicloudSourceImageFilter)
+}
+
+// HCL2Spec returns the hcl spec of a AlicloudSourceImageFilter.
+// This spec is used by HCL to read the fields of AlicloudSourceImageFilter.
+// The decoded values from this spec will then be applied to a FlatAlicloudSourceImageFilter.
+func (*FlatAlicloudSourceImageFilter) HCL2Spec() map[string]hcldec.Spec {
+	s := map[string]hcldec.Spec{
+		"filters":     &hcldec.AttrSpec{Name: "filters", Type: cty.Map(cty.String), Required: false},
+		"most_recent": &hcldec.AttrSpec{Name: "most_recent", Type: cty.Bool, Required: false},
+	}
+	return s
+}
+
 // FlatConfig is an auto-generated flat version of Config.
 // Where the contents of a field with a `mapstructure:,squash` tag are bubbled up.
 type FlatConfig struct {
-	PackerBuildName                   *string                     `mapstructure:"packer_build_name" cty:"packer_build_name"`
-	PackerBuilderType                 *string                     `mapstructure:"packer_builder_type" cty:"packer_builder_type"`
-	PackerDebug                       *bool                       `mapstructure:"packer_debug" cty:"packer_debug"`
-	PackerForce                       *bool                       `mapstructure:"packer_force" cty:"packer_force"`
-	PackerOnError                     *string                     `mapstructure:"packer_on_error" cty:"packer_on_error"`
-	PackerUserVars                    map[string]string           `mapstructure:"packer_user_variables" cty:"packer_user_variables"`
-	PackerSensitiveVars               []string                    `mapstructure:"packer_sensitive_variables" cty:"packer_sensitive_variables"`
-	AlicloudAccessKey                 *string                     `mapstructure:"access_key" required:"true" cty:"access_key"`
-	AlicloudSecretKey                 *string                     `mapstructure:"secret_key" required:"true" cty:"secret_key"`
-	AlicloudRegion                    *string                     `mapstructure:"region" required:"true" cty:"region"`
-	AlicloudSkipValidation            *bool                       `mapstructure:"skip_region_validation" required:"false" cty:"skip_region_validation"`
-	AlicloudSkipImageValidation       *bool                       `mapstructure:"skip_image_validation" required:"false" cty:"skip_image_validation"`
-	AlicloudProfile                   *string                     `mapstructure:"profile" required:"false" cty:"profile"`
-	AlicloudSharedCredentialsFile     *string                     `mapstructure:"shared_credentials_file" required:"false" cty:"shared_credentials_file"`
-	SecurityToken                     *string                     `mapstructure:"security_token" required:"false" cty:"security_token"`
-	AlicloudImageName                 *string                     `mapstructure:"image_name" required:"true" cty:"image_name"`
-	AlicloudImageVersion              *string                     `mapstructure:"image_version" required:"false" cty:"image_version"`
-	AlicloudImageDescription          *string                     `mapstructure:"image_description" required:"false" cty:"image_description"`
-	AlicloudImageShareAccounts        []string                    `mapstructure:"image_share_account" required:"false" cty:"image_share_account"`
-	AlicloudImageUNShareAccounts      []string                    `mapstructure:"image_unshare_account" cty:"image_unshare_account"`
-	AlicloudImageDestinationRegions   []string                    `mapstructure:"image_copy_regions" required:"false" cty:"image_copy_regions"`
-	AlicloudImageDestinationNames     []string                    `mapstructure:"image_copy_names" required:"false" cty:"image_copy_names"`
-	ImageEncrypted                    *bool                       `mapstructure:"image_encrypted" required:"false" cty:"image_encrypted"`
-	AlicloudImageForceDelete          *bool                       `mapstructure:"image_force_delete" required:"false" cty:"image_force_delete"`
-	AlicloudImageForceDeleteSnapshots *bool                       `mapstructure:"image_force_delete_snapshots" required:"false" cty:"image_force_delete_snapshots"`
-	AlicloudImageForceDeleteInstances *bool                       `mapstructure:"image_force_delete_instances" cty:"image_force_delete_instances"`
-	AlicloudImageIgnoreDataDisks      *bool                       `mapstructure:"image_ignore_data_disks" required:"false" cty:"image_ignore_data_disks"`
-	AlicloudImageTags                 map[string]string           `mapstructure:"tags" required:"false" cty:"tags"`
-	AlicloudImageTag                  []hcl2template.FlatKeyValue `mapstructure:"tag" required:"false" cty:"tag"`
-	ECSSystemDiskMapping              *FlatAlicloudDiskDevice     `mapstructure:"system_disk_mapping" required:"false" cty:"system_disk_mapping"`
-	ECSImagesDiskMappings             []FlatAlicloudDiskDevice    `mapstructure:"image_disk_mappings" required:"false" cty:"image_disk_mappings"`
-	AssociatePublicIpAddress          *bool                       `mapstructure:"associate_public_ip_address" cty:"associate_public_ip_address"`
-	ZoneId                            *string                     `mapstructure:"zone_id" required:"false" cty:"zone_id"`
-	IOOptimized                       *bool                       `mapstructure:"io_optimized" required:"false" cty:"io_optimized"`
-	InstanceType                      *string                     `mapstructure:"instance_type" required:"true" cty:"instance_type"`
-	Description                       *string                     `mapstructure:"description" cty:"description"`
-	AlicloudSourceImage               *string                     `mapstructure:"source_image" required:"true" cty:"source_image"`
-	ForceStopInstance                 *bool                       `mapstructure:"force_stop_instance" required:"false" cty:"force_stop_instance"`
-	DisableStopInstance               *bool                       `mapstructure:"disable_stop_instance" required:"false" cty:"disable_stop_instance"`
-	SecurityGroupId                   *string                     `mapstructure:"security_group_id" required:"false" cty:"security_group_id"`
-	SecurityGroupName                 *string                     `mapstructure:"security_group_name" required:"false" cty:"security_group_name"`
-	UserData                          *string                     `mapstructure:"user_data" required:"false" cty:"user_data"`
-	UserDataFile                      *string                     `mapstructure:"user_data_file" required:"false" cty:"user_data_file"`
-	VpcId                             *string                     `mapstructure:"vpc_id" required:"false" cty:"vpc_id"`
-	VpcName                           *string                     `mapstructure:"vpc_name" required:"false" cty:"vpc_name"`
-	CidrBlock                         *string                     `mapstructure:"vpc_cidr_block" required:"false" cty:"vpc_cidr_block"`
-	VSwitchId                         *string                     `mapstructure:"vswitch_id" required:"false" cty:"vswitch_id"`
-	VSwitchName                       *string                     `mapstructure:"vswitch_name" required:"false" cty:"vswitch_name"`
-	InstanceName                      *string                     `mapstructure:"instance_name" required:"false" cty:"instance_name"`
-	InternetChargeType                *string                     `mapstructure:"internet_charge_type" required:"false" cty:"internet_charge_type"`
-	InternetMaxBandwidthOut           *int                        `mapstructure:"internet_max_bandwidth_out" required:"false" cty:"internet_max_bandwidth_out"`
-	WaitSnapshotReadyTimeout          *int                        `mapstructure:"wait_snapshot_ready_timeout" required:"false" cty:"wait_snapshot_ready_timeout"`
-	Type                              *string                     `mapstructure:"communicator" cty:"communicator"`
-	PauseBeforeConnect                *string                     `mapstructure:"pause_before_connecting" cty:"pause_before_connecting"`
-	SSHHost                           *string                     `mapstructure:"ssh_host" cty:"ssh_host"`
-	SSHPort                           *int                        `mapstructure:"ssh_port" cty:"ssh_port"`
-	SSHUsername                       *string                     `mapstructure:"ssh_username" cty:"ssh_username"`
-	SSHPassword                       *string                     `mapstructure:"ssh_password" cty:"ssh_password"`
-	SSHKeyPairName                    *string                     `mapstructure:"ssh_keypair_name" cty:"ssh_keypair_name"`
-	SSHTemporaryKeyPairName           *string                     `mapstructure:"temporary_key_pair_name" cty:"temporary_key_pair_name"`
-	SSHClearAuthorizedKeys            *bool                       `mapstructure:"ssh_clear_authorized_keys" cty:"ssh_clear_authorized_keys"`
-	SSHPrivateKeyFile                 *string                     `mapstructure:"ssh_private_key_file" cty:"ssh_private_key_file"`
-	SSHPty                            *bool                       `mapstructure:"ssh_pty" cty:"ssh_pty"`
-	SSHTimeout                        *string                     `mapstructure:"ssh_timeout" cty:"ssh_timeout"`
-	SSHWaitTimeout                    *string                     `mapstructure:"ssh_wait_timeout" undocumented:"true" cty:"ssh_wait_timeout"`
-	SSHAgentAuth                      *bool                       `mapstructure:"ssh_agent_auth" cty:"ssh_agent_auth"`
-	SSHDisableAgentForwarding         *bool                       `mapstructure:"ssh_disable_agent_forwarding" cty:"ssh_disable_agent_forwarding"`
-	SSHHandshakeAttempts              *int                        `mapstructure:"ssh_handshake_attempts" cty:"ssh_handshake_attempts"`
-	SSHBastionHost                    *string                     `mapstructure:"ssh_bastion_host" cty:"ssh_bastion_host"`
-	SSHBastionPort                    *int                        `mapstructure:"ssh_bastion_port" cty:"ssh_bastion_port"`
-	SSHBastionAgentAuth               *bool                       `mapstructure:"ssh_bastion_agent_auth" cty:"ssh_bastion_agent_auth"`
-	SSHBastionUsername                *string                     `mapstructure:"ssh_bastion_username" cty:"ssh_bastion_username"`
-	SSHBastionPassword                *string                     `mapstructure:"ssh_bastion_password" cty:"ssh_bastion_password"`
-	SSHBastionInteractive             *bool                       `mapstructure:"ssh_bastion_interactive" cty:"ssh_bastion_interactive"`
-	SSHBastionPrivateKeyFile          *string                     `mapstructure:"ssh_bastion_private_key_file" cty:"ssh_bastion_private_key_file"`
-	SSHFileTransferMethod             *string                     `mapstructure:"ssh_file_transfer_method" cty:"ssh_file_transfer_method"`
-	SSHProxyHost                      *string                     `mapstructure:"ssh_proxy_host" cty:"ssh_proxy_host"`
-	SSHProxyPort                      *int                        `mapstructure:"ssh_proxy_port" cty:"ssh_proxy_port"`
-	SSHProxyUsername                  *string                     `mapstructure:"ssh_proxy_username" cty:"ssh_proxy_username"`
-	SSHProxyPassword                  *string                     `mapstructure:"ssh_proxy_password" cty:"ssh_proxy_password"`
-	SSHKeepAliveInterval              *string                     `mapstructure:"ssh_keep_alive_interval" cty:"ssh_keep_alive_interval"`
-	SSHReadWriteTimeout               *string                     `mapstructure:"ssh_read_write_timeout" cty:"ssh_read_write_timeout"`
-	SSHRemoteTunnels                  []string                    `mapstructure:"ssh_remote_tunnels" cty:"ssh_remote_tunnels"`
-	SSHLocalTunnels                   []string                    `mapstructure:"ssh_local_tunnels" cty:"ssh_local_tunnels"`
-	SSHPublicKey                      []byte                      `mapstructure:"ssh_public_key" cty:"ssh_public_key"`
-	SSHPrivateKey                     []byte                      `mapstructure:"ssh_private_key" cty:"ssh_private_key"`
-	WinRMUser                         *string                     `mapstructure:"winrm_username" cty:"winrm_username"`
-	WinRMPassword                     *string                     `mapstructure:"winrm_password" cty:"winrm_password"`
-	WinRMHost                         *string                     `mapstructure:"winrm_host" cty:"winrm_host"`
-	WinRMPort                         *int                        `mapstructure:"winrm_port" cty:"winrm_port"`
-	WinRMTimeout                      *string                     `mapstructure:"winrm_timeout" cty:"winrm_timeout"`
-	WinRMUseSSL                       *bool                       `mapstructure:"winrm_use_ssl" cty:"winrm_use_ssl"`
-	WinRMInsecure                     *bool                       `mapstructure:"winrm_insecure" cty:"winrm_insecure"`
-	WinRMUseNTLM                      *bool                       `mapstructure:"winrm_use_ntlm" cty:"winrm_use_ntlm"`
-	SSHPrivateIp                      *bool                       `mapstructure:"ssh_private_ip" required:"false" cty:"ssh_private_ip"`
+	PackerBuildName                    *string                        `mapstructure:"packer_build_name" cty:"packer_build_name"`
+	PackerBuilderType                  *string                        `mapstructure:"packer_builder_type" cty:"packer_builder_type"`
+	PackerDebug                        *bool                          `mapstructure:"packer_debug" cty:"packer_debug"`
+	PackerForce                        *bool                          `mapstructure:"packer_force" cty:"packer_force"`
+	PackerOnError                      *string                        `mapstructure:"packer_on_error" cty:"packer_on_error"`
+	PackerUserVars                     map[string]string              `mapstructure:"packer_user_variables" cty:"packer_user_variables"`
+	PackerSensitiveVars                []string                       `mapstructure:"packer_sensitive_variables" cty:"packer_sensitive_variables"`
+	AlicloudAccessKey                  *string                        `mapstructure:"access_key" required:"true" cty:"access_key"`
+	AlicloudSecretKey                  *string                        `mapstructure:"secret_key" required:"true" cty:"secret_key"`
+	AlicloudRegion                     *string                        `mapstructure:"region" required:"true" cty:"region"`
+	AlicloudSkipValidation             *bool                          `mapstructure:"skip_region_validation" required:"false" cty:"skip_region_validation"`
+	AlicloudSkipImageValidation        *bool                          `mapstructure:"skip_image_validation" required:"false" cty:"skip_image_validation"`
+	AlicloudProfile                    *string                        `mapstructure:"profile" required:"false" cty:"profile"`
+	AlicloudSharedCredentialsFile      *string                        `mapstructure:"shared_credentials_file" required:"false" cty:"shared_credentials_file"`
+	SecurityToken                      *string                        `mapstructure:"security_token" required:"false" cty:"security_token"`
+	AlicloudImageName                  *string                        `mapstructure:"image_name" required:"true" cty:"image_name"`
+	AlicloudImageVersion               *string                        `mapstructure:"image_version" required:"false" cty:"image_version"`
+	AlicloudImageDescription           *string                        `mapstructure:"image_description" required:"false" cty:"image_description"`
+	AlicloudImageShareAccounts         []string                       `mapstructure:"image_share_account" required:"false" cty:"image_share_account"`
+	AlicloudImageUNShareAccounts       []string                       `mapstructure:"image_unshare_account" cty:"image_unshare_account"`
+	AlicloudImageDestinationRegions    []string                       `mapstructure:"image_copy_regions" required:"false" cty:"image_copy_regions"`
+	AlicloudImageDestinationNames      []string                       `mapstructure:"image_copy_names" required:"false" cty:"image_copy_names"`
+	AlicloudImageDestinationRegionTags map[string]map[string]string   `mapstructure:"image_copy_region_tags" required:"false" cty:"image_copy_region_tags"`
+	ImageEncrypted                     *bool                          `mapstructure:"image_encrypted" required:"false" cty:"image_encrypted"`
+	AlicloudImageForceDelete           *bool                          `mapstructure:"image_force_delete" required:"false" cty:"image_force_delete"`
+	AlicloudImageForceDeleteSnapshots  *bool                          `mapstructure:"image_force_delete_snapshots" required:"false" cty:"image_force_delete_snapshots"`
+	AlicloudImageForceDeleteInstances  *bool                          `mapstructure:"image_force_delete_instances" cty:"image_force_delete_instances"`
+	AlicloudImageIgnoreDataDisks       *bool                          `mapstructure:"image_ignore_data_disks" required:"false" cty:"image_ignore_data_disks"`
+	AlicloudImageTags                  map[string]string              `mapstructure:"tags" required:"false" cty:"tags"`
+	AlicloudImageTag                   []hcl2template.FlatKeyValue    `mapstructure:"tag" required:"false" cty:"tag"`
+	ECSSystemDiskMapping               *FlatAlicloudDiskDevice        `mapstructure:"system_disk_mapping" required:"false" cty:"system_disk_mapping"`
+	ECSImagesDiskMappings              []FlatAlicloudDiskDevice       `mapstructure:"image_disk_mappings" required:"false" cty:"image_disk_mappings"`
+	AssociatePublicIpAddress           *bool                          `mapstructure:"associate_public_ip_address" cty:"associate_public_ip_address"`
+	ZoneId                             *string                        `mapstructure:"zone_id" required:"false" cty:"zone_id"`
+	IOOptimized                        *bool                          `mapstructure:"io_optimized" required:"false" cty:"io_optimized"`
+	InstanceType                       *string                        `mapstructure:"instance_type" required:"true" cty:"instance_type"`
+	Description                        *string                        `mapstructure:"description" cty:"description"`
+	AlicloudSourceImage                *string                        `mapstructure:"source_image" required:"true" cty:"source_image"`
+	AlicloudSourceImageFilter          *FlatAlicloudSourceImageFilter `mapstructure:"source_image_filter" required:"false" cty:"source_image_filter"`
+	ForceStopInstance                  *bool                          `mapstructure:"force_stop_instance" required:"false" cty:"force_stop_instance"`
+	DisableStopInstance                *bool                          `mapstructure:"disable_stop_instance" required:"false" cty:"disable_stop_instance"`
+	KeepInstanceOnFailure              *bool                          `mapstructure:"keep_instance_on_failure" required:"false" cty:"keep_instance_on_failure"`
+	SecurityGroupId                    *string                        `mapstructure:"security_group_id" required:"false" cty:"security_group_id"`
+	SecurityGroupName                  *string                        `mapstructure:"security_group_name" required:"false" cty:"security_group_name"`
+	UserData                           *string                        `mapstructure:"user_data" required:"false" cty:"user_data"`
+	UserDataFile                       *string                        `mapstructure:"user_data_file" required:"false" cty:"user_data_file"`
+	VpcId                              *string                        `mapstructure:"vpc_id" required:"false" cty:"vpc_id"`
+	VpcName                            *string                        `mapstructure:"vpc_name" required:"false" cty:"vpc_name"`
+	CidrBlock                          *string                        `mapstructure:"vpc_cidr_block" required:"false" cty:"vpc_cidr_block"`
+	VSwitchId                          *string                        `mapstructure:"vswitch_id" required:"false" cty:"vswitch_id"`
+	VSwitchName                        *string                        `mapstructure:"vswitch_name" required:"false" cty:"vswitch_name"`
+	InstanceName                       *string                        `mapstructure:"instance_name" required:"false" cty:"instance_name"`
+	InternetChargeType                 *string                        `mapstructure:"internet_charge_type" required:"false" cty:"internet_charge_type"`
+	InternetMaxBandwidthOut            *int                           `mapstructure:"internet_max_bandwidth_out" required:"false" cty:"internet_max_bandwidth_out"`
+	EipBandwidth                       *int                           `mapstructure:"eip_bandwidth" required:"false" cty:"eip_bandwidth"`
+	EipISP                             *string                        `mapstructure:"eip_isp" required:"false" cty:"eip_isp"`
+	EnableIpv6                         *bool                          `mapstructure:"enable_ipv6" required:"false" cty:"enable_ipv6"`
+	InstanceMetadataHttpTokens         *string                        `mapstructure:"instance_metadata_http_tokens" required:"false" cty:"instance_metadata_http_tokens"`
+	InstanceMetadataVerify             *bool                          `mapstructure:"instance_metadata_verify" required:"false" cty:"instance_metadata_verify"`
+	WaitSnapshotReadyTimeout           *int                           `mapstructure:"wait_snapshot_ready_timeout" required:"false" cty:"wait_snapshot_ready_timeout"`
+	Type                               *string                        `mapstructure:"communicator" cty:"communicator"`
+	PauseBeforeConnect                 *string                        `mapstructure:"pause_before_connecting" cty:"pause_before_connecting"`
+	SSHHost                            *string                        `mapstructure:"ssh_host" cty:"ssh_host"`
+	SSHPort                            *int                           `mapstructure:"ssh_port" cty:"ssh_port"`
+	SSHUsername                        *string                        `mapstructure:"ssh_username" cty:"ssh_username"`
+	SSHPassword                        *string                        `mapstructure:"ssh_password" cty:"ssh_password"`
+	SSHKeyPairName                     *string                        `mapstructure:"ssh_keypair_name" cty:"ssh_keypair_name"`
+	SSHTemporaryKeyPairName            *string                        `mapstructure:"temporary_key_pair_name" cty:"temporary_key_pair_name"`
+	SSHClearAuthorizedKeys             *bool                          `mapstructure:"ssh_clear_authorized_keys" cty:"ssh_clear_authorized_keys"`
+	SSHPrivateKeyFile                  *string                        `mapstructure:"ssh_private_key_file" cty:"ssh_private_key_file"`
+	SSHPty                             *bool                          `mapstructure:"ssh_pty" cty:"ssh_pty"`
+	SSHTimeout                         *string                        `mapstructure:"ssh_timeout" cty:"ssh_timeout"`
+	SSHWaitTimeout                     *string                        `mapstructure:"ssh_wait_timeout" undocumented:"true" cty:"ssh_wait_timeout"`
+	SSHAgentAuth                       *bool                          `mapstructure:"ssh_agent_auth" cty:"ssh_agent_auth"`
+	SSHDisableAgentForwarding          *bool                          `mapstructure:"ssh_disable_agent_forwarding" cty:"ssh_disable_agent_forwarding"`
+	SSHHandshakeAttempts               *int                           `mapstructure:"ssh_handshake_attempts" cty:"ssh_handshake_attempts"`
+	SSHBastionHost                     *string                        `mapstructure:"ssh_bastion_host" cty:"ssh_bastion_host"`
+	SSHBastionPort                     *int                           `mapstructure:"ssh_bastion_port" cty:"ssh_bastion_port"`
+	SSHBastionAgentAuth                *bool                          `mapstructure:"ssh_bastion_agent_auth" cty:"ssh_bastion_agent_auth"`
+	SSHBastionUsername                 *string                        `mapstructure:"ssh_bastion_username" cty:"ssh_bastion_username"`
+	SSHBastionPassword                 *string                        `mapstructure:"ssh_bastion_password" cty:"ssh_bastion_password"`
+	SSHBastionInteractive              *bool                          `mapstructure:"ssh_bastion_interactive" cty:"ssh_bastion_interactive"`
+	SSHBastionPrivateKeyFile           *string                        `mapstructure:"ssh_bastion_private_key_file" cty:"ssh_bastion_private_key_file"`
+	SSHFileTransferMethod              *string                        `mapstructure:"ssh_file_transfer_method" cty:"ssh_file_transfer_method"`
+	SSHProxyHost                       *string                        `mapstructure:"ssh_proxy_host" cty:"ssh_proxy_host"`
+	SSHProxyPort                       *int                           `mapstructure:"ssh_proxy_port" cty:"ssh_proxy_port"`
+	SSHProxyUsername                   *string                        `mapstructure:"ssh_proxy_username" cty:"ssh_proxy_username"`
+	SSHProxyPassword                   *string                        `mapstructure:"ssh_proxy_password" cty:"ssh_proxy_password"`
+	SSHKeepAliveInterval               *string                        `mapstructure:"ssh_keep_alive_interval" cty:"ssh_keep_alive_interval"`
+	SSHReadWriteTimeout                *string                        `mapstructure:"ssh_read_write_timeout" cty:"ssh_read_write_timeout"`
+	SSHRemoteTunnels                   []string                       `mapstructure:"ssh_remote_tunnels" cty:"ssh_remote_tunnels"`
+	SSHLocalTunnels                    []string                       `mapstructure:"ssh_local_tunnels" cty:"ssh_local_tunnels"`
+	SSHPublicKey                       []byte                         `mapstructure:"ssh_public_key" cty:"ssh_public_key"`
+	SSHPrivateKey                      []byte                         `mapstructure:"ssh_private_key" cty:"ssh_private_key"`
+	WinRMUser                          *string                        `mapstructure:"winrm_username" cty:"winrm_username"`
+	WinRMPassword                      *string                        `mapstructure:"winrm_password" cty:"winrm_password"`
+	WinRMHost                          *string                        `mapstructure:"winrm_host" cty:"winrm_host"`
+	WinRMPort                          *int                           `mapstructure:"winrm_port" cty:"winrm_port"`
+	WinRMTimeout                       *string                        `mapstructure:"winrm_timeout" cty:"winrm_timeout"`
+	WinRMUseSSL                        *bool                          `mapstructure:"winrm_use_ssl" cty:"winrm_use_ssl"`
+	WinRMInsecure                      *bool                          `mapstructure:"winrm_insecure" cty:"winrm_insecure"`
+	WinRMUseNTLM                       *bool                          `mapstructure:"winrm_use_ntlm" cty:"winrm_use_ntlm"`
+	SSHPrivateIp                       *bool                          `mapstructure:"ssh_private_ip" required:"false" cty:"ssh_private_ip"`
+	ForceCleanup                       *bool                          `mapstructure:"force_cleanup" required:"false" cty:"force_cleanup"`
+	ForceCleanupAge                    *string                        `mapstructure:"force_cleanup_age" required:"false" cty:"force_cleanup_age"`
 }
 
 // FlatMapstructure returns a new FlatConfig.
@@ -156,101 +191,111 @@ func (*Config) FlatMapstructure() interface{ HCL2Spec() map[string]hcldec.Spec }
 // The decoded values from this spec will then be applied to a FlatConfig.
 func (*FlatConfig) HCL2Spec() map[string]hcldec.Spec {
 	s := map[string]hcldec.Spec{
-		"packer_build_name":            &hcldec.AttrSpec{Name: "packer_build_name", Type: cty.String, Required: false},
-		"packer_builder_type":          &hcldec.AttrSpec{Name: "packer_builder_type", Type: cty.String, Required: false},
-		"packer_debug":                 &hcldec.AttrSpec{Name: "packer_debug", Type: cty.Bool, Required: false},
-		"packer_force":                 &hcldec.AttrSpec{Name: "packer_force", Type: cty.Bool, Required: false},
-		"packer_on_error":              &hcldec.AttrSpec{Name: "packer_on_error", Type: cty.String, Required: false},
-		"packer_user_variables":        &hcldec.AttrSpec{Name: "packer_user_variables", Type: cty.Map(cty.String), Required: false},
-		"packer_sensitive_variables":   &hcldec.AttrSpec{Name: "packer_sensitive_variables", Type: cty.List(cty.String), Required: false},
-		"access_key":                   &hcldec.AttrSpec{Name: "access_key", Type: cty.String, Required: false},
-		"secret_key":                   &hcldec.AttrSpec{Name: "secret_key", Type: cty.String, Required: false},
-		"region":                       &hcldec.AttrSpec{Name: "region", Type: cty.String, Required: false},
-		"skip_region_validation":       &hcldec.AttrSpec{Name: "skip_region_validation", Type: cty.Bool, Required: false},
-		"skip_image_validation":        &hcldec.AttrSpec{Name: "skip_image_validation", Type: cty.Bool, Required: false},
-		"profile":                      &hcldec.AttrSpec{Name: "profile", Type: cty.String, Required: false},
-		"shared_credentials_file":      &hcldec.AttrSpec{Name: "shared_credentials_file", Type: cty.String, Required: false},
-		"security_token":               &hcldec.AttrSpec{Name: "security_token", Type: cty.String, Required: false},
-		"image_name":                   &hcldec.AttrSpec{Name: "image_name", Type: cty.String, Required: false},
-		"image_version":                &hcldec.AttrSpec{Name: "image_version", Type: cty.String, Required: false},
-		"image_description":            &hcldec.AttrSpec{Name: "image_description", Type: cty.String, Required: false},
-		"image_share_account":          &hcldec.AttrSpec{Name: "image_share_account", Type: cty.List(cty.String), Required: false},
-		"image_unshare_account":        &hcldec.AttrSpec{Name: "image_unshare_account", Type: cty.List(cty.String), Required: false},
-		"image_copy_regions":           &hcldec.AttrSpec{Name: "image_copy_regions", Type: cty.List(cty.String), Required: false},
-		"image_copy_names":             &hcldec.AttrSpec{Name: "image_copy_names", Type: cty.List(cty.String), Required: false},
-		"image_encrypted":              &hcldec.AttrSpec{Name: "image_encrypted", Type: cty.Bool, Required: false},
-		"image_force_delete":           &hcldec.AttrSpec{Name: "image_force_delete", Type: cty.Bool, Required: false},
-		"image_force_delete_snapshots": &hcldec.AttrSpec{Name: "image_force_delete_snapshots", Type: cty.Bool, Required: false},
-		"image_force_delete_instances": &hcldec.AttrSpec{Name: "image_force_delete_instances", Type: cty.Bool, Required: false},
-		"image_ignore_data_disks":      &hcldec.AttrSpec{Name: "image_ignore_data_disks", Type: cty.Bool, Required: false},
-		"tags":                         &hcldec.AttrSpec{Name: "tags", Type: cty.Map(cty.String), Required: false},
-		"tag":                          &hcldec.BlockListSpec{TypeName: "tag", Nested: hcldec.ObjectSpec((*hcl2template.FlatKeyValue)(nil).HCL2Spec())},
-		"system_disk_mapping":          &hcldec.BlockSpec{TypeName: "system_disk_mapping", Nested: hcldec.ObjectSpec((*FlatAlicloudDiskDevice)(nil).HCL2Spec())},
-		"image_disk_mappings":          &hcldec.BlockListSpec{TypeName: "image_disk_mappings", Nested: hcldec.ObjectSpec((*FlatAlicloudDiskDevice)(nil).HCL2Spec())},
-		"associate_public_ip_address":  &hcldec.AttrSpec{Name: "associate_public_ip_address", Type: cty.Bool, Required: false},
-		"zone_id":                      &hcldec.AttrSpec{Name: "zone_id", Type: cty.String, Required: false},
-		"io_optimized":                 &hcldec.AttrSpec{Name: "io_optimized", Type: cty.Bool, Required: false},
-		"instance_type":                &hcldec.AttrSpec{Name: "instance_type", Type: cty.String, Required: false},
-		"description":                  &hcldec.AttrSpec{Name: "description", Type: cty.String, Required: false},
-		"source_image":                 &hcldec.AttrSpec{Name: "source_image", Type: cty.String, Required: false},
-		"force_stop_instance":          &hcldec.AttrSpec{Name: "force_stop_instance", Type: cty.Bool, Required: false},
-		"disable_stop_instance":        &hcldec.AttrSpec{Name: "disable_stop_instance", Type: cty.Bool, Required: false},
-		"security_group_id":            &hcldec.AttrSpec{Name: "security_group_id", Type: cty.String, Required: false},
-		"security_group_name":          &hcldec.AttrSpec{Name: "security_group_name", Type: cty.String, Required: false},
-		"user_data":                    &hcldec.AttrSpec{Name: "user_data", Type: cty.String, Required: false},
-		"user_data_file":               &hcldec.AttrSpec{Name: "user_data_file", Type: cty.String, Required: false},
-		"vpc_id":                       &hcldec.AttrSpec{Name: "vpc_id", Type: cty.String, Required: false},
-		"vpc_name":                     &hcldec.AttrSpec{Name: "vpc_name", Type: cty.String, Required: false},
-		"vpc_cidr_block":               &hcldec.AttrSpec{Name: "vpc_cidr_block", Type: cty.String, Required: false},
-		"vswitch_id":                   &hcldec.AttrSpec{Name: "vswitch_id", Type: cty.String, Required: false},
-		"vswitch_name":                 &hcldec.AttrSpec{Name: "vswitch_name", Type: cty.String, Required: false},
-		"instance_name":                &hcldec.AttrSpec{Name: "instance_name", Type: cty.String, Required: false},
-		"internet_charge_type":         &hcldec.AttrSpec{Name: "internet_charge_type", Type: cty.String, Required: false},
-		"internet_max_bandwidth_out":   &hcldec.AttrSpec{Name: "internet_max_bandwidth_out", Type: cty.Number, Required: false},
-		"wait_snapshot_ready_timeout":  &hcldec.AttrSpec{Name: "wait_snapshot_ready_timeout", Type: cty.Number, Required: false},
-		"communicator":                 &hcldec.AttrSpec{Name: "communicator", Type: cty.String, Required: false},
-		"pause_before_connecting":      &hcldec.AttrSpec{Name: "pause_before_connecting", Type: cty.String, Required: false},
-		"ssh_host":                     &hcldec.AttrSpec{Name: "ssh_host", Type: cty.String, Required: false},
-		"ssh_port":                     &hcldec.AttrSpec{Name: "ssh_port", Type: cty.Number, Required: false},
-		"ssh_username":                 &hcldec.AttrSpec{Name: "ssh_username", Type: cty.String, Required: false},
-		"ssh_password":                 &hcldec.AttrSpec{Name: "ssh_password", Type: cty.String, Required: false},
-		"ssh_keypair_name":             &hcldec.AttrSpec{Name: "ssh_keypair_name", Type: cty.String, Required: false},
-		"temporary_key_pair_name":      &hcldec.AttrSpec{Name: "temporary_key_pair_name", Type: cty.String, Required: false},
-		"ssh_clear_authorized_keys":    &hcldec.AttrSpec{Name: "ssh_clear_authorized_keys", Type: cty.Bool, Required: false},
-		"ssh_private_key_file":         &hcldec.AttrSpec{Name: "ssh_private_key_file", Type: cty.String, Required: false},
-		"ssh_pty":                      &hcldec.AttrSpec{Name: "ssh_pty", Type: cty.Bool, Required: false},
-		"ssh_timeout":                  &hcldec.AttrSpec{Name: "ssh_timeout", Type: cty.String, Required: false},
-		"ssh_wait_timeout":             &hcldec.AttrSpec{Name: "ssh_wait_timeout", Type: cty.String, Required: false},
-		"ssh_agent_auth":               &hcldec.AttrSpec{Name: "ssh_agent_auth", Type: cty.Bool, Required: false},
-		"ssh_disable_agent_forwarding": &hcldec.AttrSpec{Name: "ssh_disable_agent_forwarding", Type: cty.Bool, Required: false},
-		"ssh_handshake_attempts":       &hcldec.AttrSpec{Name: "ssh_handshake_attempts", Type: cty.Number, Required: false},
-		"ssh_bastion_host":             &hcldec.AttrSpec{Name: "ssh_bastion_host", Type: cty.String, Required: false},
-		"ssh_bastion_port":             &hcldec.AttrSpec{Name: "ssh_bastion_port", Type: cty.Number, Required: false},
-		"ssh_bastion_agent_auth":       &hcldec.AttrSpec{Name: "ssh_bastion_agent_auth", Type: cty.Bool, Required: false},
-		"ssh_bastion_username":         &hcldec.AttrSpec{Name: "ssh_bastion_username", Type: cty.String, Required: false},
-		"ssh_bastion_password":         &hcldec.AttrSpec{Name: "ssh_bastion_password", Type: cty.String, Required: false},
-		"ssh_bastion_interactive":      &hcldec.AttrSpec{Name: "ssh_bastion_interactive", Type: cty.Bool, Required: false},
-		"ssh_bastion_private_key_file": &hcldec.AttrSpec{Name: "ssh_bastion_private_key_file", Type: cty.String, Required: false},
-		"ssh_file_transfer_method":     &hcldec.AttrSpec{Name: "ssh_file_transfer_method", Type: cty.String, Required: false},
-		"ssh_proxy_host":               &hcldec.AttrSpec{Name: "ssh_proxy_host", Type: cty.String, Required: false},
-		"ssh_proxy_port":               &hcldec.AttrSpec{Name: "ssh_proxy_port", Type: cty.Number, Required: false},
-		"ssh_proxy_username":           &hcldec.AttrSpec{Name: "ssh_proxy_username", Type: cty.String, Required: false},
-		"ssh_proxy_password":           &hcldec.AttrSpec{Name: "ssh_proxy_password", Type: cty.String, Required: false},
-		"ssh_keep_alive_interval":      &hcldec.AttrSpec{Name: "ssh_keep_alive_interval", Type: cty.String, Required: false},
-		"ssh_read_write_timeout":       &hcldec.AttrSpec{Name: "ssh_read_write_timeout", Type: cty.String, Required: false},
-		"ssh_remote_tunnels":           &hcldec.AttrSpec{Name: "ssh_remote_tunnels", Type: cty.List(cty.String), Required: false},
-		"ssh_local_tunnels":            &hcldec.AttrSpec{Name: "ssh_local_tunnels", Type: cty.List(cty.String), Required: false},
-		"ssh_public_key":               &hcldec.AttrSpec{Name: "ssh_public_key", Type: cty.List(cty.Number), Required: false},
-		"ssh_private_key":              &hcldec.AttrSpec{Name: "ssh_private_key", Type: cty.List(cty.Number), Required: false},
-		"winrm_username":               &hcldec.AttrSpec{Name: "winrm_username", Type: cty.String, Required: false},
-		"winrm_password":               &hcldec.AttrSpec{Name: "winrm_password", Type: cty.String, Required: false},
-		"winrm_host":                   &hcldec.AttrSpec{Name: "winrm_host", Type: cty.String, Required: false},
-		"winrm_port":                   &hcldec.AttrSpec{Name: "winrm_port", Type: cty.Number, Required: false},
-		"winrm_timeout":                &hcldec.AttrSpec{Name: "winrm_timeout", Type: cty.String, Required: false},
-		"winrm_use_ssl":                &hcldec.AttrSpec{Name: "winrm_use_ssl", Type: cty.Bool, Required: false},
-		"winrm_insecure":               &hcldec.AttrSpec{Name: "winrm_insecure", Type: cty.Bool, Required: false},
-		"winrm_use_ntlm":               &hcldec.AttrSpec{Name: "winrm_use_ntlm", Type: cty.Bool, Required: false},
-		"ssh_private_ip":               &hcldec.AttrSpec{Name: "ssh_private_ip", Type: cty.Bool, Required: false},
+		"packer_build_name":             &hcldec.AttrSpec{Name: "packer_build_name", Type: cty.String, Required: false},
+		"packer_builder_type":           &hcldec.AttrSpec{Name: "packer_builder_type", Type: cty.String, Required: false},
+		"packer_debug":                  &hcldec.AttrSpec{Name: "packer_debug", Type: cty.Bool, Required: false},
+		"packer_force":                  &hcldec.AttrSpec{Name: "packer_force", Type: cty.Bool, Required: false},
+		"packer_on_error":               &hcldec.AttrSpec{Name: "packer_on_error", Type: cty.String, Required: false},
+		"packer_user_variables":         &hcldec.AttrSpec{Name: "packer_user_variables", Type: cty.Map(cty.String), Required: false},
+		"packer_sensitive_variables":    &hcldec.AttrSpec{Name: "packer_sensitive_variables", Type: cty.List(cty.String), Required: false},
+		"access_key":                    &hcldec.AttrSpec{Name: "access_key", Type: cty.String, Required: false},
+		"secret_key":                    &hcldec.AttrSpec{Name: "secret_key", Type: cty.String, Required: false},
+		"region":                        &hcldec.AttrSpec{Name: "region", Type: cty.String, Required: false},
+		"skip_region_validation":        &hcldec.AttrSpec{Name: "skip_region_validation", Type: cty.Bool, Required: false},
+		"skip_image_validation":         &hcldec.AttrSpec{Name: "skip_image_validation", Type: cty.Bool, Required: false},
+		"profile":                       &hcldec.AttrSpec{Name: "profile", Type: cty.String, Required: false},
+		"shared_credentials_file":       &hcldec.AttrSpec{Name: "shared_credentials_file", Type: cty.String, Required: false},
+		"security_token":                &hcldec.AttrSpec{Name: "security_token", Type: cty.String, Required: false},
+		"image_name":                    &hcldec.AttrSpec{Name: "image_name", Type: cty.String, Required: false},
+		"image_version":                 &hcldec.AttrSpec{Name: "image_version", Type: cty.String, Required: false},
+		"image_description":             &hcldec.AttrSpec{Name: "image_description", Type: cty.String, Required: false},
+		"image_share_account":           &hcldec.AttrSpec{Name: "image_share_account", Type: cty.List(cty.String), Required: false},
+		"image_unshare_account":         &hcldec.AttrSpec{Name: "image_unshare_account", Type: cty.List(cty.String), Required: false},
+		"image_copy_regions":            &hcldec.AttrSpec{Name: "image_copy_regions", Type: cty.List(cty.String), Required: false},
+		"image_copy_names":              &hcldec.AttrSpec{Name: "image_copy_names", Type: cty.List(cty.String), Required: false},
+		"image_copy_region_tags":        &hcldec.AttrSpec{Name: "image_copy_region_tags", Type: cty.Map(cty.String), Required: false},
+		"image_encrypted":               &hcldec.AttrSpec{Name: "image_encrypted", Type: cty.Bool, Required: false},
+		"image_force_delete":            &hcldec.AttrSpec{Name: "image_force_delete", Type: cty.Bool, Required: false},
+		"image_force_delete_snapshots":  &hcldec.AttrSpec{Name: "image_force_delete_snapshots", Type: cty.Bool, Required: false},
+		"image_force_delete_instances":  &hcldec.AttrSpec{Name: "image_force_delete_instances", Type: cty.Bool, Required: false},
+		"image_ignore_data_disks":       &hcldec.AttrSpec{Name: "image_ignore_data_disks", Type: cty.Bool, Required: false},
+		"tags":                          &hcldec.AttrSpec{Name: "tags", Type: cty.Map(cty.String), Required: false},
+		"tag":                           &hcldec.BlockListSpec{TypeName: "tag", Nested: hcldec.ObjectSpec((*hcl2template.FlatKeyValue)(nil).HCL2Spec())},
+		"system_disk_mapping":           &hcldec.BlockSpec{TypeName: "system_disk_mapping", Nested: hcldec.ObjectSpec((*FlatAlicloudDiskDevice)(nil).HCL2Spec())},
+		"image_disk_mappings":           &hcldec.BlockListSpec{TypeName: "image_disk_mappings", Nested: hcldec.ObjectSpec((*FlatAlicloudDiskDevice)(nil).HCL2Spec())},
+		"associate_public_ip_address":   &hcldec.AttrSpec{Name: "associate_public_ip_address", Type: cty.Bool, Required: false},
+		"zone_id":                       &hcldec.AttrSpec{Name: "zone_id", Type: cty.String, Required: false},
+		"io_optimized":                  &hcldec.AttrSpec{Name: "io_optimized", Type: cty.Bool, Required: false},
+		"instance_type":                 &hcldec.AttrSpec{Name: "instance_type", Type: cty.String, Required: false},
+		"description":                   &hcldec.AttrSpec{Name: "description", Type: cty.String, Required: false},
+		"source_image":                  &hcldec.AttrSpec{Name: "source_image", Type: cty.String, Required: false},
+		"source_image_filter":           &hcldec.BlockSpec{TypeName: "source_image_filter", Nested: hcldec.ObjectSpec((*FlatAlicloudSourceImageFilter)(nil).HCL2Spec())},
+		"force_stop_instance":           &hcldec.AttrSpec{Name: "force_stop_instance", Type: cty.Bool, Required: false},
+		"disable_stop_instance":         &hcldec.AttrSpec{Name: "disable_stop_instance", Type: cty.Bool, Required: false},
+		"keep_instance_on_failure":      &hcldec.AttrSpec{Name: "keep_instance_on_failure", Type: cty.Bool, Required: false},
+		"security_group_id":             &hcldec.AttrSpec{Name: "security_group_id", Type: cty.String, Required: false},
+		"security_group_name":           &hcldec.AttrSpec{Name: "security_group_name", Type: cty.String, Required: false},
+		"user_data":                     &hcldec.AttrSpec{Name: "user_data", Type: cty.String, Required: false},
+		"user_data_file":                &hcldec.AttrSpec{Name: "user_data_file", Type: cty.String, Required: false},
+		"vpc_id":                        &hcldec.AttrSpec{Name: "vpc_id", Type: cty.String, Required: false},
+		"vpc_name":                      &hcldec.AttrSpec{Name: "vpc_name", Type: cty.String, Required: false},
+		"vpc_cidr_block":                &hcldec.AttrSpec{Name: "vpc_cidr_block", Type: cty.String, Required: false},
+		"vswitch_id":                    &hcldec.AttrSpec{Name: "vswitch_id", Type: cty.String, Required: false},
+		"vswitch_name":                  &hcldec.AttrSpec{Name: "vswitch_name", Type: cty.String, Required: false},
+		"instance_name":                 &hcldec.AttrSpec{Name: "instance_name", Type: cty.String, Required: false},
+		"internet_charge_type":          &hcldec.AttrSpec{Name: "internet_charge_type", Type: cty.String, Required: false},
+		"internet_max_bandwidth_out":    &hcldec.AttrSpec{Name: "internet_max_bandwidth_out", Type: cty.Number, Required: false},
+		"eip_bandwidth":                 &hcldec.AttrSpec{Name: "eip_bandwidth", Type: cty.Number, Required: false},
+		"eip_isp":                       &hcldec.AttrSpec{Name: "eip_isp", Type: cty.String, Required: false},
+		"enable_ipv6":                   &hcldec.AttrSpec{Name: "enable_ipv6", Type: cty.Bool, Required: false},
+		"instance_metadata_http_tokens": &hcldec.AttrSpec{Name: "instance_metadata_http_tokens", Type: cty.String, Required: false},
+		"instance_metadata_verify":      &hcldec.AttrSpec{Name: "instance_metadata_verify", Type: cty.Bool, Required: false},
+		"wait_snapshot_ready_timeout":   &hcldec.AttrSpec{Name: "wait_snapshot_ready_timeout", Type: cty.Number, Required: false},
+		"communicator":                  &hcldec.AttrSpec{Name: "communicator", Type: cty.String, Required: false},
+		"pause_before_connecting":       &hcldec.AttrSpec{Name: "pause_before_connecting", Type: cty.String, Required: false},
+		"ssh_host":                      &hcldec.AttrSpec{Name: "ssh_host", Type: cty.String, Required: false},
+		"ssh_port":                      &hcldec.AttrSpec{Name: "ssh_port", Type: cty.Number, Required: false},
+		"ssh_username":                  &hcldec.AttrSpec{Name: "ssh_username", Type: cty.String, Required: false},
+		"ssh_password":                  &hcldec.AttrSpec{Name: "ssh_password", Type: cty.String, Required: false},
+		"ssh_keypair_name":              &hcldec.AttrSpec{Name: "ssh_keypair_name", Type: cty.String, Required: false},
+		"temporary_key_pair_name":       &hcldec.AttrSpec{Name: "temporary_key_pair_name", Type: cty.String, Required: false},
+		"ssh_clear_authorized_keys":     &hcldec.AttrSpec{Name: "ssh_clear_authorized_keys", Type: cty.Bool, Required: false},
+		"ssh_private_key_file":          &hcldec.AttrSpec{Name: "ssh_private_key_file", Type: cty.String, Required: false},
+		"ssh_pty":                       &hcldec.AttrSpec{Name: "ssh_pty", Type: cty.Bool, Required: false},
+		"ssh_timeout":                   &hcldec.AttrSpec{Name: "ssh_timeout", Type: cty.String, Required: false},
+		"ssh_wait_timeout":              &hcldec.AttrSpec{Name: "ssh_wait_timeout", Type: cty.String, Required: false},
+		"ssh_agent_auth":                &hcldec.AttrSpec{Name: "ssh_agent_auth", Type: cty.Bool, Required: false},
+		"ssh_disable_agent_forwarding":  &hcldec.AttrSpec{Name: "ssh_disable_agent_forwarding", Type: cty.Bool, Required: false},
+		"ssh_handshake_attempts":        &hcldec.AttrSpec{Name: "ssh_handshake_attempts", Type: cty.Number, Required: false},
+		"ssh_bastion_host":              &hcldec.AttrSpec{Name: "ssh_bastion_host", Type: cty.String, Required: false},
+		"ssh_bastion_port":              &hcldec.AttrSpec{Name: "ssh_bastion_port", Type: cty.Number, Required: false},
+		"ssh_bastion_agent_auth":        &hcldec.AttrSpec{Name: "ssh_bastion_agent_auth", Type: cty.Bool, Required: false},
+		"ssh_bastion_username":          &hcldec.AttrSpec{Name: "ssh_bastion_username", Type: cty.String, Required: false},
+		"ssh_bastion_password":          &hcldec.AttrSpec{Name: "ssh_bastion_password", Type: cty.String, Required: false},
+		"ssh_bastion_interactive":       &hcldec.AttrSpec{Name: "ssh_bastion_interactive", Type: cty.Bool, Required: false},
+		"ssh_bastion_private_key_file":  &hcldec.AttrSpec{Name: "ssh_bastion_private_key_file", Type: cty.String, Required: false},
+		"ssh_file_transfer_method":      &hcldec.AttrSpec{Name: "ssh_file_transfer_method", Type: cty.String, Required: false},
+		"ssh_proxy_host":                &hcldec.AttrSpec{Name: "ssh_proxy_host", Type: cty.String, Required: false},
+		"ssh_proxy_port":                &hcldec.AttrSpec{Name: "ssh_proxy_port", Type: cty.Number, Required: false},
+		"ssh_proxy_username":            &hcldec.AttrSpec{Name: "ssh_proxy_username", Type: cty.String, Required: false},
+		"ssh_proxy_password":            &hcldec.AttrSpec{Name: "ssh_proxy_password", Type: cty.String, Required: false},
+		"ssh_keep_alive_interval":       &hcldec.AttrSpec{Name: "ssh_keep_alive_interval", Type: cty.String, Required: false},
+		"ssh_read_write_timeout":        &hcldec.AttrSpec{Name: "ssh_read_write_timeout", Type: cty.String, Required: false},
+		"ssh_remote_tunnels":            &hcldec.AttrSpec{Name: "ssh_remote_tunnels", Type: cty.List(cty.String), Required: false},
+		"ssh_local_tunnels":             &hcldec.AttrSpec{Name: "ssh_local_tunnels", Type: cty.List(cty.String), Required: false},
+		"ssh_public_key":                &hcldec.AttrSpec{Name: "ssh_public_key", Type: cty.List(cty.Number), Required: false},
+		"ssh_private_key":               &hcldec.AttrSpec{Name: "ssh_private_key", Type: cty.List(cty.Number), Required: false},
+		"winrm_username":                &hcldec.AttrSpec{Name: "winrm_username", Type: cty.String, Required: false},
+		"winrm_password":                &hcldec.AttrSpec{Name: "winrm_password", Type: cty.String, Required: false},
+		"winrm_host":                    &hcldec.AttrSpec{Name: "winrm_host", Type: cty.String, Required: false},
+		"winrm_port":                    &hcldec.AttrSpec{Name: "winrm_port", Type: cty.Number, Required: false},
+		"winrm_timeout":                 &hcldec.AttrSpec{Name: "winrm_timeout", Type: cty.String, Required: false},
+		"winrm_use_ssl":                 &hcldec.AttrSpec{Name: "winrm_use_ssl", Type: cty.Bool, Required: false},
+		"winrm_insecure":                &hcldec.AttrSpec{Name: "winrm_insecure", Type: cty.Bool, Required: false},
+		"winrm_use_ntlm":                &hcldec.AttrSpec{Name: "winrm_use_ntlm", Type: cty.Bool, Required: false},
+		"ssh_private_ip":                &hcldec.AttrSpec{Name: "ssh_private_ip", Type: cty.Bool, Required: false},
+		"force_cleanup":                 &hcldec.AttrSpec{Name: "force_cleanup", Type: cty.Bool, Required: false},
+		"force_cleanup_age":             &hcldec.AttrSpec{Name: "force_cleanup_age", Type: cty.String, Required: false},
 	}
 	return s
 }
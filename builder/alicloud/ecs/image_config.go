@@ -160,6 +160,11 @@ type AlicloudImageConfig struct {
 	// Chinese character, and may contain numbers, _ or -. It cannot begin with
 	// `http://` or `https://`.
 	AlicloudImageDestinationNames []string `mapstructure:"image_copy_names" required:"false"`
+	// Additional key/value pair tags applied to images copied to other
+	// regions, keyed by destination region ID. They are merged with `tags`
+	// for that region's copy, so region specific cleanup or billing tags
+	// can be added on top of the common ones.
+	AlicloudImageDestinationRegionTags map[string]map[string]string `mapstructure:"image_copy_region_tags" required:"false"`
 	// Whether or not to encrypt the target images,            including those
 	// copied if image_copy_regions is specified. If this option is set to
 	// true, a temporary image will be created from the provisioned instance in
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/packer/common/uuid"
 	"github.com/hashicorp/packer/helper/communicator"
@@ -14,6 +15,22 @@ import (
 	"github.com/hashicorp/packer/template/interpolate"
 )
 
+type AlicloudSourceImageFilter struct {
+	// Filters used to select a source image, passed directly to the
+	// [DescribeImages](https://www.alibabacloud.com/help/doc-detail/25534.htm)
+	// API. Supported keys: `image_name`, `os_type`, `architecture`,
+	// `image_owner_alias` and `status`.
+	Filters map[string]string `mapstructure:"filters" required:"false"`
+	// Selects the most recently created image when the filters match more
+	// than one image. This is most useful for selecting the latest build of
+	// a regularly updated base image.
+	MostRecent bool `mapstructure:"most_recent" required:"false"`
+}
+
+func (d *AlicloudSourceImageFilter) Empty() bool {
+	return len(d.Filters) == 0
+}
+
 type RunConfig struct {
 	AssociatePublicIpAddress bool `mapstructure:"associate_public_ip_address"`
 	// ID of the zone to which the disk belongs.
@@ -33,6 +50,23 @@ type RunConfig struct {
 	// This is the base image id which you want to
 	// create your customized images.
 	AlicloudSourceImage string `mapstructure:"source_image" required:"true"`
+	// Filters used to populate the `source_image` field. Any image matched
+	// by the filters can be used as the base image, so set `most_recent` to
+	// true, or use filters specific enough to return a single image. Example
+	// usage:
+	//
+	// ```json
+	// {
+	//     "source_image_filter": {
+	//         "filters": {
+	//             "image_owner_alias": "system",
+	//             "os_type": "linux"
+	//         },
+	//         "most_recent": true
+	//     }
+	// }
+	// ```
+	AlicloudSourceImageFilter AlicloudSourceImageFilter `mapstructure:"source_image_filter" required:"false"`
 	// Whether to force shutdown upon device
 	// restart. The default value is `false`.
 	//
@@ -47,6 +81,10 @@ type RunConfig struct {
 	// E.g., Sysprep a windows which may shutdown the instance within its command.
 	// The default value is false.
 	DisableStopInstance bool `mapstructure:"disable_stop_instance" required:"false"`
+	// Keep the instance around if the build fails during provisioning, so
+	// that it can be inspected for debugging. The instance then has to be
+	// deleted manually. The default value is false.
+	KeepInstanceOnFailure bool `mapstructure:"keep_instance_on_failure" required:"false"`
 	// ID of the security group to which a newly
 	// created instance belongs. Mutual access is allowed between instances in one
 	// security group. If not specified, the newly created instance will be added
@@ -108,6 +146,40 @@ type RunConfig struct {
 	// -   `PayByTraffic`: \[1, 100\]. If this parameter is not specified, an
 	//     error is returned.
 	InternetMaxBandwidthOut int `mapstructure:"internet_max_bandwidth_out" required:"false"`
+	// Bandwidth of the EIP allocated for the build
+	// instance, measured in Mbps (Mega bits per second). When not set, the
+	// value of internet_max_bandwidth_out is used. Only used with VPC
+	// networks.
+	EipBandwidth int `mapstructure:"eip_bandwidth" required:"false"`
+	// The line of the EIP allocated for the build
+	// instance. Optional values:
+	// -   `BGP`
+	// -   `BGP_PRO`
+	//
+	// When not set, the default line of the region is used. Only used with
+	// VPC networks.
+	EipISP string `mapstructure:"eip_isp" required:"false"`
+	// Assign an IPv6 address to the primary network
+	// interface of the build instance after it is created. This requires a
+	// VPC and VSwitch with IPv6 enabled. Packer still connects to the
+	// instance over IPv4. The default value is false.
+	EnableIpv6 bool `mapstructure:"enable_ipv6" required:"false"`
+	// Enforce the hardened instance metadata service on the build
+	// instance, the Alibaba Cloud equivalent of requiring IMDSv2 on AWS
+	// builds: metadata requests must then carry a RAM role session token.
+	// Optional values:
+	// -   `optional`
+	// -   `required`
+	//
+	// When not set, the instance uses the regional default.
+	InstanceMetadataHttpTokens string `mapstructure:"instance_metadata_http_tokens" required:"false"`
+	// Verify, once the build instance is running, that the hardened
+	// metadata service is effectively enforced on it, and fail the build
+	// otherwise. Since the image is created from that instance, a passing
+	// build guarantees the image was prepared under the hardened
+	// settings. Requires instance_metadata_http_tokens to be `required`.
+	// The default value is false.
+	InstanceMetadataVerify bool `mapstructure:"instance_metadata_verify" required:"false"`
 	// Timeout of creating snapshot(s).
 	// The default timeout is 3600 seconds if this option is not set or is set
 	// to 0. For those disks containing lots of data, it may require a higher
@@ -119,6 +191,18 @@ type RunConfig struct {
 	// the ECS created through private ip instead of allocating a public ip or an
 	// EIP. The default value is false.
 	SSHPrivateIp bool `mapstructure:"ssh_private_ip" required:"false"`
+	// Delete stale temporary resources (VPCs, VSwitches and security
+	// groups) left behind by crashed Packer runs before starting the
+	// build, preventing quota exhaustion after repeated crashes. Only
+	// resources created by Packer are removed, recognized by the marker
+	// description Packer sets on them, and only when they are older than
+	// force_cleanup_age. The default value is false.
+	ForceCleanup bool `mapstructure:"force_cleanup" required:"false"`
+	// Minimum age of the stale resources removed by force_cleanup, as a
+	// duration string (e.g. `4h` or `30m`). Resources younger than this,
+	// for example those of concurrently running builds, are kept. The
+	// default value is `4h`.
+	ForceCleanupAge time.Duration `mapstructure:"force_cleanup_age" required:"false"`
 }
 
 func (c *RunConfig) Prepare(ctx *interpolate.Context) []error {
@@ -130,14 +214,22 @@ func (c *RunConfig) Prepare(ctx *interpolate.Context) []error {
 
 	// Validation
 	errs := c.Comm.Prepare(ctx)
-	if c.AlicloudSourceImage == "" {
-		errs = append(errs, errors.New("A source_image must be specified"))
+	if c.AlicloudSourceImage == "" && c.AlicloudSourceImageFilter.Empty() {
+		errs = append(errs, errors.New("A source_image or source_image_filter must be specified"))
 	}
 
 	if strings.TrimSpace(c.AlicloudSourceImage) != c.AlicloudSourceImage {
 		errs = append(errs, errors.New("The source_image can't include spaces"))
 	}
 
+	for key := range c.AlicloudSourceImageFilter.Filters {
+		switch key {
+		case "image_name", "os_type", "architecture", "image_owner_alias", "status":
+		default:
+			errs = append(errs, fmt.Errorf("Unknown source_image_filter key: %s", key))
+		}
+	}
+
 	if c.InstanceType == "" {
 		errs = append(errs, errors.New("An alicloud_instance_type must be specified"))
 	}
@@ -150,5 +242,23 @@ func (c *RunConfig) Prepare(ctx *interpolate.Context) []error {
 		}
 	}
 
+	switch c.InstanceMetadataHttpTokens {
+	case "", "optional", "required":
+	default:
+		errs = append(errs, fmt.Errorf("Unknown instance_metadata_http_tokens value: %s", c.InstanceMetadataHttpTokens))
+	}
+
+	if c.InstanceMetadataVerify && c.InstanceMetadataHttpTokens != "required" {
+		errs = append(errs, errors.New("instance_metadata_verify requires instance_metadata_http_tokens to be \"required\""))
+	}
+
+	if c.ForceCleanup {
+		if c.ForceCleanupAge == 0 {
+			c.ForceCleanupAge = 4 * time.Hour
+		}
+	} else if c.ForceCleanupAge != 0 {
+		errs = append(errs, errors.New("force_cleanup_age may only be set together with force_cleanup"))
+	}
+
 	return errs
 }
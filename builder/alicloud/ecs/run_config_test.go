@@ -4,6 +4,7 @@ import (
 	"io/ioutil"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/packer/helper/communicator"
 )
@@ -44,6 +45,26 @@ func TestRunConfigPrepare_SourceECSImage(t *testing.T) {
 	}
 }
 
+func TestRunConfigPrepare_SourceImageFilter(t *testing.T) {
+	c := testConfig()
+	c.AlicloudSourceImage = ""
+	c.AlicloudSourceImageFilter = AlicloudSourceImageFilter{
+		Filters: map[string]string{
+			"image_owner_alias": "system",
+			"os_type":           "linux",
+		},
+		MostRecent: true,
+	}
+	if err := c.Prepare(nil); len(err) != 0 {
+		t.Fatalf("err: %s", err)
+	}
+
+	c.AlicloudSourceImageFilter.Filters["bogus"] = "value"
+	if err := c.Prepare(nil); len(err) != 1 {
+		t.Fatalf("err: %s", err)
+	}
+}
+
 func TestRunConfigPrepare_SSHPort(t *testing.T) {
 	c := testConfig()
 	c.Comm.SSHPort = 0
@@ -176,3 +197,41 @@ func TestRunConfigPrepare_DisableStopInstance(t *testing.T) {
 		t.Fatalf("invalid value, expected: %t, actul: %t", false, c.DisableStopInstance)
 	}
 }
+
+func TestRunConfigPrepare_ForceCleanup(t *testing.T) {
+	c := testConfig()
+	c.ForceCleanup = true
+	if err := c.Prepare(nil); len(err) != 0 {
+		t.Fatalf("err: %s", err)
+	}
+	if c.ForceCleanupAge != 4*time.Hour {
+		t.Fatalf("invalid value, expected: %s, actul: %s", 4*time.Hour, c.ForceCleanupAge)
+	}
+
+	c = testConfig()
+	c.ForceCleanupAge = time.Hour
+	if err := c.Prepare(nil); len(err) != 1 {
+		t.Fatalf("err: %s", err)
+	}
+}
+
+func TestRunConfigPrepare_InstanceMetadataOptions(t *testing.T) {
+	c := testConfig()
+	c.InstanceMetadataHttpTokens = "bogus"
+	if err := c.Prepare(nil); len(err) != 1 {
+		t.Fatalf("err: %s", err)
+	}
+
+	c = testConfig()
+	c.InstanceMetadataVerify = true
+	if err := c.Prepare(nil); len(err) != 1 {
+		t.Fatalf("Should error when verifying without requiring tokens")
+	}
+
+	c = testConfig()
+	c.InstanceMetadataHttpTokens = "required"
+	c.InstanceMetadataVerify = true
+	if err := c.Prepare(nil); len(err) > 0 {
+		t.Fatalf("err: %s", err)
+	}
+}
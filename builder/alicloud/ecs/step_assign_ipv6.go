@@ -0,0 +1,52 @@
+package ecs
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aliyun/alibaba-cloud-sdk-go/sdk/requests"
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/ecs"
+	"github.com/hashicorp/packer/helper/multistep"
+	"github.com/hashicorp/packer/packer"
+)
+
+type stepAssignAlicloudIpv6 struct {
+	EnableIpv6 bool
+}
+
+func (s *stepAssignAlicloudIpv6) Run(ctx context.Context, state multistep.StateBag) multistep.StepAction {
+	if !s.EnableIpv6 {
+		return multistep.ActionContinue
+	}
+
+	client := state.Get("client").(*ClientWrapper)
+	ui := state.Get("ui").(packer.Ui)
+	instance := state.Get("instance").(*ecs.Instance)
+
+	networkInterfaces := instance.NetworkInterfaces.NetworkInterface
+	if len(networkInterfaces) == 0 {
+		return halt(state, fmt.Errorf("instance %s has no network interface", instance.InstanceId),
+			"Error assigning IPv6 address")
+	}
+
+	ui.Say("Assigning an IPv6 address...")
+
+	assignIpv6AddressesRequest := ecs.CreateAssignIpv6AddressesRequest()
+	assignIpv6AddressesRequest.RegionId = instance.RegionId
+	assignIpv6AddressesRequest.NetworkInterfaceId = networkInterfaces[0].NetworkInterfaceId
+	assignIpv6AddressesRequest.Ipv6AddressCount = requests.NewInteger(1)
+
+	if _, err := client.AssignIpv6Addresses(assignIpv6AddressesRequest); err != nil {
+		return halt(state, err, "Error assigning IPv6 address. Make sure the "+
+			"VPC and VSwitch used for the build have IPv6 enabled")
+	}
+
+	ui.Message(fmt.Sprintf("Assigned an IPv6 address to network interface %s",
+		networkInterfaces[0].NetworkInterfaceId))
+
+	return multistep.ActionContinue
+}
+
+func (s *stepAssignAlicloudIpv6) Cleanup(state multistep.StateBag) {
+	// The IPv6 address is released together with the instance.
+}
@@ -0,0 +1,81 @@
+package ecs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/aliyun/alibaba-cloud-sdk-go/sdk/requests"
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/ecs"
+	"github.com/hashicorp/packer/helper/multistep"
+	"github.com/hashicorp/packer/packer"
+)
+
+type stepCheckMetadataOptions struct {
+	HttpTokens string
+	Verify     bool
+	RegionId   string
+}
+
+// Run verifies that the hardened instance metadata service is effectively
+// enforced on the build instance, so the image created from it is known to
+// have been prepared under IMDSv2-equivalent settings.
+func (s *stepCheckMetadataOptions) Run(ctx context.Context, state multistep.StateBag) multistep.StepAction {
+	if !s.Verify {
+		return multistep.ActionContinue
+	}
+
+	client := state.Get("client").(*ClientWrapper)
+	ui := state.Get("ui").(packer.Ui)
+	instance := state.Get("instance").(*ecs.Instance)
+
+	ui.Say("Verifying instance metadata hardening...")
+
+	// The vendored SDK does not expose the metadata options in its typed
+	// DescribeInstances response, so issue the call as a common request
+	// and pick them out of the raw response.
+	request := requests.NewCommonRequest()
+	request.Product = "Ecs"
+	request.Version = "2014-05-26"
+	request.ApiName = "DescribeInstances"
+	request.QueryParams["RegionId"] = s.RegionId
+	request.QueryParams["InstanceIds"] = fmt.Sprintf("[\"%s\"]", instance.InstanceId)
+
+	response, err := client.ProcessCommonRequest(request)
+	if err != nil {
+		return halt(state, err, "Error describing the build instance")
+	}
+
+	var described struct {
+		Instances struct {
+			Instance []struct {
+				MetadataOptions struct {
+					HttpTokens string
+				}
+			}
+		}
+	}
+	if err := json.Unmarshal(response.GetHttpContentBytes(), &described); err != nil {
+		return halt(state, err, "Error parsing the build instance description")
+	}
+	if len(described.Instances.Instance) == 0 {
+		return halt(state, fmt.Errorf("Build instance %s not found", instance.InstanceId), "")
+	}
+
+	if got := described.Instances.Instance[0].MetadataOptions.HttpTokens; got != s.HttpTokens {
+		err := fmt.Errorf(
+			"The build instance does not enforce the hardened metadata "+
+				"service: HttpTokens is %q, expected %q. The resulting image "+
+				"would be prepared without IMDS v2-equivalent enforcement.",
+			got, s.HttpTokens)
+		return halt(state, err, "")
+	}
+
+	ui.Message(fmt.Sprintf("Instance metadata hardening is enforced (HttpTokens=%s)", s.HttpTokens))
+
+	return multistep.ActionContinue
+}
+
+func (s *stepCheckMetadataOptions) Cleanup(state multistep.StateBag) {
+	// Nothing to clean up.
+}
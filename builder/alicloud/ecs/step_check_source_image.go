@@ -3,6 +3,7 @@ package ecs
 import (
 	"context"
 	"fmt"
+	"sort"
 
 	"github.com/aliyun/alibaba-cloud-sdk-go/services/ecs"
 	"github.com/hashicorp/packer/helper/multistep"
@@ -18,9 +19,16 @@ func (s *stepCheckAlicloudSourceImage) Run(ctx context.Context, state multistep.
 	config := state.Get("config").(*Config)
 	ui := state.Get("ui").(packer.Ui)
 
+	filter := &config.AlicloudSourceImageFilter
+	useFilter := config.AlicloudSourceImage == "" && !filter.Empty()
+
 	describeImagesRequest := ecs.CreateDescribeImagesRequest()
 	describeImagesRequest.RegionId = config.AlicloudRegion
-	describeImagesRequest.ImageId = config.AlicloudSourceImage
+	if useFilter {
+		applySourceImageFilters(describeImagesRequest, filter)
+	} else {
+		describeImagesRequest.ImageId = config.AlicloudSourceImage
+	}
 	if config.AlicloudSkipImageValidation {
 		describeImagesRequest.ShowExpired = "true"
 	}
@@ -31,27 +39,67 @@ func (s *stepCheckAlicloudSourceImage) Run(ctx context.Context, state multistep.
 
 	images := imagesResponse.Images.Image
 
-	// Describe marketplace image
-	describeImagesRequest.ImageOwnerAlias = "marketplace"
-	marketImagesResponse, err := client.DescribeImages(describeImagesRequest)
-	if err != nil {
-		return halt(state, err, "Error querying alicloud marketplace image")
-	}
+	// Describe marketplace image, unless the filter already restricts the
+	// image owner.
+	if !useFilter || filter.Filters["image_owner_alias"] == "" {
+		describeImagesRequest.ImageOwnerAlias = "marketplace"
+		marketImagesResponse, err := client.DescribeImages(describeImagesRequest)
+		if err != nil {
+			return halt(state, err, "Error querying alicloud marketplace image")
+		}
 
-	marketImages := marketImagesResponse.Images.Image
-	if len(marketImages) > 0 {
-		images = append(images, marketImages...)
+		marketImages := marketImagesResponse.Images.Image
+		if len(marketImages) > 0 {
+			images = append(images, marketImages...)
+		}
 	}
 
 	if len(images) == 0 {
+		if useFilter {
+			err := fmt.Errorf("No alicloud image was found matching filters: %v", filter.Filters)
+			return halt(state, err, "")
+		}
 		err := fmt.Errorf("No alicloud image was found matching filters: %v", config.AlicloudSourceImage)
 		return halt(state, err, "")
 	}
 
+	if useFilter && len(images) > 1 {
+		if !filter.MostRecent {
+			err := fmt.Errorf("Your source_image_filter query returned more than one result. Please try a more specific search, or set most_recent to true.")
+			return halt(state, err, "")
+		}
+		sort.Slice(images, func(i, j int) bool {
+			return images[i].CreationTime > images[j].CreationTime
+		})
+	}
+
 	ui.Message(fmt.Sprintf("Found image ID: %s", images[0].ImageId))
 
+	if useFilter {
+		// Make the selected image available to the steps that launch the
+		// instance from it.
+		config.AlicloudSourceImage = images[0].ImageId
+	}
+
 	state.Put("source_image", &images[0])
 	return multistep.ActionContinue
 }
 
+func applySourceImageFilters(request *ecs.DescribeImagesRequest, filter *AlicloudSourceImageFilter) {
+	for key, value := range filter.Filters {
+		switch key {
+		case "image_name":
+			request.ImageName = value
+		case "os_type":
+			request.OSType = value
+		case "architecture":
+			request.Architecture = value
+		case "image_owner_alias":
+			request.ImageOwnerAlias = value
+		case "status":
+			request.Status = value
+		}
+	}
+}
+
 func (s *stepCheckAlicloudSourceImage) Cleanup(multistep.StateBag) {}
@@ -18,6 +18,8 @@ type stepConfigAlicloudEIP struct {
 	RegionId                 string
 	InternetChargeType       string
 	InternetMaxBandwidthOut  int
+	EipBandwidth             int
+	EipISP                   string
 	allocatedId              string
 	SSHPrivateIp             bool
 }
@@ -159,7 +161,16 @@ func (s *stepConfigAlicloudEIP) buildAllocateEipAddressRequest(state multistep.S
 	request.ClientToken = uuid.TimeOrderedUUID()
 	request.RegionId = instance.RegionId
 	request.InternetChargeType = s.InternetChargeType
-	request.Bandwidth = string(convertNumber(s.InternetMaxBandwidthOut))
+
+	bandwidth := s.InternetMaxBandwidthOut
+	if s.EipBandwidth > 0 {
+		bandwidth = s.EipBandwidth
+	}
+	request.Bandwidth = string(convertNumber(bandwidth))
+
+	if s.EipISP != "" {
+		request.ISP = s.EipISP
+	}
 
 	return request
 }
@@ -142,6 +142,7 @@ func (s *stepConfigAlicloudSecurityGroup) buildCreateSecurityGroupRequest(state
 	request.ClientToken = uuid.TimeOrderedUUID()
 	request.RegionId = s.RegionId
 	request.SecurityGroupName = s.SecurityGroupName
+	request.Description = packerResourceDescription
 
 	if networkType == InstanceNetworkVpc {
 		vpcId := state.Get("vpcid").(string)
@@ -143,6 +143,7 @@ func (s *stepConfigAlicloudVPC) buildCreateVpcRequest(state multistep.StateBag)
 	request.RegionId = config.AlicloudRegion
 	request.CidrBlock = s.CidrBlock
 	request.VpcName = s.VpcName
+	request.Description = packerResourceDescription
 
 	return request
 }
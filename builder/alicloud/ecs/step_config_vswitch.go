@@ -202,6 +202,7 @@ func (s *stepConfigAlicloudVSwitch) buildCreateVSwitchRequest(state multistep.St
 	request.ZoneId = s.ZoneId
 	request.VpcId = vpcId
 	request.VSwitchName = s.VSwitchName
+	request.Description = packerResourceDescription
 
 	return request
 }
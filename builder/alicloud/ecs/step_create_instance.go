@@ -28,6 +28,7 @@ type stepCreateAlicloudInstance struct {
 	InternetMaxBandwidthOut int
 	InstanceName            string
 	ZoneId                  string
+	HttpTokens              string
 	instance                *ecs.Instance
 }
 
@@ -88,10 +89,26 @@ func (s *stepCreateAlicloudInstance) Cleanup(state multistep.StateBag) {
 	if s.instance == nil {
 		return
 	}
+
+	config := state.Get("config").(*Config)
+	ui := state.Get("ui").(packer.Ui)
+
+	_, cancelled := state.GetOk(multistep.StateCancelled)
+	_, halted := state.GetOk(multistep.StateHalted)
+	if config.KeepInstanceOnFailure && (cancelled || halted) {
+		ui.Say(fmt.Sprintf("Build failed; keeping instance %s around "+
+			"for debugging because keep_instance_on_failure is set.",
+			s.instance.InstanceId))
+		if ipAddress, ok := state.Get("ipaddress").(string); ok && ipAddress != "" {
+			ui.Message(fmt.Sprintf("IP address: %s", ipAddress))
+		}
+		ui.Message("Delete the instance manually once you are done with it.")
+		return
+	}
+
 	cleanUpMessage(state, "instance")
 
 	client := state.Get("client").(*ClientWrapper)
-	ui := state.Get("ui").(packer.Ui)
 
 	_, err := client.WaitForExpected(&WaitForExpectArgs{
 		RequestFunc: func() (responses.AcsResponse, error) {
@@ -152,6 +169,12 @@ func (s *stepCreateAlicloudInstance) buildCreateInstanceRequest(state multistep.
 		request.IoOptimized = IOOptimizedNone
 	}
 
+	if s.HttpTokens != "" {
+		// The vendored SDK predates the typed field for the instance
+		// metadata options, so pass it as a raw query parameter.
+		request.QueryParams["HttpTokens"] = s.HttpTokens
+	}
+
 	config := state.Get("config").(*Config)
 	password := config.Comm.SSHPassword
 	if password == "" && config.Comm.WinRMPassword != "" {
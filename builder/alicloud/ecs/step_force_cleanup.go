@@ -0,0 +1,185 @@
+package ecs
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aliyun/alibaba-cloud-sdk-go/sdk/requests"
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/ecs"
+	"github.com/hashicorp/packer/helper/multistep"
+	"github.com/hashicorp/packer/packer"
+)
+
+// packerResourceDescription marks the temporary resources Packer creates so
+// that stale ones left behind by crashed runs can be discovered and removed
+// by force_cleanup.
+const packerResourceDescription = "Temporary resource created by Packer"
+
+const forceCleanupPageSize = 50
+
+type stepForceCleanup struct {
+	ForceCleanup bool
+	Age          time.Duration
+	RegionId     string
+}
+
+func (s *stepForceCleanup) Run(ctx context.Context, state multistep.StateBag) multistep.StepAction {
+	client := state.Get("client").(*ClientWrapper)
+	ui := state.Get("ui").(packer.Ui)
+
+	if !s.ForceCleanup {
+		return multistep.ActionContinue
+	}
+
+	ui.Say(fmt.Sprintf("Cleaning up stale Packer resources older than %s...", s.Age))
+
+	cutoff := time.Now().UTC().Add(-s.Age)
+
+	// Security groups and vswitches have to go before the VPCs that
+	// contain them. Failures are logged but do not fail the build; a
+	// resource still in use by a concurrent build simply stays behind
+	// until the next cleanup.
+	s.cleanupSecurityGroups(client, ui, cutoff)
+	s.cleanupVSwitches(client, ui, cutoff)
+	s.cleanupVpcs(client, ui, cutoff)
+
+	return multistep.ActionContinue
+}
+
+func (s *stepForceCleanup) cleanupSecurityGroups(client *ClientWrapper, ui packer.Ui, cutoff time.Time) {
+	for page := 1; ; page++ {
+		request := ecs.CreateDescribeSecurityGroupsRequest()
+		request.RegionId = s.RegionId
+		request.PageSize = requests.NewInteger(forceCleanupPageSize)
+		request.PageNumber = requests.NewInteger(page)
+
+		response, err := client.DescribeSecurityGroups(request)
+		if err != nil {
+			ui.Error(fmt.Sprintf("Error querying security groups for cleanup: %s", err))
+			return
+		}
+
+		for _, securityGroup := range response.SecurityGroups.SecurityGroup {
+			if !isStalePackerResource(securityGroup.Description, securityGroup.CreationTime, cutoff) {
+				continue
+			}
+
+			ui.Message(fmt.Sprintf("Deleting stale security group: %s", securityGroup.SecurityGroupId))
+
+			deleteRequest := ecs.CreateDeleteSecurityGroupRequest()
+			deleteRequest.RegionId = s.RegionId
+			deleteRequest.SecurityGroupId = securityGroup.SecurityGroupId
+			if _, err := client.DeleteSecurityGroup(deleteRequest); err != nil {
+				ui.Message(fmt.Sprintf(
+					"Failed to delete security group %s, it may still be in use: %s",
+					securityGroup.SecurityGroupId, err))
+			}
+		}
+
+		if len(response.SecurityGroups.SecurityGroup) < forceCleanupPageSize {
+			return
+		}
+	}
+}
+
+func (s *stepForceCleanup) cleanupVSwitches(client *ClientWrapper, ui packer.Ui, cutoff time.Time) {
+	for page := 1; ; page++ {
+		request := ecs.CreateDescribeVSwitchesRequest()
+		request.RegionId = s.RegionId
+		request.PageSize = requests.NewInteger(forceCleanupPageSize)
+		request.PageNumber = requests.NewInteger(page)
+
+		response, err := client.DescribeVSwitches(request)
+		if err != nil {
+			ui.Error(fmt.Sprintf("Error querying vswitches for cleanup: %s", err))
+			return
+		}
+
+		for _, vswitch := range response.VSwitches.VSwitch {
+			if !isStalePackerResource(vswitch.Description, vswitch.CreationTime, cutoff) {
+				continue
+			}
+
+			ui.Message(fmt.Sprintf("Deleting stale vswitch: %s", vswitch.VSwitchId))
+
+			deleteRequest := ecs.CreateDeleteVSwitchRequest()
+			deleteRequest.VSwitchId = vswitch.VSwitchId
+			if _, err := client.DeleteVSwitch(deleteRequest); err != nil {
+				ui.Message(fmt.Sprintf(
+					"Failed to delete vswitch %s, it may still be in use: %s",
+					vswitch.VSwitchId, err))
+			}
+		}
+
+		if len(response.VSwitches.VSwitch) < forceCleanupPageSize {
+			return
+		}
+	}
+}
+
+func (s *stepForceCleanup) cleanupVpcs(client *ClientWrapper, ui packer.Ui, cutoff time.Time) {
+	for page := 1; ; page++ {
+		request := ecs.CreateDescribeVpcsRequest()
+		request.RegionId = s.RegionId
+		request.PageSize = requests.NewInteger(forceCleanupPageSize)
+		request.PageNumber = requests.NewInteger(page)
+
+		response, err := client.DescribeVpcs(request)
+		if err != nil {
+			ui.Error(fmt.Sprintf("Error querying vpcs for cleanup: %s", err))
+			return
+		}
+
+		for _, vpc := range response.Vpcs.Vpc {
+			if !isStalePackerResource(vpc.Description, vpc.CreationTime, cutoff) {
+				continue
+			}
+
+			ui.Message(fmt.Sprintf("Deleting stale vpc: %s", vpc.VpcId))
+
+			deleteRequest := ecs.CreateDeleteVpcRequest()
+			deleteRequest.VpcId = vpc.VpcId
+			if _, err := client.DeleteVpc(deleteRequest); err != nil {
+				ui.Message(fmt.Sprintf(
+					"Failed to delete vpc %s, it may still be in use: %s",
+					vpc.VpcId, err))
+			}
+		}
+
+		if len(response.Vpcs.Vpc) < forceCleanupPageSize {
+			return
+		}
+	}
+}
+
+func (s *stepForceCleanup) Cleanup(state multistep.StateBag) {
+	// Nothing to cleanup for this step.
+}
+
+// isStalePackerResource reports whether a resource was created by Packer,
+// recognized by its marker description, and is older than the cutoff time.
+func isStalePackerResource(description string, creationTime string, cutoff time.Time) bool {
+	if description != packerResourceDescription {
+		return false
+	}
+
+	created, err := parseAlicloudCreationTime(creationTime)
+	if err != nil {
+		return false
+	}
+
+	return created.Before(cutoff)
+}
+
+// parseAlicloudCreationTime parses the creation time of an Alicloud
+// resource, which is reported either with second or minute precision.
+func parseAlicloudCreationTime(value string) (time.Time, error) {
+	for _, layout := range []string{time.RFC3339, "2006-01-02T15:04Z"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("unrecognized creation time: %s", value)
+}
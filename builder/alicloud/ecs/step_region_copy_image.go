@@ -13,9 +13,11 @@ import (
 )
 
 type stepRegionCopyAlicloudImage struct {
-	AlicloudImageDestinationRegions []string
-	AlicloudImageDestinationNames   []string
-	RegionId                        string
+	AlicloudImageDestinationRegions    []string
+	AlicloudImageDestinationNames      []string
+	AlicloudImageDestinationRegionTags map[string]map[string]string
+	AlicloudImageTags                  map[string]string
+	RegionId                           string
 }
 
 func (s *stepRegionCopyAlicloudImage) Run(ctx context.Context, state multistep.StateBag) multistep.StepAction {
@@ -64,6 +66,10 @@ func (s *stepRegionCopyAlicloudImage) Run(ctx context.Context, state multistep.S
 
 		alicloudImages[destinationRegion] = imageResponse.ImageId
 		ui.Message(fmt.Sprintf("Copy image from %s(%s) to %s(%s)", s.RegionId, srcImageId, destinationRegion, imageResponse.ImageId))
+
+		if err := s.tagCopiedImage(ui, client, destinationRegion, imageResponse.ImageId); err != nil {
+			return halt(state, err, "Error Adding tags to copied image")
+		}
 	}
 
 	if config.ImageEncrypted != confighelper.TriUnset {
@@ -75,6 +81,41 @@ func (s *stepRegionCopyAlicloudImage) Run(ctx context.Context, state multistep.S
 	return multistep.ActionContinue
 }
 
+// tagCopiedImage applies the common image tags, merged with any extra
+// tags configured for the destination region, to a copied image.
+func (s *stepRegionCopyAlicloudImage) tagCopiedImage(ui packer.Ui, client *ClientWrapper, regionId string, imageId string) error {
+	imageTags := make(map[string]string)
+	for key, value := range s.AlicloudImageTags {
+		imageTags[key] = value
+	}
+	for key, value := range s.AlicloudImageDestinationRegionTags[regionId] {
+		imageTags[key] = value
+	}
+
+	if len(imageTags) == 0 {
+		return nil
+	}
+
+	ui.Message(fmt.Sprintf("Adding tags(%s) to copied image: %s", imageTags, imageId))
+
+	var tags []ecs.AddTagsTag
+	for key, value := range imageTags {
+		var tag ecs.AddTagsTag
+		tag.Key = key
+		tag.Value = value
+		tags = append(tags, tag)
+	}
+
+	addTagsRequest := ecs.CreateAddTagsRequest()
+	addTagsRequest.RegionId = regionId
+	addTagsRequest.ResourceId = imageId
+	addTagsRequest.ResourceType = TagResourceImage
+	addTagsRequest.Tag = &tags
+
+	_, err := client.AddTags(addTagsRequest)
+	return err
+}
+
 func (s *stepRegionCopyAlicloudImage) Cleanup(state multistep.StateBag) {
 	_, cancelled := state.GetOk(multistep.StateCancelled)
 	_, halted := state.GetOk(multistep.StateHalted)
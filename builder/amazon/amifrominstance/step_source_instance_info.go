@@ -0,0 +1,76 @@
+package amifrominstance
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/hashicorp/packer/helper/multistep"
+	"github.com/hashicorp/packer/packer"
+)
+
+// stepSourceInstanceInfo looks up the instance to create the AMI from and
+// checks that an image can actually be created from it.
+type stepSourceInstanceInfo struct {
+	SourceInstanceId string
+	NoReboot         bool
+}
+
+func (s *stepSourceInstanceInfo) Run(ctx context.Context, state multistep.StateBag) multistep.StepAction {
+	ec2conn := state.Get("ec2").(*ec2.EC2)
+	ui := state.Get("ui").(packer.Ui)
+
+	ui.Say(fmt.Sprintf("Inspecting the source instance (%s)...", s.SourceInstanceId))
+
+	resp, err := ec2conn.DescribeInstances(&ec2.DescribeInstancesInput{
+		InstanceIds: []*string{aws.String(s.SourceInstanceId)},
+	})
+	if err != nil {
+		err := fmt.Errorf("Error describing source instance: %s", err)
+		state.Put("error", err)
+		ui.Error(err.Error())
+		return multistep.ActionHalt
+	}
+	if len(resp.Reservations) == 0 || len(resp.Reservations[0].Instances) == 0 {
+		err := fmt.Errorf("Source instance (%s) not found", s.SourceInstanceId)
+		state.Put("error", err)
+		ui.Error(err.Error())
+		return multistep.ActionHalt
+	}
+
+	instance := resp.Reservations[0].Instances[0]
+
+	if aws.StringValue(instance.RootDeviceType) != "ebs" {
+		err := fmt.Errorf("An image can only be created from an EBS-backed "+
+			"instance; instance %s is backed by %q",
+			s.SourceInstanceId, aws.StringValue(instance.RootDeviceType))
+		state.Put("error", err)
+		ui.Error(err.Error())
+		return multistep.ActionHalt
+	}
+
+	switch aws.StringValue(instance.State.Name) {
+	case ec2.InstanceStateNameStopped:
+	case ec2.InstanceStateNameRunning:
+		if !s.NoReboot {
+			ui.Message("The source instance is running; AWS will stop and " +
+				"reboot it while creating the image. Set no_reboot to leave " +
+				"it untouched.")
+		}
+	default:
+		err := fmt.Errorf("Source instance (%s) is %s; it must be stopped "+
+			"or running for an image to be created from it",
+			s.SourceInstanceId, aws.StringValue(instance.State.Name))
+		state.Put("error", err)
+		ui.Error(err.Error())
+		return multistep.ActionHalt
+	}
+
+	state.Put("instance", instance)
+	return multistep.ActionContinue
+}
+
+func (s *stepSourceInstanceInfo) Cleanup(multistep.StateBag) {
+	// No cleanup; the source instance is not managed by this builder.
+}
@@ -77,6 +77,14 @@ type Config struct {
 	// longer used and the following options become required:
 	// ami_virtualization_type, pre_mount_commands and root_volume_size.
 	FromScratch bool `mapstructure:"from_scratch" required:"false"`
+	// Experimental. Build the root filesystem in a local image file and
+	// write it straight into the new snapshot with the EBS direct APIs
+	// (StartSnapshot/PutSnapshotBlock), instead of creating and attaching
+	// an EBS volume. No EC2 instance or volume attach permissions are
+	// needed, so simple from-scratch images can be built anywhere; the
+	// host still needs losetup(8) and enough privileges to mount the
+	// image. Requires from_scratch. Default false.
+	EBSDirect bool `mapstructure:"ebs_direct" required:"false"`
 	// Options to supply the mount command when mounting devices. Each option
 	// will be prefixed with -o and supplied to the mount command ran by
 	// Packer. Because this command is ran in a shell, user discretion is
@@ -172,6 +180,16 @@ type Config struct {
 	// what architecture to use when registering the final AMI; valid options
 	// are "x86_64" or "arm64". Defaults to "x86_64".
 	Architecture string `mapstructure:"ami_architecture" required:"false"`
+	// The boot mode to register the final AMI with; valid options are
+	// "legacy-bios" or "uefi". When unset, instances launched from the AMI
+	// use the default boot mode of their instance type.
+	BootMode string `mapstructure:"boot_mode" required:"false"`
+	// Base64 representation of the non-volatile UEFI variable store to
+	// register the final AMI with, for example Secure Boot keys enrolled
+	// with a custom key. See the AWS documentation on
+	// [UEFI Secure Boot](https://docs.aws.amazon.com/AWSEC2/latest/UserGuide/uefi-secure-boot.html)
+	// for how to produce this blob. Requires `boot_mode` to be `uefi`.
+	UefiData string `mapstructure:"uefi_data" required:"false"`
 
 	ctx interpolate.Context
 }
@@ -319,6 +337,12 @@ func (b *Builder) Prepare(raws ...interface{}) ([]string, []string, error) {
 				errs, errors.New("If root_device_name is specified, ami_block_device_mappings must be specified"))
 		}
 	}
+
+	if b.config.EBSDirect && !b.config.FromScratch {
+		errs = packer.MultiErrorAppend(
+			errs, errors.New("ebs_direct requires from_scratch."))
+	}
+
 	valid := false
 	for _, validArch := range []string{"x86_64", "arm64"} {
 		if validArch == b.config.Architecture {
@@ -330,6 +354,13 @@ func (b *Builder) Prepare(raws ...interface{}) ([]string, []string, error) {
 		errs = packer.MultiErrorAppend(errs, errors.New(`The only valid ami_architecture values are "x86_64" and "arm64"`))
 	}
 
+	if b.config.BootMode != "" && !awscommon.ValidateBootMode(b.config.BootMode) {
+		errs = packer.MultiErrorAppend(errs, errors.New(`The only valid boot_mode values are "legacy-bios" and "uefi"`))
+	}
+	if b.config.UefiData != "" && b.config.BootMode != "uefi" {
+		errs = packer.MultiErrorAppend(errs, errors.New(`uefi_data requires boot_mode to be "uefi"`))
+	}
+
 	if errs != nil && len(errs.Errors) > 0 {
 		return nil, warns, errs
 	}
@@ -374,7 +405,12 @@ func (b *Builder) Run(ctx context.Context, ui packer.Ui, hook packer.Hook) (pack
 			DestAmiName:     b.config.AMIName,
 			ForceDeregister: b.config.AMIForceDeregister,
 		},
-		&StepInstanceInfo{},
+	}
+
+	if !b.config.EBSDirect {
+		// The EBS direct mode never talks to the instance metadata
+		// service, which is what makes it usable outside of EC2.
+		steps = append(steps, &StepInstanceInfo{})
 	}
 
 	if !b.config.FromScratch {
@@ -390,19 +426,31 @@ func (b *Builder) Run(ctx context.Context, ui packer.Ui, hook packer.Hook) (pack
 		)
 	}
 
+	if b.config.EBSDirect {
+		steps = append(steps,
+			&StepCreateImageFile{
+				RootVolumeSize: b.config.RootVolumeSize,
+				GeneratedData:  generatedData,
+			},
+		)
+	} else {
+		steps = append(steps,
+			&StepFlock{},
+			&StepPrepareDevice{
+				GeneratedData: generatedData,
+			},
+			&StepCreateVolume{
+				RootVolumeType: b.config.RootVolumeType,
+				RootVolumeSize: b.config.RootVolumeSize,
+				RootVolumeTags: b.config.RootVolumeTags,
+				Ctx:            b.config.ctx,
+			},
+			&StepAttachVolume{},
+			&StepEarlyUnflock{},
+		)
+	}
+
 	steps = append(steps,
-		&StepFlock{},
-		&StepPrepareDevice{
-			GeneratedData: generatedData,
-		},
-		&StepCreateVolume{
-			RootVolumeType: b.config.RootVolumeType,
-			RootVolumeSize: b.config.RootVolumeSize,
-			RootVolumeTags: b.config.RootVolumeTags,
-			Ctx:            b.config.ctx,
-		},
-		&StepAttachVolume{},
-		&StepEarlyUnflock{},
 		&chroot.StepPreMountCommands{
 			Commands: b.config.PreMountCommands,
 		},
@@ -422,7 +470,19 @@ func (b *Builder) Run(ctx context.Context, ui packer.Ui, hook packer.Hook) (pack
 		},
 		&chroot.StepChrootProvision{},
 		&chroot.StepEarlyCleanup{},
-		&StepSnapshot{},
+	)
+
+	if b.config.EBSDirect {
+		steps = append(steps,
+			&StepUploadSnapshot{
+				RootVolumeSize: b.config.RootVolumeSize,
+			},
+		)
+	} else {
+		steps = append(steps, &StepSnapshot{})
+	}
+
+	steps = append(steps,
 		&awscommon.StepDeregisterAMI{
 			AccessConfig:        &b.config.AccessConfig,
 			ForceDeregister:     b.config.AMIForceDeregister,
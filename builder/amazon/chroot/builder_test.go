@@ -234,3 +234,63 @@ func TestBuilderPrepare_ReturnGeneratedData(t *testing.T) {
 		t.Fatalf("Generated data should contain MountPath")
 	}
 }
+
+func TestBuilderPrepare_EBSDirect(t *testing.T) {
+	var b Builder
+	config := testConfig()
+
+	config["ebs_direct"] = true
+	_, warnings, err := b.Prepare(config)
+	if len(warnings) > 0 {
+		t.Fatalf("bad: %#v", warnings)
+	}
+	if err == nil {
+		t.Fatal("should have error: ebs_direct requires from_scratch")
+	}
+}
+
+func TestBuilderPrepare_BootMode(t *testing.T) {
+	var b Builder
+	config := testConfig()
+
+	config["boot_mode"] = "efi"
+	_, warnings, err := b.Prepare(config)
+	if len(warnings) > 0 {
+		t.Fatalf("bad: %#v", warnings)
+	}
+	if err == nil {
+		t.Fatal("should have error: unknown boot_mode")
+	}
+
+	config["boot_mode"] = "uefi"
+	_, warnings, err = b.Prepare(config)
+	if len(warnings) > 0 {
+		t.Fatalf("bad: %#v", warnings)
+	}
+	if err != nil {
+		t.Errorf("err: %s", err)
+	}
+}
+
+func TestBuilderPrepare_UefiData(t *testing.T) {
+	var b Builder
+	config := testConfig()
+
+	config["uefi_data"] = "foo"
+	_, warnings, err := b.Prepare(config)
+	if len(warnings) > 0 {
+		t.Fatalf("bad: %#v", warnings)
+	}
+	if err == nil {
+		t.Fatal("should have error: uefi_data requires boot_mode uefi")
+	}
+
+	config["boot_mode"] = "uefi"
+	_, warnings, err = b.Prepare(config)
+	if len(warnings) > 0 {
+		t.Fatalf("bad: %#v", warnings)
+	}
+	if err != nil {
+		t.Errorf("err: %s", err)
+	}
+}
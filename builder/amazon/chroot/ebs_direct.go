@@ -0,0 +1,135 @@
+package chroot
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	v4 "github.com/aws/aws-sdk-go/aws/signer/v4"
+	"github.com/hashicorp/packer/common/uuid"
+)
+
+// ebsDirectBlockSize is the block size used by the EBS direct APIs when
+// the StartSnapshot response doesn't report one.
+const ebsDirectBlockSize = 512 * 1024
+
+// ebsDirectClient is a minimal client for the EBS direct APIs
+// (StartSnapshot, PutSnapshotBlock and CompleteSnapshot). The vendored
+// aws-sdk-go predates the service, so the REST calls are built and
+// sigv4-signed by hand.
+type ebsDirectClient struct {
+	endpoint string
+	region   string
+	signer   *v4.Signer
+	client   *http.Client
+}
+
+func newEBSDirectClient(session *session.Session) *ebsDirectClient {
+	region := aws.StringValue(session.Config.Region)
+	return &ebsDirectClient{
+		endpoint: fmt.Sprintf("https://ebs.%s.amazonaws.com", region),
+		region:   region,
+		signer:   v4.NewSigner(session.Config.Credentials),
+		client:   http.DefaultClient,
+	}
+}
+
+type startSnapshotResponse struct {
+	SnapshotId string
+	BlockSize  int64
+	Status     string
+}
+
+// StartSnapshot creates a new pending snapshot of the given size in GiB.
+func (c *ebsDirectClient) StartSnapshot(volumeSize int64, description string) (*startSnapshotResponse, error) {
+	body, err := json.Marshal(map[string]interface{}{
+		"VolumeSize":  volumeSize,
+		"Description": description,
+		"ClientToken": uuid.TimeOrderedUUID(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp := new(startSnapshotResponse)
+	headers := map[string]string{"Content-Type": "application/json"}
+	if err := c.do("POST", "/snapshots", headers, body, resp); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// PutSnapshotBlock writes a single block of data to a pending snapshot.
+func (c *ebsDirectClient) PutSnapshotBlock(snapshotId string, blockIndex int64, data []byte) error {
+	checksum := sha256.Sum256(data)
+	headers := map[string]string{
+		"Content-Type":             "application/octet-stream",
+		"x-amz-Data-Length":        strconv.Itoa(len(data)),
+		"x-amz-Checksum":           base64.StdEncoding.EncodeToString(checksum[:]),
+		"x-amz-Checksum-Algorithm": "SHA256",
+	}
+
+	path := fmt.Sprintf("/snapshots/%s/blocks/%d", snapshotId, blockIndex)
+	return c.do("PUT", path, headers, data, nil)
+}
+
+// CompleteSnapshot seals a pending snapshot once all of its blocks have
+// been written.
+func (c *ebsDirectClient) CompleteSnapshot(snapshotId string, changedBlocks int64) error {
+	headers := map[string]string{
+		"x-amz-ChangedBlocksCount": strconv.FormatInt(changedBlocks, 10),
+	}
+
+	return c.do("POST", "/snapshots/completion/"+snapshotId, headers, nil, nil)
+}
+
+func (c *ebsDirectClient) do(method, path string, headers map[string]string, body []byte, out interface{}) error {
+	req, err := http.NewRequest(method, c.endpoint+path, nil)
+	if err != nil {
+		return err
+	}
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	var seeker io.ReadSeeker
+	if body != nil {
+		seeker = bytes.NewReader(body)
+	}
+	if _, err := c.signer.Sign(req, seeker, "ebs", c.region, time.Now()); err != nil {
+		return err
+	}
+	if body != nil {
+		req.Body = ioutil.NopCloser(bytes.NewReader(body))
+		req.ContentLength = int64(len(body))
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("%s %s: %s: %s", method, path, resp.Status, respBody)
+	}
+	if out != nil {
+		return json.Unmarshal(respBody, out)
+	}
+
+	return nil
+}
@@ -16,8 +16,9 @@ import (
 // available device location.
 //
 // Produces:
-//   device string - The location where the volume was attached.
-//   attach_cleanup CleanupFunc
+//
+//	device string - The location where the volume was attached.
+//	attach_cleanup CleanupFunc
 type StepAttachVolume struct {
 	attached bool
 	volumeId string
@@ -0,0 +1,100 @@
+package chroot
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/packer/builder"
+	"github.com/hashicorp/packer/common"
+	"github.com/hashicorp/packer/helper/multistep"
+	"github.com/hashicorp/packer/packer"
+	"github.com/hashicorp/packer/packer/tmp"
+)
+
+// StepCreateImageFile creates a sparse local image file for the root
+// filesystem and exposes it through a loop device, standing in for the
+// EBS volume that is normally created and attached.
+//
+// Produces:
+//
+//	device string - Path of the loop device backing the image file
+//	image_path string - Path of the image file
+type StepCreateImageFile struct {
+	RootVolumeSize int64
+	GeneratedData  *builder.GeneratedData
+
+	imagePath string
+	device    string
+}
+
+func (s *StepCreateImageFile) Run(ctx context.Context, state multistep.StateBag) multistep.StepAction {
+	ui := state.Get("ui").(packer.Ui)
+	wrappedCommand := state.Get("wrappedCommand").(common.CommandWrapper)
+
+	ui.Say("Creating the root image file...")
+	dir, err := tmp.Dir("packer-chroot")
+	if err == nil {
+		s.imagePath = filepath.Join(dir, "root.img")
+		var f *os.File
+		f, err = os.Create(s.imagePath)
+		if err == nil {
+			err = f.Truncate(s.RootVolumeSize * 1024 * 1024 * 1024)
+			f.Close()
+		}
+	}
+	if err != nil {
+		err := fmt.Errorf("Error creating image file: %s", err)
+		state.Put("error", err)
+		ui.Error(err.Error())
+		return multistep.ActionHalt
+	}
+
+	losetupCommand, err := wrappedCommand(
+		fmt.Sprintf("losetup --find --show %s", s.imagePath))
+	if err != nil {
+		err := fmt.Errorf("Error creating loop device command: %s", err)
+		state.Put("error", err)
+		ui.Error(err.Error())
+		return multistep.ActionHalt
+	}
+
+	out, err := common.ShellCommand(losetupCommand).Output()
+	if err != nil {
+		err := fmt.Errorf("Error setting up loop device: %s", err)
+		state.Put("error", err)
+		ui.Error(err.Error())
+		return multistep.ActionHalt
+	}
+	s.device = strings.TrimSpace(string(out))
+
+	ui.Message(fmt.Sprintf("Image file %s is available as %s", s.imagePath, s.device))
+	state.Put("device", s.device)
+	state.Put("image_path", s.imagePath)
+	s.GeneratedData.Put("Device", s.device)
+	return multistep.ActionContinue
+}
+
+func (s *StepCreateImageFile) Cleanup(state multistep.StateBag) {
+	if s.device != "" {
+		ui := state.Get("ui").(packer.Ui)
+		wrappedCommand := state.Get("wrappedCommand").(common.CommandWrapper)
+
+		detachCommand, err := wrappedCommand(
+			fmt.Sprintf("losetup --detach %s", s.device))
+		if err == nil {
+			err = common.ShellCommand(detachCommand).Run()
+		}
+		if err != nil {
+			ui.Error(fmt.Sprintf("Error detaching loop device %s: %s", s.device, err))
+		}
+		s.device = ""
+	}
+
+	if s.imagePath != "" {
+		os.RemoveAll(filepath.Dir(s.imagePath))
+		s.imagePath = ""
+	}
+}
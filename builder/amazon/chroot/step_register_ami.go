@@ -35,17 +35,26 @@ func (s *StepRegisterAMI) Run(ctx context.Context, state multistep.StateBag) mul
 	amiName := config.AMIName
 	state.Put("intermediary_image", false)
 	if config.AMIEncryptBootVolume.True() || s.AMISkipBuildRegion {
-		state.Put("intermediary_image", true)
-
-		// From AWS SDK docs: You can encrypt a copy of an unencrypted snapshot,
-		// but you cannot use it to create an unencrypted copy of an encrypted
-		// snapshot. Your default CMK for EBS is used unless you specify a
-		// non-default key using KmsKeyId.
-
-		// If encrypt_boot is nil or true, we need to create a temporary image
-		// so that in step_region_copy, we can copy it with the correct
-		// encryption
-		amiName = random.AlphaNum(7)
+		if config.AMIEncryptBootVolume.True() && !s.AMISkipBuildRegion &&
+			awscommon.EbsEncryptionMatchesDefault(ec2conn, config.AMIKmsKeyId) {
+			// The account already encrypts new EBS volumes by default with
+			// the requested key, so the image is encrypted as desired and
+			// the re-copy through a temporary AMI can be skipped.
+			ui.Say("Account encrypts EBS volumes by default with the " +
+				"requested key; skipping the encrypted re-copy of the AMI")
+		} else {
+			state.Put("intermediary_image", true)
+
+			// From AWS SDK docs: You can encrypt a copy of an unencrypted snapshot,
+			// but you cannot use it to create an unencrypted copy of an encrypted
+			// snapshot. Your default CMK for EBS is used unless you specify a
+			// non-default key using KmsKeyId.
+
+			// If encrypt_boot is nil or true, we need to create a temporary image
+			// so that in step_region_copy, we can copy it with the correct
+			// encryption
+			amiName = random.AlphaNum(7)
+		}
 	}
 
 	// Source Image is only required to be passed if the image is not from scratch
@@ -67,7 +76,8 @@ func (s *StepRegisterAMI) Run(ctx context.Context, state multistep.StateBag) mul
 		registerOpts.EnaSupport = aws.Bool(true)
 	}
 
-	registerResp, err := ec2conn.RegisterImage(registerOpts)
+	registerResp, err := awscommon.RegisterImage(ec2conn, registerOpts,
+		config.BootMode, config.UefiData, config.AMIMappings.GetGp3Throughput())
 	if err != nil {
 		state.Put("error", fmt.Errorf("Error registering AMI: %s", err))
 		ui.Error(state.Get("error").(error).Error())
@@ -0,0 +1,147 @@
+package chroot
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	awscommon "github.com/hashicorp/packer/builder/amazon/common"
+	"github.com/hashicorp/packer/helper/multistep"
+	"github.com/hashicorp/packer/packer"
+)
+
+// StepUploadSnapshot writes the local root image file to a new snapshot
+// through the EBS direct APIs, so no volume ever has to be attached.
+//
+// Produces:
+//
+//	snapshot_id string - ID of the created snapshot
+type StepUploadSnapshot struct {
+	RootVolumeSize int64
+
+	snapshotId string
+}
+
+func (s *StepUploadSnapshot) Run(ctx context.Context, state multistep.StateBag) multistep.StepAction {
+	ec2conn := state.Get("ec2").(*ec2.EC2)
+	session := state.Get("awsSession").(*session.Session)
+	imagePath := state.Get("image_path").(string)
+	ui := state.Get("ui").(packer.Ui)
+
+	f, err := os.Open(imagePath)
+	if err != nil {
+		err := fmt.Errorf("Error opening image file: %s", err)
+		state.Put("error", err)
+		ui.Error(err.Error())
+		return multistep.ActionHalt
+	}
+	defer f.Close()
+
+	client := newEBSDirectClient(session)
+
+	ui.Say("Creating snapshot through the EBS direct APIs...")
+	description := fmt.Sprintf("Packer: %s", time.Now().String())
+	snap, err := client.StartSnapshot(s.RootVolumeSize, description)
+	if err != nil {
+		err := fmt.Errorf("Error starting snapshot: %s", err)
+		state.Put("error", err)
+		ui.Error(err.Error())
+		return multistep.ActionHalt
+	}
+
+	// Set the snapshot ID so we can delete it later
+	s.snapshotId = snap.SnapshotId
+	ui.Message(fmt.Sprintf("Snapshot ID: %s", s.snapshotId))
+
+	blockSize := snap.BlockSize
+	if blockSize == 0 {
+		blockSize = ebsDirectBlockSize
+	}
+
+	// Upload every block that holds data; holes in the sparse image file
+	// read as zeros and can simply be left out of the snapshot.
+	buf := make([]byte, blockSize)
+	zero := make([]byte, blockSize)
+	var blockIndex, changedBlocks int64
+	for {
+		n, readErr := io.ReadFull(f, buf)
+		if n > 0 {
+			block := buf[:n]
+			if n < len(buf) {
+				padded := make([]byte, len(buf))
+				copy(padded, block)
+				block = padded
+			}
+
+			if !bytes.Equal(block, zero) {
+				if err := client.PutSnapshotBlock(s.snapshotId, blockIndex, block); err != nil {
+					err := fmt.Errorf("Error writing snapshot block %d: %s", blockIndex, err)
+					state.Put("error", err)
+					ui.Error(err.Error())
+					return multistep.ActionHalt
+				}
+				changedBlocks++
+			}
+			blockIndex++
+		}
+		if readErr == io.EOF || readErr == io.ErrUnexpectedEOF {
+			break
+		}
+		if readErr != nil {
+			err := fmt.Errorf("Error reading image file: %s", readErr)
+			state.Put("error", err)
+			ui.Error(err.Error())
+			return multistep.ActionHalt
+		}
+	}
+	ui.Message(fmt.Sprintf("Uploaded %d changed blocks", changedBlocks))
+
+	if err := client.CompleteSnapshot(s.snapshotId, changedBlocks); err != nil {
+		err := fmt.Errorf("Error completing snapshot: %s", err)
+		state.Put("error", err)
+		ui.Error(err.Error())
+		return multistep.ActionHalt
+	}
+
+	// Wait for the snapshot to be ready
+	err = awscommon.WaitUntilSnapshotDone(ctx, ec2conn, s.snapshotId)
+	if err != nil {
+		err := fmt.Errorf("Error waiting for snapshot: %s", err)
+		state.Put("error", err)
+		ui.Error(err.Error())
+		return multistep.ActionHalt
+	}
+
+	state.Put("snapshot_id", s.snapshotId)
+
+	snapshots := map[string][]string{
+		*ec2conn.Config.Region: {s.snapshotId},
+	}
+	state.Put("snapshots", snapshots)
+
+	return multistep.ActionContinue
+}
+
+func (s *StepUploadSnapshot) Cleanup(state multistep.StateBag) {
+	if s.snapshotId == "" {
+		return
+	}
+
+	_, cancelled := state.GetOk(multistep.StateCancelled)
+	_, halted := state.GetOk(multistep.StateHalted)
+
+	if cancelled || halted {
+		ec2conn := state.Get("ec2").(*ec2.EC2)
+		ui := state.Get("ui").(packer.Ui)
+		ui.Say("Removing snapshot since we cancelled or halted...")
+		_, err := ec2conn.DeleteSnapshot(&ec2.DeleteSnapshotInput{SnapshotId: &s.snapshotId})
+		if err != nil {
+			ui.Error(fmt.Sprintf("Error: %s", err))
+		}
+	}
+}
@@ -9,8 +9,10 @@ import (
 	"log"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/client"
 	"github.com/aws/aws-sdk-go/aws/credentials"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/ec2"
@@ -64,6 +66,16 @@ type AccessConfig struct {
 	// where requests are being throttled or experiencing transient failures.
 	// The delay between the subsequent API calls increases exponentially.
 	MaxRetries int `mapstructure:"max_retries" required:"false"`
+	// How retries of throttled API calls are paced. With the default
+	// (`legacy`) mode every request backs off independently. With
+	// `adaptive`, throttled responses also stretch a client-side delay
+	// that is shared by every API call this builder makes, so concurrent
+	// builds back off collectively instead of cascading into
+	// RequestLimitExceeded failures.
+	RetryMode string `mapstructure:"retry_mode" required:"false"`
+	// A timeout applied to every AWS API call, including retries, for
+	// example "30s". By default API calls do not time out client side.
+	APITimeout string `mapstructure:"api_timeout" required:"false"`
 	// The MFA
 	// [TOTP](https://en.wikipedia.org/wiki/Time-based_One-time_Password_Algorithm)
 	// code. This should probably be a user variable since it changes all the
@@ -142,6 +154,16 @@ func (c *AccessConfig) Session() (*session.Session, error) {
 		config = config.WithMaxRetries(c.MaxRetries)
 	}
 
+	if c.RetryMode == "adaptive" {
+		retryer := &AdaptiveRetryer{}
+		if c.MaxRetries > 0 {
+			retryer.NumMaxRetries = c.MaxRetries
+		} else {
+			retryer.NumMaxRetries = client.DefaultRetryerMaxNumRetries
+		}
+		config.Retryer = retryer
+	}
+
 	staticCreds := credentials.NewStaticCredentials(c.AccessKey, c.SecretKey, c.Token)
 	if _, err := staticCreds.Get(); err != credentials.ErrStaticCredentialsEmpty {
 		config.WithCredentials(staticCreds)
@@ -156,6 +178,13 @@ func (c *AccessConfig) Session() (*session.Session, error) {
 	}
 
 	config = config.WithHTTPClient(cleanhttp.DefaultClient())
+	if c.APITimeout != "" {
+		timeout, err := time.ParseDuration(c.APITimeout)
+		if err != nil {
+			return nil, fmt.Errorf("Error parsing api_timeout: %s", err)
+		}
+		config.HTTPClient.Timeout = timeout
+	}
 	transport := config.HTTPClient.Transport.(*http.Transport)
 	if c.InsecureSkipTLSVerify {
 		transport.TLSClientConfig = &tls.Config{
@@ -284,6 +313,19 @@ func (c *AccessConfig) Prepare(ctx *interpolate.Context) []error {
 			fmt.Errorf("`access_key` and `secret_key` must both be either set or not set."))
 	}
 
+	switch c.RetryMode {
+	case "", "legacy", "adaptive":
+	default:
+		errs = append(errs,
+			fmt.Errorf("Unknown retry_mode %q; expected legacy or adaptive.", c.RetryMode))
+	}
+
+	if c.APITimeout != "" {
+		if _, err := time.ParseDuration(c.APITimeout); err != nil {
+			errs = append(errs, fmt.Errorf("Error parsing api_timeout: %s", err))
+		}
+	}
+
 	return errs
 }
 
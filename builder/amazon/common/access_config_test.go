@@ -52,6 +52,34 @@ func TestAccessConfigPrepare_Region(t *testing.T) {
 	}
 }
 
+func TestAccessConfigPrepare_RetryMode(t *testing.T) {
+	c := testAccessConfig()
+
+	c.RetryMode = "dynamic"
+	if err := c.Prepare(nil); err == nil {
+		t.Fatalf("should have retry_mode validation err: %s", c.RetryMode)
+	}
+
+	c.RetryMode = "adaptive"
+	if err := c.Prepare(nil); err != nil {
+		t.Fatalf("shouldn't have err: %s", err)
+	}
+}
+
+func TestAccessConfigPrepare_APITimeout(t *testing.T) {
+	c := testAccessConfig()
+
+	c.APITimeout = "eventually"
+	if err := c.Prepare(nil); err == nil {
+		t.Fatalf("should have api_timeout validation err: %s", c.APITimeout)
+	}
+
+	c.APITimeout = "30s"
+	if err := c.Prepare(nil); err != nil {
+		t.Fatalf("shouldn't have err: %s", err)
+	}
+}
+
 func TestAccessConfigPrepare_RegionRestricted(t *testing.T) {
 	c := testAccessConfig()
 
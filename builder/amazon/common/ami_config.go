@@ -137,6 +137,20 @@ type AMIConfig struct {
 	// to create volumes from the snapshot(s). all will make the snapshot
 	// publicly accessible.
 	SnapshotGroups []string `mapstructure:"snapshot_groups" required:"false"`
+	// Enable [EC2 Windows fast
+	// launch](https://docs.aws.amazon.com/AWSEC2/latest/WindowsGuide/windows-ami-version-history.html)
+	// on the resulting AMI and wait for it to become ready. Fast launch
+	// pre-provisions snapshots so that instances launched from the AMI boot
+	// faster, which matters for Windows auto scaling groups. Only supported
+	// for EBS-backed Windows AMIs. Default `false`.
+	AMIFastLaunch bool `mapstructure:"enable_fast_launch" required:"false"`
+	// The number of pre-provisioned snapshots to keep on hand for the AMI
+	// when fast launch is enabled. When unset, the AWS default is used.
+	AMIFastLaunchTargetResourceCount int `mapstructure:"fast_launch_target_resource_count" required:"false"`
+	// The maximum number of instances AWS launches in parallel to create the
+	// pre-provisioned snapshots when fast launch is enabled. When unset, the
+	// AWS default is used.
+	AMIFastLaunchMaxParallelLaunches int `mapstructure:"fast_launch_max_parallel_launches" required:"false"`
 }
 
 func stringInSlice(s []string, searchstr string) bool {
@@ -154,6 +168,15 @@ func (c *AMIConfig) Prepare(accessConfig *AccessConfig, ctx *interpolate.Context
 	errs = append(errs, c.SnapshotTag.CopyOn(&c.SnapshotTags)...)
 	errs = append(errs, c.AMITag.CopyOn(&c.AMITags)...)
 
+	// Fail on bad tag interpolations now instead of at tagging time,
+	// when the AMI has already been created.
+	ictx := interpolate.Context{}
+	if ctx != nil {
+		ictx = *ctx
+	}
+	errs = append(errs, TagMap(c.AMITags).Validate(ictx)...)
+	errs = append(errs, TagMap(c.SnapshotTags).Validate(ictx)...)
+
 	if c.AMIName == "" {
 		errs = append(errs, fmt.Errorf("ami_name must be specified"))
 	}
@@ -197,9 +220,14 @@ func (c *AMIConfig) Prepare(accessConfig *AccessConfig, ctx *interpolate.Context
 	}
 
 	if len(kmsKeys) > 0 && !c.AMIEncryptBootVolume.True() {
-		errs = append(errs, fmt.Errorf("If you have set either "+
-			"region_kms_key_ids or kms_key_id, encrypt_boot must also be true."))
-
+		// Providing a key is only useful when encrypting, so treat an unset
+		// encrypt_boot as true instead of erroring out at RegisterImage time.
+		if c.AMIEncryptBootVolume == config.TriUnset {
+			c.AMIEncryptBootVolume = config.TriTrue
+		} else {
+			errs = append(errs, fmt.Errorf("If you have set either "+
+				"region_kms_key_ids or kms_key_id, encrypt_boot cannot be false."))
+		}
 	}
 	for _, kmsKey := range kmsKeys {
 		if !validateKmsKey(kmsKey) {
@@ -225,6 +253,22 @@ func (c *AMIConfig) Prepare(accessConfig *AccessConfig, ctx *interpolate.Context
 		errs = append(errs, fmt.Errorf("ami_name must be between 3 and 128 characters long"))
 	}
 
+	if !c.AMIFastLaunch {
+		if c.AMIFastLaunchTargetResourceCount != 0 {
+			errs = append(errs, fmt.Errorf("fast_launch_target_resource_count may only be set when enable_fast_launch is true"))
+		}
+		if c.AMIFastLaunchMaxParallelLaunches != 0 {
+			errs = append(errs, fmt.Errorf("fast_launch_max_parallel_launches may only be set when enable_fast_launch is true"))
+		}
+	} else {
+		if c.AMIFastLaunchTargetResourceCount < 0 {
+			errs = append(errs, fmt.Errorf("fast_launch_target_resource_count must be positive"))
+		}
+		if c.AMIFastLaunchMaxParallelLaunches < 0 {
+			errs = append(errs, fmt.Errorf("fast_launch_max_parallel_launches must be positive"))
+		}
+	}
+
 	if c.AMIName != templateCleanAMIName(c.AMIName) {
 		errs = append(errs, fmt.Errorf("AMIName should only contain "+
 			"alphanumeric characters, parentheses (()), square brackets ([]), spaces "+
@@ -175,6 +175,25 @@ func TestAMIConfigPrepare_Share_EncryptedBoot(t *testing.T) {
 	}
 }
 
+func TestAMIConfigPrepare_KmsKeyIdImpliesEncryptBoot(t *testing.T) {
+	c := testAMIConfig()
+	c.AMIKmsKeyId = "89c3fb9a-de87-4f2a-aedc-fddc5138193c"
+
+	accessConf := testAccessConfig()
+
+	if err := c.Prepare(accessConf, nil); err != nil {
+		t.Fatalf("should not have error: %s", err)
+	}
+	if !c.AMIEncryptBootVolume.True() {
+		t.Fatalf("encrypt_boot should default to true when kms_key_id is set")
+	}
+
+	c.AMIEncryptBootVolume = config.TriFalse
+	if err := c.Prepare(accessConf, nil); err == nil {
+		t.Fatal("should have error b/c encrypt_boot is false but kms_key_id is set")
+	}
+}
+
 func TestAMIConfigPrepare_ValidateKmsKey(t *testing.T) {
 	c := testAMIConfig()
 	c.AMIEncryptBootVolume = config.TriTrue
@@ -22,16 +22,17 @@ import (
 // build instance at launch using a specific non-default kms key:
 //
 // ```json
-// [{
-//		"device_name": "/dev/sda1",
-//		"encrypted": true,
-//		"kms_key_id": "1a2b3c4d-5e6f-1a2b-3c4d-5e6f1a2b3c4d"
-// }]
+//
+//	[{
+//			"device_name": "/dev/sda1",
+//			"encrypted": true,
+//			"kms_key_id": "1a2b3c4d-5e6f-1a2b-3c4d-5e6f1a2b3c4d"
+//	}]
+//
 // ```
 //
 // Documentation for Block Devices Mappings can be found here:
 // https://docs.aws.amazon.com/AWSEC2/latest/UserGuide/block-device-mapping-concepts.html
-//
 type BlockDevice struct {
 	// Indicates whether the EBS volume is deleted on instance termination.
 	// Default false. NOTE: If this value is not explicitly set to true and
@@ -44,13 +45,16 @@ type BlockDevice struct {
 	// Indicates whether or not to encrypt the volume. By default, Packer will
 	// keep the encryption setting to what it was in the source image. Setting
 	// false will result in an unencrypted device, and true will result in an
-	// encrypted one.
+	// encrypted one. When a kms_key_id is provided, this defaults to true.
 	Encrypted config.Trilean `mapstructure:"encrypted" required:"false"`
 	// The number of I/O operations per second (IOPS) that the volume supports.
 	// See the documentation on
 	// [IOPs](https://docs.aws.amazon.com/AWSEC2/latest/APIReference/API_EbsBlockDevice.html)
 	// for more information
 	IOPS int64 `mapstructure:"iops" required:"false"`
+	// The throughput that the volume supports, in MiB/s. Only valid for
+	// gp3 volumes. Valid range is 125 to 1000.
+	Throughput int64 `mapstructure:"throughput" required:"false"`
 	// Suppresses the specified device included in the block device mapping of
 	// the AMI.
 	NoDevice bool `mapstructure:"no_device" required:"false"`
@@ -59,9 +63,9 @@ type BlockDevice struct {
 	// The virtual device name. See the documentation on Block Device Mapping
 	// for more information.
 	VirtualName string `mapstructure:"virtual_name" required:"false"`
-	// The volume type. gp2 for General Purpose (SSD) volumes, io1 for
-	// Provisioned IOPS (SSD) volumes, st1 for Throughput Optimized HDD, sc1
-	// for Cold HDD, and standard for Magnetic volumes.
+	// The volume type. gp2 and gp3 for General Purpose (SSD) volumes, io1
+	// and io2 for Provisioned IOPS (SSD) volumes, st1 for Throughput
+	// Optimized HDD, sc1 for Cold HDD, and standard for Magnetic volumes.
 	VolumeType string `mapstructure:"volume_type" required:"false"`
 	// The size of the volume, in GiB. Required if not specifying a
 	// snapshot_id.
@@ -87,6 +91,41 @@ func (bds BlockDevices) BuildEC2BlockDeviceMappings() []*ec2.BlockDeviceMapping
 	return blockDevices
 }
 
+// GetGp3Throughput returns the configured throughput of every gp3 device,
+// keyed by device name, for use with SpliceEbsThroughput.
+func (bds BlockDevices) GetGp3Throughput() map[string]int64 {
+	throughput := map[string]int64{}
+	for _, bd := range bds {
+		if bd.VolumeType == "gp3" && bd.Throughput > 0 {
+			throughput[bd.DeviceName] = bd.Throughput
+		}
+	}
+	return throughput
+}
+
+// OverrideRootVolumeSize rewrites the mapping for the given root device so
+// that only its size changes, leaving every other mapping untouched. If the
+// root device is not mapped yet, a mapping with just the new size is added
+// and the remaining settings are inherited from the source AMI.
+func OverrideRootVolumeSize(mappings []*ec2.BlockDeviceMapping, rootDeviceName string, size int64) []*ec2.BlockDeviceMapping {
+	for _, mapping := range mappings {
+		if aws.StringValue(mapping.DeviceName) == rootDeviceName {
+			if mapping.Ebs == nil {
+				mapping.Ebs = &ec2.EbsBlockDevice{}
+			}
+			mapping.Ebs.VolumeSize = aws.Int64(size)
+			return mappings
+		}
+	}
+
+	return append(mappings, &ec2.BlockDeviceMapping{
+		DeviceName: aws.String(rootDeviceName),
+		Ebs: &ec2.EbsBlockDevice{
+			VolumeSize: aws.Int64(size),
+		},
+	})
+}
+
 func (blockDevice BlockDevice) BuildEC2BlockDeviceMapping() *ec2.BlockDeviceMapping {
 
 	mapping := &ec2.BlockDeviceMapping{
@@ -115,9 +154,18 @@ func (blockDevice BlockDevice) BuildEC2BlockDeviceMapping() *ec2.BlockDeviceMapp
 		ebsBlockDevice.VolumeSize = aws.Int64(blockDevice.VolumeSize)
 	}
 
-	// IOPS is only valid for io1 type
-	if blockDevice.VolumeType == "io1" {
+	// IOPS is only valid for io1, io2 and gp3 types
+	switch blockDevice.VolumeType {
+	case "io1", "io2":
 		ebsBlockDevice.Iops = aws.Int64(blockDevice.IOPS)
+	case "gp3":
+		// gp3 volumes have a baseline of 3000 IOPS when none is given.
+		// The throughput cannot be carried on the mapping because the
+		// vendored AWS SDK predates the field; it is added to the
+		// serialized requests by SpliceEbsThroughput instead.
+		if blockDevice.IOPS > 0 {
+			ebsBlockDevice.Iops = aws.Int64(blockDevice.IOPS)
+		}
 	}
 
 	// You cannot specify Encrypted if you specify a Snapshot ID
@@ -142,9 +190,40 @@ func (b *BlockDevice) Prepare(ctx *interpolate.Context) error {
 	}
 
 	// Warn that encrypted must be true or nil when setting kms_key_id
-	if b.KmsKeyId != "" && b.Encrypted.False() {
-		return fmt.Errorf("The device %v, must also have `encrypted: "+
-			"true` when setting a kms_key_id.", b.DeviceName)
+	if b.KmsKeyId != "" {
+		if b.Encrypted.False() {
+			return fmt.Errorf("The device %v, must also have `encrypted: "+
+				"true` when setting a kms_key_id.", b.DeviceName)
+		}
+		if !validateKmsKey(b.KmsKeyId) {
+			return fmt.Errorf("%q is not a valid KMS Key Id for device %v.",
+				b.KmsKeyId, b.DeviceName)
+		}
+		// Providing a key is only useful when encrypting, so treat an unset
+		// encrypted as true instead of failing at RegisterImage time.
+		if b.Encrypted == config.TriUnset {
+			b.Encrypted = config.TriTrue
+		}
+	}
+
+	if b.IOPS > 0 {
+		switch b.VolumeType {
+		case "io1", "io2", "gp3":
+		default:
+			return fmt.Errorf("The device %v: iops is only valid for "+
+				"io1, io2 and gp3 volume types.", b.DeviceName)
+		}
+	}
+
+	if b.Throughput > 0 {
+		if b.VolumeType != "gp3" {
+			return fmt.Errorf("The device %v: throughput is only valid "+
+				"for the gp3 volume type.", b.DeviceName)
+		}
+		if b.Throughput < 125 || b.Throughput > 1000 {
+			return fmt.Errorf("The device %v: throughput must be between "+
+				"125 and 1000 MiB/s.", b.DeviceName)
+		}
 	}
 
 	_, err := interpolate.RenderInterface(&b, ctx)
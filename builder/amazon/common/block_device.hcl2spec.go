@@ -13,6 +13,7 @@ type FlatBlockDevice struct {
 	DeviceName          *string `mapstructure:"device_name" required:"false" cty:"device_name"`
 	Encrypted           *bool   `mapstructure:"encrypted" required:"false" cty:"encrypted"`
 	IOPS                *int64  `mapstructure:"iops" required:"false" cty:"iops"`
+	Throughput          *int64  `mapstructure:"throughput" required:"false" cty:"throughput"`
 	NoDevice            *bool   `mapstructure:"no_device" required:"false" cty:"no_device"`
 	SnapshotId          *string `mapstructure:"snapshot_id" required:"false" cty:"snapshot_id"`
 	VirtualName         *string `mapstructure:"virtual_name" required:"false" cty:"virtual_name"`
@@ -37,6 +38,7 @@ func (*FlatBlockDevice) HCL2Spec() map[string]hcldec.Spec {
 		"device_name":           &hcldec.AttrSpec{Name: "device_name", Type: cty.String, Required: false},
 		"encrypted":             &hcldec.AttrSpec{Name: "encrypted", Type: cty.Bool, Required: false},
 		"iops":                  &hcldec.AttrSpec{Name: "iops", Type: cty.Number, Required: false},
+		"throughput":            &hcldec.AttrSpec{Name: "throughput", Type: cty.Number, Required: false},
 		"no_device":             &hcldec.AttrSpec{Name: "no_device", Type: cty.Bool, Required: false},
 		"snapshot_id":           &hcldec.AttrSpec{Name: "snapshot_id", Type: cty.String, Required: false},
 		"virtual_name":          &hcldec.AttrSpec{Name: "virtual_name", Type: cty.String, Required: false},
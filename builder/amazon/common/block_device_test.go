@@ -66,6 +66,64 @@ func TestBlockDevice(t *testing.T) {
 				},
 			},
 		},
+		{
+			Config: &BlockDevice{
+				DeviceName:          "/dev/sdb",
+				VolumeType:          "io2",
+				VolumeSize:          8,
+				DeleteOnTermination: true,
+				IOPS:                1000,
+			},
+
+			Result: &ec2.BlockDeviceMapping{
+				DeviceName: aws.String("/dev/sdb"),
+				Ebs: &ec2.EbsBlockDevice{
+					VolumeType:          aws.String("io2"),
+					VolumeSize:          aws.Int64(8),
+					DeleteOnTermination: aws.Bool(true),
+					Iops:                aws.Int64(1000),
+				},
+			},
+		},
+		{
+			Config: &BlockDevice{
+				DeviceName:          "/dev/sdb",
+				VolumeType:          "gp3",
+				VolumeSize:          8,
+				DeleteOnTermination: true,
+				IOPS:                4000,
+				Throughput:          300,
+			},
+
+			// The throughput is not part of the mapping; it is spliced
+			// into the serialized requests by SpliceEbsThroughput.
+			Result: &ec2.BlockDeviceMapping{
+				DeviceName: aws.String("/dev/sdb"),
+				Ebs: &ec2.EbsBlockDevice{
+					VolumeType:          aws.String("gp3"),
+					VolumeSize:          aws.Int64(8),
+					DeleteOnTermination: aws.Bool(true),
+					Iops:                aws.Int64(4000),
+				},
+			},
+		},
+		{
+			Config: &BlockDevice{
+				DeviceName:          "/dev/sdb",
+				VolumeType:          "gp3",
+				VolumeSize:          8,
+				DeleteOnTermination: true,
+			},
+
+			Result: &ec2.BlockDeviceMapping{
+				DeviceName: aws.String("/dev/sdb"),
+				Ebs: &ec2.EbsBlockDevice{
+					VolumeType:          aws.String("gp3"),
+					VolumeSize:          aws.Int64(8),
+					DeleteOnTermination: aws.Bool(true),
+				},
+			},
+		},
 		{
 			Config: &BlockDevice{
 				DeviceName:          "/dev/sdb",
@@ -163,3 +221,140 @@ func TestBlockDevice(t *testing.T) {
 		}
 	}
 }
+
+func TestBlockDevice_Prepare(t *testing.T) {
+	cases := []struct {
+		Config *BlockDevice
+		Err    bool
+	}{
+		{
+			Config: &BlockDevice{
+				DeviceName: "/dev/sdb",
+				VolumeType: "gp3",
+				IOPS:       4000,
+				Throughput: 300,
+			},
+			Err: false,
+		},
+		{
+			Config: &BlockDevice{
+				DeviceName: "/dev/sdb",
+				VolumeType: "gp2",
+				IOPS:       4000,
+			},
+			Err: true,
+		},
+		{
+			Config: &BlockDevice{
+				DeviceName: "/dev/sdb",
+				VolumeType: "gp2",
+				Throughput: 300,
+			},
+			Err: true,
+		},
+		{
+			Config: &BlockDevice{
+				DeviceName: "/dev/sdb",
+				VolumeType: "gp3",
+				Throughput: 2000,
+			},
+			Err: true,
+		},
+		{
+			Config: &BlockDevice{
+				DeviceName: "/dev/sdb",
+				VolumeType: "io2",
+				IOPS:       1000,
+			},
+			Err: false,
+		},
+		{
+			Config: &BlockDevice{
+				DeviceName: "/dev/sdb",
+				KmsKeyId:   "alias/1234abcd",
+			},
+			Err: false,
+		},
+		{
+			Config: &BlockDevice{
+				DeviceName: "/dev/sdb",
+				KmsKeyId:   "arn:aws:kms:us-east-1:012345678910:key/abcd1234-a123-456a-a12b-a123b4cd56ef",
+			},
+			Err: false,
+		},
+		{
+			Config: &BlockDevice{
+				DeviceName: "/dev/sdb",
+				KmsKeyId:   "i-am-not-a-kms-key",
+			},
+			Err: true,
+		},
+		{
+			Config: &BlockDevice{
+				DeviceName: "/dev/sdb",
+				KmsKeyId:   "alias/1234abcd",
+				Encrypted:  config.TriFalse,
+			},
+			Err: true,
+		},
+	}
+
+	for _, tc := range cases {
+		err := tc.Config.Prepare(nil)
+		if tc.Err && err == nil {
+			t.Fatalf("should have error for device %#v", tc.Config)
+		}
+		if !tc.Err && err != nil {
+			t.Fatalf("should not have error for device %#v: %s", tc.Config, err)
+		}
+	}
+}
+
+func TestBlockDevice_KmsKeyIdImpliesEncrypted(t *testing.T) {
+	blockDevice := BlockDevice{
+		DeviceName: "/dev/sdb",
+		KmsKeyId:   "alias/1234abcd",
+	}
+
+	if err := blockDevice.Prepare(nil); err != nil {
+		t.Fatalf("should not have error: %s", err)
+	}
+
+	if !blockDevice.Encrypted.True() {
+		t.Fatalf("encrypted should default to true when kms_key_id is set")
+	}
+}
+
+func TestBlockDevice_OverrideRootVolumeSize(t *testing.T) {
+	mappings := []*ec2.BlockDeviceMapping{
+		{
+			DeviceName: aws.String("/dev/xvda"),
+			Ebs:        &ec2.EbsBlockDevice{VolumeSize: aws.Int64(8)},
+		},
+		{
+			DeviceName: aws.String("/dev/sdb"),
+			Ebs:        &ec2.EbsBlockDevice{VolumeSize: aws.Int64(100)},
+		},
+	}
+
+	result := OverrideRootVolumeSize(mappings, "/dev/xvda", 40)
+	if len(result) != 2 {
+		t.Fatalf("unexpected number of mappings: %d", len(result))
+	}
+	if *result[0].Ebs.VolumeSize != 40 {
+		t.Fatalf("root volume size should be overridden, got %d", *result[0].Ebs.VolumeSize)
+	}
+	if *result[1].Ebs.VolumeSize != 100 {
+		t.Fatalf("non-root mappings should be untouched, got %d", *result[1].Ebs.VolumeSize)
+	}
+
+	// A root device that is not mapped yet gets a mapping with just the
+	// new size.
+	result = OverrideRootVolumeSize(nil, "/dev/xvda", 40)
+	if len(result) != 1 {
+		t.Fatalf("unexpected number of mappings: %d", len(result))
+	}
+	if *result[0].DeviceName != "/dev/xvda" || *result[0].Ebs.VolumeSize != 40 {
+		t.Fatalf("bad added root mapping: %#v", result[0])
+	}
+}
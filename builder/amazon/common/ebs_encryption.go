@@ -0,0 +1,38 @@
+package common
+
+import (
+	"log"
+
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+)
+
+// EbsEncryptionMatchesDefault reports whether the account already encrypts
+// new EBS volumes by default in the current region with the requested KMS
+// key, in which case re-copying an image just to encrypt it would be a
+// no-op. An empty kmsKeyID means the default key is desired. Errors are
+// treated as "no" so that callers fall back to the explicit encrypted copy;
+// reading the setting requires the ec2:GetEbsEncryptionByDefault
+// permission, which not every build user has.
+func EbsEncryptionMatchesDefault(ec2conn ec2iface.EC2API, kmsKeyID string) bool {
+	resp, err := ec2conn.GetEbsEncryptionByDefault(&ec2.GetEbsEncryptionByDefaultInput{})
+	if err != nil {
+		log.Printf("[WARN] Unable to read EBS encryption-by-default setting: %s", err)
+		return false
+	}
+	if resp.EbsEncryptionByDefault == nil || !*resp.EbsEncryptionByDefault {
+		return false
+	}
+
+	if kmsKeyID == "" {
+		return true
+	}
+
+	keyResp, err := ec2conn.GetEbsDefaultKmsKeyId(&ec2.GetEbsDefaultKmsKeyIdInput{})
+	if err != nil {
+		log.Printf("[WARN] Unable to read default EBS KMS key: %s", err)
+		return false
+	}
+
+	return keyResp.KmsKeyId != nil && *keyResp.KmsKeyId == kmsKeyID
+}
@@ -0,0 +1,52 @@
+package common
+
+import (
+	"io/ioutil"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws/request"
+)
+
+// SpliceEbsThroughput registers a build handler on req that adds the gp3
+// throughput of the given devices to the serialized query, matching the
+// block device mappings on their device name below prefix
+// ("BlockDeviceMapping" for RunInstances, RegisterImage and CreateImage,
+// "LaunchTemplateData.BlockDeviceMapping" for launch templates). The
+// vendored AWS SDK predates the Throughput field on EBS block devices, so
+// the parameter cannot be set on the input structs.
+func SpliceEbsThroughput(req *request.Request, prefix string, throughput map[string]int64) {
+	if len(throughput) == 0 {
+		return
+	}
+	req.Handlers.Build.PushBack(func(r *request.Request) {
+		body, err := ioutil.ReadAll(r.GetBody())
+		if err != nil {
+			r.Error = err
+			return
+		}
+		vals, err := url.ParseQuery(string(body))
+		if err != nil {
+			r.Error = err
+			return
+		}
+		spliceThroughputQuery(vals, prefix, throughput)
+		r.SetBufferBody([]byte(vals.Encode()))
+	})
+}
+
+func spliceThroughputQuery(vals url.Values, prefix string, throughput map[string]int64) {
+	for key, value := range vals {
+		if !strings.HasPrefix(key, prefix+".") ||
+			!strings.HasSuffix(key, ".DeviceName") || len(value) != 1 {
+			continue
+		}
+		t, ok := throughput[value[0]]
+		if !ok {
+			continue
+		}
+		vals.Set(strings.TrimSuffix(key, "DeviceName")+"Ebs.Throughput",
+			strconv.FormatInt(t, 10))
+	}
+}
@@ -0,0 +1,51 @@
+package common
+
+import (
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+func TestBlockDevices_GetGp3Throughput(t *testing.T) {
+	bds := BlockDevices{
+		{DeviceName: "/dev/sda1", VolumeType: "gp3", Throughput: 300},
+		{DeviceName: "/dev/sdb", VolumeType: "gp3"},
+		{DeviceName: "/dev/sdc", VolumeType: "gp2"},
+	}
+
+	expected := map[string]int64{"/dev/sda1": 300}
+	if got := bds.GetGp3Throughput(); !reflect.DeepEqual(got, expected) {
+		t.Fatalf("bad throughput map: %#v", got)
+	}
+}
+
+func TestSpliceThroughputQuery(t *testing.T) {
+	vals := url.Values{}
+	vals.Set("Action", "RunInstances")
+	vals.Set("BlockDeviceMapping.1.DeviceName", "/dev/sda1")
+	vals.Set("BlockDeviceMapping.1.Ebs.VolumeType", "gp2")
+	vals.Set("BlockDeviceMapping.2.DeviceName", "/dev/sdb")
+	vals.Set("BlockDeviceMapping.2.Ebs.VolumeType", "gp3")
+
+	spliceThroughputQuery(vals, "BlockDeviceMapping", map[string]int64{"/dev/sdb": 300})
+
+	if got := vals.Get("BlockDeviceMapping.2.Ebs.Throughput"); got != "300" {
+		t.Fatalf("bad throughput for /dev/sdb: %q", got)
+	}
+	if got := vals.Get("BlockDeviceMapping.1.Ebs.Throughput"); got != "" {
+		t.Fatalf("unexpected throughput for /dev/sda1: %q", got)
+	}
+}
+
+func TestSpliceThroughputQuery_launchTemplate(t *testing.T) {
+	vals := url.Values{}
+	vals.Set("Action", "CreateLaunchTemplate")
+	vals.Set("LaunchTemplateData.BlockDeviceMapping.1.DeviceName", "/dev/sdb")
+
+	spliceThroughputQuery(vals,
+		"LaunchTemplateData.BlockDeviceMapping", map[string]int64{"/dev/sdb": 125})
+
+	if got := vals.Get("LaunchTemplateData.BlockDeviceMapping.1.Ebs.Throughput"); got != "125" {
+		t.Fatalf("bad throughput for /dev/sdb: %q", got)
+	}
+}
@@ -76,12 +76,20 @@ func DestroyAMIs(imageids []*string, ec2conn *ec2.EC2) error {
 }
 
 // Returns true if the error matches all these conditions:
-//  * err is of type awserr.Error
-//  * Error.Code() matches code
-//  * Error.Message() contains message
+//   - err is of type awserr.Error
+//   - Error.Code() matches code
+//   - Error.Message() contains message
 func isAWSErr(err error, code string, message string) bool {
 	if err, ok := err.(awserr.Error); ok {
 		return err.Code() == code && strings.Contains(err.Message(), message)
 	}
 	return false
 }
+
+// isCapacityErr returns true when the launch failed because the chosen
+// availability zone cannot currently provide the instance type, which a
+// retry in another zone may solve.
+func isCapacityErr(err error) bool {
+	return isAWSErr(err, "InsufficientInstanceCapacity", "") ||
+		isAWSErr(err, "Unsupported", "")
+}
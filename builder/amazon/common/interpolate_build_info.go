@@ -1,6 +1,8 @@
 package common
 
 import (
+	"strconv"
+
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/hashicorp/packer/builder"
@@ -8,12 +10,13 @@ import (
 )
 
 type BuildInfoTemplate struct {
-	BuildRegion        string
-	SourceAMI          string
-	SourceAMIName      string
-	SourceAMIOwner     string
-	SourceAMIOwnerName string
-	SourceAMITags      map[string]string
+	BuildRegion           string
+	SourceAMI             string
+	SourceAMIName         string
+	SourceAMIOwner        string
+	SourceAMIOwnerName    string
+	SourceAMITags         map[string]string
+	SourceAMIBlockDevices map[string]string
 }
 
 func extractBuildInfo(region string, state multistep.StateBag, generatedData *builder.GeneratedData) *BuildInfoTemplate {
@@ -30,13 +33,25 @@ func extractBuildInfo(region string, state multistep.StateBag, generatedData *bu
 		sourceAMITags[aws.StringValue(tag.Key)] = aws.StringValue(tag.Value)
 	}
 
+	// Map each of the source AMI's block devices to its EBS volume size in
+	// GiB; instance store devices are mapped to an empty string.
+	sourceAMIBlockDevices := make(map[string]string, len(sourceAMI.BlockDeviceMappings))
+	for _, mapping := range sourceAMI.BlockDeviceMappings {
+		size := ""
+		if mapping.Ebs != nil && mapping.Ebs.VolumeSize != nil {
+			size = strconv.FormatInt(aws.Int64Value(mapping.Ebs.VolumeSize), 10)
+		}
+		sourceAMIBlockDevices[aws.StringValue(mapping.DeviceName)] = size
+	}
+
 	buildInfoTemplate := &BuildInfoTemplate{
-		BuildRegion:        region,
-		SourceAMI:          aws.StringValue(sourceAMI.ImageId),
-		SourceAMIName:      aws.StringValue(sourceAMI.Name),
-		SourceAMIOwner:     aws.StringValue(sourceAMI.OwnerId),
-		SourceAMIOwnerName: aws.StringValue(sourceAMI.ImageOwnerAlias),
-		SourceAMITags:      sourceAMITags,
+		BuildRegion:           region,
+		SourceAMI:             aws.StringValue(sourceAMI.ImageId),
+		SourceAMIName:         aws.StringValue(sourceAMI.Name),
+		SourceAMIOwner:        aws.StringValue(sourceAMI.OwnerId),
+		SourceAMIOwnerName:    aws.StringValue(sourceAMI.ImageOwnerAlias),
+		SourceAMITags:         sourceAMITags,
+		SourceAMIBlockDevices: sourceAMIBlockDevices,
 	}
 	generatedData.Put("SourceAMIName", buildInfoTemplate.SourceAMIName)
 	return buildInfoTemplate
@@ -26,6 +26,18 @@ func testImage() *ec2.Image {
 				Value: aws.String("value-2"),
 			},
 		},
+		BlockDeviceMappings: []*ec2.BlockDeviceMapping{
+			{
+				DeviceName: aws.String("/dev/xvda"),
+				Ebs: &ec2.EbsBlockDevice{
+					VolumeSize: aws.Int64(8),
+				},
+			},
+			{
+				DeviceName:  aws.String("/dev/sdb"),
+				VirtualName: aws.String("ephemeral0"),
+			},
+		},
 	}
 }
 
@@ -68,6 +80,10 @@ func TestInterpolateBuildInfo_extractBuildInfo_withSourceImage(t *testing.T) {
 			"key-1": "value-1",
 			"key-2": "value-2",
 		},
+		SourceAMIBlockDevices: map[string]string{
+			"/dev/xvda": "8",
+			"/dev/sdb":  "",
+		},
 	}
 	if !reflect.DeepEqual(*buildInfo, expected) {
 		t.Fatalf("Unexpected BuildInfoTemplate: expected %#v got %#v\n", expected, *buildInfo)
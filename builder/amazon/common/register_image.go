@@ -0,0 +1,53 @@
+package common
+
+import (
+	"io/ioutil"
+	"net/url"
+
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/ec2"
+)
+
+// RegisterImage wraps ec2conn.RegisterImage, optionally registering the
+// image with a boot mode, UEFI variable store data and per-device gp3
+// throughput. The vendored AWS SDK predates these parameters, so they are
+// spliced into the serialized query by hand instead of being set on the
+// input struct.
+func RegisterImage(ec2conn *ec2.EC2, opts *ec2.RegisterImageInput, bootMode string, uefiData string, throughput map[string]int64) (*ec2.RegisterImageOutput, error) {
+	req, resp := ec2conn.RegisterImageRequest(opts)
+	SpliceEbsThroughput(req, "BlockDeviceMapping", throughput)
+
+	if bootMode != "" || uefiData != "" {
+		req.Handlers.Build.PushBack(func(r *request.Request) {
+			body, err := ioutil.ReadAll(r.GetBody())
+			if err != nil {
+				r.Error = err
+				return
+			}
+			vals, err := url.ParseQuery(string(body))
+			if err != nil {
+				r.Error = err
+				return
+			}
+			if bootMode != "" {
+				vals.Set("BootMode", bootMode)
+			}
+			if uefiData != "" {
+				vals.Set("UefiData", uefiData)
+			}
+			r.SetBufferBody([]byte(vals.Encode()))
+		})
+	}
+
+	return resp, req.Send()
+}
+
+// ValidateBootMode reports whether the given boot mode is one the EC2 API
+// accepts at image registration.
+func ValidateBootMode(bootMode string) bool {
+	switch bootMode {
+	case "legacy-bios", "uefi":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,60 @@
+package common
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/client"
+	"github.com/aws/aws-sdk-go/aws/request"
+)
+
+// AdaptiveRetryer retries like the SDK's default retryer, but reacts to
+// throttling the way the aws-sdk-go-v2 "adaptive" retry mode does: each
+// throttled response stretches a shared client-side minimum delay between
+// attempts, and successful calls shrink it again. With many builds
+// hammering the same account this backs the whole fleet off instead of
+// letting every request retry at full speed and cascade into
+// RequestLimitExceeded failures.
+type AdaptiveRetryer struct {
+	client.DefaultRetryer
+
+	l sync.Mutex
+	// Extra delay applied on top of the default backoff; grows on
+	// throttling, decays on success.
+	throttleDelay time.Duration
+}
+
+const (
+	// How much each throttled response increases the shared delay.
+	adaptiveThrottleStep = 500 * time.Millisecond
+	// The shared delay never grows beyond this.
+	adaptiveThrottleCap = 20 * time.Second
+)
+
+func (r *AdaptiveRetryer) RetryRules(req *request.Request) time.Duration {
+	delay := r.DefaultRetryer.RetryRules(req)
+
+	r.l.Lock()
+	defer r.l.Unlock()
+
+	if req.IsErrorThrottle() {
+		r.throttleDelay += adaptiveThrottleStep
+		if r.throttleDelay > adaptiveThrottleCap {
+			r.throttleDelay = adaptiveThrottleCap
+		}
+	} else if r.throttleDelay > 0 {
+		r.throttleDelay -= adaptiveThrottleStep / 2
+		if r.throttleDelay < 0 {
+			r.throttleDelay = 0
+		}
+	}
+
+	if r.throttleDelay > 0 {
+		// Jitter the shared delay so concurrent builds don't retry in
+		// lockstep.
+		delay += time.Duration(rand.Int63n(int64(r.throttleDelay)))
+	}
+
+	return delay
+}
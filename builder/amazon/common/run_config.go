@@ -1,5 +1,5 @@
 //go:generate struct-markdown
-//go:generate mapstructure-to-hcl2 -type AmiFilterOptions,SecurityGroupFilterOptions,SubnetFilterOptions,VpcFilterOptions,PolicyDocument,Statement
+//go:generate mapstructure-to-hcl2 -type AmiFilterOptions,SecurityGroupFilterOptions,SubnetFilterOptions,VpcFilterOptions,PolicyDocument,Statement,MetadataOptions
 
 package common
 
@@ -19,10 +19,81 @@ import (
 
 var reShutdownBehavior = regexp.MustCompile("^(stop|terminate)$")
 
+var reOutpostArn = regexp.MustCompile(`^arn:aws[a-z\-]*:outposts:[a-z\d\-]+:\d{12}:outpost/op-[a-f\d]{17}$`)
+
 type AmiFilterOptions struct {
 	hcl2template.KeyValueFilter `mapstructure:",squash"`
 	Owners                      []string
 	MostRecent                  bool `mapstructure:"most_recent"`
+	// Tags the source AMI must carry; each entry becomes a `tag:<key>`
+	// filter on the DescribeImages call.
+	Tags map[string]string `mapstructure:"tags"`
+	// Reject any AMI whose creation date is more than this many days in
+	// the past. 0 (the default) disables the age check.
+	MaxAgeDays int `mapstructure:"max_age_days"`
+	// Shortcut for the `architecture` filter. One of `i386`, `x86_64` or
+	// `arm64`. Validated against `instance_type` at prepare time, so an
+	// arm64 AMI is never paired with an x86 instance type.
+	Architecture string `mapstructure:"architecture"`
+	// Shortcut for the `boot-mode` filter. One of `legacy-bios` or `uefi`.
+	BootMode string `mapstructure:"boot_mode"`
+	// Shortcut for the `virtualization-type` filter. One of `paravirtual`
+	// or `hvm`.
+	VirtualizationType string `mapstructure:"virtualization_type"`
+}
+
+func (d *AmiFilterOptions) Prepare() []error {
+	errs := d.KeyValueFilter.Prepare()
+	if d.MaxAgeDays < 0 {
+		errs = append(errs, fmt.Errorf("max_age_days must not be negative"))
+	}
+	for _, shortcut := range []struct {
+		option  string
+		filter  string
+		value   string
+		allowed []string
+	}{
+		{"architecture", "architecture", d.Architecture,
+			[]string{"i386", "x86_64", "arm64"}},
+		{"boot_mode", "boot-mode", d.BootMode,
+			[]string{"legacy-bios", "uefi"}},
+		{"virtualization_type", "virtualization-type", d.VirtualizationType,
+			[]string{"paravirtual", "hvm"}},
+	} {
+		if shortcut.value == "" {
+			continue
+		}
+		valid := false
+		for _, allowed := range shortcut.allowed {
+			valid = valid || shortcut.value == allowed
+		}
+		if !valid {
+			errs = append(errs, fmt.Errorf("%s must be one of %s",
+				shortcut.option, strings.Join(shortcut.allowed, ", ")))
+		}
+		if _, set := d.Filters[shortcut.filter]; set {
+			errs = append(errs, fmt.Errorf(
+				"%s conflicts with the raw %q filter; set only one of them",
+				shortcut.option, shortcut.filter))
+		}
+	}
+	return errs
+}
+
+// shortcutFilters maps the first-class filter fields that are set to the
+// DescribeImages filter name each one stands for.
+func (d *AmiFilterOptions) shortcutFilters() map[string]string {
+	shortcuts := map[string]string{}
+	if d.Architecture != "" {
+		shortcuts["architecture"] = d.Architecture
+	}
+	if d.BootMode != "" {
+		shortcuts["boot-mode"] = d.BootMode
+	}
+	if d.VirtualizationType != "" {
+		shortcuts["virtualization-type"] = d.VirtualizationType
+	}
+	return shortcuts
 }
 
 func (d *AmiFilterOptions) GetOwners() []*string {
@@ -35,7 +106,8 @@ func (d *AmiFilterOptions) GetOwners() []*string {
 }
 
 func (d *AmiFilterOptions) Empty() bool {
-	return len(d.Owners) == 0 && d.KeyValueFilter.Empty()
+	return len(d.Owners) == 0 && len(d.Tags) == 0 &&
+		len(d.shortcutFilters()) == 0 && d.KeyValueFilter.Empty()
 }
 
 func (d *AmiFilterOptions) NoOwner() bool {
@@ -67,6 +139,55 @@ type SecurityGroupFilterOptions struct {
 	hcl2template.NameValueFilter `mapstructure:",squash"`
 }
 
+// MetadataOptions configures the instance metadata service of the launched
+// instance. See [Configuring the instance metadata
+// service](https://docs.aws.amazon.com/AWSEC2/latest/UserGuide/configuring-instance-metadata-service.html)
+// for details.
+type MetadataOptions struct {
+	// Whether a session token is required when requesting instance
+	// metadata. One of `optional` (the EC2 default, IMDSv1 and IMDSv2 both
+	// work) or `required` (IMDSv2 only).
+	HttpTokens string `mapstructure:"http_tokens" required:"false"`
+	// The maximum number of network hops an instance metadata response may
+	// travel, between 1 and 64. The EC2 default of 1 keeps the metadata
+	// service reachable from the instance itself but not from containers
+	// running on it.
+	HttpPutResponseHopLimit int64 `mapstructure:"http_put_response_hop_limit" required:"false"`
+	// Whether the instance metadata endpoint is reachable at all. One of
+	// `enabled` (the EC2 default) or `disabled`.
+	HttpEndpoint string `mapstructure:"http_endpoint" required:"false"`
+}
+
+// Empty reports whether no metadata option has been configured.
+func (m *MetadataOptions) Empty() bool {
+	return m.HttpTokens == "" && m.HttpPutResponseHopLimit == 0 && m.HttpEndpoint == ""
+}
+
+// Prepare validates the metadata options against the values the EC2 API
+// accepts.
+func (m *MetadataOptions) Prepare() []error {
+	var errs []error
+	switch m.HttpTokens {
+	case "", "optional", "required":
+	default:
+		errs = append(errs, fmt.Errorf("metadata_options: http_tokens must "+
+			"be `optional` or `required`, got: %s", m.HttpTokens))
+	}
+	if m.HttpPutResponseHopLimit != 0 &&
+		(m.HttpPutResponseHopLimit < 1 || m.HttpPutResponseHopLimit > 64) {
+		errs = append(errs, fmt.Errorf("metadata_options: "+
+			"http_put_response_hop_limit must be between 1 and 64, got: %d",
+			m.HttpPutResponseHopLimit))
+	}
+	switch m.HttpEndpoint {
+	case "", "enabled", "disabled":
+	default:
+		errs = append(errs, fmt.Errorf("metadata_options: http_endpoint must "+
+			"be `enabled` or `disabled`, got: %s", m.HttpEndpoint))
+	}
+	return errs
+}
+
 // RunConfig contains configuration for running an instance from a source
 // AMI and details on how to access that launched image.
 type RunConfig struct {
@@ -75,8 +196,16 @@ type RunConfig struct {
 	// new instance will get a Public IP. default: false
 	AssociatePublicIpAddress bool `mapstructure:"associate_public_ip_address" required:"false"`
 	// Destination availability zone to launch
-	// instance in. Leave this empty to allow Amazon to auto-assign.
+	// instance in. Leave this empty to allow Amazon to auto-assign; a
+	// launch failing with `InsufficientInstanceCapacity` is then retried
+	// in the other zones of the selected subnets or region.
 	AvailabilityZone string `mapstructure:"availability_zone" required:"false"`
+	// The ARN of the AWS Outpost to build the image on, such as
+	// `arn:aws:outposts:us-east-1:123456789012:outpost/op-0123456789abcdef0`.
+	// The subnet selected via `subnet_id` or `subnet_filter` must reside on
+	// this Outpost; Packer validates the combination before launching, and
+	// scopes `subnet_filter` to subnets on the Outpost.
+	OutpostArn string `mapstructure:"outpost_arn" required:"false"`
 	// Requires spot_price to be set. The
 	// required duration for the Spot Instances (also known as Spot blocks). This
 	// value must be a multiple of 60 (60, 120, 180, 240, 300, or 360). You can't
@@ -103,6 +232,15 @@ type RunConfig struct {
 	// }
 	// ```
 	DisableStopInstance bool `mapstructure:"disable_stop_instance" required:"false"`
+	// Experimental. Packer normally terminates the source instance when the
+	// build finishes. When this option is set, the instance is stopped and
+	// tagged instead, and a later run of the same build restarts it rather
+	// than launching a new one, skipping launch and bootstrap time. This is
+	// meant for rapid provisioner iteration during development; user_data
+	// changes are not re-applied to a reused instance and temporary key
+	// pairs only get installed on first boot, so use a fixed SSH key pair or
+	// password. Not compatible with spot instances. Default `false`.
+	ReuseInstance bool `mapstructure:"reuse_instance" required:"false"`
 	// Mark instance as [EBS
 	// Optimized](https://docs.aws.amazon.com/AWSEC2/latest/UserGuide/EBSOptimized.html).
 	// Default `false`.
@@ -130,6 +268,19 @@ type RunConfig struct {
 	// Unlimited - even for instances that would usually qualify for the
 	// [AWS Free Tier](https://aws.amazon.com/free/).
 	EnableT2Unlimited bool `mapstructure:"enable_t2_unlimited" required:"false"`
+	// Enable [detailed
+	// monitoring](https://docs.aws.amazon.com/AWSEC2/latest/UserGuide/using-cloudwatch-new.html)
+	// for the launched instance, collecting CloudWatch metrics at a 1-minute
+	// frequency instead of the basic 5-minute one. Additional CloudWatch
+	// charges apply. Defaults to `false`.
+	EnableDetailedMonitoring bool `mapstructure:"enable_detailed_monitoring" required:"false"`
+	// Configure the launched instance for
+	// [hibernation](https://docs.aws.amazon.com/AWSEC2/latest/UserGuide/Hibernate.html)
+	// so that images created from it support hibernation. This does not
+	// hibernate the instance during the build. The instance type and the
+	// root volume must meet the hibernation prerequisites; in particular
+	// the root volume must be encrypted. Defaults to `false`.
+	HibernationOptions bool `mapstructure:"hibernation_options" required:"false"`
 	// The name of an [IAM instance
 	// profile](https://docs.aws.amazon.com/IAM/latest/UserGuide/instance-profiles.html)
 	// to launch the EC2 instance with.
@@ -155,13 +306,51 @@ type RunConfig struct {
 	// ```
 	//
 	TemporaryIamInstanceProfilePolicyDocument *PolicyDocument `mapstructure:"temporary_iam_instance_profile_policy_document" required:"false"`
+	// How long to keep retrying the instance launch when AWS rejects the
+	// IAM instance profile as invalid. A freshly created profile - for
+	// example one created by Terraform right before the build, or the
+	// temporary profile Packer creates for
+	// `temporary_iam_instance_profile_policy_document` - can take a while
+	// to propagate through IAM, and launches during that window fail with
+	// `InvalidParameterValue`. The value is a duration such as `2m`. When
+	// unset, Packer retries a fixed number of times for about two
+	// minutes.
+	IamInstanceProfilePropagationTimeout time.Duration `mapstructure:"iam_instance_profile_propagation_timeout" required:"false"`
 	// Automatically terminate instances on
 	// shutdown in case Packer exits ungracefully. Possible values are stop and
 	// terminate. Defaults to stop.
 	InstanceInitiatedShutdownBehavior string `mapstructure:"shutdown_behavior" required:"false"`
+	// When enabled, the source instance is left running if the build fails
+	// during or after provisioning, so that it can be inspected for
+	// debugging. Packer prints how to reach the instance; it then has to be
+	// terminated manually. Temporary key pairs and security groups are still
+	// cleaned up as usual. Defaults to false.
+	KeepInstanceOnFailure bool `mapstructure:"keep_instance_on_failure" required:"false"`
+	// Instance metadata service settings for the build instance. Use
+	// `"http_tokens": "required"` to enforce IMDSv2 on the instance.
+	//
+	// -   `http_tokens` (string) - `optional` or `required`; when
+	//     `required`, every metadata request must carry a session token
+	//     (IMDSv2).
+	// -   `http_put_response_hop_limit` (number) - maximum number of
+	//     network hops for metadata responses, between 1 and 64.
+	// -   `http_endpoint` (string) - `enabled` or `disabled`; disables the
+	//     metadata endpoint entirely when set to `disabled`.
+	//
+	// Unset fields keep the EC2 defaults. See [Configuring the instance
+	// metadata
+	// service](https://docs.aws.amazon.com/AWSEC2/latest/UserGuide/configuring-instance-metadata-service.html)
+	// for details.
+	Metadata MetadataOptions `mapstructure:"metadata_options" required:"false"`
 	// The EC2 instance type to use while building the
 	// AMI, such as t2.small.
 	InstanceType string `mapstructure:"instance_type" required:"true"`
+	// When set to auto, Packer queries the instance store capability of
+	// instance_type at build time and adds one ephemeral mapping per
+	// available instance store volume to the launch block device mappings,
+	// so templates keep working across instance families. Cannot be
+	// combined with explicit virtual_name mappings.
+	EphemeralDevices string `mapstructure:"ephemeral_devices" required:"false"`
 	// Filters used to populate the `security_group_ids` field. Example:
 	//
 	// ```json
@@ -186,7 +375,12 @@ type RunConfig struct {
 	// Key/value pair tags to apply to the instance that is that is *launched*
 	// to create the EBS volumes. This is a [template
 	// engine](/docs/templates/engine), see [Build template
-	// data](#build-template-data) for more information.
+	// data](#build-template-data) for more information. Beside the
+	// regular template functions (`build_name`, `template_dir`,
+	// `packer_version`, `user`, ...), the build-specific placeholders
+	// listed under Build template data, like `{{ .SourceAMI }}`, are
+	// available. Unknown placeholders fail validation at prepare time
+	// instead of ending up as literal text in the tags.
 	RunTags map[string]string `mapstructure:"run_tags" required:"false"`
 	// Same as [`run_tags`](#run_tags) but defined as a singular repeatable
 	// block containing a `key` and a `value` field. In HCL2 mode the
@@ -244,12 +438,44 @@ type RunConfig struct {
 	//   -   `most_recent` (boolean) - Selects the newest created image when true.
 	//       This is most useful for selecting a daily distro build.
 	//
+	//   -   `tags` (map of strings) - Tags the source AMI must carry. Each entry
+	//       is turned into a `tag:<key>` filter on the DescribeImages call.
+	//
+	//   -   `max_age_days` (number) - Fails the build if every matching AMI was
+	//       created more than this many days ago. Useful to enforce base image
+	//       freshness policies from the template. Defaults to 0 (no age limit).
+	//
+	//   -   `architecture` (string) - Shortcut for the `architecture` filter.
+	//       One of `i386`, `x86_64` or `arm64`. Validated against
+	//       `instance_type` (and `spot_instance_types`) before the build
+	//       starts, so an arm64 AMI is never paired with an x86 instance type.
+	//
+	//   -   `boot_mode` (string) - Shortcut for the `boot-mode` filter. One of
+	//       `legacy-bios` or `uefi`.
+	//
+	//   -   `virtualization_type` (string) - Shortcut for the
+	//       `virtualization-type` filter. One of `paravirtual` or `hvm`.
+	//
 	//   You may set this in place of `source_ami` or in conjunction with it. If you
 	//   set this in conjunction with `source_ami`, the `source_ami` will be added
 	//   to the filter. The provided `source_ami` must meet all of the filtering
 	//   criteria provided in `source_ami_filter`; this pins the AMI returned by the
 	//   filter, but will cause Packer to fail if the `source_ami` does not exist.
 	SourceAmiFilter AmiFilterOptions `mapstructure:"source_ami_filter" required:"false"`
+	// How to treat AWS Marketplace product codes found on the source AMI.
+	// An AMI built from a source that carries marketplace codes inherits
+	// them, which prevents the resulting AMI from being copied, shared or
+	// encrypted - something that otherwise only surfaces after the build.
+	// Can be `warn` to print a warning before the build and continue (the
+	// default), `error` to fail the build before launching anything, or
+	// `ignore` to suppress the check.
+	SourceAmiMarketplaceCodeAction string `mapstructure:"source_ami_marketplace_code_action" required:"false"`
+	// How EC2 should pick among the Spot Instance pools spanned by
+	// `spot_instance_types` when requesting spot capacity. One of
+	// `lowest-price` (the default), `diversified` or `capacity-optimized`.
+	// `capacity-optimized` launches from the pools with the most spare
+	// capacity, making interruptions less likely.
+	SpotAllocationStrategy string `mapstructure:"spot_allocation_strategy" required:"false"`
 	// a list of acceptable instance
 	// types to run your build on. We will request a spot instance using the max
 	// price of spot_price and the allocation strategy of "lowest price".
@@ -260,6 +486,19 @@ type RunConfig struct {
 	// because a particular availability zone does not have capacity for the
 	// specific instance_type requested in instance_type.
 	SpotInstanceTypes []string `mapstructure:"spot_instance_types" required:"false"`
+	// The number of times to re-launch the build on a fresh spot instance
+	// and replay the provisioners when the spot instance is interrupted
+	// mid-build, instead of failing the whole build. Only used by the
+	// amazon-ebs builder. Requires a spot build (spot_price or
+	// spot_instance_types). Defaults to 0; no retry.
+	SpotInterruptionRetries int `mapstructure:"spot_interruption_retries" required:"false"`
+	// If true, fall back to an on-demand instance when spot capacity is
+	// unavailable: a launch that the fleet API cannot fulfill is retried
+	// on-demand right away, and when `spot_interruption_retries` is also
+	// set, the last retry after repeated interruptions runs on-demand
+	// instead of requesting spot capacity again. The interruption retry
+	// path additionally requires an instance_type. Defaults to false.
+	SpotFallbackToOnDemand bool `mapstructure:"spot_fallback_to_on_demand" required:"false"`
 	// With Spot Instances, you pay the Spot price that's in effect for the
 	// time period your instances are running. Spot Instance prices are set by
 	// Amazon EC2 and adjust gradually based on long-term trends in supply and
@@ -328,6 +567,14 @@ type RunConfig struct {
 	// subnet-12345def, where Packer will launch the EC2 instance. This field is
 	// required if you are using an non-default VPC.
 	SubnetId string `mapstructure:"subnet_id" required:"false"`
+	// If true, Packer checks that the subnet can reach the AWS service
+	// endpoints the build needs before launching the source instance.
+	// Subnets with a route to an internet gateway or NAT gateway pass
+	// immediately; for private subnets Packer verifies that the VPC has the
+	// required VPC endpoints (EC2, plus SSM when `ssh_interface` is
+	// `session_manager`), turning long connection timeouts into an
+	// immediate validation error. Default `false`.
+	VpcEndpointCheck bool `mapstructure:"vpc_endpoint_check" required:"false"`
 	// The name of the temporary key pair to
 	// generate. By default, Packer generates a name that looks like
 	// `packer_<UUID>`, where &lt;UUID&gt; is a 36 character unique identifier.
@@ -347,6 +594,23 @@ type RunConfig struct {
 	// Path to a file that will be used for the user
 	// data when launching the instance.
 	UserDataFile string `mapstructure:"user_data_file" required:"false"`
+	// Deliver the user data to the instance through a temporary, KMS
+	// encrypted SSM parameter instead of passing it directly, working
+	// around the 16KB user data size limit. The real user data is
+	// replaced with a small bootstrap stub that fetches the parameter,
+	// reassembles the original script and runs it; the parameter is
+	// deleted again when the build finishes. The instance needs an
+	// [iam_instance_profile](#iam_instance_profile) allowing
+	// `ssm:GetParameter`, plus `kms:Decrypt` when a custom key is used.
+	// Linux instances fetch the parameter with the `aws` CLI, Windows
+	// instances with the preinstalled AWS PowerShell tools. Default
+	// `false`.
+	UserDataViaSSM bool `mapstructure:"user_data_via_ssm" required:"false"`
+	// The KMS key ID or alias used to encrypt the temporary SSM
+	// parameter; may only be set together with
+	// [user_data_via_ssm](#user_data_via_ssm). By default the account's
+	// default `aws/ssm` key is used.
+	UserDataSSMKMSKeyId string `mapstructure:"user_data_ssm_kms_key_id" required:"false"`
 	// Filters used to populate the `vpc_id` field.
 	// Example:
 	//
@@ -387,7 +651,21 @@ type RunConfig struct {
 	// Communicator settings
 	Comm communicator.Config `mapstructure:",squash"`
 
-	// One of `public_ip`, `private_ip`, `public_dns`, `private_dns` or `session_manager`.
+	// If true and no ssh_username was given, Packer will derive the
+	// username from the source AMI's name, owner and tags once the AMI is
+	// resolved (for example Ubuntu images use `ubuntu` and Amazon Linux
+	// uses `ec2-user`), falling back to `ec2-user` when nothing matches.
+	// An `ssh_username` tag on the AMI takes precedence over the name
+	// patterns, and an explicitly configured ssh_username always wins.
+	// Defaults to false.
+	DetectSSHUsername bool `mapstructure:"detect_ssh_username" required:"false"`
+
+	// Set in Prepare when detect_ssh_username will actually run, that is
+	// when the user opted in and did not give an explicit ssh_username.
+	sshUsernameDetection bool
+
+	// One of `public_ip`, `private_ip`, `public_dns`, `private_dns`,
+	//    `session_manager` or `instance_connect_endpoint`.
 	//    If set, either the public IP address, private IP address, public DNS name
 	//    or private DNS name will be used as the host for SSH. The default behaviour
 	//    if inside a VPC is to use the public IP address if available, otherwise
@@ -406,12 +684,39 @@ type RunConfig struct {
 	//    between the local host and the remote host on an available local port to the specified `ssh_port`.
 	//    Upon termination the secure tunnel will be terminated automatically, if however there is a failure in
 	//    terminating the tunnel it will automatically terminate itself after 20 minutes of inactivity.
+	//
+	//    When using `instance_connect_endpoint` SSH is tunneled through an
+	//    [EC2 Instance Connect Endpoint](https://docs.aws.amazon.com/AWSEC2/latest/UserGuide/connect-using-eice.html)
+	//    in the instance's subnet, covering VPCs without public IPs where
+	//    SSM is not deployed. The endpoint is not managed by Packer; its ID
+	//    and DNS name must be given with `instance_connect_endpoint_id` and
+	//    `instance_connect_endpoint_dns_name`.
 	SSHInterface string `mapstructure:"ssh_interface"`
 
+	// When `ssh_interface` is set to `public_dns`, wait up to this long
+	// for the instance's public DNS name to actually resolve before
+	// handing it to the communicator. The lookup is done with the
+	// resolver Packer itself connects through - inside a VPC that is the
+	// split-horizon VPC resolver - so a successful lookup means the name
+	// has propagated to where it matters, eliminating intermittent
+	// failures on the first connection attempt. The value is a duration
+	// such as `2m`. Disabled by default.
+	SSHDnsPropagationTimeout time.Duration `mapstructure:"ssh_dns_propagation_timeout" required:"false"`
+
 	// Which port to connect the local end of the session tunnel to. If
 	// left blank, Packer will choose a port for you from available ports.
 	// This option is only used when `ssh_interface` is set `session_manager`.
 	SessionManagerPort int `mapstructure:"session_manager_port"`
+
+	// The ID of an existing EC2 Instance Connect Endpoint in the
+	// instance's subnet, e.g. `eice-0123456789abcdef0`. Required when
+	// `ssh_interface` is set to `instance_connect_endpoint`.
+	InstanceConnectEndpointId string `mapstructure:"instance_connect_endpoint_id"`
+	// The DNS name of the EC2 Instance Connect Endpoint given in
+	// `instance_connect_endpoint_id`, as reported by the AWS console or
+	// `describe-instance-connect-endpoints`. Required when `ssh_interface`
+	// is set to `instance_connect_endpoint`.
+	InstanceConnectEndpointDnsName string `mapstructure:"instance_connect_endpoint_dns_name"`
 }
 
 func (c *RunConfig) Prepare(ctx *interpolate.Context) []error {
@@ -433,6 +738,14 @@ func (c *RunConfig) Prepare(ctx *interpolate.Context) []error {
 		c.RunTags = make(map[string]string)
 	}
 
+	// When the user opted into ssh_username detection, give the
+	// communicator a safe default so validation passes; the detection step
+	// replaces it once the source AMI is known.
+	if c.DetectSSHUsername && c.Comm.Type != "winrm" && c.Comm.SSHUsername == "" {
+		c.Comm.SSHUsername = "ec2-user"
+		c.sshUsernameDetection = true
+	}
+
 	// Validation
 	errs := c.Comm.Prepare(ctx)
 
@@ -440,11 +753,21 @@ func (c *RunConfig) Prepare(ctx *interpolate.Context) []error {
 	errs = append(errs, c.RunTag.CopyOn(&c.RunTags)...)
 	errs = append(errs, c.SpotTag.CopyOn(&c.SpotTags)...)
 
+	// Fail on bad tag interpolations now instead of once the instance
+	// is already running.
+	ictx := interpolate.Context{}
+	if ctx != nil {
+		ictx = *ctx
+	}
+	errs = append(errs, TagMap(c.RunTags).Validate(ictx)...)
+	errs = append(errs, TagMap(c.SpotTags).Validate(ictx)...)
+
 	for _, preparer := range []interface{ Prepare() []error }{
 		&c.SourceAmiFilter,
 		&c.SecurityGroupFilter,
 		&c.SubnetFilter,
 		&c.VpcFilter,
+		&c.Metadata,
 	} {
 		errs = append(errs, preparer.Prepare()...)
 	}
@@ -455,10 +778,22 @@ func (c *RunConfig) Prepare(ctx *interpolate.Context) []error {
 		c.SSHInterface != "public_dns" &&
 		c.SSHInterface != "private_dns" &&
 		c.SSHInterface != "session_manager" &&
+		c.SSHInterface != "instance_connect_endpoint" &&
 		c.SSHInterface != "" {
 		errs = append(errs, fmt.Errorf("Unknown interface type: %s", c.SSHInterface))
 	}
 
+	if c.SSHDnsPropagationTimeout != 0 && c.SSHInterface != "public_dns" {
+		errs = append(errs, fmt.Errorf(
+			`ssh_dns_propagation_timeout requires ssh_interface to be "public_dns"`))
+	}
+
+	if c.IamInstanceProfilePropagationTimeout != 0 &&
+		c.IamInstanceProfile == "" && c.TemporaryIamInstanceProfilePolicyDocument == nil {
+		errs = append(errs, fmt.Errorf(
+			"iam_instance_profile_propagation_timeout requires iam_instance_profile or temporary_iam_instance_profile_policy_document"))
+	}
+
 	// Connectivity via Session Manager has a few requirements
 	if c.SSHInterface == "session_manager" {
 		if c.Comm.Type == "winrm" {
@@ -472,6 +807,20 @@ func (c *RunConfig) Prepare(ctx *interpolate.Context) []error {
 		}
 	}
 
+	// Connectivity via an Instance Connect Endpoint needs to know which
+	// endpoint to tunnel through, as the bundled AWS SDK cannot look it up.
+	if c.SSHInterface == "instance_connect_endpoint" {
+		if c.Comm.Type == "winrm" {
+			msg := fmt.Errorf(`instance_connect_endpoint connectivity is not supported with the "winrm" communicator; please use "ssh"`)
+			errs = append(errs, msg)
+		}
+
+		if c.InstanceConnectEndpointId == "" || c.InstanceConnectEndpointDnsName == "" {
+			msg := fmt.Errorf(`both instance_connect_endpoint_id and instance_connect_endpoint_dns_name must be set when ssh_interface is "instance_connect_endpoint"`)
+			errs = append(errs, msg)
+		}
+	}
+
 	if c.Comm.SSHKeyPairName != "" {
 		if c.Comm.Type == "winrm" && c.Comm.WinRMPassword == "" && c.Comm.SSHPrivateKeyFile == "" {
 			errs = append(errs, fmt.Errorf("ssh_private_key_file must be provided to retrieve the winrm password when using ssh_keypair_name."))
@@ -488,6 +837,32 @@ func (c *RunConfig) Prepare(ctx *interpolate.Context) []error {
 		errs = append(errs, fmt.Errorf("For security reasons, your source AMI filter must declare an owner."))
 	}
 
+	// An AMI built for the wrong CPU architecture only fails once the
+	// instance is launched; catch the mismatch here when the instance type
+	// makes the architecture unambiguous.
+	if arch := c.SourceAmiFilter.Architecture; arch != "" {
+		instanceTypes := append([]string{c.InstanceType}, c.SpotInstanceTypes...)
+		for _, instanceType := range instanceTypes {
+			if instanceType == "" {
+				continue
+			}
+			instanceArch := instanceTypeArchitecture(instanceType)
+			if instanceArch == "" || (arch == "arm64") == (instanceArch == "arm64") {
+				continue
+			}
+			errs = append(errs, fmt.Errorf(
+				"source_ami_filter selects %s AMIs, but instance type %s is %s",
+				arch, instanceType, instanceArch))
+		}
+	}
+
+	switch c.SourceAmiMarketplaceCodeAction {
+	case "", "warn", "error", "ignore":
+	default:
+		errs = append(errs, fmt.Errorf("source_ami_marketplace_code_action must be one of "+
+			"\"warn\", \"error\" or \"ignore\""))
+	}
+
 	if c.InstanceType == "" && len(c.SpotInstanceTypes) == 0 {
 		errs = append(errs, fmt.Errorf("either instance_type or "+
 			"spot_instance_types must be specified"))
@@ -498,11 +873,57 @@ func (c *RunConfig) Prepare(ctx *interpolate.Context) []error {
 			"spot_instance_types must be specified, not both"))
 	}
 
+	if c.OutpostArn != "" {
+		if !reOutpostArn.MatchString(c.OutpostArn) {
+			errs = append(errs, fmt.Errorf(
+				"Invalid outpost_arn: %s. Expected an ARN of the form "+
+					"arn:aws:outposts:<region>:<account>:outpost/op-<id>", c.OutpostArn))
+		}
+
+		if c.SubnetId == "" && c.SubnetFilter.Empty() {
+			errs = append(errs, fmt.Errorf(
+				"outpost_arn requires a subnet_id or subnet_filter selecting a subnet on the Outpost"))
+		}
+	}
+
+	if c.EphemeralDevices != "" && c.EphemeralDevices != "auto" {
+		errs = append(errs, fmt.Errorf(
+			"ephemeral_devices only accepts the value auto"))
+	}
+
 	if c.BlockDurationMinutes%60 != 0 {
 		errs = append(errs, fmt.Errorf(
 			"block_duration_minutes must be multiple of 60"))
 	}
 
+	if c.SpotInterruptionRetries > 0 && !c.IsSpotInstance() && len(c.SpotInstanceTypes) == 0 {
+		errs = append(errs, fmt.Errorf(
+			"spot_interruption_retries should not be set when not requesting a spot instance"))
+	}
+
+	if c.SpotFallbackToOnDemand {
+		if !c.IsSpotInstance() && len(c.SpotInstanceTypes) == 0 {
+			errs = append(errs, fmt.Errorf(
+				"spot_fallback_to_on_demand should not be set when not requesting a spot instance"))
+		}
+		if c.SpotInterruptionRetries > 0 && c.InstanceType == "" {
+			errs = append(errs, fmt.Errorf(
+				"spot_fallback_to_on_demand with spot_interruption_retries requires an instance_type for the on-demand launch"))
+		}
+	}
+
+	switch c.SpotAllocationStrategy {
+	case "", "lowest-price", "diversified", "capacity-optimized":
+	default:
+		errs = append(errs, fmt.Errorf("spot_allocation_strategy must be one of "+
+			"\"lowest-price\", \"diversified\" or \"capacity-optimized\""))
+	}
+
+	if c.SpotAllocationStrategy != "" && len(c.SpotInstanceTypes) == 0 {
+		errs = append(errs, fmt.Errorf(
+			"spot_allocation_strategy requires spot_instance_types"))
+	}
+
 	if c.SpotTags != nil {
 		if c.SpotPrice == "" || c.SpotPrice == "0" {
 			errs = append(errs, fmt.Errorf(
@@ -518,6 +939,22 @@ func (c *RunConfig) Prepare(ctx *interpolate.Context) []error {
 		}
 	}
 
+	if c.UserDataViaSSM {
+		if c.UserData == "" && c.UserDataFile == "" {
+			errs = append(errs, fmt.Errorf(
+				"user_data_via_ssm requires user_data or user_data_file to be set"))
+		}
+		if c.IamInstanceProfile == "" && c.TemporaryIamInstanceProfilePolicyDocument == nil {
+			errs = append(errs, fmt.Errorf(
+				"user_data_via_ssm requires an iam_instance_profile or a "+
+					"temporary_iam_instance_profile_policy_document allowing the "+
+					"instance to read the SSM parameter"))
+		}
+	} else if c.UserDataSSMKMSKeyId != "" {
+		errs = append(errs, fmt.Errorf(
+			"user_data_ssm_kms_key_id may only be set together with user_data_via_ssm"))
+	}
+
 	if c.SecurityGroupId != "" {
 		if len(c.SecurityGroupIds) > 0 {
 			errs = append(errs, fmt.Errorf("Only one of security_group_id or security_group_ids can be specified."))
@@ -543,6 +980,10 @@ func (c *RunConfig) Prepare(ctx *interpolate.Context) []error {
 		errs = append(errs, fmt.Errorf("shutdown_behavior only accepts 'stop' or 'terminate' values."))
 	}
 
+	if c.ReuseInstance && c.SpotPrice != "" && c.SpotPrice != "0" {
+		errs = append(errs, fmt.Errorf("reuse_instance cannot be used in conjunction with spot instances"))
+	}
+
 	if c.EnableT2Unlimited {
 		if c.SpotPrice != "" {
 			errs = append(errs, fmt.Errorf("Error: T2 Unlimited cannot be used in conjunction with Spot Instances"))
@@ -558,6 +999,27 @@ func (c *RunConfig) Prepare(ctx *interpolate.Context) []error {
 	return errs
 }
 
+// instanceTypeArchitecture returns the CPU architecture implied by an EC2
+// instance type, or "" when it cannot be determined. Graviton (arm64)
+// families carry a "g" in the attribute suffix that follows the generation
+// number, e.g. m6g, t4g, c6gd or im4gn; the a1 family predates that
+// convention.
+func instanceTypeArchitecture(instanceType string) string {
+	family := strings.SplitN(instanceType, ".", 2)[0]
+	if family == "a1" {
+		return "arm64"
+	}
+	i := strings.IndexAny(family, "0123456789")
+	if i < 0 {
+		return ""
+	}
+	suffix := strings.TrimLeft(family[i:], "0123456789")
+	if strings.Contains(suffix, "g") {
+		return "arm64"
+	}
+	return "x86_64"
+}
+
 func (c *RunConfig) IsSpotInstance() bool {
 	return c.SpotPrice != "" && c.SpotPrice != "0"
 }
@@ -566,3 +1028,16 @@ func (c *RunConfig) SSMAgentEnabled() bool {
 	hasIamInstanceProfile := c.IamInstanceProfile != "" || c.TemporaryIamInstanceProfilePolicyDocument != nil
 	return c.SSHInterface == "session_manager" && hasIamInstanceProfile
 }
+
+// EICEEnabled reports whether SSH is tunneled through an EC2 Instance
+// Connect Endpoint.
+func (c *RunConfig) EICEEnabled() bool {
+	return c.SSHInterface == "instance_connect_endpoint"
+}
+
+// SSHUsernameDetectionEnabled reports whether the ssh_username should be
+// detected from the source AMI: the user opted in through
+// detect_ssh_username and did not give an explicit ssh_username.
+func (c *RunConfig) SSHUsernameDetectionEnabled() bool {
+	return c.sshUsernameDetection
+}
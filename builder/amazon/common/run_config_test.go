@@ -5,6 +5,7 @@ import (
 	"os"
 	"regexp"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/packer/hcl2template"
 	"github.com/hashicorp/packer/helper/communicator"
@@ -232,3 +233,252 @@ func TestRunConfigPrepare_TemporaryKeyPairName(t *testing.T) {
 		t.Fatal("keypair name does not match")
 	}
 }
+
+func TestRunConfigPrepare_OutpostArn(t *testing.T) {
+	c := testConfig()
+	c.OutpostArn = "arn:aws:outposts:us-east-1:123456789012:outpost/op-0123456789abcdef0"
+	c.SubnetId = "subnet-12345"
+	if err := c.Prepare(nil); len(err) != 0 {
+		t.Fatalf("err: %s", err)
+	}
+
+	c = testConfig()
+	c.OutpostArn = "op-0123456789abcdef0"
+	c.SubnetId = "subnet-12345"
+	if err := c.Prepare(nil); len(err) != 1 {
+		t.Fatalf("Should error with a malformed outpost_arn")
+	}
+
+	c = testConfig()
+	c.OutpostArn = "arn:aws:outposts:us-east-1:123456789012:outpost/op-0123456789abcdef0"
+	if err := c.Prepare(nil); len(err) != 1 {
+		t.Fatalf("Should error when outpost_arn is set without a subnet")
+	}
+}
+
+func TestRunConfigPrepare_SpotInterruptionRetries(t *testing.T) {
+	c := testConfig()
+	c.SpotInterruptionRetries = 2
+	if err := c.Prepare(nil); len(err) != 1 {
+		t.Fatalf("Should error when retries are requested without a spot instance")
+	}
+
+	c = testConfig()
+	c.SpotPrice = "auto"
+	c.SpotInterruptionRetries = 2
+	if err := c.Prepare(nil); len(err) != 0 {
+		t.Fatalf("err: %s", err)
+	}
+
+	c = testConfig()
+	c.SpotPrice = "auto"
+	c.SpotFallbackToOnDemand = true
+	if err := c.Prepare(nil); len(err) != 0 {
+		t.Fatalf("err: %v", err)
+	}
+
+	c = testConfig()
+	c.SpotFallbackToOnDemand = true
+	if err := c.Prepare(nil); len(err) != 1 {
+		t.Fatalf("Should error when falling back to on-demand without a spot instance")
+	}
+}
+
+func TestRunConfigPrepare_SpotAllocationStrategy(t *testing.T) {
+	c := testConfig()
+	c.InstanceType = ""
+	c.SpotInstanceTypes = []string{"t3.micro", "t3a.micro"}
+	c.SpotAllocationStrategy = "capacity-optimized"
+	if err := c.Prepare(nil); len(err) != 0 {
+		t.Fatalf("err: %v", err)
+	}
+
+	c = testConfig()
+	c.InstanceType = ""
+	c.SpotInstanceTypes = []string{"t3.micro"}
+	c.SpotAllocationStrategy = "highest-price"
+	if err := c.Prepare(nil); len(err) != 1 {
+		t.Fatalf("Should error with an unknown allocation strategy")
+	}
+
+	c = testConfig()
+	c.SpotAllocationStrategy = "diversified"
+	if err := c.Prepare(nil); len(err) != 1 {
+		t.Fatalf("Should error without spot_instance_types")
+	}
+}
+
+func TestRunConfigPrepare_MetadataOptions(t *testing.T) {
+	c := testConfig()
+	c.Metadata = MetadataOptions{
+		HttpTokens:              "required",
+		HttpPutResponseHopLimit: 2,
+		HttpEndpoint:            "enabled",
+	}
+	if err := c.Prepare(nil); len(err) != 0 {
+		t.Fatalf("err: %v", err)
+	}
+
+	c = testConfig()
+	c.Metadata = MetadataOptions{HttpTokens: "mandatory"}
+	if err := c.Prepare(nil); len(err) != 1 {
+		t.Fatalf("Should error with an unknown http_tokens value")
+	}
+
+	c = testConfig()
+	c.Metadata = MetadataOptions{HttpPutResponseHopLimit: 65}
+	if err := c.Prepare(nil); len(err) != 1 {
+		t.Fatalf("Should error with an out of range hop limit")
+	}
+
+	c = testConfig()
+	c.Metadata = MetadataOptions{HttpEndpoint: "off"}
+	if err := c.Prepare(nil); len(err) != 1 {
+		t.Fatalf("Should error with an unknown http_endpoint value")
+	}
+}
+
+func TestRunConfigPrepare_SourceAmiFilterShortcuts(t *testing.T) {
+	c := testConfigFilter()
+	c.SourceAmiFilter = AmiFilterOptions{
+		Owners:             []string{"123"},
+		Architecture:       "x86_64",
+		BootMode:           "uefi",
+		VirtualizationType: "hvm",
+	}
+	if err := c.Prepare(nil); len(err) != 0 {
+		t.Fatalf("err: %s", err)
+	}
+
+	c = testConfigFilter()
+	c.SourceAmiFilter = AmiFilterOptions{
+		Owners:       []string{"123"},
+		Architecture: "amd64",
+	}
+	if err := c.Prepare(nil); len(err) != 1 {
+		t.Fatalf("Should error with an invalid architecture value")
+	}
+
+	// A shortcut and the equivalent raw filter may not both be set.
+	c = testConfigFilter()
+	c.SourceAmiFilter = AmiFilterOptions{
+		Owners:   []string{"123"},
+		BootMode: "uefi",
+		KeyValueFilter: hcl2template.KeyValueFilter{
+			Filters: map[string]string{"boot-mode": "legacy-bios"},
+		},
+	}
+	if err := c.Prepare(nil); len(err) != 1 {
+		t.Fatalf("Should error when boot_mode and the boot-mode filter are both set")
+	}
+}
+
+func TestRunConfigPrepare_SourceAmiFilterArchitectureMismatch(t *testing.T) {
+	c := testConfigFilter()
+	c.SourceAmiFilter = AmiFilterOptions{
+		Owners:       []string{"123"},
+		Architecture: "arm64",
+	}
+	c.InstanceType = "m6g.large"
+	if err := c.Prepare(nil); len(err) != 0 {
+		t.Fatalf("err: %s", err)
+	}
+
+	c.InstanceType = "m5.large"
+	if err := c.Prepare(nil); len(err) != 1 {
+		t.Fatalf("Should error with an arm64 filter on an x86 instance type")
+	}
+
+	c.InstanceType = ""
+	c.SpotInstanceTypes = []string{"t4g.small", "t3.small"}
+	if err := c.Prepare(nil); len(err) != 1 {
+		t.Fatalf("Should error on the x86 spot instance type only")
+	}
+}
+
+func TestInstanceTypeArchitecture(t *testing.T) {
+	cases := map[string]string{
+		"m5.large":     "x86_64",
+		"t2.micro":     "x86_64",
+		"g4dn.xlarge":  "x86_64",
+		"u-6tb1.metal": "x86_64",
+		"a1.medium":    "arm64",
+		"m6g.large":    "arm64",
+		"t4g.small":    "arm64",
+		"c6gd.medium":  "arm64",
+		"im4gn.large":  "arm64",
+		"g5g.xlarge":   "arm64",
+		"weird":        "",
+	}
+	for instanceType, expected := range cases {
+		if arch := instanceTypeArchitecture(instanceType); arch != expected {
+			t.Errorf("%s: expected %q, got %q", instanceType, expected, arch)
+		}
+	}
+}
+
+func TestRunConfigPrepare_SourceAmiMarketplaceCodeAction(t *testing.T) {
+	c := testConfig()
+	c.SourceAmiMarketplaceCodeAction = "bogus"
+	if err := c.Prepare(nil); len(err) != 1 {
+		t.Fatalf("Should error with an invalid source_ami_marketplace_code_action")
+	}
+
+	for _, action := range []string{"", "warn", "error", "ignore"} {
+		c = testConfig()
+		c.SourceAmiMarketplaceCodeAction = action
+		if err := c.Prepare(nil); len(err) != 0 {
+			t.Fatalf("err: %v", err)
+		}
+	}
+}
+
+func TestRunConfigPrepare_RunTagsValidation(t *testing.T) {
+	c := testConfig()
+	c.RunTags = map[string]string{"Name": "{{ .SourceAMI }} ({{ build_name }})"}
+	if err := c.Prepare(nil); len(err) != 0 {
+		t.Fatalf("err: %v", err)
+	}
+
+	c = testConfig()
+	c.RunTags = map[string]string{"Name": "{{ .SourceAmi }}"}
+	if err := c.Prepare(nil); len(err) != 1 {
+		t.Fatalf("Should error with an unknown placeholder")
+	}
+
+	c = testConfig()
+	c.RunTags = map[string]string{"Name": "{{ bulid_name }}"}
+	if err := c.Prepare(nil); len(err) != 1 {
+		t.Fatalf("Should error with an unknown function")
+	}
+}
+
+func TestRunConfigPrepare_SSHDnsPropagationTimeout(t *testing.T) {
+	c := testConfig()
+	c.SSHDnsPropagationTimeout = 2 * time.Minute
+	if err := c.Prepare(nil); len(err) != 1 {
+		t.Fatalf("Should error without ssh_interface set to public_dns")
+	}
+
+	c = testConfig()
+	c.SSHInterface = "public_dns"
+	c.SSHDnsPropagationTimeout = 2 * time.Minute
+	if err := c.Prepare(nil); len(err) != 0 {
+		t.Fatalf("err: %v", err)
+	}
+}
+
+func TestRunConfigPrepare_IamInstanceProfilePropagationTimeout(t *testing.T) {
+	c := testConfig()
+	c.IamInstanceProfilePropagationTimeout = 2 * time.Minute
+	if err := c.Prepare(nil); len(err) != 1 {
+		t.Fatalf("Should error without an instance profile configured")
+	}
+
+	c = testConfig()
+	c.IamInstanceProfile = "packer"
+	c.IamInstanceProfilePropagationTimeout = 2 * time.Minute
+	if err := c.Prepare(nil); len(err) != 0 {
+		t.Fatalf("err: %v", err)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"net"
 	"time"
 
 	"github.com/aws/aws-sdk-go/service/ec2"
@@ -20,15 +21,18 @@ var (
 )
 
 // SSHHost returns a function that can be given to the SSH communicator
-// for determining the SSH address based on the instance DNS name.
-func SSHHost(e ec2Describer, sshInterface string, host string) func(multistep.StateBag) (string, error) {
+// for determining the SSH address based on the instance DNS name. When
+// dnsPropagationTimeout is non-zero and the public DNS name is used as
+// the address, the function also waits for the name to resolve before
+// returning it.
+func SSHHost(e ec2Describer, sshInterface string, host string, dnsPropagationTimeout time.Duration) func(multistep.StateBag) (string, error) {
 	return func(state multistep.StateBag) (string, error) {
 		if host != "" {
 			log.Printf("Using host value: %s", host)
 			return host, nil
 		}
 
-		if sshInterface == "session_manager" {
+		if sshInterface == "session_manager" || sshInterface == "instance_connect_endpoint" {
 			return "localhost", nil
 		}
 
@@ -68,6 +72,11 @@ func SSHHost(e ec2Describer, sshInterface string, host string) func(multistep.St
 			}
 
 			if host != "" {
+				if sshInterface == "public_dns" && dnsPropagationTimeout > 0 {
+					if err := waitForDnsResolution(host, dnsPropagationTimeout); err != nil {
+						return "", err
+					}
+				}
 				return host, nil
 			}
 
@@ -90,17 +99,45 @@ func SSHHost(e ec2Describer, sshInterface string, host string) func(multistep.St
 	}
 }
 
+// waitForDnsResolution polls until host resolves or the timeout expires.
+// The lookup uses the default resolver, i.e. the one the connection
+// itself will go through, which inside a VPC is the split-horizon VPC
+// resolver.
+func waitForDnsResolution(host string, timeout time.Duration) error {
+	log.Printf("Waiting up to %s for %s to resolve...", timeout, host)
+
+	deadline := time.Now().Add(timeout)
+	for {
+		addrs, err := net.LookupHost(host)
+		if err == nil && len(addrs) > 0 {
+			log.Printf("DNS name %s resolves to %v", host, addrs)
+			return nil
+		}
+
+		if time.Now().After(deadline) {
+			if err != nil {
+				return fmt.Errorf(
+					"timeout waiting for %s to resolve: %s", host, err)
+			}
+			return fmt.Errorf("timeout waiting for %s to resolve", host)
+		}
+
+		log.Printf("DNS name %s does not resolve yet, retrying...", host)
+		time.Sleep(sshHostSleepDuration)
+	}
+}
+
 // Port returns a function that can be given to the communicator
 // for determining the port to use when connecting to an instance.
 func Port(sshInterface string, port int) func(multistep.StateBag) (int, error) {
 	return func(state multistep.StateBag) (int, error) {
-		if sshInterface != "session_manager" {
+		if sshInterface != "session_manager" && sshInterface != "instance_connect_endpoint" {
 			return port, nil
 		}
 
 		port, ok := state.GetOk("sessionPort")
 		if !ok {
-			return 0, fmt.Errorf("no local port defined for session-manager")
+			return 0, fmt.Errorf("no local port defined for the %s tunnel", sshInterface)
 		}
 		return port.(int), nil
 
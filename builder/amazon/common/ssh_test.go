@@ -2,6 +2,7 @@ package common
 
 import (
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/ec2"
@@ -79,7 +80,7 @@ func testSSHHost(t *testing.T, allowTries int, vpcId string, sshInterface string
 		publicDNS:  publicDNS,
 	}
 
-	f := SSHHost(e, sshInterface, sshHostOverride)
+	f := SSHHost(e, sshInterface, sshHostOverride, 0)
 	st := &multistep.BasicStateBag{}
 	st.Put("instance", &ec2.Instance{
 		InstanceId: aws.String("instance-id"),
@@ -103,6 +104,22 @@ func testSSHHost(t *testing.T, allowTries int, vpcId string, sshInterface string
 	}
 }
 
+func TestWaitForDnsResolution(t *testing.T) {
+	origSshHostSleepDuration := sshHostSleepDuration
+	defer func() { sshHostSleepDuration = origSshHostSleepDuration }()
+	sshHostSleepDuration = 0
+
+	// localhost always resolves via the hosts file.
+	if err := waitForDnsResolution("localhost", time.Second); err != nil {
+		t.Fatalf("expected no error, got %+v", err)
+	}
+
+	// .invalid is reserved and never resolves.
+	if err := waitForDnsResolution("packer.invalid", time.Millisecond); err == nil {
+		t.Fatal("expected a timeout error, got none")
+	}
+}
+
 type fakeEC2Describer struct {
 	allowTries int
 	tries      int
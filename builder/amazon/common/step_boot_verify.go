@@ -0,0 +1,125 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/hashicorp/packer/common/retry"
+	"github.com/hashicorp/packer/helper/communicator"
+	"github.com/hashicorp/packer/helper/multistep"
+	"github.com/hashicorp/packer/packer"
+)
+
+// StepBootVerifyInstance boots a throwaway instance from the AMI that was
+// just created, so that a check script can be run against it before the
+// build is finalized. It is only part of the step sequence when the build
+// was started with "packer test". The instance reuses the temporary key
+// pair and security group of the source instance and is terminated once
+// verification is done.
+type StepBootVerifyInstance struct {
+	Comm         *communicator.Config
+	InstanceType string
+
+	instanceId string
+}
+
+func (s *StepBootVerifyInstance) Run(ctx context.Context, state multistep.StateBag) multistep.StepAction {
+	ec2conn := state.Get("ec2").(*ec2.EC2)
+	ui := state.Get("ui").(packer.Ui)
+
+	amis := state.Get("amis").(map[string]string)
+	ami, ok := amis[*ec2conn.Config.Region]
+	if !ok {
+		state.Put("error", fmt.Errorf("No AMI found in the build region to verify"))
+		return multistep.ActionHalt
+	}
+
+	ui.Say(fmt.Sprintf("Booting a verification instance from AMI %s...", ami))
+
+	runOpts := &ec2.RunInstancesInput{
+		ImageId:      aws.String(ami),
+		InstanceType: &s.InstanceType,
+		MaxCount:     aws.Int64(1),
+		MinCount:     aws.Int64(1),
+	}
+
+	if s.Comm.SSHKeyPairName != "" {
+		runOpts.KeyName = &s.Comm.SSHKeyPairName
+	}
+
+	if v, ok := state.GetOk("subnet_id"); ok && v.(string) != "" {
+		runOpts.SubnetId = aws.String(v.(string))
+	}
+	if v, ok := state.GetOk("securityGroupIds"); ok {
+		runOpts.SecurityGroupIds = aws.StringSlice(v.([]string))
+	}
+
+	runResp, err := ec2conn.RunInstances(runOpts)
+	if err != nil {
+		err := fmt.Errorf("Error launching verification instance: %s", err)
+		state.Put("error", err)
+		ui.Error(err.Error())
+		return multistep.ActionHalt
+	}
+	s.instanceId = *runResp.Instances[0].InstanceId
+
+	ui.Message(fmt.Sprintf("Instance ID: %s", s.instanceId))
+	ui.Say(fmt.Sprintf("Waiting for instance (%v) to become ready...", s.instanceId))
+
+	if err := WaitUntilInstanceRunning(ctx, ec2conn, s.instanceId); err != nil {
+		err := fmt.Errorf("Error waiting for instance (%s) to become ready: %s", s.instanceId, err)
+		state.Put("error", err)
+		ui.Error(err.Error())
+		return multistep.ActionHalt
+	}
+
+	// Retry the describe a couple of times to mitigate AWS's eventual
+	// consistency, just like the source instance launch does.
+	var r *ec2.DescribeInstancesOutput
+	err = retry.Config{Tries: 11, ShouldRetry: func(err error) bool {
+		return isAWSErr(err, "InvalidInstanceID.NotFound", "")
+	},
+		RetryDelay: (&retry.Backoff{InitialBackoff: 200 * time.Millisecond, MaxBackoff: 30 * time.Second, Multiplier: 2}).Linear,
+	}.Run(ctx, func(ctx context.Context) error {
+		r, err = ec2conn.DescribeInstances(&ec2.DescribeInstancesInput{
+			InstanceIds: []*string{aws.String(s.instanceId)},
+		})
+		return err
+	})
+	if err != nil || len(r.Reservations) == 0 || len(r.Reservations[0].Instances) == 0 {
+		err := fmt.Errorf("Error finding verification instance.")
+		state.Put("error", err)
+		ui.Error(err.Error())
+		return multistep.ActionHalt
+	}
+
+	// The connect step that follows picks the instance to reach out to from
+	// the state bag; point it at the verification instance.
+	instance := r.Reservations[0].Instances[0]
+	state.Put("instance", instance)
+	state.Put("instance_id", instance.InstanceId)
+
+	return multistep.ActionContinue
+}
+
+func (s *StepBootVerifyInstance) Cleanup(state multistep.StateBag) {
+	if s.instanceId == "" {
+		return
+	}
+
+	ec2conn := state.Get("ec2").(*ec2.EC2)
+	ui := state.Get("ui").(packer.Ui)
+
+	ui.Say("Terminating the verification instance...")
+	if _, err := ec2conn.TerminateInstances(&ec2.TerminateInstancesInput{InstanceIds: []*string{&s.instanceId}}); err != nil {
+		ui.Error(fmt.Sprintf("Error terminating instance, may still be around: %s", err))
+		return
+	}
+
+	if err := WaitUntilInstanceTerminated(aws.BackgroundContext(), ec2conn, s.instanceId); err != nil {
+		ui.Error(err.Error())
+	}
+}
@@ -0,0 +1,191 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	v4 "github.com/aws/aws-sdk-go/aws/signer/v4"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/gorilla/websocket"
+	"github.com/hashicorp/packer/common/net"
+	"github.com/hashicorp/packer/helper/multistep"
+	"github.com/hashicorp/packer/packer"
+)
+
+// StepCreateEICETunnel tunnels SSH through an EC2 Instance Connect Endpoint
+// (EICE) in the instance's subnet, so builds work in VPCs without public IPs
+// where SSM is not deployed. The endpoint itself is not managed by Packer;
+// its ID and DNS name come from the template, as looking them up is not
+// supported by the bundled AWS SDK. A local listener is opened and every
+// accepted connection is forwarded to the instance over a signed websocket
+// to the endpoint's openTunnel action.
+type StepCreateEICETunnel struct {
+	AWSSession       *session.Session
+	Region           string
+	LocalPortNumber  int
+	RemotePortNumber int
+	EndpointID       string
+	EndpointDNSName  string
+	EICEEnabled      bool
+
+	privateIp string
+	listener  *net.Listener
+}
+
+// Run executes the Packer build step that creates an EICE tunnel.
+func (s *StepCreateEICETunnel) Run(ctx context.Context, state multistep.StateBag) multistep.StepAction {
+	ui := state.Get("ui").(packer.Ui)
+
+	if !s.EICEEnabled {
+		return multistep.ActionContinue
+	}
+
+	instance, ok := state.Get("instance").(*ec2.Instance)
+	if !ok {
+		err := fmt.Errorf("error encountered in obtaining target instance for the tunnel")
+		ui.Error(err.Error())
+		state.Put("error", err)
+		return multistep.ActionHalt
+	}
+	s.privateIp = aws.StringValue(instance.PrivateIpAddress)
+	if s.privateIp == "" {
+		err := fmt.Errorf("instance has no private IP address to tunnel to")
+		ui.Error(err.Error())
+		state.Put("error", err)
+		return multistep.ActionHalt
+	}
+
+	minPortNumber, maxPortNumber := 8000, 9000
+	if s.LocalPortNumber != 0 {
+		minPortNumber = s.LocalPortNumber
+		maxPortNumber = minPortNumber
+	}
+	l, err := net.ListenRangeConfig{
+		Min:     minPortNumber,
+		Max:     maxPortNumber,
+		Addr:    "localhost",
+		Network: "tcp",
+	}.Listen(ctx)
+	if err != nil {
+		err := fmt.Errorf("error finding an available port for the tunnel: %s", err)
+		state.Put("error", err)
+		ui.Error(err.Error())
+		return multistep.ActionHalt
+	}
+	s.listener = l
+	s.LocalPortNumber = l.Port
+
+	go s.serve()
+
+	ui.Message(fmt.Sprintf("Tunnel to %s via endpoint %s listening on localhost:%d",
+		s.privateIp, s.EndpointID, s.LocalPortNumber))
+	state.Put("sessionPort", s.LocalPortNumber)
+	return multistep.ActionContinue
+}
+
+// Cleanup closes the local listener; in-flight tunnels die with it.
+func (s *StepCreateEICETunnel) Cleanup(state multistep.StateBag) {
+	if s.listener != nil {
+		s.listener.Close()
+	}
+}
+
+// serve accepts local connections and forwards each one through a fresh
+// websocket tunnel until the listener is closed.
+func (s *StepCreateEICETunnel) serve() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			log.Printf("[DEBUG] EICE tunnel listener closed: %s", err)
+			return
+		}
+		go func() {
+			defer conn.Close()
+			if err := s.tunnel(conn); err != nil {
+				log.Printf("[ERROR] EICE tunnel: %s", err)
+			}
+		}()
+	}
+}
+
+// tunnel opens a websocket to the endpoint's openTunnel action and pumps
+// bytes between it and the local connection.
+func (s *StepCreateEICETunnel) tunnel(conn io.ReadWriteCloser) error {
+	url, err := s.presignTunnelURL()
+	if err != nil {
+		return fmt.Errorf("error signing tunnel request: %s", err)
+	}
+
+	ws, _, err := websocket.DefaultDialer.Dial(url, nil)
+	if err != nil {
+		return fmt.Errorf("error opening websocket to endpoint %s: %s", s.EndpointID, err)
+	}
+	defer ws.Close()
+
+	errCh := make(chan error, 2)
+	go func() {
+		for {
+			_, data, err := ws.ReadMessage()
+			if err != nil {
+				errCh <- err
+				return
+			}
+			if _, err := conn.Write(data); err != nil {
+				errCh <- err
+				return
+			}
+		}
+	}()
+	go func() {
+		buf := make([]byte, 32*1024)
+		for {
+			n, err := conn.Read(buf)
+			if n > 0 {
+				if err := ws.WriteMessage(websocket.BinaryMessage, buf[:n]); err != nil {
+					errCh <- err
+					return
+				}
+			}
+			if err != nil {
+				errCh <- err
+				return
+			}
+		}
+	}()
+
+	if err := <-errCh; err != io.EOF {
+		return err
+	}
+	return nil
+}
+
+// presignTunnelURL builds the sigv4 presigned websocket URL for the
+// endpoint's openTunnel action. A fresh URL is signed for every connection
+// as they are only valid for 60 seconds.
+func (s *StepCreateEICETunnel) presignTunnelURL() (string, error) {
+	req, err := http.NewRequest("GET", fmt.Sprintf("wss://%s/openTunnel", s.EndpointDNSName), nil)
+	if err != nil {
+		return "", err
+	}
+
+	q := req.URL.Query()
+	q.Set("instanceConnectEndpointId", s.EndpointID)
+	q.Set("privateIpAddress", s.privateIp)
+	q.Set("remotePort", strconv.Itoa(s.RemotePortNumber))
+	q.Set("maxTunnelDuration", "3600")
+	req.URL.RawQuery = q.Encode()
+
+	signer := v4.NewSigner(s.AWSSession.Config.Credentials)
+	if _, err := signer.Presign(req, nil, "ec2-instance-connect", s.Region, 60*time.Second, time.Now()); err != nil {
+		return "", err
+	}
+
+	return req.URL.String(), nil
+}
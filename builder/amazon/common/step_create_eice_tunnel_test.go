@@ -0,0 +1,61 @@
+package common
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+)
+
+func TestStepCreateEICETunnel_PresignTunnelURL(t *testing.T) {
+	sess := session.Must(session.NewSession(&aws.Config{
+		Region:      aws.String("us-east-1"),
+		Credentials: credentials.NewStaticCredentials("AKID", "SECRET", ""),
+	}))
+
+	step := StepCreateEICETunnel{
+		AWSSession:       sess,
+		Region:           "us-east-1",
+		RemotePortNumber: 22,
+		EndpointID:       "eice-0123456789abcdef0",
+		EndpointDNSName:  "eice-0123456789abcdef0.abc.ec2-instance-connect-endpoint.us-east-1.amazonaws.com",
+		privateIp:        "10.0.0.5",
+	}
+
+	signed, err := step.presignTunnelURL()
+	if err != nil {
+		t.Fatalf("presigning the tunnel URL should not fail: %v", err)
+	}
+
+	u, err := url.Parse(signed)
+	if err != nil {
+		t.Fatalf("the presigned URL should parse: %v", err)
+	}
+
+	if u.Scheme != "wss" || u.Host != step.EndpointDNSName || u.Path != "/openTunnel" {
+		t.Errorf("unexpected URL base: %s", signed)
+	}
+
+	q := u.Query()
+	expected := map[string]string{
+		"instanceConnectEndpointId": step.EndpointID,
+		"privateIpAddress":          "10.0.0.5",
+		"remotePort":                "22",
+		"maxTunnelDuration":         "3600",
+	}
+	for k, v := range expected {
+		if q.Get(k) != v {
+			t.Errorf("query parameter %s = %q, expected %q", k, q.Get(k), v)
+		}
+	}
+
+	if q.Get("X-Amz-Signature") == "" {
+		t.Errorf("the URL should carry a sigv4 signature: %s", signed)
+	}
+	if !strings.Contains(q.Get("X-Amz-Credential"), "ec2-instance-connect") {
+		t.Errorf("the URL should be signed for the ec2-instance-connect service: %s", signed)
+	}
+}
@@ -0,0 +1,98 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/hashicorp/packer/helper/communicator"
+	"github.com/hashicorp/packer/helper/multistep"
+	"github.com/hashicorp/packer/packer"
+)
+
+// Owner IDs of well known public image publishers, used when an AMI name
+// does not match any of the distribution patterns.
+const (
+	canonicalOwnerId = "099720109477"
+	amazonOwnerId    = "137112412989"
+)
+
+// The distribution patterns matched against the lower-cased AMI name, in
+// order, and the default user shipped by that distribution.
+var sshUsernamePatterns = []struct {
+	Pattern  string
+	Username string
+}{
+	{"ubuntu", "ubuntu"},
+	{"amzn", "ec2-user"},
+	{"amazon linux", "ec2-user"},
+	{"centos", "centos"},
+	{"debian", "admin"},
+	{"fedora", "fedora"},
+	{"rhel", "ec2-user"},
+	{"red hat", "ec2-user"},
+	{"suse", "ec2-user"},
+	{"sles", "ec2-user"},
+	{"bitnami", "bitnami"},
+}
+
+// This step fills in ssh_username from the source AMI metadata when the
+// user opted into detect_ssh_username without giving a username. It must
+// run after StepSourceAMIInfo so the source image is in the state.
+type StepDetectSSHUsername struct {
+	Comm    *communicator.Config
+	Enabled bool
+}
+
+func (s *StepDetectSSHUsername) Run(ctx context.Context, state multistep.StateBag) multistep.StepAction {
+	if !s.Enabled {
+		return multistep.ActionContinue
+	}
+
+	ui := state.Get("ui").(packer.Ui)
+	image := state.Get("source_image").(*ec2.Image)
+
+	if username := detectSSHUsername(image); username != "" {
+		ui.Say(fmt.Sprintf("Detected ssh_username %q from source AMI %s",
+			username, aws.StringValue(image.ImageId)))
+		s.Comm.SSHUsername = username
+	} else {
+		ui.Say(fmt.Sprintf("Could not detect an ssh_username from source AMI %s; using %q",
+			aws.StringValue(image.ImageId), s.Comm.SSHUsername))
+	}
+
+	return multistep.ActionContinue
+}
+
+// detectSSHUsername derives the default user of an image from its tags,
+// name and owner. An empty string means nothing matched.
+func detectSSHUsername(image *ec2.Image) string {
+	// An ssh_username tag on the AMI beats any name pattern.
+	for _, tag := range image.Tags {
+		if aws.StringValue(tag.Key) == "ssh_username" && aws.StringValue(tag.Value) != "" {
+			return aws.StringValue(tag.Value)
+		}
+	}
+
+	name := strings.ToLower(aws.StringValue(image.Name))
+	for _, candidate := range sshUsernamePatterns {
+		if strings.Contains(name, candidate.Pattern) {
+			return candidate.Username
+		}
+	}
+
+	// Well known publishers keep their default user even when the AMI name
+	// doesn't mention the distribution.
+	switch aws.StringValue(image.OwnerId) {
+	case canonicalOwnerId:
+		return "ubuntu"
+	case amazonOwnerId:
+		return "ec2-user"
+	}
+
+	return ""
+}
+
+func (s *StepDetectSSHUsername) Cleanup(state multistep.StateBag) {}
@@ -0,0 +1,109 @@
+package common
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/hashicorp/packer/helper/communicator"
+	"github.com/hashicorp/packer/helper/multistep"
+	"github.com/hashicorp/packer/packer"
+)
+
+func TestDetectSSHUsername(t *testing.T) {
+	cases := []struct {
+		Image    *ec2.Image
+		Expected string
+	}{
+		{
+			Image: &ec2.Image{
+				Name: aws.String("ubuntu/images/hvm-ssd/ubuntu-bionic-18.04-amd64-server"),
+			},
+			Expected: "ubuntu",
+		},
+		{
+			Image: &ec2.Image{
+				Name: aws.String("amzn2-ami-hvm-2.0-x86_64-gp2"),
+			},
+			Expected: "ec2-user",
+		},
+		{
+			Image: &ec2.Image{
+				Name: aws.String("CentOS Linux 7 x86_64 HVM EBS"),
+			},
+			Expected: "centos",
+		},
+		{
+			Image: &ec2.Image{
+				Name: aws.String("debian-10-amd64"),
+			},
+			Expected: "admin",
+		},
+		{
+			// An ssh_username tag beats the name patterns
+			Image: &ec2.Image{
+				Name: aws.String("ubuntu-18.04-custom"),
+				Tags: []*ec2.Tag{
+					{Key: aws.String("ssh_username"), Value: aws.String("builder")},
+				},
+			},
+			Expected: "builder",
+		},
+		{
+			// Fall back to well known owner ids
+			Image: &ec2.Image{
+				Name:    aws.String("mystery-image"),
+				OwnerId: aws.String("099720109477"),
+			},
+			Expected: "ubuntu",
+		},
+		{
+			Image: &ec2.Image{
+				Name: aws.String("mystery-image"),
+			},
+			Expected: "",
+		},
+	}
+
+	for _, tc := range cases {
+		if username := detectSSHUsername(tc.Image); username != tc.Expected {
+			t.Fatalf("bad username for %s: got %q, expected %q",
+				aws.StringValue(tc.Image.Name), username, tc.Expected)
+		}
+	}
+}
+
+func TestStepDetectSSHUsername(t *testing.T) {
+	state := new(multistep.BasicStateBag)
+	state.Put("ui", &packer.BasicUi{
+		Reader: new(bytes.Buffer),
+		Writer: new(bytes.Buffer),
+	})
+	state.Put("source_image", &ec2.Image{
+		ImageId: aws.String("ami-1234"),
+		Name:    aws.String("ubuntu-18.04-server"),
+	})
+
+	comm := &communicator.Config{}
+	comm.SSHUsername = "ec2-user"
+
+	step := &StepDetectSSHUsername{Comm: comm, Enabled: true}
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+	if comm.SSHUsername != "ubuntu" {
+		t.Fatalf("bad username: %s", comm.SSHUsername)
+	}
+
+	// Disabled steps must leave the username alone
+	comm.SSHUsername = "custom"
+	step = &StepDetectSSHUsername{Comm: comm, Enabled: false}
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+	if comm.SSHUsername != "custom" {
+		t.Fatalf("bad username: %s", comm.SSHUsername)
+	}
+}
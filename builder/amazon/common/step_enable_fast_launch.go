@@ -0,0 +1,169 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/hashicorp/packer/helper/multistep"
+	"github.com/hashicorp/packer/packer"
+)
+
+// The vendored aws-sdk-go predates the fast launch API, so the request and
+// response shapes are declared here and sent through the client's generic
+// request machinery. EC2 has kept a single API version (2016-11-15) since
+// these actions were introduced, so the client metadata works as-is.
+type fastLaunchSnapshotConfiguration struct {
+	TargetResourceCount *int64 `locationName:"TargetResourceCount" type:"integer"`
+}
+
+type enableFastLaunchInput struct {
+	_                     struct{}                         `type:"structure"`
+	ImageId               *string                          `locationName:"ImageId" type:"string"`
+	ResourceType          *string                          `locationName:"ResourceType" type:"string"`
+	MaxParallelLaunches   *int64                           `locationName:"MaxParallelLaunches" type:"integer"`
+	SnapshotConfiguration *fastLaunchSnapshotConfiguration `locationName:"SnapshotConfiguration" type:"structure"`
+}
+
+type enableFastLaunchOutput struct {
+	_       struct{} `type:"structure"`
+	ImageId *string  `locationName:"imageId" type:"string"`
+	State   *string  `locationName:"state" type:"string"`
+}
+
+type describeFastLaunchImagesInput struct {
+	_        struct{}  `type:"structure"`
+	ImageIds []*string `locationName:"ImageId" locationNameList:"ImageId" type:"list"`
+}
+
+type fastLaunchImage struct {
+	_                     struct{} `type:"structure"`
+	ImageId               *string  `locationName:"imageId" type:"string"`
+	State                 *string  `locationName:"state" type:"string"`
+	StateTransitionReason *string  `locationName:"stateTransitionReason" type:"string"`
+}
+
+type describeFastLaunchImagesOutput struct {
+	_                struct{}           `type:"structure"`
+	FastLaunchImages []*fastLaunchImage `locationName:"fastLaunchImageSet" locationNameList:"item" type:"list"`
+}
+
+// StepEnableFastLaunch enables EC2 Windows fast launch on the AMI created
+// in the build region and waits for the pre-provisioned snapshots to become
+// ready. The step is a no-op unless enable_fast_launch is set.
+type StepEnableFastLaunch struct {
+	EnableFastLaunch    bool
+	TargetResourceCount int
+	MaxParallelLaunches int
+}
+
+func (s *StepEnableFastLaunch) Run(ctx context.Context, state multistep.StateBag) multistep.StepAction {
+	if !s.EnableFastLaunch {
+		return multistep.ActionContinue
+	}
+
+	ec2conn := state.Get("ec2").(*ec2.EC2)
+	ui := state.Get("ui").(packer.Ui)
+	amis := state.Get("amis").(map[string]string)
+
+	amiId, ok := amis[*ec2conn.Config.Region]
+	if !ok {
+		err := fmt.Errorf("No AMI found in the build region to enable fast launch on")
+		state.Put("error", err)
+		ui.Error(err.Error())
+		return multistep.ActionHalt
+	}
+
+	ui.Say(fmt.Sprintf("Enabling Windows fast launch on AMI %s...", amiId))
+
+	input := &enableFastLaunchInput{
+		ImageId:      aws.String(amiId),
+		ResourceType: aws.String("snapshot"),
+	}
+	if s.MaxParallelLaunches > 0 {
+		input.MaxParallelLaunches = aws.Int64(int64(s.MaxParallelLaunches))
+	}
+	if s.TargetResourceCount > 0 {
+		input.SnapshotConfiguration = &fastLaunchSnapshotConfiguration{
+			TargetResourceCount: aws.Int64(int64(s.TargetResourceCount)),
+		}
+	}
+
+	if _, err := enableFastLaunch(ec2conn, input); err != nil {
+		err = fmt.Errorf("Error enabling fast launch on AMI %s: %s", amiId, err)
+		state.Put("error", err)
+		ui.Error(err.Error())
+		return multistep.ActionHalt
+	}
+
+	ui.Say("Waiting for fast launch to become ready on the AMI...")
+	if err := s.waitForFastLaunchEnabled(ctx, ec2conn, amiId, ui); err != nil {
+		state.Put("error", err)
+		ui.Error(err.Error())
+		return multistep.ActionHalt
+	}
+
+	ui.Message(fmt.Sprintf("Fast launch enabled on AMI %s", amiId))
+	return multistep.ActionContinue
+}
+
+func (s *StepEnableFastLaunch) waitForFastLaunchEnabled(ctx context.Context, ec2conn *ec2.EC2, amiId string, ui packer.Ui) error {
+	for {
+		output, err := describeFastLaunchImages(ec2conn, &describeFastLaunchImagesInput{
+			ImageIds: []*string{aws.String(amiId)},
+		})
+		if err != nil {
+			return fmt.Errorf("Error describing fast launch state of AMI %s: %s", amiId, err)
+		}
+
+		if len(output.FastLaunchImages) > 0 {
+			image := output.FastLaunchImages[0]
+			switch aws.StringValue(image.State) {
+			case "enabled":
+				return nil
+			case "enabled-failed":
+				return fmt.Errorf("Enabling fast launch on AMI %s failed: %s",
+					amiId, aws.StringValue(image.StateTransitionReason))
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("Cancelled waiting for fast launch to become ready on AMI %s", amiId)
+		case <-time.After(15 * time.Second):
+		}
+	}
+}
+
+func (s *StepEnableFastLaunch) Cleanup(state multistep.StateBag) {
+	// No cleanup: fast launch belongs to the AMI, which is the build output.
+}
+
+func enableFastLaunch(ec2conn *ec2.EC2, input *enableFastLaunchInput) (*enableFastLaunchOutput, error) {
+	req := ec2conn.NewRequest(&request.Operation{
+		Name:       "EnableFastLaunch",
+		HTTPMethod: "POST",
+		HTTPPath:   "/",
+	}, input, &enableFastLaunchOutput{})
+
+	if err := req.Send(); err != nil {
+		return nil, err
+	}
+	return req.Data.(*enableFastLaunchOutput), nil
+}
+
+func describeFastLaunchImages(ec2conn *ec2.EC2, input *describeFastLaunchImagesInput) (*describeFastLaunchImagesOutput, error) {
+	req := ec2conn.NewRequest(&request.Operation{
+		Name:       "DescribeFastLaunchImages",
+		HTTPMethod: "POST",
+		HTTPPath:   "/",
+	}, input, &describeFastLaunchImagesOutput{})
+
+	if err := req.Send(); err != nil {
+		return nil, err
+	}
+	return req.Data.(*describeFastLaunchImagesOutput), nil
+}
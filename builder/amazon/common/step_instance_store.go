@@ -0,0 +1,143 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+	"github.com/hashicorp/packer/helper/multistep"
+	"github.com/hashicorp/packer/packer"
+)
+
+// StepInstanceStore validates the configured ephemeral (instance store)
+// block device mappings against the capability of the chosen instance type,
+// and generates one mapping per available instance store volume when
+// ephemeral_devices is set to "auto".
+//
+// Produces (adding them to the state bag):
+//
+//	ephemeral_block_device_mappings []*ec2.BlockDeviceMapping - the
+//	generated mappings, appended at launch time
+type StepInstanceStore struct {
+	InstanceType     string
+	LaunchMappings   BlockDevices
+	EphemeralDevices string
+}
+
+func (s *StepInstanceStore) Run(ctx context.Context, state multistep.StateBag) multistep.StepAction {
+	ec2conn := state.Get("ec2").(*ec2.EC2)
+	ui := state.Get("ui").(packer.Ui)
+
+	ephemerals := ephemeralIndexes(s.LaunchMappings)
+	if len(ephemerals) == 0 && s.EphemeralDevices != "auto" {
+		return multistep.ActionContinue
+	}
+
+	count, err := instanceStoreVolumeCount(ec2conn, s.InstanceType)
+	if err != nil {
+		// Missing ec2:DescribeInstanceTypes permission must not fail
+		// builds that worked before; EC2 still rejects truly invalid
+		// requests at launch time.
+		ui.Say(fmt.Sprintf(
+			"Warning: could not check the instance store capability of %s: %s",
+			s.InstanceType, err))
+		return multistep.ActionContinue
+	}
+
+	if err := validateEphemeralMappings(ephemerals, s.InstanceType, count); err != nil {
+		state.Put("error", err)
+		ui.Error(err.Error())
+		return multistep.ActionHalt
+	}
+
+	if s.EphemeralDevices == "auto" {
+		if len(ephemerals) > 0 {
+			err := fmt.Errorf("ephemeral_devices: auto cannot be combined " +
+				"with virtual_name mappings in launch_block_device_mappings.")
+			state.Put("error", err)
+			ui.Error(err.Error())
+			return multistep.ActionHalt
+		}
+
+		mappings := make([]*ec2.BlockDeviceMapping, 0, count)
+		for i := int64(0); i < count; i++ {
+			mappings = append(mappings, &ec2.BlockDeviceMapping{
+				DeviceName:  aws.String(fmt.Sprintf("/dev/sd%c", 'b'+i)),
+				VirtualName: aws.String(fmt.Sprintf("ephemeral%d", i)),
+			})
+		}
+		ui.Say(fmt.Sprintf("Adding %d instance store mapping(s) for %s",
+			len(mappings), s.InstanceType))
+		state.Put("ephemeral_block_device_mappings", mappings)
+	}
+
+	return multistep.ActionContinue
+}
+
+// ephemeralIndexes returns the instance store volume indexes referenced by
+// the virtual_name mappings, keyed by virtual device name.
+func ephemeralIndexes(mappings BlockDevices) map[string]int64 {
+	indexes := map[string]int64{}
+	for _, mapping := range mappings {
+		if !strings.HasPrefix(mapping.VirtualName, "ephemeral") {
+			continue
+		}
+		i, err := strconv.ParseInt(
+			strings.TrimPrefix(mapping.VirtualName, "ephemeral"), 10, 64)
+		if err != nil {
+			// Anything but ephemeralN is dropped at launch time already
+			continue
+		}
+		indexes[mapping.VirtualName] = i
+	}
+	return indexes
+}
+
+// instanceStoreVolumeCount returns how many instance store volumes
+// instanceType provides.
+func instanceStoreVolumeCount(ec2conn ec2iface.EC2API, instanceType string) (int64, error) {
+	resp, err := ec2conn.DescribeInstanceTypes(&ec2.DescribeInstanceTypesInput{
+		InstanceTypes: []*string{aws.String(instanceType)},
+	})
+	if err != nil {
+		return 0, err
+	}
+	if len(resp.InstanceTypes) != 1 {
+		return 0, fmt.Errorf("unknown instance type: %s", instanceType)
+	}
+
+	info := resp.InstanceTypes[0]
+	if !aws.BoolValue(info.InstanceStorageSupported) || info.InstanceStorageInfo == nil {
+		return 0, nil
+	}
+
+	var count int64
+	for _, disk := range info.InstanceStorageInfo.Disks {
+		count += aws.Int64Value(disk.Count)
+	}
+	return count, nil
+}
+
+// validateEphemeralMappings checks every referenced instance store volume
+// exists on the instance type, so mappings are not silently ignored.
+func validateEphemeralMappings(ephemerals map[string]int64, instanceType string, count int64) error {
+	for name, i := range ephemerals {
+		if count == 0 {
+			return fmt.Errorf("launch_block_device_mappings maps %s, but "+
+				"the instance type %s has no instance store volumes.",
+				name, instanceType)
+		}
+		if i >= count {
+			return fmt.Errorf("launch_block_device_mappings maps %s, but "+
+				"the instance type %s only provides %d instance store "+
+				"volume(s).", name, instanceType, count)
+		}
+	}
+	return nil
+}
+
+func (s *StepInstanceStore) Cleanup(state multistep.StateBag) {}
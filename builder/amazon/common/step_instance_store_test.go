@@ -0,0 +1,93 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+)
+
+type mockEC2ClientInstanceTypes struct {
+	ec2iface.EC2API
+}
+
+func (m *mockEC2ClientInstanceTypes) DescribeInstanceTypes(input *ec2.DescribeInstanceTypesInput) (*ec2.DescribeInstanceTypesOutput, error) {
+	switch aws.StringValue(input.InstanceTypes[0]) {
+	case "m5d.large":
+		return &ec2.DescribeInstanceTypesOutput{
+			InstanceTypes: []*ec2.InstanceTypeInfo{
+				{
+					InstanceType:             aws.String("m5d.large"),
+					InstanceStorageSupported: aws.Bool(true),
+					InstanceStorageInfo: &ec2.InstanceStorageInfo{
+						Disks: []*ec2.DiskInfo{
+							{Count: aws.Int64(1)},
+						},
+					},
+				},
+			},
+		}, nil
+	case "t2.micro":
+		return &ec2.DescribeInstanceTypesOutput{
+			InstanceTypes: []*ec2.InstanceTypeInfo{
+				{
+					InstanceType:             aws.String("t2.micro"),
+					InstanceStorageSupported: aws.Bool(false),
+				},
+			},
+		}, nil
+	}
+	return &ec2.DescribeInstanceTypesOutput{}, nil
+}
+
+func TestInstanceStoreVolumeCount(t *testing.T) {
+	conn := &mockEC2ClientInstanceTypes{}
+
+	count, err := instanceStoreVolumeCount(conn, "m5d.large")
+	if err != nil {
+		t.Fatalf("shouldn't have err: %s", err)
+	}
+	if count != 1 {
+		t.Fatalf("bad count: %d", count)
+	}
+
+	count, err = instanceStoreVolumeCount(conn, "t2.micro")
+	if err != nil {
+		t.Fatalf("shouldn't have err: %s", err)
+	}
+	if count != 0 {
+		t.Fatalf("bad count: %d", count)
+	}
+
+	if _, err = instanceStoreVolumeCount(conn, "t2.bogus"); err == nil {
+		t.Fatal("should have error")
+	}
+}
+
+func TestValidateEphemeralMappings(t *testing.T) {
+	mappings := BlockDevices{
+		{DeviceName: "/dev/sdb", VirtualName: "ephemeral0"},
+		{DeviceName: "/dev/sda1", VolumeSize: 8},
+	}
+
+	ephemerals := ephemeralIndexes(mappings)
+	if len(ephemerals) != 1 || ephemerals["ephemeral0"] != 0 {
+		t.Fatalf("bad indexes: %#v", ephemerals)
+	}
+
+	if err := validateEphemeralMappings(ephemerals, "m5d.large", 1); err != nil {
+		t.Fatalf("shouldn't have err: %s", err)
+	}
+
+	// No instance store volumes at all
+	if err := validateEphemeralMappings(ephemerals, "t2.micro", 0); err == nil {
+		t.Fatal("should have error")
+	}
+
+	// Not enough instance store volumes
+	ephemerals["ephemeral1"] = 1
+	if err := validateEphemeralMappings(ephemerals, "m5d.large", 1); err == nil {
+		t.Fatal("should have error")
+	}
+}
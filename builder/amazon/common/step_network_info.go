@@ -7,6 +7,7 @@ import (
 	"math/rand"
 	"sort"
 
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/hashicorp/packer/helper/multistep"
 	"github.com/hashicorp/packer/packer"
@@ -16,19 +17,31 @@ import (
 // VPC's and Subnets that is used throughout the AMI creation process.
 //
 // Produces (adding them to the state bag):
-//   vpc_id string - the VPC ID
-//   subnet_id string - the Subnet ID
-//   availability_zone string - the AZ name
+//
+//	vpc_id string - the VPC ID
+//	subnet_id string - the Subnet ID
+//	availability_zone string - the AZ name
+//	az_fallbacks []AZFallback - alternative zones for capacity fallback,
+//	    only present when neither availability_zone nor subnet_id is pinned
 type StepNetworkInfo struct {
 	VpcId               string
 	VpcFilter           VpcFilterOptions
 	SubnetId            string
 	SubnetFilter        SubnetFilterOptions
 	AvailabilityZone    string
+	OutpostArn          string
+	InstanceType        string
 	SecurityGroupIds    []string
 	SecurityGroupFilter SecurityGroupFilterOptions
 }
 
+// AZFallback pairs an alternative availability zone with a subnet living
+// in it. Launches failing on capacity problems are retried there.
+type AZFallback struct {
+	AvailabilityZone string
+	SubnetId         string
+}
+
 type subnetsSort []*ec2.Subnet
 
 func (a subnetsSort) Len() int      { return len(a) }
@@ -48,6 +61,10 @@ func (s *StepNetworkInfo) Run(ctx context.Context, state multistep.StateBag) mul
 	ec2conn := state.Get("ec2").(*ec2.EC2)
 	ui := state.Get("ui").(packer.Ui)
 
+	azPinned := s.AvailabilityZone != ""
+	subnetPinned := s.SubnetId != ""
+	var filterSubnets []*ec2.Subnet
+
 	// VPC
 	if s.VpcId == "" && !s.VpcFilter.Empty() {
 		params := &ec2.DescribeVpcsInput{}
@@ -86,6 +103,9 @@ func (s *StepNetworkInfo) Run(ctx context.Context, state multistep.StateBag) mul
 		if s.AvailabilityZone != "" {
 			s.SubnetFilter.Filters["availabilityZone"] = s.AvailabilityZone
 		}
+		if s.OutpostArn != "" {
+			s.SubnetFilter.Filters["outpost-arn"] = s.OutpostArn
+		}
 		params.Filters = buildEc2Filters(s.SubnetFilter.Filters)
 		log.Printf("Using Subnet Filters %v", params)
 
@@ -111,6 +131,8 @@ func (s *StepNetworkInfo) Run(ctx context.Context, state multistep.StateBag) mul
 			return multistep.ActionHalt
 		}
 
+		filterSubnets = subnetsResp.Subnets
+
 		var subnet *ec2.Subnet
 		switch {
 		case s.SubnetFilter.MostFree:
@@ -144,10 +166,105 @@ func (s *StepNetworkInfo) Run(ctx context.Context, state multistep.StateBag) mul
 		}
 	}
 
+	// Make sure the subnet actually lives on the configured Outpost before
+	// launching there. A subnet chosen via subnet_filter is already scoped
+	// with the outpost-arn filter above, but an explicit subnet_id is not.
+	if s.OutpostArn != "" && s.SubnetId != "" {
+		resp, err := ec2conn.DescribeSubnets(&ec2.DescribeSubnetsInput{
+			SubnetIds: []*string{&s.SubnetId},
+			Filters:   buildEc2Filters(map[string]string{"outpost-arn": s.OutpostArn}),
+		})
+		if err != nil {
+			err := fmt.Errorf("Error checking subnet %s against Outpost %s: %s", s.SubnetId, s.OutpostArn, err)
+			state.Put("error", err)
+			ui.Error(err.Error())
+			return multistep.ActionHalt
+		}
+		if len(resp.Subnets) == 0 {
+			err := fmt.Errorf("Subnet %s is not on Outpost %s", s.SubnetId, s.OutpostArn)
+			state.Put("error", err)
+			ui.Error(err.Error())
+			return multistep.ActionHalt
+		}
+	}
+
+	// Once the zone is known, check the instance type is actually offered
+	// there. Outposts and Local Zones only offer a subset of instance
+	// types, so this turns an opaque launch failure into a direct error.
+	// A failed DescribeInstanceTypeOfferings call only logs a warning, so
+	// builds keep working with IAM policies predating that API.
+	if s.InstanceType != "" && s.AvailabilityZone != "" {
+		resp, err := ec2conn.DescribeInstanceTypeOfferings(&ec2.DescribeInstanceTypeOfferingsInput{
+			LocationType: aws.String("availability-zone"),
+			Filters: buildEc2Filters(map[string]string{
+				"location":      s.AvailabilityZone,
+				"instance-type": s.InstanceType,
+			}),
+		})
+		if err != nil {
+			log.Printf("[WARN] Unable to check instance type offerings for %s: %s", s.AvailabilityZone, err)
+		} else if len(resp.InstanceTypeOfferings) == 0 {
+			err := fmt.Errorf("Instance type %s is not offered in %s", s.InstanceType, s.AvailabilityZone)
+			state.Put("error", err)
+			ui.Error(err.Error())
+			return multistep.ActionHalt
+		}
+	}
+
+	// With no explicit availability_zone or subnet_id to honor, gather
+	// alternative zones so the launch can fall back when the chosen zone
+	// has no capacity for the instance type.
+	if !azPinned && !subnetPinned {
+		if fallbacks := s.azFallbacks(ec2conn, filterSubnets); len(fallbacks) > 0 {
+			log.Printf("[INFO] %d alternative zone(s) available for capacity fallback", len(fallbacks))
+			state.Put("az_fallbacks", fallbacks)
+		}
+	}
+
 	state.Put("vpc_id", s.VpcId)
 	state.Put("availability_zone", s.AvailabilityZone)
 	state.Put("subnet_id", s.SubnetId)
 	return multistep.ActionContinue
 }
 
+// azFallbacks returns alternative zone/subnet pairs for launch retries.
+// When the subnet came from subnet_filter, only the other subnets matched
+// by the filter qualify; without any subnet, every other available zone
+// of the region does.
+func (s *StepNetworkInfo) azFallbacks(ec2conn *ec2.EC2, subnets []*ec2.Subnet) []AZFallback {
+	var fallbacks []AZFallback
+
+	if s.SubnetId != "" {
+		seen := map[string]bool{s.AvailabilityZone: true}
+		for _, subnet := range subnets {
+			az := aws.StringValue(subnet.AvailabilityZone)
+			if seen[az] {
+				continue
+			}
+			seen[az] = true
+			fallbacks = append(fallbacks, AZFallback{
+				AvailabilityZone: az,
+				SubnetId:         aws.StringValue(subnet.SubnetId),
+			})
+		}
+		return fallbacks
+	}
+
+	resp, err := ec2conn.DescribeAvailabilityZones(&ec2.DescribeAvailabilityZonesInput{
+		Filters: buildEc2Filters(map[string]string{"state": "available"}),
+	})
+	if err != nil {
+		log.Printf("[WARN] Unable to list availability zones for capacity fallback: %s", err)
+		return nil
+	}
+	for _, zone := range resp.AvailabilityZones {
+		az := aws.StringValue(zone.ZoneName)
+		if az == s.AvailabilityZone {
+			continue
+		}
+		fallbacks = append(fallbacks, AZFallback{AvailabilityZone: az})
+	}
+	return fallbacks
+}
+
 func (s *StepNetworkInfo) Cleanup(multistep.StateBag) {}
@@ -0,0 +1,53 @@
+package common
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/ec2"
+)
+
+func TestStepNetworkInfo_azFallbacks(t *testing.T) {
+	subnets := []*ec2.Subnet{
+		{SubnetId: aws.String("subnet-a"), AvailabilityZone: aws.String("us-east-1a")},
+		{SubnetId: aws.String("subnet-b"), AvailabilityZone: aws.String("us-east-1b")},
+		{SubnetId: aws.String("subnet-b2"), AvailabilityZone: aws.String("us-east-1b")},
+		{SubnetId: aws.String("subnet-c"), AvailabilityZone: aws.String("us-east-1c")},
+	}
+
+	// The chosen subnet's zone is excluded and zones are not repeated.
+	s := &StepNetworkInfo{SubnetId: "subnet-a", AvailabilityZone: "us-east-1a"}
+	fallbacks := s.azFallbacks(nil, subnets)
+	expected := []AZFallback{
+		{AvailabilityZone: "us-east-1b", SubnetId: "subnet-b"},
+		{AvailabilityZone: "us-east-1c", SubnetId: "subnet-c"},
+	}
+	if len(fallbacks) != len(expected) {
+		t.Fatalf("bad fallbacks: %#v", fallbacks)
+	}
+	for i, fallback := range expected {
+		if fallbacks[i] != fallback {
+			t.Fatalf("bad fallback %d: %#v", i, fallbacks[i])
+		}
+	}
+}
+
+func TestIsCapacityErr(t *testing.T) {
+	cases := []struct {
+		err      error
+		expected bool
+	}{
+		{awserr.New("InsufficientInstanceCapacity", "no capacity", nil), true},
+		{awserr.New("Unsupported", "not supported in your requested Availability Zone", nil), true},
+		{awserr.New("InvalidParameterValue", "bad value", nil), false},
+		{fmt.Errorf("plain error"), false},
+		{nil, false},
+	}
+	for _, tc := range cases {
+		if isCapacityErr(tc.err) != tc.expected {
+			t.Errorf("%v: expected %t", tc.err, tc.expected)
+		}
+	}
+}
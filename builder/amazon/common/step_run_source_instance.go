@@ -25,21 +25,40 @@ type StepRunSourceInstance struct {
 	Ctx                               interpolate.Context
 	Debug                             bool
 	EbsOptimized                      bool
+	EnableDetailedMonitoring          bool
 	EnableT2Unlimited                 bool
+	HibernationOptions                bool
 	ExpectedRootDevice                string
+	IamProfilePropagationTimeout      time.Duration
 	InstanceInitiatedShutdownBehavior string
 	InstanceType                      string
 	IsRestricted                      bool
+	KeepInstanceOnFailure             bool
+	Metadata                          MetadataOptions
 	SourceAMI                         string
 	Tags                              map[string]string
 	UserData                          string
 	UserDataFile                      string
 	VolumeTags                        map[string]string
 	NoEphemeral                       bool
+	RootVolumeSize                    int64
+	ReuseInstance                     bool
+	BuildName                         string
 
 	instanceId string
 }
 
+// reuseTagKey marks instances that reuse_instance may pick up again on a
+// later run of the same build.
+const reuseTagKey = "packer.io/reuse"
+
+func (s *StepRunSourceInstance) reuseTagValue() string {
+	if s.BuildName == "" {
+		return "packer"
+	}
+	return s.BuildName
+}
+
 func (s *StepRunSourceInstance) Run(ctx context.Context, state multistep.StateBag) multistep.StepAction {
 	ec2conn := state.Get("ec2").(*ec2.EC2)
 
@@ -65,6 +84,12 @@ func (s *StepRunSourceInstance) Run(ctx context.Context, state multistep.StateBa
 		userData = base64.StdEncoding.EncodeToString([]byte(userData))
 	}
 
+	// A previous step may have replaced the real user data with a small
+	// bootstrap stub, e.g. when it is handed off through SSM parameters.
+	if stub, ok := state.GetOk("user_data_stub"); ok {
+		userData = base64.StdEncoding.EncodeToString([]byte(stub.(string)))
+	}
+
 	ui.Say("Launching a source AWS instance...")
 	image, ok := state.Get("source_image").(*ec2.Image)
 	if !ok {
@@ -83,6 +108,40 @@ func (s *StepRunSourceInstance) Run(ctx context.Context, state multistep.StateBa
 
 	var instanceId string
 
+	if s.ReuseInstance {
+		reusedId, err := s.findReusableInstance(ec2conn)
+		if err != nil {
+			err := fmt.Errorf("Error looking for a reusable source instance: %s", err)
+			state.Put("error", err)
+			ui.Error(err.Error())
+			return multistep.ActionHalt
+		}
+
+		if reusedId != "" {
+			s.instanceId = reusedId
+			ui.Say(fmt.Sprintf("Restarting stopped source instance %s for reuse...", reusedId))
+			if _, err := ec2conn.StartInstances(&ec2.StartInstancesInput{
+				InstanceIds: []*string{&reusedId},
+			}); err != nil {
+				err := fmt.Errorf("Error restarting source instance %s: %s", reusedId, err)
+				state.Put("error", err)
+				ui.Error(err.Error())
+				return multistep.ActionHalt
+			}
+
+			ui.Say(fmt.Sprintf("Waiting for instance (%v) to become ready...", reusedId))
+			if _, err := s.waitForInstanceReady(ctx, state, reusedId); err != nil {
+				state.Put("error", err)
+				ui.Error(err.Error())
+				return multistep.ActionHalt
+			}
+			return multistep.ActionContinue
+		}
+
+		ui.Say("No stopped source instance found to reuse; launching a new one")
+		s.Tags[reuseTagKey] = s.reuseTagValue()
+	}
+
 	ui.Say("Adding tags to source instance")
 	if _, exists := s.Tags["Name"]; !exists {
 		s.Tags["Name"] = "Packer Builder"
@@ -104,6 +163,17 @@ func (s *StepRunSourceInstance) Run(ctx context.Context, state multistep.StateBa
 		return multistep.ActionHalt
 	}
 
+	blockDeviceMappings := s.LaunchMappings.BuildEC2BlockDeviceMappings()
+	if v, ok := state.GetOk("ephemeral_block_device_mappings"); ok {
+		blockDeviceMappings = append(blockDeviceMappings,
+			v.([]*ec2.BlockDeviceMapping)...)
+	}
+	if s.RootVolumeSize > 0 {
+		image := state.Get("source_image").(*ec2.Image)
+		blockDeviceMappings = OverrideRootVolumeSize(blockDeviceMappings,
+			aws.StringValue(image.RootDeviceName), s.RootVolumeSize)
+	}
+
 	az := state.Get("availability_zone").(string)
 	runOpts := &ec2.RunInstancesInput{
 		ImageId:             &s.SourceAMI,
@@ -112,7 +182,7 @@ func (s *StepRunSourceInstance) Run(ctx context.Context, state multistep.StateBa
 		MaxCount:            aws.Int64(1),
 		MinCount:            aws.Int64(1),
 		IamInstanceProfile:  &ec2.IamInstanceProfileSpecification{Name: iamInstanceProfile},
-		BlockDeviceMappings: s.LaunchMappings.BuildEC2BlockDeviceMappings(),
+		BlockDeviceMappings: blockDeviceMappings,
 		Placement:           &ec2.Placement{AvailabilityZone: &az},
 		EbsOptimized:        &s.EbsOptimized,
 	}
@@ -141,6 +211,28 @@ func (s *StepRunSourceInstance) Run(ctx context.Context, state multistep.StateBa
 		runOpts.CreditSpecification = &ec2.CreditSpecificationRequest{CpuCredits: &creditOption}
 	}
 
+	if s.EnableDetailedMonitoring {
+		runOpts.Monitoring = &ec2.RunInstancesMonitoringEnabled{Enabled: aws.Bool(true)}
+	}
+
+	if s.HibernationOptions {
+		runOpts.HibernationOptions = &ec2.HibernationOptionsRequest{Configured: aws.Bool(true)}
+	}
+
+	if !s.Metadata.Empty() {
+		metadataOptions := &ec2.InstanceMetadataOptionsRequest{}
+		if s.Metadata.HttpTokens != "" {
+			metadataOptions.HttpTokens = aws.String(s.Metadata.HttpTokens)
+		}
+		if s.Metadata.HttpPutResponseHopLimit != 0 {
+			metadataOptions.HttpPutResponseHopLimit = aws.Int64(s.Metadata.HttpPutResponseHopLimit)
+		}
+		if s.Metadata.HttpEndpoint != "" {
+			metadataOptions.HttpEndpoint = aws.String(s.Metadata.HttpEndpoint)
+		}
+		runOpts.MetadataOptions = metadataOptions
+	}
+
 	// Collect tags for tagging on resource creation
 	var tagSpecs []*ec2.TagSpecification
 
@@ -194,8 +286,20 @@ func (s *StepRunSourceInstance) Run(ctx context.Context, state multistep.StateBa
 		runOpts.InstanceInitiatedShutdownBehavior = &s.InstanceInitiatedShutdownBehavior
 	}
 
-	var runResp *ec2.Reservation
-	err = retry.Config{
+	// gp3 throughput is spliced into the serialized request because the
+	// vendored AWS SDK cannot carry it on the block device mappings.
+	gp3Throughput := s.LaunchMappings.GetGp3Throughput()
+	runInstances := func() (*ec2.Reservation, error) {
+		req, resp := ec2conn.RunInstancesRequest(runOpts)
+		SpliceEbsThroughput(req, "BlockDeviceMapping", gp3Throughput)
+		return resp, req.Send()
+	}
+
+	// A freshly created IAM instance profile may not have propagated
+	// through IAM yet, making RunInstances reject it as invalid. Retry
+	// for a fixed number of tries by default, or for as long as the user
+	// asked with iam_instance_profile_propagation_timeout.
+	launchRetry := retry.Config{
 		Tries: 11,
 		ShouldRetry: func(err error) bool {
 			if isAWSErr(err, "InvalidParameterValue", "iamInstanceProfile") {
@@ -204,11 +308,54 @@ func (s *StepRunSourceInstance) Run(ctx context.Context, state multistep.StateBa
 			return false
 		},
 		RetryDelay: (&retry.Backoff{InitialBackoff: 200 * time.Millisecond, MaxBackoff: 30 * time.Second, Multiplier: 2}).Linear,
-	}.Run(ctx, func(ctx context.Context) error {
-		runResp, err = ec2conn.RunInstances(runOpts)
+	}
+	if s.IamProfilePropagationTimeout != 0 {
+		launchRetry.Tries = 0
+		launchRetry.StartTimeout = s.IamProfilePropagationTimeout
+	}
+
+	var runResp *ec2.Reservation
+	err = launchRetry.Run(ctx, func(ctx context.Context) error {
+		runResp, err = runInstances()
 		return err
 	})
 
+	// When the zone was not pinned by the user, retry capacity problems
+	// in the alternative zones gathered by StepNetworkInfo instead of
+	// failing the build on the first zone's capacity shortage.
+	if fallbacks, ok := state.GetOk("az_fallbacks"); ok && isCapacityErr(err) {
+		for _, fallback := range fallbacks.([]AZFallback) {
+			ui.Say(fmt.Sprintf(
+				"No %s capacity in %s, retrying in %s...",
+				s.InstanceType, az, fallback.AvailabilityZone))
+			az = fallback.AvailabilityZone
+			subnetId = fallback.SubnetId
+			runOpts.Placement.AvailabilityZone = &az
+			if len(runOpts.NetworkInterfaces) > 0 {
+				runOpts.NetworkInterfaces[0].SubnetId = aws.String(subnetId)
+			} else {
+				runOpts.SubnetId = aws.String(subnetId)
+			}
+
+			err = retry.Config{
+				Tries:       2,
+				ShouldRetry: isCapacityErr,
+				RetryDelay:  (&retry.Backoff{InitialBackoff: 5 * time.Second, MaxBackoff: 30 * time.Second, Multiplier: 2}).Linear,
+			}.Run(ctx, func(ctx context.Context) error {
+				runResp, err = runInstances()
+				return err
+			})
+			if err == nil {
+				state.Put("availability_zone", az)
+				state.Put("subnet_id", subnetId)
+				break
+			}
+			if !isCapacityErr(err) {
+				break
+			}
+		}
+	}
+
 	if isAWSErr(err, "VPCIdNotSpecified", "No default VPC for this user") && subnetId == "" {
 		err := fmt.Errorf("Error launching source instance: a valid Subnet Id was not specified")
 		state.Put("error", err)
@@ -230,61 +377,13 @@ func (s *StepRunSourceInstance) Run(ctx context.Context, state multistep.StateBa
 	ui.Message(fmt.Sprintf("Instance ID: %s", instanceId))
 	ui.Say(fmt.Sprintf("Waiting for instance (%v) to become ready...", instanceId))
 
-	describeInstance := &ec2.DescribeInstancesInput{
-		InstanceIds: []*string{aws.String(instanceId)},
-	}
-
-	if err := WaitUntilInstanceRunning(ctx, ec2conn, instanceId); err != nil {
-		err := fmt.Errorf("Error waiting for instance (%s) to become ready: %s", instanceId, err)
-		state.Put("error", err)
-		ui.Error(err.Error())
-		return multistep.ActionHalt
-	}
-
-	// there's a race condition that can happen because of AWS's eventual
-	// consistency where even though the wait is complete, the describe call
-	// will fail. Retry a couple of times to try to mitigate that race.
-
-	var r *ec2.DescribeInstancesOutput
-	err = retry.Config{Tries: 11, ShouldRetry: func(err error) bool {
-		if isAWSErr(err, "InvalidInstanceID.NotFound", "") {
-			return true
-		}
-		return false
-	},
-		RetryDelay: (&retry.Backoff{InitialBackoff: 200 * time.Millisecond, MaxBackoff: 30 * time.Second, Multiplier: 2}).Linear,
-	}.Run(ctx, func(ctx context.Context) error {
-		r, err = ec2conn.DescribeInstances(describeInstance)
-		return err
-	})
-	if err != nil || len(r.Reservations) == 0 || len(r.Reservations[0].Instances) == 0 {
-		err := fmt.Errorf("Error finding source instance.")
+	instance, err := s.waitForInstanceReady(ctx, state, instanceId)
+	if err != nil {
 		state.Put("error", err)
 		ui.Error(err.Error())
 		return multistep.ActionHalt
 	}
 
-	instance := r.Reservations[0].Instances[0]
-
-	if s.Debug {
-		if instance.PublicDnsName != nil && *instance.PublicDnsName != "" {
-			ui.Message(fmt.Sprintf("Public DNS: %s", *instance.PublicDnsName))
-		}
-
-		if instance.PublicIpAddress != nil && *instance.PublicIpAddress != "" {
-			ui.Message(fmt.Sprintf("Public IP: %s", *instance.PublicIpAddress))
-		}
-
-		if instance.PrivateIpAddress != nil && *instance.PrivateIpAddress != "" {
-			ui.Message(fmt.Sprintf("Private IP: %s", *instance.PrivateIpAddress))
-		}
-	}
-
-	state.Put("instance", instance)
-	// instance_id is the generic term used so that users can have access to the
-	// instance id inside of the provisioners, used in step_provision.
-	state.Put("instance_id", instance.InstanceId)
-
 	// If we're in a region that doesn't support tagging on instance creation,
 	// do that now.
 
@@ -351,6 +450,102 @@ func (s *StepRunSourceInstance) Run(ctx context.Context, state multistep.StateBa
 	return multistep.ActionContinue
 }
 
+// waitForInstanceReady waits for the instance to reach the running state,
+// fetches its description and stores it in the state bag for the following
+// steps.
+func (s *StepRunSourceInstance) waitForInstanceReady(ctx context.Context, state multistep.StateBag, instanceId string) (*ec2.Instance, error) {
+	ec2conn := state.Get("ec2").(*ec2.EC2)
+	ui := state.Get("ui").(packer.Ui)
+
+	describeInstance := &ec2.DescribeInstancesInput{
+		InstanceIds: []*string{aws.String(instanceId)},
+	}
+
+	if err := WaitUntilInstanceRunning(ctx, ec2conn, instanceId); err != nil {
+		return nil, fmt.Errorf("Error waiting for instance (%s) to become ready: %s", instanceId, err)
+	}
+
+	// there's a race condition that can happen because of AWS's eventual
+	// consistency where even though the wait is complete, the describe call
+	// will fail. Retry a couple of times to try to mitigate that race.
+
+	var r *ec2.DescribeInstancesOutput
+	var err error
+	err = retry.Config{Tries: 11, ShouldRetry: func(err error) bool {
+		if isAWSErr(err, "InvalidInstanceID.NotFound", "") {
+			return true
+		}
+		return false
+	},
+		RetryDelay: (&retry.Backoff{InitialBackoff: 200 * time.Millisecond, MaxBackoff: 30 * time.Second, Multiplier: 2}).Linear,
+	}.Run(ctx, func(ctx context.Context) error {
+		r, err = ec2conn.DescribeInstances(describeInstance)
+		return err
+	})
+	if err != nil || len(r.Reservations) == 0 || len(r.Reservations[0].Instances) == 0 {
+		return nil, fmt.Errorf("Error finding source instance.")
+	}
+
+	instance := r.Reservations[0].Instances[0]
+
+	if s.Debug {
+		if instance.PublicDnsName != nil && *instance.PublicDnsName != "" {
+			ui.Message(fmt.Sprintf("Public DNS: %s", *instance.PublicDnsName))
+		}
+
+		if instance.PublicIpAddress != nil && *instance.PublicIpAddress != "" {
+			ui.Message(fmt.Sprintf("Public IP: %s", *instance.PublicIpAddress))
+		}
+
+		if instance.PrivateIpAddress != nil && *instance.PrivateIpAddress != "" {
+			ui.Message(fmt.Sprintf("Private IP: %s", *instance.PrivateIpAddress))
+		}
+	}
+
+	state.Put("instance", instance)
+	// instance_id is the generic term used so that users can have access to the
+	// instance id inside of the provisioners, used in step_provision.
+	state.Put("instance_id", instance.InstanceId)
+
+	return instance, nil
+}
+
+// findReusableInstance looks for a stopped instance left behind by an
+// earlier run of the same build. Only instances carrying the reuse tag and
+// matching the current source AMI and instance type are considered.
+func (s *StepRunSourceInstance) findReusableInstance(ec2conn *ec2.EC2) (string, error) {
+	resp, err := ec2conn.DescribeInstances(&ec2.DescribeInstancesInput{
+		Filters: []*ec2.Filter{
+			{
+				Name:   aws.String("instance-state-name"),
+				Values: []*string{aws.String("stopped")},
+			},
+			{
+				Name:   aws.String("image-id"),
+				Values: []*string{aws.String(s.SourceAMI)},
+			},
+			{
+				Name:   aws.String("instance-type"),
+				Values: []*string{aws.String(s.InstanceType)},
+			},
+			{
+				Name:   aws.String("tag:" + reuseTagKey),
+				Values: []*string{aws.String(s.reuseTagValue())},
+			},
+		},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	for _, reservation := range resp.Reservations {
+		for _, instance := range reservation.Instances {
+			return *instance.InstanceId, nil
+		}
+	}
+	return "", nil
+}
+
 func (s *StepRunSourceInstance) Cleanup(state multistep.StateBag) {
 
 	ec2conn := state.Get("ec2").(*ec2.EC2)
@@ -358,6 +553,22 @@ func (s *StepRunSourceInstance) Cleanup(state multistep.StateBag) {
 
 	// Terminate the source instance if it exists
 	if s.instanceId != "" {
+		if keepInstanceOnFailure(state, s.KeepInstanceOnFailure, s.instanceId, ui) {
+			return
+		}
+
+		if s.ReuseInstance {
+			ui.Say("Stopping the source AWS instance for later reuse...")
+			if _, err := ec2conn.StopInstances(&ec2.StopInstancesInput{InstanceIds: []*string{&s.instanceId}}); err != nil {
+				ui.Error(fmt.Sprintf("Error stopping instance, may still be running: %s", err))
+				return
+			}
+			if err := WaitUntilInstanceStopped(aws.BackgroundContext(), ec2conn, s.instanceId); err != nil {
+				ui.Error(err.Error())
+			}
+			return
+		}
+
 		ui.Say("Terminating the source AWS instance...")
 		if _, err := ec2conn.TerminateInstances(&ec2.TerminateInstancesInput{InstanceIds: []*string{&s.instanceId}}); err != nil {
 			ui.Error(fmt.Sprintf("Error terminating instance, may still be around: %s", err))
@@ -369,3 +580,33 @@ func (s *StepRunSourceInstance) Cleanup(state multistep.StateBag) {
 		}
 	}
 }
+
+// keepInstanceOnFailure reports whether the source instance should be left
+// running because the build failed and keep_instance_on_failure is set. When
+// it is, it tells the user how to reach the instance.
+func keepInstanceOnFailure(state multistep.StateBag, keep bool, instanceId string, ui packer.Ui) bool {
+	if !keep {
+		return false
+	}
+	_, cancelled := state.GetOk(multistep.StateCancelled)
+	_, halted := state.GetOk(multistep.StateHalted)
+	if !cancelled && !halted {
+		return false
+	}
+
+	ui.Say(fmt.Sprintf("Build failed; keeping source instance (%s) running "+
+		"for debugging because keep_instance_on_failure is set.", instanceId))
+	if instance, ok := state.Get("instance").(*ec2.Instance); ok {
+		if instance.PublicDnsName != nil && *instance.PublicDnsName != "" {
+			ui.Message(fmt.Sprintf("Public DNS: %s", *instance.PublicDnsName))
+		}
+		if instance.PublicIpAddress != nil && *instance.PublicIpAddress != "" {
+			ui.Message(fmt.Sprintf("Public IP: %s", *instance.PublicIpAddress))
+		}
+		if instance.PrivateIpAddress != nil && *instance.PrivateIpAddress != "" {
+			ui.Message(fmt.Sprintf("Private IP: %s", *instance.PrivateIpAddress))
+		}
+	}
+	ui.Message("Terminate the instance manually once you are done with it.")
+	return true
+}
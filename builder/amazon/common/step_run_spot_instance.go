@@ -6,9 +6,12 @@ import (
 	"fmt"
 	"io/ioutil"
 	"log"
+	"net/url"
+	"strconv"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/hashicorp/packer/common/random"
 	"github.com/hashicorp/packer/common/retry"
@@ -20,6 +23,7 @@ import (
 
 type EC2BlockDeviceMappingsBuilder interface {
 	BuildEC2BlockDeviceMappings() []*ec2.BlockDeviceMapping
+	GetGp3Throughput() map[string]int64
 }
 
 type StepRunSpotInstance struct {
@@ -29,26 +33,44 @@ type StepRunSpotInstance struct {
 	Debug                             bool
 	Comm                              *communicator.Config
 	EbsOptimized                      bool
+	EnableDetailedMonitoring          bool
 	ExpectedRootDevice                string
+	HibernationOptions                bool
 	InstanceInitiatedShutdownBehavior string
 	InstanceType                      string
+	KeepInstanceOnFailure             bool
+	Metadata                          MetadataOptions
 	SourceAMI                         string
 	SpotPrice                         string
 	SpotTags                          map[string]string
 	SpotInstanceTypes                 []string
+	SpotAllocationStrategy            string
+	FallbackToOnDemand                bool
 	Tags                              map[string]string
 	VolumeTags                        map[string]string
 	UserData                          string
 	UserDataFile                      string
 	Ctx                               interpolate.Context
 	NoEphemeral                       bool
+	RootVolumeSize                    int64
+	InterruptionCheck                 bool
 
-	instanceId string
+	instanceId  string
+	monitorDone chan struct{}
 }
 
 func (s *StepRunSpotInstance) CreateTemplateData(userData *string, az string,
 	state multistep.StateBag, marketOptions *ec2.LaunchTemplateInstanceMarketOptionsRequest) *ec2.RequestLaunchTemplateData {
 	blockDeviceMappings := s.LaunchMappings.BuildEC2BlockDeviceMappings()
+	if v, ok := state.GetOk("ephemeral_block_device_mappings"); ok {
+		blockDeviceMappings = append(blockDeviceMappings,
+			v.([]*ec2.BlockDeviceMapping)...)
+	}
+	if s.RootVolumeSize > 0 {
+		image := state.Get("source_image").(*ec2.Image)
+		blockDeviceMappings = OverrideRootVolumeSize(blockDeviceMappings,
+			aws.StringValue(image.RootDeviceName), s.RootVolumeSize)
+	}
 	// Convert the BlockDeviceMapping into a
 	// LaunchTemplateBlockDeviceMappingRequest. These structs are identical,
 	// except for the EBS field -- on one, that field contains a
@@ -101,6 +123,14 @@ func (s *StepRunSpotInstance) CreateTemplateData(userData *string, az string,
 		},
 		UserData: userData,
 	}
+
+	if s.EnableDetailedMonitoring {
+		templateData.Monitoring = &ec2.LaunchTemplatesMonitoringRequest{Enabled: aws.Bool(true)}
+	}
+
+	if s.HibernationOptions {
+		templateData.HibernationOptions = &ec2.LaunchTemplateHibernationOptionsRequest{Configured: aws.Bool(true)}
+	}
 	// Create a network interface
 	securityGroupIds := aws.StringSlice(state.Get("securityGroupIds").([]string))
 	subnetId := state.Get("subnet_id").(string)
@@ -233,6 +263,11 @@ func (s *StepRunSpotInstance) Run(ctx context.Context, state multistep.StateBag)
 		state.Put("error", err)
 		return multistep.ActionHalt
 	}
+	// A previous step may have replaced the real user data with a small
+	// bootstrap stub, e.g. when it is handed off through SSM parameters.
+	if stub, ok := state.GetOk("user_data_stub"); ok {
+		userData = base64.StdEncoding.EncodeToString([]byte(stub.(string)))
+	}
 	ui.Message("Creating Spot Fleet launch template...")
 	templateData := s.CreateTemplateData(&userData, az, state, marketOptions)
 	launchTemplate := &ec2.CreateLaunchTemplateInput{
@@ -242,7 +277,11 @@ func (s *StepRunSpotInstance) Run(ctx context.Context, state multistep.StateBag)
 	}
 
 	// Tell EC2 to create the template
-	_, err = ec2conn.CreateLaunchTemplate(launchTemplate)
+	createTemplateReq, _ := ec2conn.CreateLaunchTemplateRequest(launchTemplate)
+	s.addMetadataOptions(createTemplateReq)
+	SpliceEbsThroughput(createTemplateReq,
+		"LaunchTemplateData.BlockDeviceMapping", s.LaunchMappings.GetGp3Throughput())
+	err = createTemplateReq.Send()
 	if err != nil {
 		err := fmt.Errorf("Error creating launch template for spot instance: %s", err)
 		state.Put("error", err)
@@ -277,11 +316,28 @@ func (s *StepRunSpotInstance) Run(ctx context.Context, state multistep.StateBag)
 		Type: aws.String("instant"),
 	}
 
+	if s.SpotAllocationStrategy != "" {
+		createFleetInput.SpotOptions = &ec2.SpotOptionsRequest{
+			AllocationStrategy: aws.String(s.SpotAllocationStrategy),
+		}
+	}
+
 	// Create the request for the spot instance.
 	req, createOutput := ec2conn.CreateFleetRequest(createFleetInput)
 	ui.Message(fmt.Sprintf("Sending spot request (%s)...", req.RequestID))
 	// Actually send the spot connection request.
 	err = req.Send()
+
+	// An "instant" fleet request reports missing spot capacity either as
+	// a request error or as a fulfilled request without instances. With
+	// the fallback enabled, retry the launch with on-demand capacity
+	// before giving up.
+	if s.FallbackToOnDemand && fleetSpotCapacityErr(createOutput, err) {
+		ui.Say("No spot capacity available, falling back to an on-demand instance...")
+		createOutput, err = s.launchOnDemandFallback(
+			ui, ec2conn, launchTemplateName, &userData, az, state, createFleetInput)
+	}
+
 	if err != nil {
 		if createOutput.FleetId != nil {
 			err = fmt.Errorf("Error waiting for fleet request (%s): %s", *createOutput.FleetId, err)
@@ -344,7 +400,9 @@ func (s *StepRunSpotInstance) Run(ctx context.Context, state multistep.StateBag)
 		return multistep.ActionHalt
 	}
 
-	if len(spotTags) > 0 && len(s.SpotTags) > 0 {
+	// There is no spot request to tag when the launch fell back to
+	// on-demand capacity.
+	if len(spotTags) > 0 && len(s.SpotTags) > 0 && instance.SpotInstanceRequestId != nil {
 		spotTags.Report(ui)
 		// Use the instance ID to find out the SIR, so that we can tag the spot
 		// request associated with this instance.
@@ -445,16 +503,164 @@ func (s *StepRunSpotInstance) Run(ctx context.Context, state multistep.StateBag)
 	// instance id inside of the provisioners, used in step_provision.
 	state.Put("instance_id", instance.InstanceId)
 
+	if s.InterruptionCheck {
+		if sir := aws.StringValue(instance.SpotInstanceRequestId); sir != "" {
+			s.monitorDone = make(chan struct{})
+			go s.watchForInterruption(ec2conn, sir, state)
+		}
+	}
+
 	return multistep.ActionContinue
 }
 
+// spotCapacityErrCodes are the fleet-level error codes that mean no spot
+// capacity could be obtained, as opposed to the request itself being
+// invalid.
+var spotCapacityErrCodes = map[string]bool{
+	"InsufficientInstanceCapacity": true,
+	"MaxSpotInstanceCountExceeded": true,
+	"SpotMaxPriceTooLow":           true,
+	"UnfulfillableCapacity":        true,
+}
+
+// fleetSpotCapacityErr tells whether a fleet request came back without an
+// instance because of missing spot capacity.
+func fleetSpotCapacityErr(createOutput *ec2.CreateFleetOutput, err error) bool {
+	if err != nil {
+		return isCapacityErr(err)
+	}
+	if len(createOutput.Instances) > 0 {
+		return false
+	}
+	for _, outErr := range createOutput.Errors {
+		if spotCapacityErrCodes[aws.StringValue(outErr.ErrorCode)] {
+			return true
+		}
+	}
+	return false
+}
+
+// addMetadataOptions splices the configured instance metadata options into
+// the serialized query of a CreateLaunchTemplate or
+// CreateLaunchTemplateVersion request. The vendored AWS SDK predates the
+// MetadataOptions field on launch template data, so the parameters cannot
+// be set on the input struct.
+func (s *StepRunSpotInstance) addMetadataOptions(req *request.Request) {
+	if s.Metadata.Empty() {
+		return
+	}
+	req.Handlers.Build.PushBack(func(r *request.Request) {
+		body, err := ioutil.ReadAll(r.GetBody())
+		if err != nil {
+			r.Error = err
+			return
+		}
+		vals, err := url.ParseQuery(string(body))
+		if err != nil {
+			r.Error = err
+			return
+		}
+		if s.Metadata.HttpTokens != "" {
+			vals.Set("LaunchTemplateData.MetadataOptions.HttpTokens",
+				s.Metadata.HttpTokens)
+		}
+		if s.Metadata.HttpPutResponseHopLimit != 0 {
+			vals.Set("LaunchTemplateData.MetadataOptions.HttpPutResponseHopLimit",
+				strconv.FormatInt(s.Metadata.HttpPutResponseHopLimit, 10))
+		}
+		if s.Metadata.HttpEndpoint != "" {
+			vals.Set("LaunchTemplateData.MetadataOptions.HttpEndpoint",
+				s.Metadata.HttpEndpoint)
+		}
+		r.SetBufferBody([]byte(vals.Encode()))
+	})
+}
+
+// launchOnDemandFallback retries the fleet request with on-demand
+// capacity. The launch template has the spot market options baked in, so a
+// second template version without them is registered first and the fleet
+// request is pointed at it.
+func (s *StepRunSpotInstance) launchOnDemandFallback(ui packer.Ui, ec2conn *ec2.EC2,
+	launchTemplateName string, userData *string, az string, state multistep.StateBag,
+	createFleetInput *ec2.CreateFleetInput) (*ec2.CreateFleetOutput, error) {
+
+	templateData := s.CreateTemplateData(userData, az, state, nil)
+	versionReq, versionOutput := ec2conn.CreateLaunchTemplateVersionRequest(&ec2.CreateLaunchTemplateVersionInput{
+		LaunchTemplateData: templateData,
+		LaunchTemplateName: aws.String(launchTemplateName),
+		VersionDescription: aws.String("on-demand fallback without spot market options"),
+	})
+	s.addMetadataOptions(versionReq)
+	SpliceEbsThroughput(versionReq,
+		"LaunchTemplateData.BlockDeviceMapping", s.LaunchMappings.GetGp3Throughput())
+	err := versionReq.Send()
+	if err != nil {
+		return nil, fmt.Errorf("Error creating launch template version for on-demand fallback: %s", err)
+	}
+
+	version := strconv.FormatInt(
+		aws.Int64Value(versionOutput.LaunchTemplateVersion.VersionNumber), 10)
+	createFleetInput.LaunchTemplateConfigs[0].LaunchTemplateSpecification.Version = aws.String(version)
+	createFleetInput.TargetCapacitySpecification.DefaultTargetCapacityType = aws.String("on-demand")
+	createFleetInput.SpotOptions = nil
+
+	req, createOutput := ec2conn.CreateFleetRequest(createFleetInput)
+	ui.Message(fmt.Sprintf("Sending on-demand request (%s)...", req.RequestID))
+	return createOutput, req.Send()
+}
+
+// watchForInterruption polls the spot request backing the source instance
+// and flags the build in the state bag when EC2 marks the instance for
+// termination, so the builder can re-launch the attempt instead of timing
+// out against a dying instance.
+func (s *StepRunSpotInstance) watchForInterruption(ec2conn *ec2.EC2, sir string, state multistep.StateBag) {
+	ui := state.Get("ui").(packer.Ui)
+
+	for {
+		select {
+		case <-s.monitorDone:
+			return
+		case <-time.After(30 * time.Second):
+		}
+
+		resp, err := ec2conn.DescribeSpotInstanceRequests(&ec2.DescribeSpotInstanceRequestsInput{
+			SpotInstanceRequestIds: []*string{aws.String(sir)},
+		})
+		if err != nil {
+			log.Printf("[WARN] Error polling spot request %s for interruption: %s", sir, err)
+			continue
+		}
+
+		for _, req := range resp.SpotInstanceRequests {
+			if req.Status == nil || req.Status.Code == nil {
+				continue
+			}
+			switch *req.Status.Code {
+			case "marked-for-termination", "instance-terminated-by-price",
+				"instance-terminated-no-capacity",
+				"instance-terminated-capacity-oversubscribed",
+				"instance-terminated-by-service":
+				ui.Error(fmt.Sprintf(
+					"Spot instance %s is being interrupted (%s)", s.instanceId, *req.Status.Code))
+				state.Put("spot_interrupted", true)
+				return
+			}
+		}
+	}
+}
+
 func (s *StepRunSpotInstance) Cleanup(state multistep.StateBag) {
+	if s.monitorDone != nil {
+		close(s.monitorDone)
+		s.monitorDone = nil
+	}
+
 	ec2conn := state.Get("ec2").(*ec2.EC2)
 	ui := state.Get("ui").(packer.Ui)
 	launchTemplateName := state.Get("launchTemplateName").(string)
 
 	// Terminate the source instance if it exists
-	if s.instanceId != "" {
+	if s.instanceId != "" && !keepInstanceOnFailure(state, s.KeepInstanceOnFailure, s.instanceId, ui) {
 		ui.Say("Terminating the source AWS instance...")
 		if _, err := ec2conn.TerminateInstances(&ec2.TerminateInstancesInput{InstanceIds: []*string{&s.instanceId}}); err != nil {
 			ui.Error(fmt.Sprintf("Error terminating instance, may still be around: %s", err))
@@ -5,8 +5,10 @@ import (
 	"fmt"
 	"log"
 	"sort"
+	"strings"
 	"time"
 
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	confighelper "github.com/hashicorp/packer/helper/config"
 	"github.com/hashicorp/packer/helper/multistep"
@@ -17,13 +19,18 @@ import (
 // that is used throughout the AMI creation process.
 //
 // Produces:
-//   source_image *ec2.Image - the source AMI info
+//
+//	source_image *ec2.Image - the source AMI info
 type StepSourceAMIInfo struct {
 	SourceAmi                string
 	EnableAMISriovNetSupport bool
 	EnableAMIENASupport      confighelper.Trilean
 	AMIVirtType              string
 	AmiFilters               AmiFilterOptions
+	// MarketplaceCodeAction is what to do when the source AMI carries
+	// marketplace product codes: "warn" (also the default when empty),
+	// "error" or "ignore".
+	MarketplaceCodeAction string
 }
 
 type imageSort []*ec2.Image
@@ -43,6 +50,26 @@ func mostRecentAmi(images []*ec2.Image) *ec2.Image {
 	return sortedImages[len(sortedImages)-1]
 }
 
+// Returns the images created no more than maxAgeDays ago. Images whose
+// creation date cannot be parsed are dropped.
+func filterImagesByAge(images []*ec2.Image, maxAgeDays int) []*ec2.Image {
+	cutoff := time.Now().AddDate(0, 0, -maxAgeDays)
+
+	var recent []*ec2.Image
+	for _, image := range images {
+		created, err := time.Parse(time.RFC3339, *image.CreationDate)
+		if err != nil {
+			log.Printf("Ignoring image %s with unparseable creation date %q: %s",
+				*image.ImageId, *image.CreationDate, err)
+			continue
+		}
+		if created.After(cutoff) {
+			recent = append(recent, image)
+		}
+	}
+	return recent
+}
+
 func (s *StepSourceAMIInfo) Run(ctx context.Context, state multistep.StateBag) multistep.StepAction {
 	ec2conn := state.Get("ec2").(*ec2.EC2)
 	ui := state.Get("ui").(packer.Ui)
@@ -57,6 +84,22 @@ func (s *StepSourceAMIInfo) Run(ctx context.Context, state multistep.StateBag) m
 	if len(s.AmiFilters.Filters) > 0 {
 		params.Filters = buildEc2Filters(s.AmiFilters.Filters)
 	}
+	for name, value := range s.AmiFilters.shortcutFilters() {
+		name := name
+		value := value
+		params.Filters = append(params.Filters, &ec2.Filter{
+			Name:   &name,
+			Values: []*string{&value},
+		})
+	}
+	for key, value := range s.AmiFilters.Tags {
+		name := fmt.Sprintf("tag:%s", key)
+		value := value
+		params.Filters = append(params.Filters, &ec2.Filter{
+			Name:   &name,
+			Values: []*string{&value},
+		})
+	}
 	if len(s.AmiFilters.Owners) > 0 {
 		params.Owners = s.AmiFilters.GetOwners()
 	}
@@ -77,6 +120,16 @@ func (s *StepSourceAMIInfo) Run(ctx context.Context, state multistep.StateBag) m
 		return multistep.ActionHalt
 	}
 
+	if s.AmiFilters.MaxAgeDays > 0 {
+		imageResp.Images = filterImagesByAge(imageResp.Images, s.AmiFilters.MaxAgeDays)
+		if len(imageResp.Images) == 0 {
+			err := fmt.Errorf("No matching AMI is newer than %d days", s.AmiFilters.MaxAgeDays)
+			state.Put("error", err)
+			ui.Error(err.Error())
+			return multistep.ActionHalt
+		}
+	}
+
 	if len(imageResp.Images) > 1 && !s.AmiFilters.MostRecent {
 		err := fmt.Errorf("Your query returned more than one result. Please try a more specific search, or set most_recent to true.")
 		state.Put("error", err)
@@ -93,6 +146,24 @@ func (s *StepSourceAMIInfo) Run(ctx context.Context, state multistep.StateBag) m
 
 	ui.Message(fmt.Sprintf("Found Image ID: %s", *image.ImageId))
 
+	// Marketplace product codes are inherited by every AMI built from this
+	// source, making the result impossible to copy, share or encrypt.
+	// Surface that before spending time on the build.
+	if codes := marketplaceProductCodes(image); len(codes) > 0 && s.MarketplaceCodeAction != "ignore" {
+		msg := fmt.Sprintf(
+			"The source AMI %s carries marketplace product code(s) %s. The "+
+				"resulting AMI will inherit them and cannot be copied, shared "+
+				"or encrypted.", *image.ImageId, strings.Join(codes, ", "))
+		if s.MarketplaceCodeAction == "error" {
+			err := fmt.Errorf("%s Set source_ami_marketplace_code_action to "+
+				"\"warn\" or \"ignore\" to build anyway.", msg)
+			state.Put("error", err)
+			ui.Error(err.Error())
+			return multistep.ActionHalt
+		}
+		ui.Say(fmt.Sprintf("Warning: %s", msg))
+	}
+
 	// Enhanced Networking can only be enabled on HVM AMIs.
 	// See http://goo.gl/icuXh5
 	if s.EnableAMIENASupport.True() || s.EnableAMISriovNetSupport {
@@ -110,6 +181,18 @@ func (s *StepSourceAMIInfo) Run(ctx context.Context, state multistep.StateBag) m
 
 func (s *StepSourceAMIInfo) Cleanup(multistep.StateBag) {}
 
+// marketplaceProductCodes returns the AWS Marketplace product codes the
+// image carries, if any.
+func marketplaceProductCodes(image *ec2.Image) []string {
+	var codes []string
+	for _, pc := range image.ProductCodes {
+		if aws.StringValue(pc.ProductCodeType) == "marketplace" {
+			codes = append(codes, aws.StringValue(pc.ProductCodeId))
+		}
+	}
+	return codes
+}
+
 func (s *StepSourceAMIInfo) canEnableEnhancedNetworking(image *ec2.Image) error {
 	if s.AMIVirtType == "hvm" {
 		return nil
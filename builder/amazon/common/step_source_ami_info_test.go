@@ -2,6 +2,7 @@ package common
 
 import (
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/ec2"
@@ -41,3 +42,33 @@ func TestStepSourceAmiInfo_PVImageWithAMIVirtHVM(t *testing.T) {
 	})
 	assert.NoError(t, err)
 }
+
+func TestStepSourceAmiInfo_filterImagesByAge(t *testing.T) {
+	recent := &ec2.Image{
+		ImageId:      aws.String("ami-recent"),
+		CreationDate: aws.String(time.Now().AddDate(0, 0, -5).Format(time.RFC3339)),
+	}
+	old := &ec2.Image{
+		ImageId:      aws.String("ami-old"),
+		CreationDate: aws.String(time.Now().AddDate(0, 0, -60).Format(time.RFC3339)),
+	}
+	unparseable := &ec2.Image{
+		ImageId:      aws.String("ami-bad-date"),
+		CreationDate: aws.String("not-a-date"),
+	}
+
+	images := filterImagesByAge([]*ec2.Image{recent, old, unparseable}, 30)
+	assert.Len(t, images, 1)
+	assert.Equal(t, "ami-recent", *images[0].ImageId)
+}
+
+func TestStepSourceAmiInfo_marketplaceProductCodes(t *testing.T) {
+	image := &ec2.Image{
+		ProductCodes: []*ec2.ProductCode{
+			{ProductCodeId: aws.String("abcd1234"), ProductCodeType: aws.String("marketplace")},
+			{ProductCodeId: aws.String("devpay123"), ProductCodeType: aws.String("devpay")},
+		},
+	}
+	assert.Equal(t, []string{"abcd1234"}, marketplaceProductCodes(image))
+	assert.Empty(t, marketplaceProductCodes(&ec2.Image{}))
+}
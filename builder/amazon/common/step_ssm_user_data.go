@@ -0,0 +1,160 @@
+package common
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/ssm"
+	"github.com/hashicorp/packer/common/random"
+	"github.com/hashicorp/packer/helper/multistep"
+	"github.com/hashicorp/packer/packer"
+)
+
+// ssmUserDataChunkSize is the maximum length of a single SSM parameter
+// value in the standard tier.
+const ssmUserDataChunkSize = 4096
+
+// StepSSMUserData delivers the configured user_data to the instance through
+// temporary, KMS encrypted SSM parameters instead of passing it directly,
+// working around the 16KB user_data size limit. The real user_data is
+// replaced with a small bootstrap stub that fetches the parameters,
+// reassembles the original script and runs it. The parameters are deleted
+// again when the build finishes.
+type StepSSMUserData struct {
+	AWSSession   *session.Session
+	Region       string
+	Enabled      bool
+	UserData     string
+	UserDataFile string
+	KMSKeyId     string
+	IsWindows    bool
+
+	paramNames []*string
+}
+
+func (s *StepSSMUserData) Run(ctx context.Context, state multistep.StateBag) multistep.StepAction {
+	if !s.Enabled {
+		return multistep.ActionContinue
+	}
+
+	ui := state.Get("ui").(packer.Ui)
+
+	userData := s.UserData
+	if s.UserDataFile != "" {
+		contents, err := ioutil.ReadFile(s.UserDataFile)
+		if err != nil {
+			err := fmt.Errorf("Problem reading user data file: %s", err)
+			state.Put("error", err)
+			ui.Error(err.Error())
+			return multistep.ActionHalt
+		}
+		userData = string(contents)
+	}
+
+	// If the user data was given base64 encoded, decode it; the bootstrap
+	// stub reassembles and decodes the raw script itself.
+	if decoded, err := base64.StdEncoding.DecodeString(userData); err == nil {
+		userData = string(decoded)
+	}
+
+	chunks := chunkSSMUserData(
+		base64.StdEncoding.EncodeToString([]byte(userData)), ssmUserDataChunkSize)
+	prefix := fmt.Sprintf("/packer/user-data-%s", random.AlphaNum(7))
+
+	ui.Say(fmt.Sprintf("Uploading user data as %d SSM parameter(s) under %s...",
+		len(chunks), prefix))
+
+	ssmconn := ssm.New(s.AWSSession)
+	for i, chunk := range chunks {
+		name := fmt.Sprintf("%s/%d", prefix, i)
+		input := &ssm.PutParameterInput{
+			Name:        aws.String(name),
+			Type:        aws.String(ssm.ParameterTypeSecureString),
+			Value:       aws.String(chunk),
+			Description: aws.String("Temporary user data parameter created by Packer"),
+		}
+		if s.KMSKeyId != "" {
+			input.KeyId = aws.String(s.KMSKeyId)
+		}
+		if _, err := ssmconn.PutParameterWithContext(ctx, input); err != nil {
+			err := fmt.Errorf("Error creating SSM parameter %s: %s", name, err)
+			state.Put("error", err)
+			ui.Error(err.Error())
+			s.deleteParameters(ui)
+			return multistep.ActionHalt
+		}
+		s.paramNames = append(s.paramNames, aws.String(name))
+	}
+
+	state.Put("user_data_stub", s.bootstrapStub(prefix, len(chunks)))
+	return multistep.ActionContinue
+}
+
+// bootstrapStub returns the small user_data script that replaces the real
+// one on the instance. It fetches the parameter chunks, reassembles the
+// original script and runs it. The instance needs an IAM instance profile
+// allowing ssm:GetParameter (and kms:Decrypt for a custom key).
+func (s *StepSSMUserData) bootstrapStub(prefix string, count int) string {
+	if s.IsWindows {
+		return fmt.Sprintf(`<powershell>
+$b64 = ""
+for ($i = 0; $i -lt %d; $i++) {
+  $b64 += (Get-SSMParameterValue -Name "%s/$i" -WithDecryption $true).Parameters[0].Value
+}
+$path = Join-Path $env:TEMP "packer-user-data.ps1"
+[System.IO.File]::WriteAllBytes($path, [System.Convert]::FromBase64String($b64))
+powershell.exe -ExecutionPolicy Bypass -File $path
+</powershell>
+`, count, prefix)
+	}
+
+	return fmt.Sprintf(`#!/bin/sh
+set -e
+b64=""
+i=0
+while [ $i -lt %d ]; do
+  b64="$b64$(aws ssm get-parameter --region %s --with-decryption --name '%s/'$i --query Parameter.Value --output text)"
+  i=$((i+1))
+done
+printf '%%s' "$b64" | base64 -d > /var/tmp/packer-user-data
+chmod +x /var/tmp/packer-user-data
+/var/tmp/packer-user-data
+`, count, s.Region, prefix)
+}
+
+func (s *StepSSMUserData) deleteParameters(ui packer.Ui) {
+	if len(s.paramNames) == 0 {
+		return
+	}
+
+	ui.Say("Deleting temporary SSM user data parameters...")
+	ssmconn := ssm.New(s.AWSSession)
+	_, err := ssmconn.DeleteParameters(&ssm.DeleteParametersInput{
+		Names: s.paramNames,
+	})
+	if err != nil {
+		ui.Error(fmt.Sprintf("Error deleting SSM user data parameters: %s\n"+
+			"Please delete them manually", err))
+	}
+	s.paramNames = nil
+}
+
+func (s *StepSSMUserData) Cleanup(state multistep.StateBag) {
+	ui := state.Get("ui").(packer.Ui)
+	s.deleteParameters(ui)
+}
+
+// chunkSSMUserData splits the encoded user data into pieces that fit into
+// a single SSM parameter value.
+func chunkSSMUserData(data string, size int) []string {
+	var chunks []string
+	for len(data) > size {
+		chunks = append(chunks, data[:size])
+		data = data[size:]
+	}
+	return append(chunks, data)
+}
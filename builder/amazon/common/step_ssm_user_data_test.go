@@ -0,0 +1,51 @@
+package common
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestChunkSSMUserData(t *testing.T) {
+	chunks := chunkSSMUserData("", 4)
+	if len(chunks) != 1 || chunks[0] != "" {
+		t.Fatalf("bad: %#v", chunks)
+	}
+
+	chunks = chunkSSMUserData("aaaabbbbcc", 4)
+	if len(chunks) != 3 {
+		t.Fatalf("bad: %#v", chunks)
+	}
+	if chunks[0] != "aaaa" || chunks[1] != "bbbb" || chunks[2] != "cc" {
+		t.Fatalf("bad: %#v", chunks)
+	}
+
+	// Exact multiples should not produce an empty trailing chunk
+	chunks = chunkSSMUserData("aaaabbbb", 4)
+	if len(chunks) != 2 {
+		t.Fatalf("bad: %#v", chunks)
+	}
+}
+
+func TestStepSSMUserData_BootstrapStub(t *testing.T) {
+	step := &StepSSMUserData{Region: "us-east-1"}
+
+	stub := step.bootstrapStub("/packer/user-data-test", 3)
+	if !strings.Contains(stub, "#!/bin/sh") {
+		t.Fatalf("bad stub: %s", stub)
+	}
+	if !strings.Contains(stub, "/packer/user-data-test") {
+		t.Fatalf("stub should reference the parameter prefix: %s", stub)
+	}
+	if !strings.Contains(stub, "-lt 3") {
+		t.Fatalf("stub should loop over all chunks: %s", stub)
+	}
+
+	step.IsWindows = true
+	stub = step.bootstrapStub("/packer/user-data-test", 1)
+	if !strings.Contains(stub, "<powershell>") {
+		t.Fatalf("bad windows stub: %s", stub)
+	}
+	if !strings.Contains(stub, "/packer/user-data-test") {
+		t.Fatalf("stub should reference the parameter prefix: %s", stub)
+	}
+}
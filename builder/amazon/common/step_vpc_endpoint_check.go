@@ -0,0 +1,200 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+	"github.com/hashicorp/packer/helper/multistep"
+	"github.com/hashicorp/packer/packer"
+)
+
+// StepVpcEndpointCheck is a pre-flight check for builds in private
+// subnets. Before the source instance is launched it verifies that the
+// subnet can reach the AWS service endpoints the build needs: subnets
+// with a route to an internet gateway or NAT gateway pass immediately,
+// otherwise the VPC must have a VPC endpoint for every required service.
+// Without this check a missing endpoint only surfaces as a connection
+// timeout many minutes into the build.
+type StepVpcEndpointCheck struct {
+	Enabled      bool
+	SSHInterface string
+	// Additional service names to check beyond the defaults, without the
+	// com.amazonaws.<region> prefix, e.g. "s3". Gateway services (s3) are
+	// checked against the subnet's route table, interface services
+	// against the VPC.
+	RequiredServices []string
+}
+
+// requiredServices returns every service the build needs to reach: the
+// EC2 API itself, the Session Manager services when that is how we
+// connect, and whatever the builder added on top.
+func (s *StepVpcEndpointCheck) requiredServices() []string {
+	services := []string{"ec2"}
+	if s.SSHInterface == "session_manager" {
+		services = append(services, "ssm", "ec2messages", "ssmmessages")
+	}
+	return append(services, s.RequiredServices...)
+}
+
+func (s *StepVpcEndpointCheck) Run(ctx context.Context, state multistep.StateBag) multistep.StepAction {
+	if !s.Enabled {
+		return multistep.ActionContinue
+	}
+
+	ec2conn := state.Get("ec2").(*ec2.EC2)
+	ui := state.Get("ui").(packer.Ui)
+
+	vpcId, _ := state.Get("vpc_id").(string)
+	subnetId, _ := state.Get("subnet_id").(string)
+	if subnetId == "" || vpcId == "" {
+		ui.Message("No subnet configured; skipping VPC endpoint check")
+		return multistep.ActionContinue
+	}
+
+	ui.Say(fmt.Sprintf("Checking service connectivity for subnet %s...", subnetId))
+
+	routeTable, err := subnetRouteTable(ec2conn, vpcId, subnetId)
+	if err != nil {
+		err := fmt.Errorf("Error describing route tables for subnet %s: %s", subnetId, err)
+		state.Put("error", err)
+		ui.Error(err.Error())
+		return multistep.ActionHalt
+	}
+
+	if routeTableHasInternetRoute(routeTable) {
+		ui.Message("Subnet has a route to the internet; skipping VPC endpoint check")
+		return multistep.ActionContinue
+	}
+
+	region := aws.StringValue(ec2conn.Config.Region)
+	endpoints, err := vpcEndpointsByService(ec2conn, vpcId)
+	if err != nil {
+		err := fmt.Errorf("Error describing VPC endpoints for VPC %s: %s", vpcId, err)
+		state.Put("error", err)
+		ui.Error(err.Error())
+		return multistep.ActionHalt
+	}
+
+	var missing []string
+	for _, service := range s.requiredServices() {
+		serviceName := fmt.Sprintf("com.amazonaws.%s.%s", region, service)
+		endpoint, ok := endpoints[serviceName]
+		if !ok {
+			missing = append(missing, serviceName)
+			continue
+		}
+		// Gateway endpoints only work for subnets whose route table they
+		// are associated with.
+		if aws.StringValue(endpoint.VpcEndpointType) == ec2.VpcEndpointTypeGateway &&
+			!endpointRoutesTable(endpoint, routeTable) {
+			missing = append(missing, fmt.Sprintf(
+				"%s (gateway endpoint %s is not associated with the subnet's route table %s)",
+				serviceName, aws.StringValue(endpoint.VpcEndpointId), aws.StringValue(routeTable.RouteTableId)))
+			continue
+		}
+		ui.Message(fmt.Sprintf("Found VPC endpoint %s for %s",
+			aws.StringValue(endpoint.VpcEndpointId), serviceName))
+	}
+
+	if len(missing) > 0 {
+		err := fmt.Errorf("Subnet %s has no route to the internet and is missing VPC endpoints "+
+			"for the following services: %s. Create the missing endpoints in VPC %s, or launch "+
+			"in a subnet with internet access.",
+			subnetId, strings.Join(missing, ", "), vpcId)
+		state.Put("error", err)
+		ui.Error(err.Error())
+		return multistep.ActionHalt
+	}
+
+	return multistep.ActionContinue
+}
+
+func (s *StepVpcEndpointCheck) Cleanup(multistep.StateBag) {}
+
+// subnetRouteTable returns the route table associated with the subnet,
+// falling back to the VPC's main route table when the subnet has no
+// explicit association.
+func subnetRouteTable(ec2conn ec2iface.EC2API, vpcId, subnetId string) (*ec2.RouteTable, error) {
+	resp, err := ec2conn.DescribeRouteTables(&ec2.DescribeRouteTablesInput{
+		Filters: []*ec2.Filter{
+			{
+				Name:   aws.String("association.subnet-id"),
+				Values: []*string{aws.String(subnetId)},
+			},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.RouteTables) > 0 {
+		return resp.RouteTables[0], nil
+	}
+
+	resp, err = ec2conn.DescribeRouteTables(&ec2.DescribeRouteTablesInput{
+		Filters: []*ec2.Filter{
+			{
+				Name:   aws.String("vpc-id"),
+				Values: []*string{aws.String(vpcId)},
+			},
+			{
+				Name:   aws.String("association.main"),
+				Values: []*string{aws.String("true")},
+			},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.RouteTables) == 0 {
+		return nil, fmt.Errorf("no route table found for subnet %s or VPC %s", subnetId, vpcId)
+	}
+	return resp.RouteTables[0], nil
+}
+
+func routeTableHasInternetRoute(table *ec2.RouteTable) bool {
+	for _, route := range table.Routes {
+		if strings.HasPrefix(aws.StringValue(route.GatewayId), "igw-") {
+			return true
+		}
+		if aws.StringValue(route.NatGatewayId) != "" {
+			return true
+		}
+	}
+	return false
+}
+
+func vpcEndpointsByService(ec2conn ec2iface.EC2API, vpcId string) (map[string]*ec2.VpcEndpoint, error) {
+	resp, err := ec2conn.DescribeVpcEndpoints(&ec2.DescribeVpcEndpointsInput{
+		Filters: []*ec2.Filter{
+			{
+				Name:   aws.String("vpc-id"),
+				Values: []*string{aws.String(vpcId)},
+			},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	endpoints := map[string]*ec2.VpcEndpoint{}
+	for _, endpoint := range resp.VpcEndpoints {
+		if aws.StringValue(endpoint.State) != "available" {
+			continue
+		}
+		endpoints[aws.StringValue(endpoint.ServiceName)] = endpoint
+	}
+	return endpoints, nil
+}
+
+func endpointRoutesTable(endpoint *ec2.VpcEndpoint, table *ec2.RouteTable) bool {
+	for _, id := range endpoint.RouteTableIds {
+		if aws.StringValue(id) == aws.StringValue(table.RouteTableId) {
+			return true
+		}
+	}
+	return false
+}
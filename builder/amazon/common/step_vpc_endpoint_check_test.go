@@ -0,0 +1,121 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+)
+
+type mockVpcEndpointConn struct {
+	ec2iface.EC2API
+
+	routeTables []*ec2.RouteTable
+	endpoints   []*ec2.VpcEndpoint
+}
+
+func (m *mockVpcEndpointConn) DescribeRouteTables(input *ec2.DescribeRouteTablesInput) (*ec2.DescribeRouteTablesOutput, error) {
+	return &ec2.DescribeRouteTablesOutput{RouteTables: m.routeTables}, nil
+}
+
+func (m *mockVpcEndpointConn) DescribeVpcEndpoints(input *ec2.DescribeVpcEndpointsInput) (*ec2.DescribeVpcEndpointsOutput, error) {
+	return &ec2.DescribeVpcEndpointsOutput{VpcEndpoints: m.endpoints}, nil
+}
+
+func TestStepVpcEndpointCheck_requiredServices(t *testing.T) {
+	step := StepVpcEndpointCheck{}
+	if got := step.requiredServices(); len(got) != 1 || got[0] != "ec2" {
+		t.Fatalf("expected [ec2], got %v", got)
+	}
+
+	step = StepVpcEndpointCheck{
+		SSHInterface:     "session_manager",
+		RequiredServices: []string{"s3"},
+	}
+	expected := []string{"ec2", "ssm", "ec2messages", "ssmmessages", "s3"}
+	got := step.requiredServices()
+	if len(got) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, got)
+	}
+	for i := range expected {
+		if got[i] != expected[i] {
+			t.Fatalf("expected %v, got %v", expected, got)
+		}
+	}
+}
+
+func TestRouteTableHasInternetRoute(t *testing.T) {
+	private := &ec2.RouteTable{
+		Routes: []*ec2.Route{
+			{GatewayId: aws.String("local")},
+			{GatewayId: aws.String("vpce-0123456789abcdef0")},
+		},
+	}
+	if routeTableHasInternetRoute(private) {
+		t.Fatal("route table without igw/nat routes should not count as internet-connected")
+	}
+
+	igw := &ec2.RouteTable{
+		Routes: []*ec2.Route{
+			{GatewayId: aws.String("local")},
+			{GatewayId: aws.String("igw-0123456789abcdef0")},
+		},
+	}
+	if !routeTableHasInternetRoute(igw) {
+		t.Fatal("route table with an igw route should count as internet-connected")
+	}
+
+	nat := &ec2.RouteTable{
+		Routes: []*ec2.Route{
+			{NatGatewayId: aws.String("nat-0123456789abcdef0")},
+		},
+	}
+	if !routeTableHasInternetRoute(nat) {
+		t.Fatal("route table with a nat gateway route should count as internet-connected")
+	}
+}
+
+func TestSubnetRouteTable(t *testing.T) {
+	conn := &mockVpcEndpointConn{
+		routeTables: []*ec2.RouteTable{
+			{RouteTableId: aws.String("rtb-subnet")},
+		},
+	}
+
+	table, err := subnetRouteTable(conn, "vpc-test", "subnet-test")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if aws.StringValue(table.RouteTableId) != "rtb-subnet" {
+		t.Fatalf("expected rtb-subnet, got %s", aws.StringValue(table.RouteTableId))
+	}
+}
+
+func TestVpcEndpointsByService(t *testing.T) {
+	conn := &mockVpcEndpointConn{
+		endpoints: []*ec2.VpcEndpoint{
+			{
+				VpcEndpointId: aws.String("vpce-available"),
+				ServiceName:   aws.String("com.amazonaws.us-east-1.ec2"),
+				State:         aws.String("available"),
+			},
+			{
+				VpcEndpointId: aws.String("vpce-pending"),
+				ServiceName:   aws.String("com.amazonaws.us-east-1.ssm"),
+				State:         aws.String("pending"),
+			},
+		},
+	}
+
+	endpoints, err := vpcEndpointsByService(conn, "vpc-test")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if len(endpoints) != 1 {
+		t.Fatalf("expected 1 available endpoint, got %d", len(endpoints))
+	}
+	if _, ok := endpoints["com.amazonaws.us-east-1.ec2"]; !ok {
+		t.Fatal("expected the available ec2 endpoint to be returned")
+	}
+}
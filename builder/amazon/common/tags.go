@@ -21,6 +21,28 @@ func (t EC2Tags) Report(ui packer.Ui) {
 	}
 }
 
+// Validate checks every tag key and value at prepare time: the
+// interpolations must parse and any `.Field` placeholder must exist in
+// the build context that EC2Tags later renders with. Typos are then
+// reported during validation instead of after the instance has already
+// launched. Template functions are not executed, so functions reaching
+// out to external services, like vault, stay untouched.
+func (t TagMap) Validate(ictx interpolate.Context) []error {
+	var errs []error
+	ictx.Data = &BuildInfoTemplate{}
+
+	for key, value := range t {
+		if err := interpolate.ValidateTemplateFields(key, &ictx); err != nil {
+			errs = append(errs, fmt.Errorf("Error processing tag: %s:%s - %s", key, value, err))
+			continue
+		}
+		if err := interpolate.ValidateTemplateFields(value, &ictx); err != nil {
+			errs = append(errs, fmt.Errorf("Error processing tag: %s:%s - %s", key, value, err))
+		}
+	}
+	return errs
+}
+
 func (t TagMap) EC2Tags(ictx interpolate.Context, region string, state multistep.StateBag) (EC2Tags, error) {
 	var ec2Tags []*ec2.Tag
 	generatedData := builder.GeneratedData{State: state}
@@ -12,6 +12,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/aws/aws-sdk-go/service/iam"
 	"github.com/hashicorp/hcl/v2/hcldec"
@@ -49,6 +50,13 @@ type Config struct {
 	// from the source instance. See the
 	// [BlockDevices](#block-devices-configuration) documentation for fields.
 	LaunchMappings awscommon.BlockDevices `mapstructure:"launch_block_device_mappings" required:"false"`
+	// The size of the root volume of the launched instance, in GiB. When
+	// set, only the source AMI's root mapping is rewritten; every other
+	// block device mapping is carried over unchanged. Use this instead of
+	// re-specifying launch_block_device_mappings when all you want to do is
+	// grow the root volume. If the root device is also listed in
+	// launch_block_device_mappings, root_volume_size wins.
+	RootVolumeSize int64 `mapstructure:"root_volume_size" required:"false"`
 	// Tags to apply to the volumes that are *launched* to create the AMI.
 	// These tags are *not* applied to the resulting AMI unless they're
 	// duplicated in `tags`. This is a [template
@@ -143,6 +151,71 @@ func (b *Builder) Run(ctx context.Context, ui packer.Ui, hook packer.Hook) (pack
 		return nil, err
 	}
 
+	if b.config.PackerDryRun {
+		b.describeDryRun(ui)
+		return nil, nil
+	}
+
+	// A spot interruption late in a long build is expensive: without a
+	// retry the whole provisioning run is lost. When
+	// spot_interruption_retries is set, an interrupted attempt is re-run
+	// from scratch on a fresh instance - replaying the provisioners -
+	// optionally on-demand for the last attempt.
+	spot := b.config.IsSpotInstance()
+	attempts := 1
+	if spot {
+		attempts += b.config.SpotInterruptionRetries
+	}
+
+	for attempt := 1; ; attempt++ {
+		artifact, interrupted, err := b.runAttempt(ctx, ui, hook, session, spot)
+		if err == nil || !interrupted || attempt >= attempts || ctx.Err() != nil {
+			return artifact, err
+		}
+
+		if b.config.SpotFallbackToOnDemand && attempt == attempts-1 {
+			ui.Say("Spot instance was interrupted; retrying on an on-demand instance...")
+			spot = false
+		} else {
+			ui.Say(fmt.Sprintf(
+				"Spot instance was interrupted; retrying on a new spot instance (attempt %d of %d)...",
+				attempt+1, attempts))
+		}
+	}
+}
+
+// describeDryRun prints the principal EC2 API mutations the build would
+// perform, with the parameters as resolved from the template. No API calls
+// are made, so values that are only known at run time (such as the AMI
+// selected by source_ami_filter) are shown as configured.
+func (b *Builder) describeDryRun(ui packer.Ui) {
+	sourceAmi := b.config.SourceAmi
+	if sourceAmi == "" {
+		sourceAmi = fmt.Sprintf("resolved from source_ami_filter %v", b.config.SourceAmiFilter.Filters)
+	}
+
+	launchCall := "RunInstances"
+	if b.config.IsSpotInstance() {
+		launchCall = "RequestSpotInstances"
+	}
+
+	ui.Say(fmt.Sprintf("Dry run: the %s build would perform the following EC2 API mutations in region %s:", b.config.PackerBuildName, b.config.RawRegion))
+	ui.Message(fmt.Sprintf("%s: instance type %s, source AMI %s", launchCall, b.config.InstanceType, sourceAmi))
+	if len(b.config.RunTags) > 0 {
+		ui.Message(fmt.Sprintf("CreateTags: tag the instance and its volumes with %v", b.config.RunTags))
+	}
+	ui.Message("StopInstances: stop the instance once provisioning finishes")
+	ui.Message(fmt.Sprintf("CreateImage: create an AMI named %q", b.config.AMIName))
+	for _, region := range b.config.AMIRegions {
+		ui.Message(fmt.Sprintf("CopyImage: copy the AMI to region %s", region))
+	}
+	if len(b.config.AMITags) > 0 {
+		ui.Message(fmt.Sprintf("CreateTags: tag the AMI and its snapshots with %v", b.config.AMITags))
+	}
+	ui.Message("TerminateInstances: terminate the instance")
+}
+
+func (b *Builder) runAttempt(ctx context.Context, ui packer.Ui, hook packer.Hook, session *session.Session, spot bool) (packer.Artifact, bool, error) {
 	ec2conn := ec2.New(session)
 	iam := iam.New(session)
 	// Setup the state bag and initial state for the steps
@@ -159,39 +232,53 @@ func (b *Builder) Run(ctx context.Context, ui packer.Ui, hook packer.Hook) (pack
 
 	var instanceStep multistep.Step
 
-	if b.config.IsSpotInstance() {
+	if spot {
 		instanceStep = &awscommon.StepRunSpotInstance{
 			AssociatePublicIpAddress:          b.config.AssociatePublicIpAddress,
 			LaunchMappings:                    b.config.LaunchMappings,
+			RootVolumeSize:                    b.config.RootVolumeSize,
 			BlockDurationMinutes:              b.config.BlockDurationMinutes,
 			Ctx:                               b.config.ctx,
 			Comm:                              &b.config.RunConfig.Comm,
 			Debug:                             b.config.PackerDebug,
 			EbsOptimized:                      b.config.EbsOptimized,
+			EnableDetailedMonitoring:          b.config.EnableDetailedMonitoring,
 			ExpectedRootDevice:                "ebs",
+			HibernationOptions:                b.config.HibernationOptions,
 			InstanceInitiatedShutdownBehavior: b.config.InstanceInitiatedShutdownBehavior,
+			KeepInstanceOnFailure:             b.config.KeepInstanceOnFailure,
+			Metadata:                          b.config.Metadata,
 			InstanceType:                      b.config.InstanceType,
 			SourceAMI:                         b.config.SourceAmi,
 			SpotPrice:                         b.config.SpotPrice,
 			SpotTags:                          b.config.SpotTags,
 			Tags:                              b.config.RunTags,
 			SpotInstanceTypes:                 b.config.SpotInstanceTypes,
+			SpotAllocationStrategy:            b.config.SpotAllocationStrategy,
+			FallbackToOnDemand:                b.config.SpotFallbackToOnDemand,
 			UserData:                          b.config.UserData,
 			UserDataFile:                      b.config.UserDataFile,
 			VolumeTags:                        b.config.VolumeRunTags,
 			NoEphemeral:                       b.config.NoEphemeral,
+			InterruptionCheck:                 b.config.SpotInterruptionRetries > 0,
 		}
 	} else {
 		instanceStep = &awscommon.StepRunSourceInstance{
 			AssociatePublicIpAddress:          b.config.AssociatePublicIpAddress,
 			LaunchMappings:                    b.config.LaunchMappings,
+			RootVolumeSize:                    b.config.RootVolumeSize,
 			Comm:                              &b.config.RunConfig.Comm,
 			Ctx:                               b.config.ctx,
 			Debug:                             b.config.PackerDebug,
 			EbsOptimized:                      b.config.EbsOptimized,
+			EnableDetailedMonitoring:          b.config.EnableDetailedMonitoring,
 			EnableT2Unlimited:                 b.config.EnableT2Unlimited,
 			ExpectedRootDevice:                "ebs",
+			HibernationOptions:                b.config.HibernationOptions,
+			IamProfilePropagationTimeout:      b.config.IamInstanceProfilePropagationTimeout,
 			InstanceInitiatedShutdownBehavior: b.config.InstanceInitiatedShutdownBehavior,
+			KeepInstanceOnFailure:             b.config.KeepInstanceOnFailure,
+			Metadata:                          b.config.Metadata,
 			InstanceType:                      b.config.InstanceType,
 			IsRestricted:                      b.config.IsChinaCloud() || b.config.IsGovCloud(),
 			SourceAMI:                         b.config.SourceAmi,
@@ -200,6 +287,8 @@ func (b *Builder) Run(ctx context.Context, ui packer.Ui, hook packer.Hook) (pack
 			UserDataFile:                      b.config.UserDataFile,
 			VolumeTags:                        b.config.VolumeRunTags,
 			NoEphemeral:                       b.config.NoEphemeral,
+			ReuseInstance:                     b.config.ReuseInstance,
+			BuildName:                         b.config.PackerBuildName,
 		}
 	}
 
@@ -219,6 +308,11 @@ func (b *Builder) Run(ctx context.Context, ui packer.Ui, hook packer.Hook) (pack
 			EnableAMIENASupport:      b.config.AMIENASupport,
 			AmiFilters:               b.config.SourceAmiFilter,
 			AMIVirtType:              b.config.AMIVirtType,
+			MarketplaceCodeAction:    b.config.SourceAmiMarketplaceCodeAction,
+		},
+		&awscommon.StepDetectSSHUsername{
+			Comm:    &b.config.RunConfig.Comm,
+			Enabled: b.config.RunConfig.SSHUsernameDetectionEnabled(),
 		},
 		&awscommon.StepNetworkInfo{
 			VpcId:               b.config.VpcId,
@@ -228,6 +322,17 @@ func (b *Builder) Run(ctx context.Context, ui packer.Ui, hook packer.Hook) (pack
 			SubnetId:            b.config.SubnetId,
 			SubnetFilter:        b.config.SubnetFilter,
 			AvailabilityZone:    b.config.AvailabilityZone,
+			OutpostArn:          b.config.OutpostArn,
+			InstanceType:        b.config.InstanceType,
+		},
+		&awscommon.StepInstanceStore{
+			InstanceType:     b.config.InstanceType,
+			LaunchMappings:   b.config.LaunchMappings,
+			EphemeralDevices: b.config.EphemeralDevices,
+		},
+		&awscommon.StepVpcEndpointCheck{
+			Enabled:      b.config.VpcEndpointCheck,
+			SSHInterface: b.config.SSHInterface,
 		},
 		&awscommon.StepKeyPair{
 			Debug:        b.config.PackerDebug,
@@ -249,6 +354,15 @@ func (b *Builder) Run(ctx context.Context, ui packer.Ui, hook packer.Hook) (pack
 		&awscommon.StepCleanupVolumes{
 			LaunchMappings: b.config.LaunchMappings,
 		},
+		&awscommon.StepSSMUserData{
+			AWSSession:   session,
+			Region:       *ec2conn.Config.Region,
+			Enabled:      b.config.UserDataViaSSM,
+			UserData:     b.config.UserData,
+			UserDataFile: b.config.UserDataFile,
+			KMSKeyId:     b.config.UserDataSSMKMSKeyId,
+			IsWindows:    b.config.RunConfig.Comm.Type == "winrm",
+		},
 		instanceStep,
 		&awscommon.StepGetPassword{
 			Debug:     b.config.PackerDebug,
@@ -263,12 +377,21 @@ func (b *Builder) Run(ctx context.Context, ui packer.Ui, hook packer.Hook) (pack
 			RemotePortNumber: b.config.Comm.Port(),
 			SSMAgentEnabled:  b.config.SSMAgentEnabled(),
 		},
+		&awscommon.StepCreateEICETunnel{
+			AWSSession:       session,
+			Region:           *ec2conn.Config.Region,
+			RemotePortNumber: b.config.Comm.Port(),
+			EndpointID:       b.config.InstanceConnectEndpointId,
+			EndpointDNSName:  b.config.InstanceConnectEndpointDnsName,
+			EICEEnabled:      b.config.EICEEnabled(),
+		},
 		&communicator.StepConnect{
 			Config: &b.config.RunConfig.Comm,
 			Host: awscommon.SSHHost(
 				ec2conn,
 				b.config.SSHInterface,
 				b.config.Comm.Host(),
+				b.config.SSHDnsPropagationTimeout,
 			),
 			SSHPort: awscommon.Port(
 				b.config.SSHInterface,
@@ -281,7 +404,7 @@ func (b *Builder) Run(ctx context.Context, ui packer.Ui, hook packer.Hook) (pack
 			Comm: &b.config.RunConfig.Comm,
 		},
 		&awscommon.StepStopEBSBackedInstance{
-			Skip:                b.config.IsSpotInstance(),
+			Skip:                spot,
 			DisableStopInstance: b.config.DisableStopInstance,
 		},
 		&awscommon.StepModifyEBSBackedInstance{
@@ -298,6 +421,11 @@ func (b *Builder) Run(ctx context.Context, ui packer.Ui, hook packer.Hook) (pack
 		&stepCreateAMI{
 			AMISkipBuildRegion: b.config.AMISkipBuildRegion,
 		},
+		&awscommon.StepEnableFastLaunch{
+			EnableFastLaunch:    b.config.AMIFastLaunch,
+			TargetResourceCount: b.config.AMIFastLaunchTargetResourceCount,
+			MaxParallelLaunches: b.config.AMIFastLaunchMaxParallelLaunches,
+		},
 		&awscommon.StepAMIRegionCopy{
 			AccessConfig:       &b.config.AccessConfig,
 			Regions:            b.config.AMIRegions,
@@ -325,17 +453,48 @@ func (b *Builder) Run(ctx context.Context, ui packer.Ui, hook packer.Hook) (pack
 		},
 	}
 
+	// When the build was started with "packer test", boot a throwaway
+	// instance from the AMI that was just created and run the check script
+	// on it. A failing script halts the build, which deregisters the AMI
+	// through the cleanup of the steps above.
+	if b.config.PackerTestScript != "" {
+		steps = append(steps,
+			&awscommon.StepBootVerifyInstance{
+				Comm:         &b.config.RunConfig.Comm,
+				InstanceType: b.config.InstanceType,
+			},
+			&communicator.StepConnect{
+				Config: &b.config.RunConfig.Comm,
+				Host: awscommon.SSHHost(
+					ec2conn,
+					b.config.SSHInterface,
+					b.config.Comm.Host(),
+					b.config.SSHDnsPropagationTimeout,
+				),
+				SSHPort: awscommon.Port(
+					b.config.SSHInterface,
+					b.config.Comm.Port(),
+				),
+				SSHConfig: b.config.RunConfig.Comm.SSHConfigFunc(),
+			},
+			&common.StepTestScript{
+				Script: b.config.PackerTestScript,
+			},
+		)
+	}
+
 	// Run!
 	b.runner = common.NewRunner(steps, b.config.PackerConfig, ui)
 	b.runner.Run(ctx, state)
 	// If there was an error, return that
 	if rawErr, ok := state.GetOk("error"); ok {
-		return nil, rawErr.(error)
+		_, interrupted := state.GetOk("spot_interrupted")
+		return nil, interrupted, rawErr.(error)
 	}
 
 	// If there are no AMIs, then just return
 	if _, ok := state.GetOk("amis"); !ok {
-		return nil, nil
+		return nil, false, nil
 	}
 
 	// Build the artifact and return it
@@ -346,5 +505,5 @@ func (b *Builder) Run(ctx context.Context, ui packer.Ui, hook packer.Hook) (pack
 		StateData:      map[string]interface{}{"generated_data": state.Get("generated_data")},
 	}
 
-	return artifact, nil
+	return artifact, false, nil
 }
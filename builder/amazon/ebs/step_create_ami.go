@@ -28,17 +28,26 @@ func (s *stepCreateAMI) Run(ctx context.Context, state multistep.StateBag) multi
 	amiName := config.AMIName
 	state.Put("intermediary_image", false)
 	if config.AMIEncryptBootVolume.True() || s.AMISkipBuildRegion {
-		state.Put("intermediary_image", true)
-
-		// From AWS SDK docs: You can encrypt a copy of an unencrypted snapshot,
-		// but you cannot use it to create an unencrypted copy of an encrypted
-		// snapshot. Your default CMK for EBS is used unless you specify a
-		// non-default key using KmsKeyId.
-
-		// If encrypt_boot is nil or true, we need to create a temporary image
-		// so that in step_region_copy, we can copy it with the correct
-		// encryption
-		amiName = random.AlphaNum(7)
+		if config.AMIEncryptBootVolume.True() && !s.AMISkipBuildRegion &&
+			awscommon.EbsEncryptionMatchesDefault(ec2conn, config.AMIKmsKeyId) {
+			// The account already encrypts new EBS volumes by default with
+			// the requested key, so the image is encrypted as desired and
+			// the re-copy through a temporary AMI can be skipped.
+			ui.Say("Account encrypts EBS volumes by default with the " +
+				"requested key; skipping the encrypted re-copy of the AMI")
+		} else {
+			state.Put("intermediary_image", true)
+
+			// From AWS SDK docs: You can encrypt a copy of an unencrypted snapshot,
+			// but you cannot use it to create an unencrypted copy of an encrypted
+			// snapshot. Your default CMK for EBS is used unless you specify a
+			// non-default key using KmsKeyId.
+
+			// If encrypt_boot is nil or true, we need to create a temporary image
+			// so that in step_region_copy, we can copy it with the correct
+			// encryption
+			amiName = random.AlphaNum(7)
+		}
 	}
 
 	ui.Say(fmt.Sprintf("Creating AMI %s from instance %s", amiName, *instance.InstanceId))
@@ -48,7 +57,10 @@ func (s *stepCreateAMI) Run(ctx context.Context, state multistep.StateBag) multi
 		BlockDeviceMappings: config.AMIMappings.BuildEC2BlockDeviceMappings(),
 	}
 
-	createResp, err := ec2conn.CreateImage(createOpts)
+	createImageReq, createResp := ec2conn.CreateImageRequest(createOpts)
+	awscommon.SpliceEbsThroughput(createImageReq,
+		"BlockDeviceMapping", config.AMIMappings.GetGp3Throughput())
+	err := createImageReq.Send()
 	if err != nil {
 		err := fmt.Errorf("Error creating AMI: %s", err)
 		state.Put("error", err)
@@ -41,6 +41,10 @@ func (bds BlockDevices) BuildEC2BlockDeviceMappings() []*ec2.BlockDeviceMapping
 	return blockDevices
 }
 
+func (bds BlockDevices) GetGp3Throughput() map[string]int64 {
+	return awscommon.BlockDevices(bds.Common()).GetGp3Throughput()
+}
+
 func (blockDevice BlockDevice) BuildEC2BlockDeviceMapping() *ec2.BlockDeviceMapping {
 
 	mapping := &ec2.BlockDeviceMapping{
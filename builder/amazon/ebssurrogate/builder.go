@@ -64,6 +64,16 @@ type Config struct {
 	// what architecture to use when registering the
 	// final AMI; valid options are "x86_64" or "arm64". Defaults to "x86_64".
 	Architecture string `mapstructure:"ami_architecture" required:"false"`
+	// The boot mode to register the final AMI with; valid options are
+	// "legacy-bios" or "uefi". When unset, instances launched from the AMI
+	// use the default boot mode of their instance type.
+	BootMode string `mapstructure:"boot_mode" required:"false"`
+	// Base64 representation of the non-volatile UEFI variable store to
+	// register the final AMI with, for example Secure Boot keys enrolled
+	// with a custom key. See the AWS documentation on
+	// [UEFI Secure Boot](https://docs.aws.amazon.com/AWSEC2/latest/UserGuide/uefi-secure-boot.html)
+	// for how to produce this blob. Requires `boot_mode` to be `uefi`.
+	UefiData string `mapstructure:"uefi_data" required:"false"`
 
 	ctx interpolate.Context
 }
@@ -149,6 +159,14 @@ func (b *Builder) Prepare(raws ...interface{}) ([]string, []string, error) {
 	if !valid {
 		errs = packer.MultiErrorAppend(errs, errors.New(`The only valid ami_architecture values are "x86_64" and "arm64"`))
 	}
+
+	if b.config.BootMode != "" && !awscommon.ValidateBootMode(b.config.BootMode) {
+		errs = packer.MultiErrorAppend(errs, errors.New(`The only valid boot_mode values are "legacy-bios" and "uefi"`))
+	}
+	if b.config.UefiData != "" && b.config.BootMode != "uefi" {
+		errs = packer.MultiErrorAppend(errs, errors.New(`uefi_data requires boot_mode to be "uefi"`))
+	}
+
 	if errs != nil && len(errs.Errors) > 0 {
 		return nil, warns, errs
 	}
@@ -191,13 +209,18 @@ func (b *Builder) Run(ctx context.Context, ui packer.Ui, hook packer.Hook) (pack
 			Comm:                              &b.config.RunConfig.Comm,
 			Debug:                             b.config.PackerDebug,
 			EbsOptimized:                      b.config.EbsOptimized,
+			EnableDetailedMonitoring:          b.config.EnableDetailedMonitoring,
 			ExpectedRootDevice:                "ebs",
+			HibernationOptions:                b.config.HibernationOptions,
 			InstanceInitiatedShutdownBehavior: b.config.InstanceInitiatedShutdownBehavior,
+			KeepInstanceOnFailure:             b.config.KeepInstanceOnFailure,
+			Metadata:                          b.config.Metadata,
 			InstanceType:                      b.config.InstanceType,
 			SourceAMI:                         b.config.SourceAmi,
 			SpotPrice:                         b.config.SpotPrice,
 			SpotInstanceTypes:                 b.config.SpotInstanceTypes,
 			SpotTags:                          b.config.SpotTags,
+			FallbackToOnDemand:                b.config.SpotFallbackToOnDemand,
 			Tags:                              b.config.RunTags,
 			UserData:                          b.config.UserData,
 			UserDataFile:                      b.config.UserDataFile,
@@ -211,9 +234,14 @@ func (b *Builder) Run(ctx context.Context, ui packer.Ui, hook packer.Hook) (pack
 			Ctx:                               b.config.ctx,
 			Debug:                             b.config.PackerDebug,
 			EbsOptimized:                      b.config.EbsOptimized,
+			EnableDetailedMonitoring:          b.config.EnableDetailedMonitoring,
 			EnableT2Unlimited:                 b.config.EnableT2Unlimited,
 			ExpectedRootDevice:                "ebs",
+			HibernationOptions:                b.config.HibernationOptions,
+			IamProfilePropagationTimeout:      b.config.IamInstanceProfilePropagationTimeout,
 			InstanceInitiatedShutdownBehavior: b.config.InstanceInitiatedShutdownBehavior,
+			KeepInstanceOnFailure:             b.config.KeepInstanceOnFailure,
+			Metadata:                          b.config.Metadata,
 			InstanceType:                      b.config.InstanceType,
 			IsRestricted:                      b.config.IsChinaCloud() || b.config.IsGovCloud(),
 			SourceAMI:                         b.config.SourceAmi,
@@ -221,6 +249,8 @@ func (b *Builder) Run(ctx context.Context, ui packer.Ui, hook packer.Hook) (pack
 			UserData:                          b.config.UserData,
 			UserDataFile:                      b.config.UserDataFile,
 			VolumeTags:                        b.config.VolumeRunTags,
+			ReuseInstance:                     b.config.ReuseInstance,
+			BuildName:                         b.config.PackerBuildName,
 		}
 	}
 
@@ -242,6 +272,11 @@ func (b *Builder) Run(ctx context.Context, ui packer.Ui, hook packer.Hook) (pack
 			EnableAMIENASupport:      b.config.AMIENASupport,
 			AmiFilters:               b.config.SourceAmiFilter,
 			AMIVirtType:              b.config.AMIVirtType,
+			MarketplaceCodeAction:    b.config.SourceAmiMarketplaceCodeAction,
+		},
+		&awscommon.StepDetectSSHUsername{
+			Comm:    &b.config.RunConfig.Comm,
+			Enabled: b.config.RunConfig.SSHUsernameDetectionEnabled(),
 		},
 		&awscommon.StepNetworkInfo{
 			VpcId:               b.config.VpcId,
@@ -251,6 +286,17 @@ func (b *Builder) Run(ctx context.Context, ui packer.Ui, hook packer.Hook) (pack
 			SubnetId:            b.config.SubnetId,
 			SubnetFilter:        b.config.SubnetFilter,
 			AvailabilityZone:    b.config.AvailabilityZone,
+			OutpostArn:          b.config.OutpostArn,
+			InstanceType:        b.config.InstanceType,
+		},
+		&awscommon.StepInstanceStore{
+			InstanceType:     b.config.InstanceType,
+			LaunchMappings:   b.config.LaunchMappings.Common(),
+			EphemeralDevices: b.config.EphemeralDevices,
+		},
+		&awscommon.StepVpcEndpointCheck{
+			Enabled:      b.config.VpcEndpointCheck,
+			SSHInterface: b.config.SSHInterface,
 		},
 		&awscommon.StepKeyPair{
 			Debug:        b.config.PackerDebug,
@@ -272,6 +318,15 @@ func (b *Builder) Run(ctx context.Context, ui packer.Ui, hook packer.Hook) (pack
 		&awscommon.StepCleanupVolumes{
 			LaunchMappings: b.config.LaunchMappings.Common(),
 		},
+		&awscommon.StepSSMUserData{
+			AWSSession:   session,
+			Region:       *ec2conn.Config.Region,
+			Enabled:      b.config.UserDataViaSSM,
+			UserData:     b.config.UserData,
+			UserDataFile: b.config.UserDataFile,
+			KMSKeyId:     b.config.UserDataSSMKMSKeyId,
+			IsWindows:    b.config.RunConfig.Comm.Type == "winrm",
+		},
 		instanceStep,
 		&awscommon.StepGetPassword{
 			Debug:     b.config.PackerDebug,
@@ -286,12 +341,21 @@ func (b *Builder) Run(ctx context.Context, ui packer.Ui, hook packer.Hook) (pack
 			RemotePortNumber: b.config.Comm.Port(),
 			SSMAgentEnabled:  b.config.SSMAgentEnabled(),
 		},
+		&awscommon.StepCreateEICETunnel{
+			AWSSession:       session,
+			Region:           *ec2conn.Config.Region,
+			RemotePortNumber: b.config.Comm.Port(),
+			EndpointID:       b.config.InstanceConnectEndpointId,
+			EndpointDNSName:  b.config.InstanceConnectEndpointDnsName,
+			EICEEnabled:      b.config.EICEEnabled(),
+		},
 		&communicator.StepConnect{
 			Config: &b.config.RunConfig.Comm,
 			Host: awscommon.SSHHost(
 				ec2conn,
 				b.config.SSHInterface,
 				b.config.Comm.Host(),
+				b.config.SSHDnsPropagationTimeout,
 			),
 			SSHPort: awscommon.Port(
 				b.config.SSHInterface,
@@ -333,6 +397,11 @@ func (b *Builder) Run(ctx context.Context, ui packer.Ui, hook packer.Hook) (pack
 			LaunchOmitMap:            b.config.LaunchMappings.GetOmissions(),
 			AMISkipBuildRegion:       b.config.AMISkipBuildRegion,
 		},
+		&awscommon.StepEnableFastLaunch{
+			EnableFastLaunch:    b.config.AMIFastLaunch,
+			TargetResourceCount: b.config.AMIFastLaunchTargetResourceCount,
+			MaxParallelLaunches: b.config.AMIFastLaunchMaxParallelLaunches,
+		},
 		&awscommon.StepAMIRegionCopy{
 			AccessConfig:      &b.config.AccessConfig,
 			Regions:           b.config.AMIRegions,
@@ -24,6 +24,14 @@ type BlockDevice struct {
 
 type BlockDevices []BlockDevice
 
+func (bds BlockDevices) Common() []awscommon.BlockDevice {
+	res := []awscommon.BlockDevice{}
+	for _, bd := range bds {
+		res = append(res, bd.BlockDevice)
+	}
+	return res
+}
+
 func (bds BlockDevices) BuildEC2BlockDeviceMappings() []*ec2.BlockDeviceMapping {
 	var blockDevices []*ec2.BlockDeviceMapping
 
@@ -33,6 +41,10 @@ func (bds BlockDevices) BuildEC2BlockDeviceMappings() []*ec2.BlockDeviceMapping
 	return blockDevices
 }
 
+func (bds BlockDevices) GetGp3Throughput() map[string]int64 {
+	return awscommon.BlockDevices(bds.Common()).GetGp3Throughput()
+}
+
 func (bds BlockDevices) Prepare(ctx *interpolate.Context) (errs []error) {
 
 	for _, block := range bds {
@@ -178,13 +178,18 @@ func (b *Builder) Run(ctx context.Context, ui packer.Ui, hook packer.Hook) (pack
 			Ctx:                               b.config.ctx,
 			Debug:                             b.config.PackerDebug,
 			EbsOptimized:                      b.config.EbsOptimized,
+			EnableDetailedMonitoring:          b.config.EnableDetailedMonitoring,
 			ExpectedRootDevice:                "ebs",
+			HibernationOptions:                b.config.HibernationOptions,
 			InstanceInitiatedShutdownBehavior: b.config.InstanceInitiatedShutdownBehavior,
+			KeepInstanceOnFailure:             b.config.KeepInstanceOnFailure,
+			Metadata:                          b.config.Metadata,
 			InstanceType:                      b.config.InstanceType,
 			SourceAMI:                         b.config.SourceAmi,
 			SpotInstanceTypes:                 b.config.SpotInstanceTypes,
 			SpotPrice:                         b.config.SpotPrice,
 			SpotTags:                          b.config.SpotTags,
+			FallbackToOnDemand:                b.config.SpotFallbackToOnDemand,
 			Tags:                              b.config.RunTags,
 			UserData:                          b.config.UserData,
 			UserDataFile:                      b.config.UserDataFile,
@@ -198,9 +203,14 @@ func (b *Builder) Run(ctx context.Context, ui packer.Ui, hook packer.Hook) (pack
 			Ctx:                               b.config.ctx,
 			Debug:                             b.config.PackerDebug,
 			EbsOptimized:                      b.config.EbsOptimized,
+			EnableDetailedMonitoring:          b.config.EnableDetailedMonitoring,
 			EnableT2Unlimited:                 b.config.EnableT2Unlimited,
 			ExpectedRootDevice:                "ebs",
+			HibernationOptions:                b.config.HibernationOptions,
+			IamProfilePropagationTimeout:      b.config.IamInstanceProfilePropagationTimeout,
 			InstanceInitiatedShutdownBehavior: b.config.InstanceInitiatedShutdownBehavior,
+			KeepInstanceOnFailure:             b.config.KeepInstanceOnFailure,
+			Metadata:                          b.config.Metadata,
 			InstanceType:                      b.config.InstanceType,
 			IsRestricted:                      b.config.IsChinaCloud() || b.config.IsGovCloud(),
 			SourceAMI:                         b.config.SourceAmi,
@@ -208,6 +218,8 @@ func (b *Builder) Run(ctx context.Context, ui packer.Ui, hook packer.Hook) (pack
 			UserData:                          b.config.UserData,
 			UserDataFile:                      b.config.UserDataFile,
 			VolumeTags:                        b.config.VolumeRunTags,
+			ReuseInstance:                     b.config.ReuseInstance,
+			BuildName:                         b.config.PackerBuildName,
 		}
 	}
 
@@ -218,6 +230,11 @@ func (b *Builder) Run(ctx context.Context, ui packer.Ui, hook packer.Hook) (pack
 			EnableAMISriovNetSupport: b.config.AMISriovNetSupport,
 			EnableAMIENASupport:      b.config.AMIENASupport,
 			AmiFilters:               b.config.SourceAmiFilter,
+			MarketplaceCodeAction:    b.config.SourceAmiMarketplaceCodeAction,
+		},
+		&awscommon.StepDetectSSHUsername{
+			Comm:    &b.config.RunConfig.Comm,
+			Enabled: b.config.RunConfig.SSHUsernameDetectionEnabled(),
 		},
 		&awscommon.StepNetworkInfo{
 			VpcId:               b.config.VpcId,
@@ -227,6 +244,17 @@ func (b *Builder) Run(ctx context.Context, ui packer.Ui, hook packer.Hook) (pack
 			SubnetId:            b.config.SubnetId,
 			SubnetFilter:        b.config.SubnetFilter,
 			AvailabilityZone:    b.config.AvailabilityZone,
+			OutpostArn:          b.config.OutpostArn,
+			InstanceType:        b.config.InstanceType,
+		},
+		&awscommon.StepInstanceStore{
+			InstanceType:     b.config.InstanceType,
+			LaunchMappings:   b.config.VolumeMappings.Common(),
+			EphemeralDevices: b.config.EphemeralDevices,
+		},
+		&awscommon.StepVpcEndpointCheck{
+			Enabled:      b.config.VpcEndpointCheck,
+			SSHInterface: b.config.SSHInterface,
 		},
 		&awscommon.StepKeyPair{
 			Debug:        b.config.PackerDebug,
@@ -245,6 +273,15 @@ func (b *Builder) Run(ctx context.Context, ui packer.Ui, hook packer.Hook) (pack
 			SkipProfileValidation:                     b.config.SkipProfileValidation,
 			TemporaryIamInstanceProfilePolicyDocument: b.config.TemporaryIamInstanceProfilePolicyDocument,
 		},
+		&awscommon.StepSSMUserData{
+			AWSSession:   session,
+			Region:       *ec2conn.Config.Region,
+			Enabled:      b.config.UserDataViaSSM,
+			UserData:     b.config.UserData,
+			UserDataFile: b.config.UserDataFile,
+			KMSKeyId:     b.config.UserDataSSMKMSKeyId,
+			IsWindows:    b.config.RunConfig.Comm.Type == "winrm",
+		},
 		instanceStep,
 		&stepTagEBSVolumes{
 			VolumeMapping: b.config.VolumeMappings,
@@ -263,12 +300,21 @@ func (b *Builder) Run(ctx context.Context, ui packer.Ui, hook packer.Hook) (pack
 			RemotePortNumber: b.config.Comm.Port(),
 			SSMAgentEnabled:  b.config.SSMAgentEnabled(),
 		},
+		&awscommon.StepCreateEICETunnel{
+			AWSSession:       session,
+			Region:           *ec2conn.Config.Region,
+			RemotePortNumber: b.config.Comm.Port(),
+			EndpointID:       b.config.InstanceConnectEndpointId,
+			EndpointDNSName:  b.config.InstanceConnectEndpointDnsName,
+			EICEEnabled:      b.config.EICEEnabled(),
+		},
 		&communicator.StepConnect{
 			Config: &b.config.RunConfig.Comm,
 			Host: awscommon.SSHHost(
 				ec2conn,
 				b.config.SSHInterface,
 				b.config.Comm.Host(),
+				b.config.SSHDnsPropagationTimeout,
 			),
 			SSHPort: awscommon.Port(
 				b.config.SSHInterface,
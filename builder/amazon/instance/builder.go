@@ -262,30 +262,40 @@ func (b *Builder) Run(ctx context.Context, ui packer.Ui, hook packer.Hook) (pack
 			Comm:                     &b.config.RunConfig.Comm,
 			Debug:                    b.config.PackerDebug,
 			EbsOptimized:             b.config.EbsOptimized,
+			EnableDetailedMonitoring: b.config.EnableDetailedMonitoring,
+			HibernationOptions:       b.config.HibernationOptions,
 			InstanceType:             b.config.InstanceType,
+			KeepInstanceOnFailure:    b.config.KeepInstanceOnFailure,
+			Metadata:                 b.config.Metadata,
 			SourceAMI:                b.config.SourceAmi,
 			SpotPrice:                b.config.SpotPrice,
 			SpotInstanceTypes:        b.config.SpotInstanceTypes,
 			Tags:                     b.config.RunTags,
 			SpotTags:                 b.config.SpotTags,
+			FallbackToOnDemand:       b.config.SpotFallbackToOnDemand,
 			UserData:                 b.config.UserData,
 			UserDataFile:             b.config.UserDataFile,
 		}
 	} else {
 		instanceStep = &awscommon.StepRunSourceInstance{
-			AssociatePublicIpAddress: b.config.AssociatePublicIpAddress,
-			LaunchMappings:           b.config.LaunchMappings,
-			Comm:                     &b.config.RunConfig.Comm,
-			Ctx:                      b.config.ctx,
-			Debug:                    b.config.PackerDebug,
-			EbsOptimized:             b.config.EbsOptimized,
-			EnableT2Unlimited:        b.config.EnableT2Unlimited,
-			InstanceType:             b.config.InstanceType,
-			IsRestricted:             b.config.IsChinaCloud() || b.config.IsGovCloud(),
-			SourceAMI:                b.config.SourceAmi,
-			Tags:                     b.config.RunTags,
-			UserData:                 b.config.UserData,
-			UserDataFile:             b.config.UserDataFile,
+			AssociatePublicIpAddress:     b.config.AssociatePublicIpAddress,
+			LaunchMappings:               b.config.LaunchMappings,
+			Comm:                         &b.config.RunConfig.Comm,
+			Ctx:                          b.config.ctx,
+			Debug:                        b.config.PackerDebug,
+			EbsOptimized:                 b.config.EbsOptimized,
+			EnableDetailedMonitoring:     b.config.EnableDetailedMonitoring,
+			EnableT2Unlimited:            b.config.EnableT2Unlimited,
+			HibernationOptions:           b.config.HibernationOptions,
+			IamProfilePropagationTimeout: b.config.IamInstanceProfilePropagationTimeout,
+			InstanceType:                 b.config.InstanceType,
+			IsRestricted:                 b.config.IsChinaCloud() || b.config.IsGovCloud(),
+			KeepInstanceOnFailure:        b.config.KeepInstanceOnFailure,
+			Metadata:                     b.config.Metadata,
+			SourceAMI:                    b.config.SourceAmi,
+			Tags:                         b.config.RunTags,
+			UserData:                     b.config.UserData,
+			UserDataFile:                 b.config.UserDataFile,
 		}
 	}
 
@@ -304,6 +314,11 @@ func (b *Builder) Run(ctx context.Context, ui packer.Ui, hook packer.Hook) (pack
 			EnableAMIENASupport:      b.config.AMIENASupport,
 			AmiFilters:               b.config.SourceAmiFilter,
 			AMIVirtType:              b.config.AMIVirtType,
+			MarketplaceCodeAction:    b.config.SourceAmiMarketplaceCodeAction,
+		},
+		&awscommon.StepDetectSSHUsername{
+			Comm:    &b.config.RunConfig.Comm,
+			Enabled: b.config.RunConfig.SSHUsernameDetectionEnabled(),
 		},
 		&awscommon.StepNetworkInfo{
 			VpcId:               b.config.VpcId,
@@ -313,6 +328,18 @@ func (b *Builder) Run(ctx context.Context, ui packer.Ui, hook packer.Hook) (pack
 			SubnetId:            b.config.SubnetId,
 			SubnetFilter:        b.config.SubnetFilter,
 			AvailabilityZone:    b.config.AvailabilityZone,
+			OutpostArn:          b.config.OutpostArn,
+			InstanceType:        b.config.InstanceType,
+		},
+		&awscommon.StepInstanceStore{
+			InstanceType:     b.config.InstanceType,
+			LaunchMappings:   b.config.LaunchMappings,
+			EphemeralDevices: b.config.EphemeralDevices,
+		},
+		&awscommon.StepVpcEndpointCheck{
+			Enabled:          b.config.VpcEndpointCheck,
+			SSHInterface:     b.config.SSHInterface,
+			RequiredServices: []string{"s3"},
 		},
 		&awscommon.StepKeyPair{
 			Debug:        b.config.PackerDebug,
@@ -331,6 +358,15 @@ func (b *Builder) Run(ctx context.Context, ui packer.Ui, hook packer.Hook) (pack
 			SkipProfileValidation:                     b.config.SkipProfileValidation,
 			TemporaryIamInstanceProfilePolicyDocument: b.config.TemporaryIamInstanceProfilePolicyDocument,
 		},
+		&awscommon.StepSSMUserData{
+			AWSSession:   session,
+			Region:       *ec2conn.Config.Region,
+			Enabled:      b.config.UserDataViaSSM,
+			UserData:     b.config.UserData,
+			UserDataFile: b.config.UserDataFile,
+			KMSKeyId:     b.config.UserDataSSMKMSKeyId,
+			IsWindows:    b.config.RunConfig.Comm.Type == "winrm",
+		},
 		instanceStep,
 		&awscommon.StepGetPassword{
 			Debug:     b.config.PackerDebug,
@@ -345,6 +381,14 @@ func (b *Builder) Run(ctx context.Context, ui packer.Ui, hook packer.Hook) (pack
 			RemotePortNumber: b.config.Comm.Port(),
 			SSMAgentEnabled:  b.config.SSMAgentEnabled(),
 		},
+		&awscommon.StepCreateEICETunnel{
+			AWSSession:       session,
+			Region:           *ec2conn.Config.Region,
+			RemotePortNumber: b.config.Comm.Port(),
+			EndpointID:       b.config.InstanceConnectEndpointId,
+			EndpointDNSName:  b.config.InstanceConnectEndpointDnsName,
+			EICEEnabled:      b.config.EICEEnabled(),
+		},
 		&communicator.StepConnect{
 			// StepConnect is provided settings for WinRM and SSH, but
 			// the communicator will ultimately determine which port to use.
@@ -353,6 +397,7 @@ func (b *Builder) Run(ctx context.Context, ui packer.Ui, hook packer.Hook) (pack
 				ec2conn,
 				b.config.SSHInterface,
 				b.config.Comm.Host(),
+				b.config.SSHDnsPropagationTimeout,
 			),
 			SSHPort: awscommon.Port(
 				b.config.SSHInterface,
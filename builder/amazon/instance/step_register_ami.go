@@ -65,7 +65,8 @@ func (s *StepRegisterAMI) Run(ctx context.Context, state multistep.StateBag) mul
 		registerOpts.EnaSupport = aws.Bool(true)
 	}
 
-	registerResp, err := ec2conn.RegisterImage(registerOpts)
+	registerResp, err := awscommon.RegisterImage(ec2conn, registerOpts,
+		"", "", config.AMIMappings.GetGp3Throughput())
 	if err != nil {
 		state.Put("error", fmt.Errorf("Error registering AMI: %s", err))
 		ui.Error(state.Get("error").(error).Error())
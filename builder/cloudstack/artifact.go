@@ -3,6 +3,7 @@ package cloudstack
 import (
 	"fmt"
 	"log"
+	"sort"
 	"strings"
 
 	"github.com/xanzy/go-cloudstack/cloudstack"
@@ -14,6 +15,10 @@ type Artifact struct {
 	config   *Config
 	template *cloudstack.CreateTemplateResponse
 
+	// The IDs of the template copies per additional zone, keyed by
+	// zone ID.
+	templateZones map[string]string
+
 	// StateData should store data such as GeneratedData
 	// to be shared with post-processors
 	StateData map[string]interface{}
@@ -59,10 +64,24 @@ func (a *Artifact) Id() string {
 
 // String returns the string representation of the artifact.
 func (a *Artifact) String() string {
-	return fmt.Sprintf("A template was created: %s", a.template.Name)
+	s := fmt.Sprintf("A template was created: %s", a.template.Name)
+
+	zones := make([]string, 0, len(a.templateZones))
+	for zone := range a.templateZones {
+		zones = append(zones, zone)
+	}
+	sort.Strings(zones)
+	for _, zone := range zones {
+		s += fmt.Sprintf("\nTemplate in zone %s: %s", zone, a.templateZones[zone])
+	}
+
+	return s
 }
 
 // State returns specific details from the artifact.
 func (a *Artifact) State(name string) interface{} {
+	if name == "template_zones" {
+		return a.templateZones
+	}
 	return a.StateData[name]
 }
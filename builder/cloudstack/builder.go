@@ -3,6 +3,8 @@ package cloudstack
 import (
 	"context"
 	"fmt"
+	"log"
+	"strings"
 
 	"github.com/hashicorp/hcl/v2/hcldec"
 	"github.com/hashicorp/packer/common"
@@ -50,6 +52,13 @@ func (b *Builder) Run(ctx context.Context, ui packer.Ui, hook packer.Hook) (pack
 	// Some CloudStack service providers only allow HTTP GET calls.
 	client.HTTPGETOnly = b.config.HTTPGetOnly
 
+	// Fail fast on invalid API credentials before doing any real work, so
+	// a typo'd secret key doesn't surface as a confusing 401 halfway
+	// through the build.
+	if err := b.verifyApiCredentials(client); err != nil {
+		return nil, err
+	}
+
 	// Set up the state.
 	state := new(multistep.BasicStateBag)
 	state.Put("client", client)
@@ -89,7 +98,9 @@ func (b *Builder) Run(ctx context.Context, ui packer.Ui, hook packer.Hook) (pack
 			Comm: &b.config.Comm,
 		},
 		&stepShutdownInstance{},
+		&stepForceDeleteTemplates{},
 		&stepCreateTemplate{},
+		&stepCopyTemplate{},
 	}
 
 	// Configure the runner and run the steps.
@@ -115,5 +126,48 @@ func (b *Builder) Run(ctx context.Context, ui packer.Ui, hook packer.Hook) (pack
 		StateData: map[string]interface{}{"generated_data": state.Get("generated_data")},
 	}
 
+	if templateZones, ok := state.GetOk("template_zones"); ok {
+		artifact.templateZones = templateZones.(map[string]string)
+	}
+
 	return artifact, nil
 }
+
+// verifyApiCredentials makes a cheap read-only API call to check that the
+// configured keys are accepted. listCapabilities is available to every
+// account role; if the provider has it disabled, listAccounts is tried as
+// a fallback. Only authentication failures fail the build, other errors
+// are logged and ignored as some providers restrict which APIs they
+// expose.
+func (b *Builder) verifyApiCredentials(client *cloudstack.CloudStackClient) error {
+	b.ui.Message("Verifying API credentials...")
+
+	_, err := client.Configuration.ListCapabilities(
+		client.Configuration.NewListCapabilitiesParams())
+	if err == nil {
+		return nil
+	}
+	if isAuthError(err) {
+		return fmt.Errorf(
+			"API credentials rejected, check your api_key and secret_key: %s", err)
+	}
+	log.Printf("[WARN] listCapabilities failed with a non-authentication "+
+		"error, trying listAccounts: %s", err)
+
+	if _, err := client.Account.ListAccounts(
+		client.Account.NewListAccountsParams()); err != nil {
+		if isAuthError(err) {
+			return fmt.Errorf(
+				"API credentials rejected, check your api_key and secret_key: %s", err)
+		}
+		log.Printf("[WARN] Unable to verify API credentials: %s", err)
+	}
+
+	return nil
+}
+
+// isAuthError says whether an API error indicates rejected credentials.
+// The CloudStack API reports those with HTTP status 401.
+func isAuthError(err error) bool {
+	return strings.Contains(err.Error(), "API error 401")
+}
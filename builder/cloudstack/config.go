@@ -36,6 +36,17 @@ type Config struct {
 	// The time duration to wait for async calls to
 	// finish. Defaults to 30m.
 	AsyncTimeout time.Duration `mapstructure:"async_timeout" required:"false"`
+	// The time duration to wait for the instance deployment to finish.
+	// Defaults to the value of async_timeout.
+	DeployTimeout time.Duration `mapstructure:"deploy_timeout" required:"false"`
+	// The time duration to wait for the instance to shut down before the
+	// template is created. Defaults to the value of async_timeout.
+	SnapshotTimeout time.Duration `mapstructure:"snapshot_timeout" required:"false"`
+	// The time duration to wait for the template creation (and the copies
+	// to template_zones) to finish. Creating a template from a large root
+	// volume legitimately takes hours, while deploy problems should still
+	// fail fast. Defaults to the value of async_timeout.
+	TemplateTimeout time.Duration `mapstructure:"template_timeout" required:"false"`
 	// Some cloud providers only allow HTTP GET calls
 	// to their CloudStack API. If using such a provider, you need to set this to
 	// true in order for the provider to only make GET calls and no POST calls.
@@ -80,6 +91,10 @@ type Config struct {
 	InstanceName string `mapstructure:"instance_name" required:"false"`
 	// The display name of the instance. Defaults to "Created by Packer".
 	InstanceDisplayName string `mapstructure:"instance_display_name" required:"false"`
+	// Keep the instance around if the build fails during provisioning, so
+	// that it can be inspected for debugging. The instance then has to be
+	// destroyed manually. Defaults to false.
+	KeepInstanceOnFailure bool `mapstructure:"keep_instance_on_failure" required:"false"`
 	// The name or ID of the network to connect the instance
 	// to.
 	Network string `mapstructure:"network" required:"true"`
@@ -100,6 +115,15 @@ type Config struct {
 	// The name or ID of the service offering used
 	// for the instance.
 	ServiceOffering string `mapstructure:"service_offering" required:"true"`
+	// The number of CPU cores to allocate to the
+	// instance. Only used with a custom (unconstrained) service offering.
+	CPUNumber int `mapstructure:"cpu_number" required:"false"`
+	// The CPU speed in MHz to allocate to the
+	// instance. Only used with a custom (unconstrained) service offering.
+	CPUSpeed int `mapstructure:"cpu_speed" required:"false"`
+	// The amount of memory in MB to allocate to the
+	// instance. Only used with a custom (unconstrained) service offering.
+	Memory int `mapstructure:"memory" required:"false"`
 	// Set to true to prevent network
 	// ACLs or firewall rules creation. Defaults to false.
 	PreventFirewallChanges bool `mapstructure:"prevent_firewall_changes" required:"false"`
@@ -158,10 +182,38 @@ type Config struct {
 	// contains tools to support dynamic scaling of VM cpu/memory. Defaults to
 	// false.
 	TemplateScalable bool `mapstructure:"template_scalable" required:"false"`
+	// Alias of template_scalable. Set to true to
+	// indicate that the template contains tools to support dynamic scaling of
+	// VM cpu/memory. Defaults to false.
+	TemplateDynamicallyScalable bool `mapstructure:"template_dynamically_scalable" required:"false"`
+	// Set to true to indicate the template
+	// supports the sshkey upload feature. Defaults to false.
+	TemplateSSHKeyEnabled bool `mapstructure:"template_sshkey_enabled" required:"false"`
+	// Key/value pair details to set on the new
+	// template, for template properties supported by the API that have no
+	// dedicated option.
+	TemplateDetails map[string]string `mapstructure:"template_details" required:"false"`
 	//
 	TemplateTag string `mapstructure:"template_tag"`
 
 	Tags map[string]string `mapstructure:"tags"`
+	// Key/value pair tags to apply to the temporary instance, in addition
+	// to tags.
+	InstanceTags map[string]string `mapstructure:"instance_tags"`
+	// Key/value pair tags to apply to the resulting template.
+	TemplateTags map[string]string `mapstructure:"template_tags"`
+	// A list of zones (name or ID) to copy the created template to, in
+	// addition to the zone it is built in. The builder waits until the
+	// template is ready in every listed zone and the artifact lists the
+	// per-zone template IDs.
+	TemplateZones []string `mapstructure:"template_zones" required:"false"`
+	// Set to true to delete any existing templates carrying the same name
+	// as template_name from the destination zones - the build zone and
+	// every zone listed in template_zones - before the new template is
+	// created. Without this, repeat builds fail with a duplicate template
+	// name error. When expunge is also set, the old templates are deleted
+	// forcibly, even when they are still in use. Defaults to false.
+	TemplateForceDelete bool `mapstructure:"template_force_delete" required:"false"`
 
 	ctx interpolate.Context
 }
@@ -176,6 +228,10 @@ func (c *Config) Prepare(raws ...interface{}) error {
 				"user_data",
 			},
 		},
+		MutuallyExclusive: [][]string{
+			{"source_iso", "source_template"},
+			{"user_data", "user_data_file"},
+		},
 	}, raws...)
 	if err != nil {
 		return err
@@ -203,6 +259,18 @@ func (c *Config) Prepare(raws ...interface{}) error {
 		c.AsyncTimeout = 30 * time.Minute
 	}
 
+	if c.DeployTimeout == 0 {
+		c.DeployTimeout = c.AsyncTimeout
+	}
+
+	if c.SnapshotTimeout == 0 {
+		c.SnapshotTimeout = c.AsyncTimeout
+	}
+
+	if c.TemplateTimeout == 0 {
+		c.TemplateTimeout = c.AsyncTimeout
+	}
+
 	if len(c.CIDRList) == 0 {
 		c.CIDRList = []string{"0.0.0.0/0"}
 	}
@@ -267,11 +335,6 @@ func (c *Config) Prepare(raws ...interface{}) error {
 			errs, errors.New("either source_iso or source_template must be specified"))
 	}
 
-	if c.SourceISO != "" && c.SourceTemplate != "" {
-		errs = packer.MultiErrorAppend(
-			errs, errors.New("only one of source_iso or source_template can be specified"))
-	}
-
 	if c.SourceISO != "" && c.DiskOffering == "" {
 		errs = packer.MultiErrorAppend(
 			errs, errors.New("a disk_offering must be specified when using source_iso"))
@@ -286,11 +349,6 @@ func (c *Config) Prepare(raws ...interface{}) error {
 		errs = packer.MultiErrorAppend(errs, errors.New("a template_os must be specified"))
 	}
 
-	if c.UserData != "" && c.UserDataFile != "" {
-		errs = packer.MultiErrorAppend(
-			errs, errors.New("only one of user_data or user_data_file can be specified"))
-	}
-
 	if c.UserDataFile != "" {
 		if _, err := os.Stat(c.UserDataFile); err != nil {
 			errs = packer.MultiErrorAppend(
@@ -302,6 +360,14 @@ func (c *Config) Prepare(raws ...interface{}) error {
 		errs = packer.MultiErrorAppend(errs, errors.New("a zone must be specified"))
 	}
 
+	for _, zone := range c.TemplateZones {
+		if zone == "" {
+			errs = packer.MultiErrorAppend(
+				errs, errors.New("template_zones must not contain empty entries"))
+			break
+		}
+	}
+
 	if es := c.Comm.Prepare(&c.ctx); len(es) > 0 {
 		errs = packer.MultiErrorAppend(errs, es...)
 	}
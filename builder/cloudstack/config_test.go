@@ -1,6 +1,9 @@
 package cloudstack
 
-import "testing"
+import (
+	"testing"
+	"time"
+)
 
 func TestNewConfig(t *testing.T) {
 	cases := map[string]struct {
@@ -147,6 +150,43 @@ func TestNewConfig(t *testing.T) {
 	}
 }
 
+func TestNewConfig_phaseTimeouts(t *testing.T) {
+	// The per-phase timeouts default to async_timeout...
+	var c Config
+	if errs := c.Prepare(testConfig(map[string]interface{}{
+		"disk_size":       "20",
+		"source_template": "d31e6af5-94a8-4756-abf3-6493c38db7e5",
+		"async_timeout":   "10m",
+	})); errs != nil {
+		t.Fatalf("should not error: %s", errs)
+	}
+	for desc, timeout := range map[string]time.Duration{
+		"deploy_timeout":   c.DeployTimeout,
+		"snapshot_timeout": c.SnapshotTimeout,
+		"template_timeout": c.TemplateTimeout,
+	} {
+		if timeout != 10*time.Minute {
+			t.Errorf("%s should default to async_timeout, got %s", desc, timeout)
+		}
+	}
+
+	// ...but an explicit value wins.
+	c = Config{}
+	if errs := c.Prepare(testConfig(map[string]interface{}{
+		"disk_size":        "20",
+		"source_template":  "d31e6af5-94a8-4756-abf3-6493c38db7e5",
+		"template_timeout": "4h",
+	})); errs != nil {
+		t.Fatalf("should not error: %s", errs)
+	}
+	if c.TemplateTimeout != 4*time.Hour {
+		t.Errorf("bad template_timeout: %s", c.TemplateTimeout)
+	}
+	if c.DeployTimeout != 30*time.Minute {
+		t.Errorf("bad deploy_timeout: %s", c.DeployTimeout)
+	}
+}
+
 func testConfig(config map[string]interface{}) map[string]interface{} {
 	raw := map[string]interface{}{
 		"api_url":          "https://cloudstack.com/client/api",
@@ -0,0 +1,107 @@
+package cloudstack
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/packer/helper/multistep"
+	"github.com/hashicorp/packer/packer"
+	"github.com/xanzy/go-cloudstack/cloudstack"
+)
+
+type stepCopyTemplate struct{}
+
+func (s *stepCopyTemplate) Run(ctx context.Context, state multistep.StateBag) multistep.StepAction {
+	client := state.Get("client").(*cloudstack.CloudStackClient)
+	config := state.Get("config").(*Config)
+	ui := state.Get("ui").(packer.Ui)
+
+	if len(config.TemplateZones) == 0 {
+		return multistep.ActionContinue
+	}
+
+	// Template copies are part of the template creation phase and may
+	// take just as long, so they share the template timeout.
+	client.AsyncTimeout(int64(config.TemplateTimeout.Seconds()))
+	defer client.AsyncTimeout(int64(config.AsyncTimeout.Seconds()))
+
+	template := state.Get("template").(*cloudstack.CreateTemplateResponse)
+
+	templateZones := make(map[string]string, len(config.TemplateZones))
+	for _, zone := range config.TemplateZones {
+		ui.Say(fmt.Sprintf("Copying template to zone: %s", zone))
+
+		p := client.Template.NewCopyTemplateParams(template.Id)
+		p.SetSourcezoneid(config.Zone)
+		p.SetDestzoneid(zone)
+
+		copy, err := client.Template.CopyTemplate(p)
+		if err != nil {
+			err := fmt.Errorf("Error copying template to zone %s: %s", zone, err)
+			state.Put("error", err)
+			ui.Error(err.Error())
+			return multistep.ActionHalt
+		}
+
+		ui.Message(fmt.Sprintf("Waiting for template to become ready in zone %s...", zone))
+		if err := s.waitForTemplateReady(ctx, client, config, copy.Id, zone); err != nil {
+			state.Put("error", err)
+			ui.Error(err.Error())
+			return multistep.ActionHalt
+		}
+
+		templateZones[zone] = copy.Id
+	}
+
+	state.Put("template_zones", templateZones)
+
+	return multistep.ActionContinue
+}
+
+// waitForTemplateReady polls the template in the given zone until CloudStack
+// reports it ready, as the async copy job finishes before the template is
+// fully downloaded into the destination zone.
+func (s *stepCopyTemplate) waitForTemplateReady(
+	ctx context.Context,
+	client *cloudstack.CloudStackClient,
+	config *Config,
+	id string,
+	zone string,
+) error {
+	start := time.Now()
+	for {
+		p := client.Template.NewListTemplatesParams("executable")
+		p.SetId(id)
+		p.SetZoneid(zone)
+		if config.Project != "" {
+			p.SetProjectid(config.Project)
+		}
+
+		templates, err := client.Template.ListTemplates(p)
+		if err != nil {
+			return fmt.Errorf("Error retrieving template in zone %s: %s", zone, err)
+		}
+
+		if templates.Count > 0 && templates.Templates[0].Isready {
+			return nil
+		}
+
+		if time.Since(start) > config.TemplateTimeout {
+			return fmt.Errorf(
+				"Timeout waiting for template to become ready in zone %s", zone)
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf(
+				"Cancelled waiting for template to become ready in zone %s", zone)
+		case <-time.After(5 * time.Second):
+		}
+	}
+}
+
+// Cleanup any resources that may have been created during the Run phase.
+func (s *stepCopyTemplate) Cleanup(state multistep.StateBag) {
+	// Nothing to cleanup for this step.
+}
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"net"
+	"strconv"
 	"strings"
 
 	"github.com/hashicorp/packer/helper/multistep"
@@ -34,6 +35,11 @@ func (s *stepCreateInstance) Run(ctx context.Context, state multistep.StateBag)
 
 	ui.Say("Creating instance...")
 
+	// Deploy problems should surface quickly, so async calls of this step
+	// wait with the deploy timeout instead of the global one.
+	client.AsyncTimeout(int64(config.DeployTimeout.Seconds()))
+	defer client.AsyncTimeout(int64(config.AsyncTimeout.Seconds()))
+
 	// Create a new parameter struct.
 	p := client.VirtualMachine.NewDeployVirtualMachineParams(
 		config.ServiceOffering,
@@ -54,6 +60,22 @@ func (s *stepCreateInstance) Run(ctx context.Context, state multistep.StateBag)
 		p.SetSecuritygroupids(securitygroups.([]string))
 	}
 
+	// When using a custom (unconstrained) service offering, the compute
+	// parameters are passed as deployment details.
+	if config.CPUNumber > 0 || config.CPUSpeed > 0 || config.Memory > 0 {
+		details := make(map[string]string)
+		if config.CPUNumber > 0 {
+			details["cpuNumber"] = strconv.Itoa(config.CPUNumber)
+		}
+		if config.CPUSpeed > 0 {
+			details["cpuSpeed"] = strconv.Itoa(config.CPUSpeed)
+		}
+		if config.Memory > 0 {
+			details["memory"] = strconv.Itoa(config.Memory)
+		}
+		p.SetDetails(details)
+	}
+
 	// If we use an ISO, configure the disk offering.
 	if config.SourceISO != "" {
 		p.SetDiskofferingid(config.DiskOffering)
@@ -149,9 +171,17 @@ func (s *stepCreateInstance) Run(ctx context.Context, state multistep.StateBag)
 	state.Put("instance_id", instance.Id)
 
 	// Set instance tags
-	if len(config.Tags) > 0 {
+	instanceTags := make(map[string]string)
+	for k, v := range config.Tags {
+		instanceTags[k] = v
+	}
+	for k, v := range config.InstanceTags {
+		instanceTags[k] = v
+	}
+
+	if len(instanceTags) > 0 {
 		resourceID := []string{instance.Id}
-		tp := client.Resourcetags.NewCreateTagsParams(resourceID, "UserVm", config.Tags)
+		tp := client.Resourcetags.NewCreateTagsParams(resourceID, "UserVm", instanceTags)
 
 		_, err = client.Resourcetags.CreateTags(tp)
 
@@ -176,6 +206,19 @@ func (s *stepCreateInstance) Cleanup(state multistep.StateBag) {
 		return
 	}
 
+	_, cancelled := state.GetOk(multistep.StateCancelled)
+	_, halted := state.GetOk(multistep.StateHalted)
+	if config.KeepInstanceOnFailure && (cancelled || halted) {
+		ui.Say(fmt.Sprintf("Build failed; keeping instance %s (%s) around "+
+			"for debugging because keep_instance_on_failure is set.",
+			config.InstanceName, instanceID))
+		if ipAddress, ok := state.Get("ipaddress").(string); ok && ipAddress != "" {
+			ui.Message(fmt.Sprintf("IP address: %s", ipAddress))
+		}
+		ui.Message("Destroy the instance manually once you are done with it.")
+		return
+	}
+
 	// Create a new parameter struct.
 	p := client.VirtualMachine.NewDestroyVirtualMachineParams(instanceID)
 
@@ -19,6 +19,11 @@ func (s *stepCreateTemplate) Run(ctx context.Context, state multistep.StateBag)
 
 	ui.Say(fmt.Sprintf("Creating template: %s", config.TemplateName))
 
+	// Creating a template from a large root volume legitimately takes
+	// hours, so async calls of this step wait with the template timeout.
+	client.AsyncTimeout(int64(config.TemplateTimeout.Seconds()))
+	defer client.AsyncTimeout(int64(config.AsyncTimeout.Seconds()))
+
 	// Retrieve the instance ID from the previously saved state.
 	instanceID, ok := state.Get("instance_id").(string)
 	if !ok || instanceID == "" {
@@ -38,9 +43,14 @@ func (s *stepCreateTemplate) Run(ctx context.Context, state multistep.StateBag)
 	// Configure the template according to the supplied config.
 	p.SetIsfeatured(config.TemplateFeatured)
 	p.SetIspublic(config.TemplatePublic)
-	p.SetIsdynamicallyscalable(config.TemplateScalable)
+	p.SetIsdynamicallyscalable(config.TemplateScalable || config.TemplateDynamicallyScalable)
 	p.SetPasswordenabled(config.TemplatePasswordEnabled)
 	p.SetRequireshvm(config.TemplateRequiresHVM)
+	p.SetSshkeyenabled(config.TemplateSSHKeyEnabled)
+
+	if len(config.TemplateDetails) > 0 {
+		p.SetDetails(config.TemplateDetails)
+	}
 
 	if config.Project != "" {
 		p.SetProjectid(config.Project)
@@ -78,6 +88,21 @@ func (s *stepCreateTemplate) Run(ctx context.Context, state multistep.StateBag)
 
 	ui.Message("Template has been created!")
 
+	// Set template tags
+	if len(config.TemplateTags) > 0 {
+		resourceID := []string{template.Id}
+		tp := client.Resourcetags.NewCreateTagsParams(resourceID, "Template", config.TemplateTags)
+
+		_, err = client.Resourcetags.CreateTags(tp)
+
+		if err != nil {
+			err := fmt.Errorf("Error tagging the new template %s: %s", config.TemplateName, err)
+			state.Put("error", err)
+			ui.Error(err.Error())
+			return multistep.ActionHalt
+		}
+	}
+
 	// Store the template.
 	state.Put("template", template)
 
@@ -0,0 +1,76 @@
+package cloudstack
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/packer/helper/multistep"
+	"github.com/hashicorp/packer/packer"
+	"github.com/xanzy/go-cloudstack/cloudstack"
+)
+
+type stepForceDeleteTemplates struct{}
+
+// Run deletes any existing templates carrying the configured template name
+// from the build zone and every zone the template will be copied to, so the
+// creation and copies that follow do not fail with duplicate name errors.
+func (s *stepForceDeleteTemplates) Run(ctx context.Context, state multistep.StateBag) multistep.StepAction {
+	client := state.Get("client").(*cloudstack.CloudStackClient)
+	config := state.Get("config").(*Config)
+	ui := state.Get("ui").(packer.Ui)
+
+	if !config.TemplateForceDelete {
+		return multistep.ActionContinue
+	}
+
+	zones := append([]string{config.Zone}, config.TemplateZones...)
+	for _, zone := range zones {
+		p := client.Template.NewListTemplatesParams("self")
+		p.SetName(config.TemplateName)
+		p.SetZoneid(zone)
+		if config.Project != "" {
+			p.SetProjectid(config.Project)
+		}
+
+		templates, err := client.Template.ListTemplates(p)
+		if err != nil {
+			err := fmt.Errorf("Error listing templates in zone %s: %s", zone, err)
+			state.Put("error", err)
+			ui.Error(err.Error())
+			return multistep.ActionHalt
+		}
+
+		for _, template := range templates.Templates {
+			// The name parameter is a keyword filter, so only delete exact
+			// matches.
+			if template.Name != config.TemplateName {
+				continue
+			}
+
+			ui.Say(fmt.Sprintf(
+				"Deleting existing template %s (%s) in zone %s...",
+				template.Name, template.Id, zone))
+
+			dp := client.Template.NewDeleteTemplateParams(template.Id)
+			dp.SetZoneid(zone)
+			if config.Expunge {
+				dp.SetForced(true)
+			}
+
+			if _, err := client.Template.DeleteTemplate(dp); err != nil {
+				err := fmt.Errorf(
+					"Error deleting template %s in zone %s: %s", template.Id, zone, err)
+				state.Put("error", err)
+				ui.Error(err.Error())
+				return multistep.ActionHalt
+			}
+		}
+	}
+
+	return multistep.ActionContinue
+}
+
+// Cleanup any resources that may have been created during the Run phase.
+func (s *stepForceDeleteTemplates) Cleanup(state multistep.StateBag) {
+	// Nothing to cleanup for this step.
+}
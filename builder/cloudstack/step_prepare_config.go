@@ -46,6 +46,15 @@ func (s *stepPrepareConfig) Run(ctx context.Context, state multistep.StateBag) m
 		}
 	}
 
+	for i := range config.TemplateZones {
+		if !isUUID(config.TemplateZones[i]) {
+			config.TemplateZones[i], _, err = client.Zone.GetZoneID(config.TemplateZones[i])
+			if err != nil {
+				errs = packer.MultiErrorAppend(errs, &retrieveErr{"template zone", config.TemplateZones[i], err})
+			}
+		}
+	}
+
 	// Then try to get the remaining UUID's.
 	if config.DiskOffering != "" && !isUUID(config.DiskOffering) {
 		config.DiskOffering, _, err = client.DiskOffering.GetDiskOfferingID(config.DiskOffering)
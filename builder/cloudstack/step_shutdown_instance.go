@@ -18,6 +18,11 @@ func (s *stepShutdownInstance) Run(ctx context.Context, state multistep.StateBag
 
 	ui.Say("Shutting down instance...")
 
+	// Async calls of this step wait with the snapshot timeout, as this is
+	// where the instance settles down before the template is created.
+	client.AsyncTimeout(int64(config.SnapshotTimeout.Seconds()))
+	defer client.AsyncTimeout(int64(config.AsyncTimeout.Seconds()))
+
 	// Retrieve the instance ID from the previously saved state.
 	instanceID, ok := state.Get("instance_id").(string)
 	if !ok || instanceID == "" {
@@ -33,7 +33,7 @@ func (b *Builder) Prepare(raws ...interface{}) ([]string, []string, error) {
 }
 
 func (b *Builder) Run(ctx context.Context, ui packer.Ui, hook packer.Hook) (packer.Artifact, error) {
-	driver := &DockerDriver{Ctx: &b.config.ctx, Ui: ui}
+	driver := &DockerDriver{Ctx: &b.config.ctx, Ui: ui, Host: b.config.DockerHost}
 	if err := driver.Verify(); err != nil {
 		return nil, err
 	}
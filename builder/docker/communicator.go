@@ -31,6 +31,17 @@ type Communicator struct {
 
 var _ packer.Communicator = new(Communicator)
 
+// dockerCmd returns an *exec.Cmd for the docker CLI, pointed at the
+// configured Docker host (if any) via the DOCKER_HOST environment
+// variable.
+func (c *Communicator) dockerCmd(args ...string) *exec.Cmd {
+	cmd := exec.Command("docker", args...)
+	if c.Config.DockerHost != "" {
+		cmd.Env = append(os.Environ(), "DOCKER_HOST="+c.Config.DockerHost)
+	}
+	return cmd
+}
+
 func (c *Communicator) Start(ctx context.Context, remote *packer.RemoteCmd) error {
 	dockerArgs := []string{
 		"exec",
@@ -49,7 +60,7 @@ func (c *Communicator) Start(ctx context.Context, remote *packer.RemoteCmd) erro
 			append([]string{"-u", c.Config.ExecUser}, dockerArgs[2:]...)...)
 	}
 
-	cmd := exec.Command("docker", dockerArgs...)
+	cmd := c.dockerCmd(dockerArgs...)
 
 	var (
 		stdin_w io.WriteCloser
@@ -111,7 +122,7 @@ func (c *Communicator) uploadFile(dst string, src io.Reader, fi *os.FileInfo) er
 	// command format: docker cp /path/to/infile containerid:/path/to/outfile
 	log.Printf("Copying to %s on container %s.", dst, c.ContainerID)
 
-	localCmd := exec.Command("docker", "cp", "-",
+	localCmd := c.dockerCmd("cp", "-",
 		fmt.Sprintf("%s:%s", c.ContainerID, filepath.Dir(dst)))
 
 	stderrP, err := localCmd.StderrPipe()
@@ -192,7 +203,7 @@ func (c *Communicator) UploadDir(dst string, src string, exclude []string) error
 	}
 
 	// Make the directory, then copy into it
-	localCmd := exec.Command("docker", "cp", dockerSource, fmt.Sprintf("%s:%s", c.ContainerID, dst))
+	localCmd := c.dockerCmd("cp", dockerSource, fmt.Sprintf("%s:%s", c.ContainerID, dst))
 
 	stderrP, err := localCmd.StderrPipe()
 	if err != nil {
@@ -223,7 +234,7 @@ func (c *Communicator) UploadDir(dst string, src string, exclude []string) error
 // cp to write to stdout, and then copy the stream to our destination io.Writer.
 func (c *Communicator) Download(src string, dst io.Writer) error {
 	log.Printf("Downloading file from container: %s:%s", c.ContainerID, src)
-	localCmd := exec.Command("docker", "cp", fmt.Sprintf("%s:%s", c.ContainerID, src), "-")
+	localCmd := c.dockerCmd("cp", fmt.Sprintf("%s:%s", c.ContainerID, src), "-")
 
 	pipe, err := localCmd.StdoutPipe()
 	if err != nil {
@@ -43,6 +43,12 @@ type Config struct {
 	// the [artifice
 	// post-processor](/docs/post-processors/artifice).
 	Discard bool `mapstructure:"discard" required:"true"`
+	// The Docker daemon to connect to, for example `ssh://user@host` for a
+	// remote or rootless daemon reachable over SSH, or `tcp://127.0.0.1:2376`.
+	// This is passed to every `docker` command the builder runs (including
+	// export) as the `DOCKER_HOST` environment variable. When unset, the
+	// Docker CLI's own configuration applies.
+	DockerHost string `mapstructure:"docker_host" required:"false"`
 	// Username (UID) to run remote commands with. You can also set the group
 	// name/ID if you want: (UID or UID:GID). You may need this if you get
 	// permission errors trying to run the shell or other provisioners.
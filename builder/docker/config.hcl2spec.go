@@ -63,6 +63,7 @@ type FlatConfig struct {
 	Commit                    *bool             `mapstructure:"commit" required:"true" cty:"commit"`
 	ContainerDir              *string           `mapstructure:"container_dir" required:"false" cty:"container_dir"`
 	Discard                   *bool             `mapstructure:"discard" required:"true" cty:"discard"`
+	DockerHost                *string           `mapstructure:"docker_host" required:"false" cty:"docker_host"`
 	ExecUser                  *string           `mapstructure:"exec_user" required:"false" cty:"exec_user"`
 	ExportPath                *string           `mapstructure:"export_path" required:"true" cty:"export_path"`
 	Image                     *string           `mapstructure:"image" required:"true" cty:"image"`
@@ -151,6 +152,7 @@ func (*FlatConfig) HCL2Spec() map[string]hcldec.Spec {
 		"commit":                       &hcldec.AttrSpec{Name: "commit", Type: cty.Bool, Required: false},
 		"container_dir":                &hcldec.AttrSpec{Name: "container_dir", Type: cty.String, Required: false},
 		"discard":                      &hcldec.AttrSpec{Name: "discard", Type: cty.Bool, Required: false},
+		"docker_host":                  &hcldec.AttrSpec{Name: "docker_host", Type: cty.String, Required: false},
 		"exec_user":                    &hcldec.AttrSpec{Name: "exec_user", Type: cty.String, Required: false},
 		"export_path":                  &hcldec.AttrSpec{Name: "export_path", Type: cty.String, Required: false},
 		"image":                        &hcldec.AttrSpec{Name: "image", Type: cty.String, Required: false},
@@ -20,12 +20,28 @@ type DockerDriver struct {
 	Ui  packer.Ui
 	Ctx *interpolate.Context
 
+	// The Docker daemon to connect to, as accepted by the DOCKER_HOST
+	// environment variable (e.g. ssh://user@host). Empty means the
+	// Docker CLI's default.
+	Host string
+
 	l sync.Mutex
 }
 
+// newCommand returns an *exec.Cmd for the docker CLI, pointed at the
+// configured Docker host (if any) via the DOCKER_HOST environment
+// variable.
+func (d *DockerDriver) newCommand(args ...string) *exec.Cmd {
+	cmd := exec.Command("docker", args...)
+	if d.Host != "" {
+		cmd.Env = append(os.Environ(), "DOCKER_HOST="+d.Host)
+	}
+	return cmd
+}
+
 func (d *DockerDriver) DeleteImage(id string) error {
 	var stderr bytes.Buffer
-	cmd := exec.Command("docker", "rmi", id)
+	cmd := d.newCommand("rmi", id)
 	cmd.Stderr = &stderr
 
 	log.Printf("Deleting image: %s", id)
@@ -59,7 +75,7 @@ func (d *DockerDriver) Commit(id string, author string, changes []string, messag
 	args = append(args, id)
 
 	log.Printf("Committing container with args: %v", args)
-	cmd := exec.Command("docker", args...)
+	cmd := d.newCommand(args...)
 	cmd.Stdout = &stdout
 	cmd.Stderr = &stderr
 
@@ -78,7 +94,7 @@ func (d *DockerDriver) Commit(id string, author string, changes []string, messag
 
 func (d *DockerDriver) Export(id string, dst io.Writer) error {
 	var stderr bytes.Buffer
-	cmd := exec.Command("docker", "export", id)
+	cmd := d.newCommand("export", id)
 	cmd.Stdout = dst
 	cmd.Stderr = &stderr
 
@@ -108,7 +124,7 @@ func (d *DockerDriver) Import(path string, changes []string, repo string) (strin
 	args = append(args, "-")
 	args = append(args, repo)
 
-	cmd := exec.Command("docker", args...)
+	cmd := d.newCommand(args...)
 	cmd.Stdout = &stdout
 	cmd.Stderr = &stderr
 	stdin, err := cmd.StdinPipe()
@@ -144,8 +160,7 @@ func (d *DockerDriver) Import(path string, changes []string, repo string) (strin
 
 func (d *DockerDriver) IPAddress(id string) (string, error) {
 	var stderr, stdout bytes.Buffer
-	cmd := exec.Command(
-		"docker",
+	cmd := d.newCommand(
 		"inspect",
 		"--format",
 		"{{ .NetworkSettings.IPAddress }}",
@@ -178,8 +193,7 @@ func (d *DockerDriver) Login(repo, user, pass string) error {
 		return err
 	}
 
-	cmd := exec.Command("docker")
-	cmd.Args = append(cmd.Args, "login")
+	cmd := d.newCommand("login")
 
 	if user != "" {
 		cmd.Args = append(cmd.Args, "-u", user)
@@ -220,25 +234,25 @@ func (d *DockerDriver) Logout(repo string) error {
 		args = append(args, repo)
 	}
 
-	cmd := exec.Command("docker", args...)
+	cmd := d.newCommand(args...)
 	err := runAndStream(cmd, d.Ui)
 	d.l.Unlock()
 	return err
 }
 
 func (d *DockerDriver) Pull(image string) error {
-	cmd := exec.Command("docker", "pull", image)
+	cmd := d.newCommand("pull", image)
 	return runAndStream(cmd, d.Ui)
 }
 
 func (d *DockerDriver) Push(name string) error {
-	cmd := exec.Command("docker", "push", name)
+	cmd := d.newCommand("push", name)
 	return runAndStream(cmd, d.Ui)
 }
 
 func (d *DockerDriver) SaveImage(id string, dst io.Writer) error {
 	var stderr bytes.Buffer
-	cmd := exec.Command("docker", "save", id)
+	cmd := d.newCommand("save", id)
 	cmd.Stdout = dst
 	cmd.Stderr = &stderr
 
@@ -284,7 +298,7 @@ func (d *DockerDriver) StartContainer(config *ContainerConfig) (string, error) {
 
 	// Start the container
 	var stdout, stderr bytes.Buffer
-	cmd := exec.Command("docker", args...)
+	cmd := d.newCommand(args...)
 	cmd.Stdout = &stdout
 	cmd.Stderr = &stderr
 
@@ -308,18 +322,18 @@ func (d *DockerDriver) StartContainer(config *ContainerConfig) (string, error) {
 }
 
 func (d *DockerDriver) StopContainer(id string) error {
-	if err := exec.Command("docker", "stop", id).Run(); err != nil {
+	if err := d.newCommand("stop", id).Run(); err != nil {
 		return err
 	}
 	return nil
 }
 
 func (d *DockerDriver) KillContainer(id string) error {
-	if err := exec.Command("docker", "kill", id).Run(); err != nil {
+	if err := d.newCommand("kill", id).Run(); err != nil {
 		return err
 	}
 
-	return exec.Command("docker", "rm", id).Run()
+	return d.newCommand("rm", id).Run()
 }
 
 func (d *DockerDriver) TagImage(id string, repo string, force bool) error {
@@ -356,7 +370,7 @@ func (d *DockerDriver) TagImage(id string, repo string, force bool) error {
 	args = append(args, id, repo)
 
 	var stderr bytes.Buffer
-	cmd := exec.Command("docker", args...)
+	cmd := d.newCommand(args...)
 	cmd.Stderr = &stderr
 
 	if err := cmd.Start(); err != nil {
@@ -381,7 +395,7 @@ func (d *DockerDriver) Verify() error {
 }
 
 func (d *DockerDriver) Version() (*version.Version, error) {
-	output, err := exec.Command("docker", "-v").Output()
+	output, err := d.newCommand("-v").Output()
 	if err != nil {
 		return nil, err
 	}
@@ -1,7 +1,36 @@
 package docker
 
-import "testing"
+import (
+	"strings"
+	"testing"
+)
 
 func TestDockerDriver_impl(t *testing.T) {
 	var _ Driver = new(DockerDriver)
 }
+
+func TestDockerDriver_newCommand(t *testing.T) {
+	d := &DockerDriver{}
+	cmd := d.newCommand("version")
+	if !strings.HasSuffix(cmd.Path, "docker") {
+		t.Fatalf("bad command path: %s", cmd.Path)
+	}
+	if len(cmd.Args) != 2 || cmd.Args[1] != "version" {
+		t.Fatalf("bad command args: %v", cmd.Args)
+	}
+	if cmd.Env != nil {
+		t.Fatalf("env should not be set without a docker host: %v", cmd.Env)
+	}
+
+	d = &DockerDriver{Host: "ssh://user@host"}
+	cmd = d.newCommand("version")
+	found := false
+	for _, env := range cmd.Env {
+		if env == "DOCKER_HOST=ssh://user@host" {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("DOCKER_HOST should be set in env: %v", cmd.Env)
+	}
+}
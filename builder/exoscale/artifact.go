@@ -0,0 +1,44 @@
+package exoscale
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/exoscale/egoscale"
+)
+
+type Artifact struct {
+	template egoscale.Template
+	exo      *egoscale.Client
+
+	// StateData should store data such as GeneratedData
+	// to be shared with post-processors
+	StateData map[string]interface{}
+}
+
+func (a *Artifact) BuilderId() string {
+	return BuilderId
+}
+
+func (a *Artifact) Files() []string {
+	return nil
+}
+
+func (a *Artifact) Id() string {
+	return a.template.ID.String()
+}
+
+func (a *Artifact) String() string {
+	return fmt.Sprintf("%s @ %s (%s)", a.template.Name, a.template.ZoneName, a.template.ID)
+}
+
+func (a *Artifact) State(name string) interface{} {
+	return a.StateData[name]
+}
+
+func (a *Artifact) Destroy() error {
+	_, err := a.exo.RequestWithContext(context.Background(), &egoscale.DeleteTemplate{
+		ID: a.template.ID,
+	})
+	return err
+}
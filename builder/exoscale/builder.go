@@ -0,0 +1,81 @@
+package exoscale
+
+import (
+	"context"
+
+	"github.com/exoscale/egoscale"
+	"github.com/hashicorp/hcl/v2/hcldec"
+	"github.com/hashicorp/packer/common"
+	"github.com/hashicorp/packer/helper/communicator"
+	"github.com/hashicorp/packer/helper/multistep"
+	"github.com/hashicorp/packer/packer"
+)
+
+const BuilderId = "packer.builder.exoscale"
+
+type Builder struct {
+	config Config
+	runner multistep.Runner
+}
+
+func (b *Builder) ConfigSpec() hcldec.ObjectSpec { return b.config.FlatMapstructure().HCL2Spec() }
+
+func (b *Builder) Prepare(raws ...interface{}) ([]string, []string, error) {
+	if err := b.config.Prepare(raws...); err != nil {
+		return nil, nil, err
+	}
+
+	return nil, nil, nil
+}
+
+func (b *Builder) Run(ctx context.Context, ui packer.Ui, hook packer.Hook) (packer.Artifact, error) {
+	exo := egoscale.NewClient(b.config.APIEndpoint, b.config.APIKey, b.config.APISecret)
+	exo.Timeout = b.config.APITimeout
+	exo.RetryStrategy = egoscale.FibonacciRetryStrategy
+
+	state := new(multistep.BasicStateBag)
+	state.Put("config", &b.config)
+	state.Put("exo", exo)
+	state.Put("hook", hook)
+	state.Put("ui", ui)
+
+	steps := []multistep.Step{
+		&stepCreateSSHKey{},
+		&stepCreateInstance{},
+		&communicator.StepConnect{
+			Config:    &b.config.Comm,
+			Host:      commHost,
+			SSHConfig: b.config.Comm.SSHConfigFunc(),
+		},
+		&common.StepProvision{},
+		&common.StepCleanupTempKeys{
+			Comm: &b.config.Comm,
+		},
+		&stepStopInstance{},
+		&stepSnapshotRootVolume{},
+		&stepExportSnapshot{},
+		&stepRegisterTemplate{},
+	}
+
+	b.runner = common.NewRunnerWithPauseFn(steps, b.config.PackerConfig, ui, state)
+	b.runner.Run(ctx, state)
+
+	if rawErr, ok := state.GetOk("error"); ok {
+		return nil, rawErr.(error)
+	}
+
+	template, ok := state.GetOk("template")
+	if !ok {
+		return nil, nil
+	}
+
+	artifact := &Artifact{
+		template: template.(egoscale.Template),
+		exo:      exo,
+		StateData: map[string]interface{}{
+			"generated_data": state.Get("generated_data"),
+		},
+	}
+
+	return artifact, nil
+}
@@ -0,0 +1,168 @@
+//go:generate struct-markdown
+//go:generate mapstructure-to-hcl2 -type Config
+
+package exoscale
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/exoscale/egoscale"
+	"github.com/hashicorp/packer/common"
+	"github.com/hashicorp/packer/helper/communicator"
+	"github.com/hashicorp/packer/helper/config"
+	"github.com/hashicorp/packer/packer"
+	"github.com/hashicorp/packer/template/interpolate"
+	"github.com/hashicorp/packer/version"
+)
+
+var (
+	defaultAPIEndpoint    = "https://api.exoscale.com/compute"
+	defaultZone           = "ch-gva-2"
+	defaultInstanceType   = "Medium"
+	defaultDiskSize       = int64(50)
+	defaultTemplateFilter = "featured"
+)
+
+func init() {
+	egoscale.UserAgent = "Packer-Exoscale/" + version.FormattedVersion() + " " + egoscale.UserAgent
+}
+
+type Config struct {
+	common.PackerConfig `mapstructure:",squash"`
+
+	// The API endpoint used to perform Exoscale API calls. Defaults to
+	// https://api.exoscale.com/compute.
+	APIEndpoint string `mapstructure:"api_endpoint" required:"false"`
+	// The Exoscale API key. If omitted it defaults to the EXOSCALE_API_KEY
+	// environment variable.
+	APIKey string `mapstructure:"api_key" required:"true"`
+	// The Exoscale API secret. If omitted it defaults to the
+	// EXOSCALE_API_SECRET environment variable.
+	APISecret string `mapstructure:"api_secret" required:"true"`
+	// The timeout for asynchronous Exoscale API calls such as the root
+	// disk snapshot and the template registration. Defaults to 30m.
+	APITimeout time.Duration `mapstructure:"api_timeout" required:"false"`
+	// The Exoscale zone to build in and register the resulting template
+	// to, e.g. de-fra-1. Defaults to ch-gva-2.
+	Zone string `mapstructure:"zone" required:"false"`
+	// The Exoscale instance type (service offering) of the compute
+	// instance used for building, e.g. Large. Defaults to Medium.
+	InstanceType string `mapstructure:"instance_type" required:"false"`
+	// The name of the compute instance used for building. Defaults to
+	// packer-[build_name].
+	InstanceName string `mapstructure:"instance_name" required:"false"`
+	// The root disk size of the compute instance in GB, which is also the
+	// disk size of the resulting template. Defaults to 50.
+	DiskSize int64 `mapstructure:"disk_size" required:"false"`
+	// The names of anti-affinity groups to place the compute instance
+	// into. Defaults to empty.
+	AntiAffinityGroups []string `mapstructure:"anti_affinity_groups" required:"false"`
+	// The names of security groups applied to the compute instance. The
+	// rules must allow the communicator (usually SSH) to reach the
+	// instance. Defaults to ["default"].
+	SecurityGroups []string `mapstructure:"security_groups" required:"false"`
+	// The name or ID of the template to base the compute instance on, for
+	// example Linux Ubuntu 20.04 LTS 64-bit.
+	SourceTemplate string `mapstructure:"source_template" required:"true"`
+	// The template filter used when looking up source_template by name:
+	// featured for Exoscale-provided templates or self for custom
+	// templates previously registered in the organization. Defaults to
+	// featured.
+	SourceTemplateFilter string `mapstructure:"source_template_filter" required:"false"`
+	// The name of the resulting template.
+	TemplateName string `mapstructure:"template_name" required:"true"`
+	// The description (display text) of the resulting template.
+	TemplateDescription string `mapstructure:"template_description" required:"false"`
+	// The default username of the resulting template, stored in the
+	// template details so instance users know which account to log into.
+	// If ssh_username is not set, it is also used for provisioning.
+	TemplateUsername string `mapstructure:"template_username" required:"false"`
+	// Disable the password reset feature on the resulting template.
+	// Defaults to false.
+	TemplateDisablePassword bool `mapstructure:"template_disable_password" required:"false"`
+	// Disable SSH key installation on the resulting template. Defaults to
+	// false.
+	TemplateDisableSSHKey bool `mapstructure:"template_disable_sshkey" required:"false"`
+
+	Comm communicator.Config `mapstructure:",squash"`
+
+	ctx interpolate.Context
+}
+
+func (c *Config) Prepare(raws ...interface{}) error {
+	c.APIEndpoint = defaultAPIEndpoint
+	c.Zone = defaultZone
+	c.InstanceType = defaultInstanceType
+	c.DiskSize = defaultDiskSize
+	c.SourceTemplateFilter = defaultTemplateFilter
+
+	err := config.Decode(c, &config.DecodeOpts{
+		Interpolate:        true,
+		InterpolateContext: &c.ctx,
+	}, raws...)
+	if err != nil {
+		return err
+	}
+
+	if c.APIKey == "" {
+		c.APIKey = os.Getenv("EXOSCALE_API_KEY")
+	}
+
+	if c.APISecret == "" {
+		c.APISecret = os.Getenv("EXOSCALE_API_SECRET")
+	}
+
+	if c.APITimeout == 0 {
+		c.APITimeout = 30 * time.Minute
+	}
+
+	if c.InstanceName == "" {
+		c.InstanceName = fmt.Sprintf("packer-%s", c.PackerBuildName)
+	}
+
+	if len(c.SecurityGroups) == 0 {
+		c.SecurityGroups = []string{"default"}
+	}
+
+	// Logging in as the template's default user is the most common case,
+	// so use it for provisioning unless told otherwise.
+	if c.Comm.SSHUsername == "" {
+		c.Comm.SSHUsername = c.TemplateUsername
+	}
+
+	requiredArgs := map[string]*string{
+		"api_key":         &c.APIKey,
+		"api_secret":      &c.APISecret,
+		"source_template": &c.SourceTemplate,
+		"template_name":   &c.TemplateName,
+	}
+
+	errs := new(packer.MultiError)
+	for k, v := range requiredArgs {
+		if *v == "" {
+			errs = packer.MultiErrorAppend(
+				errs, fmt.Errorf("%s must be set", k))
+		}
+	}
+
+	switch c.SourceTemplateFilter {
+	case "featured", "self":
+	default:
+		errs = packer.MultiErrorAppend(errs, fmt.Errorf(
+			"source_template_filter must be either featured or self"))
+	}
+
+	if es := c.Comm.Prepare(&c.ctx); len(es) > 0 {
+		errs = packer.MultiErrorAppend(errs, es...)
+	}
+
+	if len(errs.Errors) > 0 {
+		return errs
+	}
+
+	packer.LogSecretFilter.Set(c.APIKey, c.APISecret)
+
+	return nil
+}
@@ -0,0 +1,120 @@
+package exoscale
+
+import (
+	"testing"
+	"time"
+)
+
+func testConfig(config map[string]interface{}) map[string]interface{} {
+	raw := map[string]interface{}{
+		"api_key":         "EXOabcdef0123456789abcdef01",
+		"api_secret":      "some-api-secret",
+		"source_template": "Linux Ubuntu 20.04 LTS 64-bit",
+		"template_name":   "my-application",
+		"ssh_username":    "ubuntu",
+	}
+
+	for k, v := range config {
+		raw[k] = v
+	}
+
+	return raw
+}
+
+func TestConfigPrepare(t *testing.T) {
+	cases := map[string]struct {
+		Config  map[string]interface{}
+		Nullify string
+		Err     bool
+	}{
+		"good": {},
+		"no_api_key": {
+			Nullify: "api_key",
+			Err:     true,
+		},
+		"no_api_secret": {
+			Nullify: "api_secret",
+			Err:     true,
+		},
+		"no_source_template": {
+			Nullify: "source_template",
+			Err:     true,
+		},
+		"no_template_name": {
+			Nullify: "template_name",
+			Err:     true,
+		},
+		"bad_source_template_filter": {
+			Config: map[string]interface{}{
+				"source_template_filter": "community",
+			},
+			Err: true,
+		},
+		"self_source_template_filter": {
+			Config: map[string]interface{}{
+				"source_template_filter": "self",
+			},
+		},
+	}
+
+	for desc, tc := range cases {
+		raw := testConfig(tc.Config)
+
+		if tc.Nullify != "" {
+			raw[tc.Nullify] = ""
+		}
+
+		var c Config
+		err := c.Prepare(raw)
+
+		if tc.Err {
+			if err == nil {
+				t.Fatalf("%q should error", desc)
+			}
+		} else {
+			if err != nil {
+				t.Fatalf("%q should not error: %s", desc, err)
+			}
+		}
+	}
+}
+
+func TestConfigPrepare_defaults(t *testing.T) {
+	var c Config
+	if err := c.Prepare(testConfig(nil)); err != nil {
+		t.Fatalf("should not error: %s", err)
+	}
+
+	if c.APIEndpoint != defaultAPIEndpoint {
+		t.Errorf("bad api_endpoint: %s", c.APIEndpoint)
+	}
+	if c.Zone != defaultZone {
+		t.Errorf("bad zone: %s", c.Zone)
+	}
+	if c.InstanceType != defaultInstanceType {
+		t.Errorf("bad instance_type: %s", c.InstanceType)
+	}
+	if c.DiskSize != defaultDiskSize {
+		t.Errorf("bad disk_size: %d", c.DiskSize)
+	}
+	if c.APITimeout != 30*time.Minute {
+		t.Errorf("bad api_timeout: %s", c.APITimeout)
+	}
+}
+
+func TestConfigPrepare_templateUsername(t *testing.T) {
+	// The template's default username doubles as the SSH username when
+	// none is given.
+	raw := testConfig(map[string]interface{}{
+		"template_username": "admin",
+	})
+	raw["ssh_username"] = ""
+
+	var c Config
+	if err := c.Prepare(raw); err != nil {
+		t.Fatalf("should not error: %s", err)
+	}
+	if c.Comm.SSHUsername != "admin" {
+		t.Errorf("bad ssh_username: %s", c.Comm.SSHUsername)
+	}
+}
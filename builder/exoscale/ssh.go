@@ -0,0 +1,16 @@
+package exoscale
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/packer/helper/multistep"
+)
+
+func commHost(state multistep.StateBag) (string, error) {
+	ip, hasIP := state.Get("instance_ip").(string)
+	if !hasIP {
+		return "", fmt.Errorf("Failed to retrieve the instance IP address")
+	}
+
+	return ip, nil
+}
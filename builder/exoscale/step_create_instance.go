@@ -0,0 +1,143 @@
+package exoscale
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/exoscale/egoscale"
+	"github.com/hashicorp/packer/helper/multistep"
+	"github.com/hashicorp/packer/packer"
+)
+
+// stepCreateInstance deploys the compute instance the template is built
+// from and waits for it to become running.
+type stepCreateInstance struct{}
+
+func (s *stepCreateInstance) Run(ctx context.Context, state multistep.StateBag) multistep.StepAction {
+	config := state.Get("config").(*Config)
+	exo := state.Get("exo").(*egoscale.Client)
+	ui := state.Get("ui").(packer.Ui)
+
+	zone, err := s.lookupZone(ctx, exo, config.Zone)
+	if err != nil {
+		state.Put("error", err)
+		ui.Error(err.Error())
+		return multistep.ActionHalt
+	}
+
+	serviceOffering, err := s.lookupServiceOffering(ctx, exo, config.InstanceType)
+	if err != nil {
+		state.Put("error", err)
+		ui.Error(err.Error())
+		return multistep.ActionHalt
+	}
+
+	template, err := s.lookupTemplate(ctx, exo, config, zone)
+	if err != nil {
+		state.Put("error", err)
+		ui.Error(err.Error())
+		return multistep.ActionHalt
+	}
+
+	ui.Say(fmt.Sprintf("Creating instance: %s", config.InstanceName))
+
+	req := &egoscale.DeployVirtualMachine{
+		Name:               config.InstanceName,
+		ZoneID:             zone.ID,
+		ServiceOfferingID:  serviceOffering.ID,
+		TemplateID:         template.ID,
+		RootDiskSize:       config.DiskSize,
+		AffinityGroupNames: config.AntiAffinityGroups,
+		SecurityGroupNames: config.SecurityGroups,
+	}
+
+	if name, ok := state.Get("ssh_key_name").(string); ok && name != "" {
+		req.KeyPair = name
+	} else if config.Comm.SSHKeyPairName != "" {
+		req.KeyPair = config.Comm.SSHKeyPairName
+	}
+
+	resp, err := exo.RequestWithContext(ctx, req)
+	if err != nil {
+		state.Put("error", fmt.Errorf("Error creating instance %s: %s", config.InstanceName, err))
+		return multistep.ActionHalt
+	}
+	vm := resp.(*egoscale.VirtualMachine)
+
+	ui.Message(fmt.Sprintf("Instance ID: %s", vm.ID))
+
+	state.Put("instance", vm)
+	// instance_id is the generic term used so that users can have access
+	// to the instance id inside of the provisioners, used in step_provision.
+	state.Put("instance_id", vm.ID.String())
+
+	if ip := vm.IP(); ip != nil {
+		state.Put("instance_ip", ip.String())
+	}
+
+	// Set the auto generated password if a password was not explicitly
+	// configured.
+	if config.Comm.Type == "ssh" && config.Comm.SSHPassword == "" {
+		config.Comm.SSHPassword = vm.Password
+	}
+
+	return multistep.ActionContinue
+}
+
+func (s *stepCreateInstance) Cleanup(state multistep.StateBag) {
+	exo := state.Get("exo").(*egoscale.Client)
+	ui := state.Get("ui").(packer.Ui)
+
+	vm, ok := state.Get("instance").(*egoscale.VirtualMachine)
+	if !ok {
+		return
+	}
+
+	ui.Say("Deleting instance...")
+	_, err := exo.RequestWithContext(context.Background(), &egoscale.DestroyVirtualMachine{
+		ID: vm.ID,
+	})
+	if err != nil {
+		ui.Error(fmt.Sprintf(
+			"Error destroying instance %s. Please destroy it manually: %s", vm.ID, err))
+	}
+}
+
+func (s *stepCreateInstance) lookupZone(ctx context.Context, exo *egoscale.Client, name string) (*egoscale.Zone, error) {
+	resp, err := exo.GetWithContext(ctx, &egoscale.Zone{Name: name})
+	if err != nil {
+		return nil, fmt.Errorf("Zone %s lookup failed: %s", name, err)
+	}
+
+	return resp.(*egoscale.Zone), nil
+}
+
+func (s *stepCreateInstance) lookupServiceOffering(ctx context.Context, exo *egoscale.Client, name string) (*egoscale.ServiceOffering, error) {
+	resp, err := exo.GetWithContext(ctx, &egoscale.ServiceOffering{Name: name})
+	if err != nil {
+		return nil, fmt.Errorf("Instance type %s lookup failed: %s", name, err)
+	}
+
+	return resp.(*egoscale.ServiceOffering), nil
+}
+
+func (s *stepCreateInstance) lookupTemplate(ctx context.Context, exo *egoscale.Client, config *Config, zone *egoscale.Zone) (*egoscale.Template, error) {
+	template := &egoscale.Template{
+		ZoneID:     zone.ID,
+		IsFeatured: config.SourceTemplateFilter == "featured",
+	}
+
+	// The source template may be referenced either by ID or by name.
+	if id, err := egoscale.ParseUUID(config.SourceTemplate); err == nil {
+		template.ID = id
+	} else {
+		template.Name = config.SourceTemplate
+	}
+
+	resp, err := exo.GetWithContext(ctx, template)
+	if err != nil {
+		return nil, fmt.Errorf("Template %s lookup failed: %s", config.SourceTemplate, err)
+	}
+
+	return resp.(*egoscale.Template), nil
+}
@@ -0,0 +1,64 @@
+package exoscale
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/exoscale/egoscale"
+	"github.com/hashicorp/packer/common/uuid"
+	"github.com/hashicorp/packer/helper/multistep"
+	"github.com/hashicorp/packer/packer"
+)
+
+// stepCreateSSHKey creates a throwaway SSH key pair through the Exoscale
+// API for provisioning, unless the communicator is already set up with a
+// key or a password.
+type stepCreateSSHKey struct{}
+
+func (s *stepCreateSSHKey) Run(ctx context.Context, state multistep.StateBag) multistep.StepAction {
+	config := state.Get("config").(*Config)
+	exo := state.Get("exo").(*egoscale.Client)
+	ui := state.Get("ui").(packer.Ui)
+
+	if config.Comm.Type != "ssh" ||
+		config.Comm.SSHPrivateKeyFile != "" ||
+		config.Comm.SSHAgentAuth ||
+		config.Comm.SSHPassword != "" {
+		return multistep.ActionContinue
+	}
+
+	name := fmt.Sprintf("packer-%s", uuid.TimeOrderedUUID())
+	ui.Say(fmt.Sprintf("Creating temporary SSH key pair: %s", name))
+
+	resp, err := exo.RequestWithContext(ctx, &egoscale.CreateSSHKeyPair{Name: name})
+	if err != nil {
+		state.Put("error", fmt.Errorf("Problem creating SSH key pair: %s", err))
+		return multistep.ActionHalt
+	}
+
+	config.Comm.SSHKeyPairName = name
+	config.Comm.SSHTemporaryKeyPairName = name
+	config.Comm.SSHPrivateKey = []byte(resp.(*egoscale.SSHKeyPair).PrivateKey)
+	state.Put("ssh_key_name", name)
+
+	return multistep.ActionContinue
+}
+
+func (s *stepCreateSSHKey) Cleanup(state multistep.StateBag) {
+	exo := state.Get("exo").(*egoscale.Client)
+	ui := state.Get("ui").(packer.Ui)
+
+	name, ok := state.Get("ssh_key_name").(string)
+	if !ok || name == "" {
+		return
+	}
+
+	ui.Say(fmt.Sprintf("Deleting temporary SSH key pair: %s", name))
+	err := exo.BooleanRequestWithContext(context.Background(), &egoscale.DeleteSSHKeyPair{
+		Name: name,
+	})
+	if err != nil {
+		ui.Error(fmt.Sprintf(
+			"Error deleting SSH key pair %s. Please delete it manually: %s", name, err))
+	}
+}
@@ -0,0 +1,62 @@
+package exoscale
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/exoscale/egoscale"
+	"github.com/hashicorp/packer/helper/multistep"
+	"github.com/hashicorp/packer/packer"
+)
+
+// exportSnapshot represents the Exoscale-specific exportSnapshot API
+// call, which exports a snapshot to SOS (the Exoscale object storage) and
+// returns a pre-signed download URL. It is not part of the stock
+// CloudStack API, hence not part of egoscale.
+type exportSnapshot struct {
+	ID *egoscale.UUID `json:"id" doc:"the ID of the snapshot"`
+	_  bool           `name:"exportSnapshot" description:"Exports a snapshot to SOS"`
+}
+
+// Response returns the struct to unmarshal
+func (exportSnapshot) Response() interface{} {
+	return new(egoscale.AsyncJobResult)
+}
+
+// AsyncResponse returns the struct to unmarshal the async job
+func (exportSnapshot) AsyncResponse() interface{} {
+	return new(snapshotExport)
+}
+
+type snapshotExport struct {
+	PresignedURL string `json:"presignedurl,omitempty"`
+	MD5Sum       string `json:"md5sum,omitempty"`
+}
+
+// stepExportSnapshot exports the root disk snapshot to SOS, where the
+// template registration downloads it from.
+type stepExportSnapshot struct{}
+
+func (s *stepExportSnapshot) Run(ctx context.Context, state multistep.StateBag) multistep.StepAction {
+	exo := state.Get("exo").(*egoscale.Client)
+	ui := state.Get("ui").(packer.Ui)
+	snapshot := state.Get("snapshot").(*egoscale.Snapshot)
+
+	ui.Say("Exporting snapshot to SOS...")
+
+	resp, err := exo.RequestWithContext(ctx, &exportSnapshot{ID: snapshot.ID})
+	if err != nil {
+		state.Put("error", fmt.Errorf("Error exporting snapshot: %s", err))
+		return multistep.ActionHalt
+	}
+	export := resp.(*snapshotExport)
+
+	state.Put("snapshot_url", export.PresignedURL)
+	state.Put("snapshot_checksum", export.MD5Sum)
+
+	return multistep.ActionContinue
+}
+
+func (s *stepExportSnapshot) Cleanup(state multistep.StateBag) {
+	// Nothing to cleanup for this step.
+}
@@ -0,0 +1,86 @@
+package exoscale
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/exoscale/egoscale"
+	"github.com/hashicorp/packer/helper/multistep"
+	"github.com/hashicorp/packer/packer"
+)
+
+// stepRegisterTemplate registers the exported snapshot as a custom
+// template in the build zone.
+type stepRegisterTemplate struct{}
+
+func (s *stepRegisterTemplate) Run(ctx context.Context, state multistep.StateBag) multistep.StepAction {
+	config := state.Get("config").(*Config)
+	exo := state.Get("exo").(*egoscale.Client)
+	ui := state.Get("ui").(packer.Ui)
+	vm := state.Get("instance").(*egoscale.VirtualMachine)
+
+	url := state.Get("snapshot_url").(string)
+	checksum := state.Get("snapshot_checksum").(string)
+
+	var (
+		passwordEnabled = !config.TemplateDisablePassword
+		sshkeyEnabled   = !config.TemplateDisableSSHKey
+		regErr          error
+	)
+
+	displayText := config.TemplateDescription
+	if displayText == "" {
+		displayText = config.TemplateName
+	}
+
+	ui.Say(fmt.Sprintf("Registering template: %s", config.TemplateName))
+
+	req := egoscale.RegisterCustomTemplate{
+		URL:             url,
+		ZoneID:          vm.ZoneID,
+		Name:            config.TemplateName,
+		Displaytext:     displayText,
+		PasswordEnabled: &passwordEnabled,
+		SSHKeyEnabled:   &sshkeyEnabled,
+		Details:         map[string]string{"username": config.TemplateUsername},
+		Checksum:        checksum,
+	}
+
+	res := make([]egoscale.Template, 0)
+
+	exo.AsyncRequestWithContext(ctx, req, func(jobRes *egoscale.AsyncJobResult, err error) bool {
+		if err != nil {
+			regErr = fmt.Errorf("request failed: %s", err)
+			return false
+		} else if jobRes.JobStatus == egoscale.Pending {
+			// Job is not completed yet
+			ui.Message("template registration in progress")
+			return true
+		}
+
+		if err := jobRes.Result(&res); err != nil {
+			regErr = err
+			return false
+		}
+
+		if len(res) != 1 {
+			regErr = fmt.Errorf("unexpected response from API (expected 1 item, got %d)", len(res))
+			return false
+		}
+
+		return false
+	})
+	if regErr != nil {
+		state.Put("error", fmt.Errorf("Error registering template: %s", regErr))
+		return multistep.ActionHalt
+	}
+
+	ui.Message(fmt.Sprintf("Template ID: %s", res[0].ID))
+	state.Put("template", res[0])
+
+	return multistep.ActionContinue
+}
+
+func (s *stepRegisterTemplate) Cleanup(state multistep.StateBag) {
+	// Nothing to cleanup for this step.
+}
@@ -0,0 +1,68 @@
+package exoscale
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/exoscale/egoscale"
+	"github.com/hashicorp/packer/helper/multistep"
+	"github.com/hashicorp/packer/packer"
+)
+
+// stepSnapshotRootVolume snapshots the root disk of the stopped compute
+// instance. The snapshot is deleted again once the template has been
+// registered from its export.
+type stepSnapshotRootVolume struct{}
+
+func (s *stepSnapshotRootVolume) Run(ctx context.Context, state multistep.StateBag) multistep.StepAction {
+	exo := state.Get("exo").(*egoscale.Client)
+	ui := state.Get("ui").(packer.Ui)
+	vm := state.Get("instance").(*egoscale.VirtualMachine)
+
+	ui.Say("Creating snapshot of the root disk...")
+
+	resp, err := exo.GetWithContext(ctx, &egoscale.Volume{
+		VirtualMachineID: vm.ID,
+		Type:             "ROOT",
+	})
+	if err != nil {
+		state.Put("error", fmt.Errorf("Could not find the root disk of instance %s: %s", vm.ID, err))
+		return multistep.ActionHalt
+	}
+	volume := resp.(*egoscale.Volume)
+
+	resp, err = exo.RequestWithContext(ctx, &egoscale.CreateSnapshot{
+		VolumeID: volume.ID,
+	})
+	if err != nil {
+		state.Put("error", fmt.Errorf("Error creating snapshot: %s", err))
+		return multistep.ActionHalt
+	}
+	snapshot := resp.(*egoscale.Snapshot)
+
+	ui.Message(fmt.Sprintf("Snapshot ID: %s", snapshot.ID))
+	state.Put("snapshot", snapshot)
+
+	return multistep.ActionContinue
+}
+
+func (s *stepSnapshotRootVolume) Cleanup(state multistep.StateBag) {
+	exo := state.Get("exo").(*egoscale.Client)
+	ui := state.Get("ui").(packer.Ui)
+
+	snapshot, ok := state.Get("snapshot").(*egoscale.Snapshot)
+	if !ok {
+		return
+	}
+
+	// The registered template holds its own copy of the data, so the
+	// snapshot is not needed anymore.
+	ui.Say("Deleting root disk snapshot...")
+	_, err := exo.RequestWithContext(context.Background(), &egoscale.DeleteSnapshot{
+		ID: snapshot.ID,
+	})
+	if err != nil {
+		ui.Error(fmt.Sprintf(
+			"Error deleting snapshot %s. Please delete it manually: %s", snapshot.ID, err))
+	}
+}
@@ -0,0 +1,34 @@
+package exoscale
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/exoscale/egoscale"
+	"github.com/hashicorp/packer/helper/multistep"
+	"github.com/hashicorp/packer/packer"
+)
+
+// stepStopInstance stops the compute instance so the root disk is in a
+// consistent state for the snapshot.
+type stepStopInstance struct{}
+
+func (s *stepStopInstance) Run(ctx context.Context, state multistep.StateBag) multistep.StepAction {
+	exo := state.Get("exo").(*egoscale.Client)
+	ui := state.Get("ui").(packer.Ui)
+	vm := state.Get("instance").(*egoscale.VirtualMachine)
+
+	ui.Say("Stopping instance...")
+
+	_, err := exo.RequestWithContext(ctx, &egoscale.StopVirtualMachine{ID: vm.ID})
+	if err != nil {
+		state.Put("error", fmt.Errorf("Error stopping instance: %s", err))
+		return multistep.ActionHalt
+	}
+
+	return multistep.ActionContinue
+}
+
+func (s *stepStopInstance) Cleanup(state multistep.StateBag) {
+	// Nothing to cleanup for this step.
+}
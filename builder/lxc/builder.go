@@ -19,6 +19,11 @@ type wrappedCommandTemplate struct {
 	Command string
 }
 
+type executeCommandTemplate struct {
+	Command string
+	User    string
+}
+
 type Builder struct {
 	config Config
 	runner multistep.Runner
@@ -41,6 +46,11 @@ func (b *Builder) Run(ctx context.Context, ui packer.Ui, hook packer.Hook) (pack
 		return interpolate.Render(b.config.CommandWrapper, &b.config.ctx)
 	}
 
+	executeCommand := func(command string) (string, error) {
+		b.config.ctx.Data = &executeCommandTemplate{Command: command, User: b.config.AttachUser}
+		return interpolate.Render(b.config.ExecuteCommand, &b.config.ctx)
+	}
+
 	steps := []multistep.Step{
 		new(stepPrepareOutputDir),
 		new(stepLxcCreate),
@@ -57,6 +67,7 @@ func (b *Builder) Run(ctx context.Context, ui packer.Ui, hook packer.Hook) (pack
 	state.Put("hook", hook)
 	state.Put("ui", ui)
 	state.Put("wrappedCommand", CommandWrapper(wrappedCommand))
+	state.Put("executeCommand", CommandWrapper(executeCommand))
 
 	// Run
 	b.runner = common.NewRunnerWithPauseFn(steps, b.config.PackerConfig, ui, state)
@@ -81,10 +92,15 @@ func (b *Builder) Run(ctx context.Context, ui packer.Ui, hook packer.Hook) (pack
 		return nil, err
 	}
 
+	stateData := map[string]interface{}{"generated_data": state.Get("generated_data")}
+	if checksum, ok := state.GetOk("rootfs_checksum"); ok {
+		stateData["rootfs_checksum"] = checksum
+	}
+
 	artifact := &Artifact{
 		dir:       b.config.OutputDir,
 		f:         files,
-		StateData: map[string]interface{}{"generated_data": state.Get("generated_data")},
+		StateData: stateData,
 	}
 
 	return artifact, nil
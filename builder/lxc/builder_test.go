@@ -47,6 +47,58 @@ func TestBuilderPrepare_ConfigFile(t *testing.T) {
 
 }
 
+func TestBuilderPrepare_Mounts(t *testing.T) {
+	var b Builder
+	// Good
+	config := testConfig()
+	config["mounts"] = []map[string]interface{}{
+		{
+			"source":      "builder_test.go",
+			"destination": "mnt/mirror",
+			"read_only":   true,
+		},
+	}
+	_, warnings, err := b.Prepare(config)
+	if len(warnings) > 0 {
+		t.Fatalf("bad: %#v", warnings)
+	}
+	if err != nil {
+		t.Fatalf("should not have error: %s", err)
+	}
+
+	// Bad, missing source
+	config = testConfig()
+	config["mounts"] = []map[string]interface{}{
+		{
+			"destination": "mnt/mirror",
+		},
+	}
+	b = Builder{}
+	_, warnings, err = b.Prepare(config)
+	if len(warnings) > 0 {
+		t.Fatalf("bad: %#v", warnings)
+	}
+	if err == nil {
+		t.Fatalf("should have error")
+	}
+
+	// Bad, missing destination
+	config = testConfig()
+	config["mounts"] = []map[string]interface{}{
+		{
+			"source": "builder_test.go",
+		},
+	}
+	b = Builder{}
+	_, warnings, err = b.Prepare(config)
+	if len(warnings) > 0 {
+		t.Fatalf("bad: %#v", warnings)
+	}
+	if err == nil {
+		t.Fatalf("should have error")
+	}
+}
+
 func TestBuilder_ImplementsBuilder(t *testing.T) {
 	var raw interface{}
 	raw = &Builder{}
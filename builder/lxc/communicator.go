@@ -21,6 +21,10 @@ type LxcAttachCommunicator struct {
 	ContainerName string
 	AttachOptions []string
 	CmdWrapper    CommandWrapper
+	// ExecuteWrapper renders the in-container command through the
+	// execute_command template, so commands can for example run as a
+	// non-root user through sudo.
+	ExecuteWrapper CommandWrapper
 }
 
 func (c *LxcAttachCommunicator) Start(ctx context.Context, cmd *packer.RemoteCmd) error {
@@ -136,12 +140,21 @@ func (c *LxcAttachCommunicator) DownloadDir(src string, dst string, exclude []st
 func (c *LxcAttachCommunicator) Execute(commandString string) (*exec.Cmd, error) {
 	log.Printf("Executing with lxc-attach in container: %s %s %s", c.ContainerName, c.RootFs, commandString)
 
+	executeCommand := fmt.Sprintf("/bin/sh -c \"%s\"", commandString)
+	if c.ExecuteWrapper != nil {
+		var err error
+		executeCommand, err = c.ExecuteWrapper(commandString)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	attachCommand := []string{"lxc-attach"}
 	attachCommand = append(attachCommand, c.AttachOptions...)
-	attachCommand = append(attachCommand, []string{"--name", "%s", "--", "/bin/sh -c \"%s\""}...)
+	attachCommand = append(attachCommand, []string{"--name", "%s", "--", "%s"}...)
 
 	command, err := c.CmdWrapper(
-		fmt.Sprintf(strings.Join(attachCommand, " "), c.ContainerName, commandString))
+		fmt.Sprintf(strings.Join(attachCommand, " "), c.ContainerName, executeCommand))
 	if err != nil {
 		return nil, err
 	}
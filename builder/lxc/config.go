@@ -1,11 +1,12 @@
 //go:generate struct-markdown
-//go:generate mapstructure-to-hcl2 -type Config
+//go:generate mapstructure-to-hcl2 -type Config,MountConfig
 
 package lxc
 
 import (
 	"fmt"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/packer/common"
@@ -22,6 +23,13 @@ type Config struct {
 	// The directory in which to save the exported
 	// tar.gz. Defaults to `output-<BuildName>` in the current directory.
 	OutputDir string `mapstructure:"output_directory" required:"false"`
+	// If true, the exported rootfs tarball is
+	// normalized so that two builds from identical inputs produce
+	// byte-identical output: entries are sorted by name, all timestamps are
+	// set to the epoch and the gzip stream carries no timestamp. The SHA256
+	// of the tarball is recorded in the artifact either way. Requires GNU
+	// tar. Defaults to false.
+	Reproducible bool `mapstructure:"reproducible" required:"false"`
 	// The name of the LXC container. Usually stored
 	// in `/var/lib/lxc/containers/<container_name>`. Defaults to
 	// `packer-<BuildName>`.
@@ -44,11 +52,38 @@ type Config struct {
 	// file via ["--define", "KEY=VALUE"]. Defaults to []. See
 	// man 1 lxc-start for available options.
 	StartOptions []string `mapstructure:"start_options" required:"false"`
+	// Host directories to bind mount into the
+	// container while provisioners run, so large local package mirrors or
+	// caches can be used without copying them into the rootfs. Mounts are
+	// not part of the exported image. Example:
+	//
+	// ```json
+	// "mounts": [
+	//   {
+	//     "source": "/srv/mirror",
+	//     "destination": "mnt/mirror",
+	//     "read_only": true
+	//   }
+	// ]
+	// ```
+	Mounts []MountConfig `mapstructure:"mounts" required:"false"`
 	// Options to pass to lxc-attach. For
 	// instance, you can prevent the container from inheriting the host machine's
 	// environment by specifying ["--clear-env"]. Defaults to []. See
 	// man 1 lxc-attach for available options.
 	AttachOptions []string `mapstructure:"attach_options" required:"false"`
+	// The user to run provisioning commands as
+	// inside the container. lxc-attach itself always attaches as root;
+	// commands are wrapped with execute_command so they are executed as
+	// this user instead. Defaults to empty; i.e. commands run as root.
+	AttachUser string `mapstructure:"attach_user" required:"false"`
+	// The command used to run provisioning
+	// commands inside the container. This is a [configuration
+	// template](/docs/templates/engine) where the `Command` and `User`
+	// variables are available. Defaults to `/bin/sh -c "{{.Command}}"`, or
+	// to `sudo -u {{.User}} -H -- /bin/sh -c "{{.Command}}"` when
+	// attach_user is set.
+	ExecuteCommand string `mapstructure:"execute_command" required:"false"`
 	// The LXC template name to use.
 	Name string `mapstructure:"template_name" required:"true"`
 	// Options to pass to the given
@@ -68,6 +103,31 @@ type Config struct {
 	ctx interpolate.Context
 }
 
+// MountConfig is a single bind mount made available inside the build
+// container while provisioners run.
+type MountConfig struct {
+	// The host directory to bind mount into the
+	// container.
+	Source string `mapstructure:"source" required:"true"`
+	// Where to mount the directory inside the
+	// container, relative to the container root. E.G. `mnt/mirror`.
+	Destination string `mapstructure:"destination" required:"true"`
+	// Mount the directory read-only inside the
+	// container. Defaults to false; i.e. read-write.
+	ReadOnly bool `mapstructure:"read_only" required:"false"`
+}
+
+// mountEntry renders the mount as an lxc.mount.entry configuration line
+// suitable for lxc-start --define.
+func (m *MountConfig) mountEntry() string {
+	options := "bind,create=dir"
+	if m.ReadOnly {
+		options = "ro," + options
+	}
+	return fmt.Sprintf("lxc.mount.entry=%s %s none %s 0 0",
+		m.Source, strings.TrimPrefix(m.Destination, "/"), options)
+}
+
 func (c *Config) Prepare(raws ...interface{}) error {
 
 	var md mapstructure.Metadata
@@ -102,10 +162,29 @@ func (c *Config) Prepare(raws ...interface{}) error {
 		c.InitTimeout = 20 * time.Second
 	}
 
+	if c.ExecuteCommand == "" {
+		if c.AttachUser != "" {
+			c.ExecuteCommand = `sudo -u {{.User}} -H -- /bin/sh -c "{{.Command}}"`
+		} else {
+			c.ExecuteCommand = `/bin/sh -c "{{.Command}}"`
+		}
+	}
+
 	if _, err := os.Stat(c.ConfigFile); os.IsNotExist(err) {
 		errs = packer.MultiErrorAppend(errs, fmt.Errorf("LXC Config file appears to be missing: %s", c.ConfigFile))
 	}
 
+	for i, mount := range c.Mounts {
+		if mount.Source == "" {
+			errs = packer.MultiErrorAppend(errs, fmt.Errorf("mounts[%d]: source is required", i))
+		} else if _, err := os.Stat(mount.Source); err != nil {
+			errs = packer.MultiErrorAppend(errs, fmt.Errorf("mounts[%d]: source %s appears to be missing: %s", i, mount.Source, err))
+		}
+		if mount.Destination == "" {
+			errs = packer.MultiErrorAppend(errs, fmt.Errorf("mounts[%d]: destination is required", i))
+		}
+	}
+
 	if errs != nil && len(errs.Errors) > 0 {
 		return errs
 	}
@@ -2,6 +2,8 @@ package lxc
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"io"
 	"log"
@@ -59,13 +61,23 @@ func (s *stepExport) Run(ctx context.Context, state multistep.StateBag) multiste
 		ui.Error(err.Error())
 		return multistep.ActionHalt
 	}
+	tarCommand := []string{
+		"tar", "-C", containerDir, "--numeric-owner", "--anchored", "--exclude=./rootfs/dev/log",
+	}
+	if config.Reproducible {
+		// Sort entries, pin all timestamps to the epoch and keep the gzip
+		// header free of a timestamp so identical inputs yield a
+		// byte-identical tarball. These options require GNU tar.
+		tarCommand = append(tarCommand, "--sort=name", "--mtime=@0", "--use-compress-program=gzip -n", "-cf", outputPath, "./rootfs")
+	} else {
+		tarCommand = append(tarCommand, "-czf", outputPath, "./rootfs")
+	}
+
 	commands := make([][]string, 3)
 	commands[0] = []string{
 		"lxc-stop", "--name", name,
 	}
-	commands[1] = []string{
-		"tar", "-C", containerDir, "--numeric-owner", "--anchored", "--exclude=./rootfs/dev/log", "-czf", outputPath, "./rootfs",
-	}
+	commands[1] = tarCommand
 	commands[2] = []string{
 		"chmod", "+x", configFilePath,
 	}
@@ -81,7 +93,34 @@ func (s *stepExport) Run(ctx context.Context, state multistep.StateBag) multiste
 		}
 	}
 
+	checksum, err := fileSHA256(outputPath)
+	if err != nil {
+		err := fmt.Errorf("Error checksumming rootfs tarball: %s", err)
+		state.Put("error", err)
+		ui.Error(err.Error())
+		return multistep.ActionHalt
+	}
+
+	ui.Message(fmt.Sprintf("SHA256 of rootfs tarball: %s", checksum))
+	state.Put("rootfs_checksum", checksum)
+
 	return multistep.ActionContinue
 }
 
+// fileSHA256 returns the hex-encoded SHA256 digest of the file at path.
+func fileSHA256(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, f); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
 func (s *stepExport) Cleanup(state multistep.StateBag) {}
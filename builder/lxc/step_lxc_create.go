@@ -45,6 +45,9 @@ func (s *stepLxcCreate) Run(ctx context.Context, state multistep.StateBag) multi
 	// todo: wait for init to finish before moving on to provisioning instead of this
 	commands[1] = []string{"touch", filepath.Join(rootfs, "tmp", ".tmpfs")}
 	commands[2] = append([]string{"lxc-start"}, config.StartOptions...)
+	for _, mount := range config.Mounts {
+		commands[2] = append(commands[2], "--define", mount.mountEntry())
+	}
 	commands[2] = append(commands[2], []string{"-d", "--name", name}...)
 
 	ui.Say("Creating container...")
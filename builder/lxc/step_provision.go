@@ -18,13 +18,15 @@ func (s *StepProvision) Run(ctx context.Context, state multistep.StateBag) multi
 	mountPath := state.Get("mount_path").(string)
 	ui := state.Get("ui").(packer.Ui)
 	wrappedCommand := state.Get("wrappedCommand").(CommandWrapper)
+	executeCommand := state.Get("executeCommand").(CommandWrapper)
 
 	// Create our communicator
 	comm := &LxcAttachCommunicator{
-		ContainerName: config.ContainerName,
-		AttachOptions: config.AttachOptions,
-		RootFs:        mountPath,
-		CmdWrapper:    wrappedCommand,
+		ContainerName:  config.ContainerName,
+		AttachOptions:  config.AttachOptions,
+		RootFs:         mountPath,
+		CmdWrapper:     wrappedCommand,
+		ExecuteWrapper: executeCommand,
 	}
 
 	// Loads hook data from builder's state, if it has been set.
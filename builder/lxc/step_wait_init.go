@@ -67,6 +67,7 @@ func (s *StepWaitInit) waitForInit(state multistep.StateBag, cancel <-chan struc
 	config := state.Get("config").(*Config)
 	mountPath := state.Get("mount_path").(string)
 	wrappedCommand := state.Get("wrappedCommand").(CommandWrapper)
+	executeCommand := state.Get("executeCommand").(CommandWrapper)
 
 	for {
 		select {
@@ -77,10 +78,11 @@ func (s *StepWaitInit) waitForInit(state multistep.StateBag, cancel <-chan struc
 		}
 
 		comm := &LxcAttachCommunicator{
-			ContainerName: config.ContainerName,
-			AttachOptions: config.AttachOptions,
-			RootFs:        mountPath,
-			CmdWrapper:    wrappedCommand,
+			ContainerName:  config.ContainerName,
+			AttachOptions:  config.AttachOptions,
+			RootFs:         mountPath,
+			CmdWrapper:     wrappedCommand,
+			ExecuteWrapper: executeCommand,
 		}
 
 		runlevel, _ := comm.CheckInit()
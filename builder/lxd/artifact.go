@@ -7,6 +7,10 @@ import (
 type Artifact struct {
 	id string
 
+	// Tarballs written by `lxc image export` when export_path is set,
+	// empty otherwise.
+	files []string
+
 	// StateData should store data such as GeneratedData
 	// to be shared with post-processors
 	StateData map[string]interface{}
@@ -17,7 +21,7 @@ func (*Artifact) BuilderId() string {
 }
 
 func (a *Artifact) Files() []string {
-	return nil
+	return a.files
 }
 
 func (a *Artifact) Id() string {
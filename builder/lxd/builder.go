@@ -45,6 +45,10 @@ func (b *Builder) Run(ctx context.Context, ui packer.Ui, hook packer.Hook) (pack
 		&stepPublish{},
 	}
 
+	if b.config.ExportPath != "" {
+		steps = append(steps, &stepExport{})
+	}
+
 	// Setup the state bag
 	state := new(multistep.BasicStateBag)
 	state.Put("config", &b.config)
@@ -66,5 +70,18 @@ func (b *Builder) Run(ctx context.Context, ui packer.Ui, hook packer.Hook) (pack
 		StateData: map[string]interface{}{"generated_data": state.Get("generated_data")},
 	}
 
+	if files, ok := state.GetOk("exportedFiles"); ok {
+		artifact.files = files.([]string)
+	}
+
+	if checksums, ok := state.GetOk("exportedFilesChecksums"); ok {
+		artifact.StateData["exported_files_checksums"] = checksums
+	}
+
+	// Record the cluster member the image was built on.
+	if b.config.Target != "" {
+		artifact.StateData["target"] = b.config.Target
+	}
+
 	return artifact, nil
 }
@@ -40,6 +40,33 @@ type Config struct {
 	// List of key/value pairs you wish to
 	// pass to lxc launch via --config. Defaults to empty.
 	LaunchConfig map[string]string `mapstructure:"launch_config" required:"false"`
+	// List of extra arguments to pass to lxc
+	// launch when creating the build container, e.g. ["--network", "lxdbr0"].
+	// Defaults to empty.
+	LaunchArguments []string `mapstructure:"launch_arguments" required:"false"`
+	// Key/value pairs of environment variables
+	// set in the build container through the environment.* LXD config keys,
+	// for example proxy variables needed during provisioning. Defaults to
+	// empty.
+	Environment map[string]string `mapstructure:"environment" required:"false"`
+	// If true, the build container is launched with
+	// lxc launch --ephemeral, so LXD destroys it automatically when it
+	// stops - for example when the packer process dies before it can clean
+	// up. Defaults to false.
+	Ephemeral bool `mapstructure:"ephemeral" required:"false"`
+	// The cluster member to launch the build container on,
+	// passed to lxc launch via --target. This pins builds in an LXD
+	// cluster to a specific member, for example one with local NVMe
+	// storage or a GPU. Defaults to empty; LXD picks a member.
+	Target string `mapstructure:"target" required:"false"`
+	// Path the published image is exported to once the
+	// build is done, e.g. `output/my-image`. LXD appends the appropriate
+	// extension, writing a unified tarball such as `output/my-image.tar.gz`
+	// (or separate metadata and rootfs tarballs for split images). The
+	// exported files become the files of the artifact, so post-processors
+	// such as compress or upload can operate on LXD images. Defaults to
+	// empty; no export.
+	ExportPath string `mapstructure:"export_path" required:"false"`
 
 	ctx interpolate.Context
 }
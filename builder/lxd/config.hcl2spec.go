@@ -24,6 +24,11 @@ type FlatConfig struct {
 	InitSleep           *string           `mapstructure:"init_sleep" required:"false" cty:"init_sleep"`
 	PublishProperties   map[string]string `mapstructure:"publish_properties" required:"false" cty:"publish_properties"`
 	LaunchConfig        map[string]string `mapstructure:"launch_config" required:"false" cty:"launch_config"`
+	LaunchArguments     []string          `mapstructure:"launch_arguments" required:"false" cty:"launch_arguments"`
+	Environment         map[string]string `mapstructure:"environment" required:"false" cty:"environment"`
+	Ephemeral           *bool             `mapstructure:"ephemeral" required:"false" cty:"ephemeral"`
+	Target              *string           `mapstructure:"target" required:"false" cty:"target"`
+	ExportPath          *string           `mapstructure:"export_path" required:"false" cty:"export_path"`
 }
 
 // FlatMapstructure returns a new FlatConfig.
@@ -53,6 +58,11 @@ func (*FlatConfig) HCL2Spec() map[string]hcldec.Spec {
 		"init_sleep":                 &hcldec.AttrSpec{Name: "init_sleep", Type: cty.String, Required: false},
 		"publish_properties":         &hcldec.AttrSpec{Name: "publish_properties", Type: cty.Map(cty.String), Required: false},
 		"launch_config":              &hcldec.AttrSpec{Name: "launch_config", Type: cty.Map(cty.String), Required: false},
+		"launch_arguments":           &hcldec.AttrSpec{Name: "launch_arguments", Type: cty.List(cty.String), Required: false},
+		"environment":                &hcldec.AttrSpec{Name: "environment", Type: cty.Map(cty.String), Required: false},
+		"ephemeral":                  &hcldec.AttrSpec{Name: "ephemeral", Type: cty.Bool, Required: false},
+		"target":                     &hcldec.AttrSpec{Name: "target", Type: cty.String, Required: false},
+		"export_path":                &hcldec.AttrSpec{Name: "export_path", Type: cty.String, Required: false},
 	}
 	return s
 }
@@ -0,0 +1,89 @@
+package lxd
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/hashicorp/packer/helper/multistep"
+	"github.com/hashicorp/packer/packer"
+)
+
+type stepExport struct{}
+
+func (s *stepExport) Run(ctx context.Context, state multistep.StateBag) multistep.StepAction {
+	config := state.Get("config").(*Config)
+	ui := state.Get("ui").(packer.Ui)
+
+	fingerprint := state.Get("imageFingerprint").(string)
+
+	export_args := []string{
+		"image", "export", fingerprint, config.ExportPath,
+	}
+
+	ui.Say(fmt.Sprintf("Exporting image to %s...", config.ExportPath))
+	if _, err := LXDCommand(export_args...); err != nil {
+		err := fmt.Errorf("Error exporting image: %s", err)
+		state.Put("error", err)
+		ui.Error(err.Error())
+		return multistep.ActionHalt
+	}
+
+	// LXD appends the appropriate extension to the target path, and split
+	// images are exported as separate metadata and rootfs tarballs, so
+	// glob for everything it produced rather than guessing names.
+	files, err := filepath.Glob(config.ExportPath + "*")
+	if err == nil && len(files) == 0 {
+		err = fmt.Errorf("no files found under %s", config.ExportPath)
+	}
+	if err != nil {
+		err := fmt.Errorf("Error locating exported image: %s", err)
+		state.Put("error", err)
+		ui.Error(err.Error())
+		return multistep.ActionHalt
+	}
+
+	// LXD image fingerprints are the SHA256 of the (unified) image tarball,
+	// but split images ship two tarballs, so checksum every exported file
+	// and record the digests in the artifact for later verification.
+	checksums := make(map[string]string, len(files))
+	for _, f := range files {
+		checksum, err := fileSHA256(f)
+		if err != nil {
+			err := fmt.Errorf("Error checksumming exported image: %s", err)
+			state.Put("error", err)
+			ui.Error(err.Error())
+			return multistep.ActionHalt
+		}
+
+		checksums[f] = checksum
+		ui.Message(fmt.Sprintf("Exported: %s (SHA256: %s)", f, checksum))
+	}
+
+	state.Put("exportedFiles", files)
+	state.Put("exportedFilesChecksums", checksums)
+
+	return multistep.ActionContinue
+}
+
+// fileSHA256 returns the hex-encoded SHA256 digest of the file at path.
+func fileSHA256(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, f); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+func (s *stepExport) Cleanup(state multistep.StateBag) {}
@@ -22,13 +22,23 @@ func (s *stepLxdLaunch) Run(ctx context.Context, state multistep.StateBag) multi
 	profile := fmt.Sprintf("--profile=%s", config.Profile)
 
 	launch_args := []string{
-		"launch", "--ephemeral=false", profile, image, name,
+		"launch", fmt.Sprintf("--ephemeral=%t", config.Ephemeral), profile, image, name,
+	}
+
+	if config.Target != "" {
+		launch_args = append(launch_args, "--target", config.Target)
 	}
 
 	for k, v := range config.LaunchConfig {
 		launch_args = append(launch_args, "--config", fmt.Sprintf("%s=%s", k, v))
 	}
 
+	for k, v := range config.Environment {
+		launch_args = append(launch_args, "--config", fmt.Sprintf("environment.%s=%s", k, v))
+	}
+
+	launch_args = append(launch_args, config.LaunchArguments...)
+
 	ui.Say("Creating container...")
 	_, err := LXDCommand(launch_args...)
 	if err != nil {
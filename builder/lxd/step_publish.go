@@ -16,22 +16,48 @@ func (s *stepPublish) Run(ctx context.Context, state multistep.StateBag) multist
 	ui := state.Get("ui").(packer.Ui)
 
 	name := config.ContainerName
-	stop_args := []string{
-		// We created the container with "--ephemeral=false" so we know it is safe to stop.
-		"stop", name,
-	}
+	publishName := name
 
-	ui.Say("Stopping container...")
-	_, err := LXDCommand(stop_args...)
-	if err != nil {
-		err := fmt.Errorf("Error stopping container: %s", err)
-		state.Put("error", err)
-		ui.Error(err.Error())
-		return multistep.ActionHalt
+	if config.Ephemeral {
+		// Stopping an ephemeral container destroys it, so publish from a
+		// stopped, non-ephemeral copy of it instead.
+		publishName = name + "-publish"
+		copy_args := []string{
+			"copy", name, publishName,
+		}
+
+		ui.Say("Copying container for publishing...")
+		if _, err := LXDCommand(copy_args...); err != nil {
+			err := fmt.Errorf("Error copying container: %s", err)
+			state.Put("error", err)
+			ui.Error(err.Error())
+			return multistep.ActionHalt
+		}
+		defer func() {
+			delete_args := []string{
+				"delete", "--force", publishName,
+			}
+			if _, err := LXDCommand(delete_args...); err != nil {
+				ui.Error(fmt.Sprintf("Error deleting container copy: %s", err))
+			}
+		}()
+	} else {
+		stop_args := []string{
+			// We created the container with "--ephemeral=false" so we know it is safe to stop.
+			"stop", name,
+		}
+
+		ui.Say("Stopping container...")
+		if _, err := LXDCommand(stop_args...); err != nil {
+			err := fmt.Errorf("Error stopping container: %s", err)
+			state.Put("error", err)
+			ui.Error(err.Error())
+			return multistep.ActionHalt
+		}
 	}
 
 	publish_args := []string{
-		"publish", name, "--alias", config.OutputImage,
+		"publish", publishName, "--alias", config.OutputImage,
 	}
 
 	for k, v := range config.PublishProperties {
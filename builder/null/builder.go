@@ -2,6 +2,8 @@ package null
 
 import (
 	"context"
+	"fmt"
+	"sync"
 
 	"github.com/hashicorp/hcl/v2/hcldec"
 	"github.com/hashicorp/packer/common"
@@ -29,6 +31,10 @@ func (b *Builder) Prepare(raws ...interface{}) ([]string, []string, error) {
 }
 
 func (b *Builder) Run(ctx context.Context, ui packer.Ui, hook packer.Hook) (packer.Artifact, error) {
+	if len(b.config.Hosts) > 0 {
+		return b.runHosts(ctx, ui, hook)
+	}
+
 	steps := []multistep.Step{}
 
 	steps = append(steps,
@@ -62,3 +68,74 @@ func (b *Builder) Run(ctx context.Context, ui packer.Ui, hook packer.Hook) (pack
 	artifact := &NullArtifact{}
 	return artifact, nil
 }
+
+// runHosts runs the provisioner chain against every host in hosts, either
+// one after the other or concurrently when parallel is set.
+func (b *Builder) runHosts(ctx context.Context, ui packer.Ui, hook packer.Hook) (packer.Artifact, error) {
+	runHost := func(host string) error {
+		// Every host gets its own copy of the communicator
+		// configuration, since the connect step stores connection
+		// details in it.
+		comm := b.config.CommConfig
+		if comm.Type == "winrm" {
+			comm.WinRMHost = host
+		} else {
+			comm.SSHHost = host
+		}
+
+		steps := []multistep.Step{
+			&communicator.StepConnect{
+				Config:    &comm,
+				Host:      CommHost(host),
+				SSHConfig: comm.SSHConfigFunc(),
+			},
+			new(common.StepProvision),
+		}
+
+		state := new(multistep.BasicStateBag)
+		state.Put("hook", hook)
+		state.Put("ui", ui)
+		state.Put("instance_id", host)
+
+		ui.Say(fmt.Sprintf("Provisioning host %s...", host))
+		runner := common.NewRunner(steps, b.config.PackerConfig, ui)
+		runner.Run(ctx, state)
+
+		if rawErr, ok := state.GetOk("error"); ok {
+			return fmt.Errorf("error provisioning host %s: %s", host, rawErr.(error))
+		}
+		return nil
+	}
+
+	if b.config.Parallel {
+		var wg sync.WaitGroup
+		errCh := make(chan error, len(b.config.Hosts))
+		for _, host := range b.config.Hosts {
+			wg.Add(1)
+			go func(host string) {
+				defer wg.Done()
+				if err := runHost(host); err != nil {
+					errCh <- err
+				}
+			}(host)
+		}
+		wg.Wait()
+		close(errCh)
+
+		var errs *packer.MultiError
+		for err := range errCh {
+			errs = packer.MultiErrorAppend(errs, err)
+		}
+		if errs != nil && len(errs.Errors) > 0 {
+			return nil, errs
+		}
+	} else {
+		for _, host := range b.config.Hosts {
+			if err := runHost(host); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return &NullArtifact{}, nil
+}
@@ -16,6 +16,17 @@ type Config struct {
 	common.PackerConfig `mapstructure:",squash"`
 
 	CommConfig communicator.Config `mapstructure:",squash"`
+
+	// A list of hosts to run the provisioners against, as an alternative
+	// to a single communicator host. The provisioner chain is run against
+	// every host in the list, making the null builder usable as an ad-hoc
+	// remote provisioning runner for pre-existing machines.
+	Hosts []string `mapstructure:"hosts"`
+
+	// Whether to provision the hosts given in hosts concurrently instead
+	// of one after the other. Only used when hosts is set. Defaults to
+	// false.
+	Parallel bool `mapstructure:"parallel"`
 }
 
 func (c *Config) Prepare(raws ...interface{}) ([]string, error) {
@@ -33,8 +44,25 @@ func (c *Config) Prepare(raws ...interface{}) ([]string, error) {
 		errs = packer.MultiErrorAppend(errs, es...)
 	}
 
+	for i, host := range c.Hosts {
+		if host == "" {
+			errs = packer.MultiErrorAppend(errs,
+				fmt.Errorf("hosts[%d] must not be empty", i))
+		}
+	}
+
+	if c.Parallel && len(c.Hosts) == 0 {
+		errs = packer.MultiErrorAppend(errs,
+			fmt.Errorf("parallel is only used when hosts is specified"))
+	}
+
 	if c.CommConfig.Type != "none" {
-		if c.CommConfig.Host() == "" {
+		if c.CommConfig.Host() != "" && len(c.Hosts) > 0 {
+			errs = packer.MultiErrorAppend(errs,
+				fmt.Errorf("specify either a single communicator host or hosts, not both"))
+		}
+
+		if c.CommConfig.Host() == "" && len(c.Hosts) == 0 {
 			errs = packer.MultiErrorAppend(errs,
 				fmt.Errorf("a Host must be specified, please reference your communicator documentation"))
 		}
@@ -58,6 +58,8 @@ type FlatConfig struct {
 	WinRMUseSSL               *bool             `mapstructure:"winrm_use_ssl" cty:"winrm_use_ssl"`
 	WinRMInsecure             *bool             `mapstructure:"winrm_insecure" cty:"winrm_insecure"`
 	WinRMUseNTLM              *bool             `mapstructure:"winrm_use_ntlm" cty:"winrm_use_ntlm"`
+	Hosts                     []string          `mapstructure:"hosts" cty:"hosts"`
+	Parallel                  *bool             `mapstructure:"parallel" cty:"parallel"`
 }
 
 // FlatMapstructure returns a new FlatConfig.
@@ -121,6 +123,8 @@ func (*FlatConfig) HCL2Spec() map[string]hcldec.Spec {
 		"winrm_use_ssl":                &hcldec.AttrSpec{Name: "winrm_use_ssl", Type: cty.Bool, Required: false},
 		"winrm_insecure":               &hcldec.AttrSpec{Name: "winrm_insecure", Type: cty.Bool, Required: false},
 		"winrm_use_ntlm":               &hcldec.AttrSpec{Name: "winrm_use_ntlm", Type: cty.Bool, Required: false},
+		"hosts":                        &hcldec.AttrSpec{Name: "hosts", Type: cty.List(cty.String), Required: false},
+		"parallel":                     &hcldec.AttrSpec{Name: "parallel", Type: cty.Bool, Required: false},
 	}
 	return s
 }
@@ -74,6 +74,43 @@ func TestConfigPrepare_host(t *testing.T) {
 	testConfigOk(t, warns, errs)
 }
 
+func TestConfigPrepare_hosts(t *testing.T) {
+	raw := testConfig()
+
+	// hosts instead of a single ssh_host
+	delete(raw, "ssh_host")
+	raw["hosts"] = []string{"one", "two"}
+	var c Config
+	warns, errs := c.Prepare(raw)
+	testConfigOk(t, warns, errs)
+
+	// both ssh_host and hosts set
+	raw["ssh_host"] = "foo"
+	warns, errs = (&Config{}).Prepare(raw)
+	testConfigErr(t, warns, errs)
+
+	// empty host entry
+	delete(raw, "ssh_host")
+	raw["hosts"] = []string{"one", ""}
+	warns, errs = (&Config{}).Prepare(raw)
+	testConfigErr(t, warns, errs)
+}
+
+func TestConfigPrepare_parallel(t *testing.T) {
+	raw := testConfig()
+
+	// parallel without hosts
+	raw["parallel"] = true
+	warns, errs := (&Config{}).Prepare(raw)
+	testConfigErr(t, warns, errs)
+
+	// parallel with hosts
+	delete(raw, "ssh_host")
+	raw["hosts"] = []string{"one", "two"}
+	warns, errs = (&Config{}).Prepare(raw)
+	testConfigOk(t, warns, errs)
+}
+
 func TestConfigPrepare_sshCredential(t *testing.T) {
 	raw := testConfig()
 
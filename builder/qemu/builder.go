@@ -80,6 +80,12 @@ type Config struct {
 	// Use iso from provided url. Qemu must support
 	// curl block device. This defaults to `false`.
 	ISOSkipCache bool `mapstructure:"iso_skip_cache" required:"false"`
+	// Additional ISO files to attach to the virtual machine as extra CD-ROM
+	// drives alongside the boot ISO, for example driver disks or a kickstart
+	// ISO. Each entry is a path to an ISO on the local filesystem; the files
+	// are attached as-is and are not downloaded or checksummed. Unset by
+	// default.
+	AttachISOs []string `mapstructure:"attach_isos" required:"false"`
 	// The accelerator type to use when running the VM.
 	// This may be `none`, `kvm`, `tcg`, `hax`, `hvf`, `whpx`, or `xen`. The appropriate
 	// software must have already been installed on your build machine to use the
@@ -165,6 +171,17 @@ type Config struct {
 	// use it as a backing file (if `use_backing_file` is `true`); then, it
 	// will resize the image according to `disk_size` and boot it.
 	DiskImage bool `mapstructure:"disk_image" required:"false"`
+	// Only applicable when disk_image is true. When enabled, the builder
+	// assumes the source is a cloud image and boots it with an
+	// auto-generated cloud-init [NoCloud](https://cloudinit.readthedocs.io/en/latest/topics/datasources/nocloud.html)
+	// seed ISO that installs the communicator credentials, instead of
+	// typing a `boot_command` over VNC. If no SSH password or private key
+	// is configured, an ephemeral SSH key pair is created for the build.
+	// This is the standard way of building derivatives of Ubuntu or
+	// Debian cloud images. One of `genisoimage`, `mkisofs` or `xorriso`
+	// (`hdiutil` on macOS) must be installed on the build machine.
+	// Defaults to `false`.
+	CloudInit bool `mapstructure:"cloud_init" required:"false"`
 	// Only applicable when disk_image is true
 	// and format is qcow2, set this option to true to create a new QCOW2
 	// file that uses the file located at iso_url as a backing file. The new file
@@ -486,6 +503,28 @@ func (b *Builder) Prepare(raws ...interface{}) ([]string, []string, error) {
 			errs, errors.New("disk_additional_size can only be used when disk_image is false"))
 	}
 
+	for _, attachPath := range b.config.AttachISOs {
+		if _, err := os.Stat(attachPath); err != nil {
+			errs = packer.MultiErrorAppend(
+				errs, fmt.Errorf("attach_isos: %s: %s", attachPath, err))
+		}
+	}
+
+	if b.config.CloudInit {
+		if !b.config.DiskImage {
+			errs = packer.MultiErrorAppend(
+				errs, errors.New("cloud_init can only be used when disk_image is true"))
+		}
+		if b.config.Comm.Type != "ssh" {
+			errs = packer.MultiErrorAppend(
+				errs, errors.New("cloud_init only supports the ssh communicator"))
+		}
+		if len(b.config.VNCConfig.BootConfig.BootCommand) > 0 {
+			errs = packer.MultiErrorAppend(
+				errs, errors.New("boot_command is not used when cloud_init is enabled"))
+		}
+	}
+
 	if _, ok := accels[b.config.Accelerator]; !ok {
 		errs = packer.MultiErrorAppend(
 			errs, errors.New("invalid accelerator, only 'kvm', 'tcg', 'xen', 'hax', 'hvf', 'whpx', or 'none' are allowed"))
@@ -609,6 +648,12 @@ func (b *Builder) Run(ctx context.Context, ui packer.Ui, hook packer.Hook) (pack
 		)
 	}
 
+	if b.config.CloudInit {
+		steps = append(steps,
+			new(stepCloudInitSeed),
+		)
+	}
+
 	steps = append(steps,
 		new(stepConfigureVNC),
 		steprun,
@@ -616,9 +661,14 @@ func (b *Builder) Run(ctx context.Context, ui packer.Ui, hook packer.Hook) (pack
 			VNCUsePassword: b.config.VNCUsePassword,
 			QMPSocketPath:  b.config.QMPSocketPath,
 		},
-		&stepTypeBootCommand{},
 	)
 
+	if !b.config.CloudInit {
+		steps = append(steps,
+			&stepTypeBootCommand{},
+		)
+	}
+
 	if b.config.Comm.Type != "none" {
 		steps = append(steps,
 			&communicator.StepConnect{
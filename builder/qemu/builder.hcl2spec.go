@@ -79,6 +79,7 @@ type FlatConfig struct {
 	FloppyDirectories         []string          `mapstructure:"floppy_dirs" cty:"floppy_dirs"`
 	FloppyLabel               *string           `mapstructure:"floppy_label" cty:"floppy_label"`
 	ISOSkipCache              *bool             `mapstructure:"iso_skip_cache" required:"false" cty:"iso_skip_cache"`
+	AttachISOs                []string          `mapstructure:"attach_isos" required:"false" cty:"attach_isos"`
 	Accelerator               *string           `mapstructure:"accelerator" required:"false" cty:"accelerator"`
 	AdditionalDiskSize        []string          `mapstructure:"disk_additional_size" required:"false" cty:"disk_additional_size"`
 	CpuCount                  *int              `mapstructure:"cpus" required:"false" cty:"cpus"`
@@ -92,6 +93,7 @@ type FlatConfig struct {
 	Format                    *string           `mapstructure:"format" required:"false" cty:"format"`
 	Headless                  *bool             `mapstructure:"headless" required:"false" cty:"headless"`
 	DiskImage                 *bool             `mapstructure:"disk_image" required:"false" cty:"disk_image"`
+	CloudInit                 *bool             `mapstructure:"cloud_init" required:"false" cty:"cloud_init"`
 	UseBackingFile            *bool             `mapstructure:"use_backing_file" required:"false" cty:"use_backing_file"`
 	MachineType               *string           `mapstructure:"machine_type" required:"false" cty:"machine_type"`
 	MemorySize                *int              `mapstructure:"memory" required:"false" cty:"memory"`
@@ -195,6 +197,7 @@ func (*FlatConfig) HCL2Spec() map[string]hcldec.Spec {
 		"floppy_dirs":                  &hcldec.AttrSpec{Name: "floppy_dirs", Type: cty.List(cty.String), Required: false},
 		"floppy_label":                 &hcldec.AttrSpec{Name: "floppy_label", Type: cty.String, Required: false},
 		"iso_skip_cache":               &hcldec.AttrSpec{Name: "iso_skip_cache", Type: cty.Bool, Required: false},
+		"attach_isos":                  &hcldec.AttrSpec{Name: "attach_isos", Type: cty.List(cty.String), Required: false},
 		"accelerator":                  &hcldec.AttrSpec{Name: "accelerator", Type: cty.String, Required: false},
 		"disk_additional_size":         &hcldec.AttrSpec{Name: "disk_additional_size", Type: cty.List(cty.String), Required: false},
 		"cpus":                         &hcldec.AttrSpec{Name: "cpus", Type: cty.Number, Required: false},
@@ -208,6 +211,7 @@ func (*FlatConfig) HCL2Spec() map[string]hcldec.Spec {
 		"format":                       &hcldec.AttrSpec{Name: "format", Type: cty.String, Required: false},
 		"headless":                     &hcldec.AttrSpec{Name: "headless", Type: cty.Bool, Required: false},
 		"disk_image":                   &hcldec.AttrSpec{Name: "disk_image", Type: cty.Bool, Required: false},
+		"cloud_init":                   &hcldec.AttrSpec{Name: "cloud_init", Type: cty.Bool, Required: false},
 		"use_backing_file":             &hcldec.AttrSpec{Name: "use_backing_file", Type: cty.Bool, Required: false},
 		"machine_type":                 &hcldec.AttrSpec{Name: "machine_type", Type: cty.String, Required: false},
 		"memory":                       &hcldec.AttrSpec{Name: "memory", Type: cty.Number, Required: false},
@@ -0,0 +1,182 @@
+package qemu
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/hashicorp/packer/common/uuid"
+	"github.com/hashicorp/packer/helper/multistep"
+	"github.com/hashicorp/packer/helper/ssh"
+	"github.com/hashicorp/packer/packer"
+)
+
+// stepCloudInitSeed generates a cloud-init NoCloud seed ISO carrying the
+// credentials for the communicator and puts its path into the state bag
+// under "cloud_init_seed_path" so that the VM is booted with it attached.
+// If no SSH credentials were configured, an ephemeral SSH key pair is
+// created and installed through the seed.
+type stepCloudInitSeed struct {
+	seedDir string
+}
+
+func (s *stepCloudInitSeed) Run(ctx context.Context, state multistep.StateBag) multistep.StepAction {
+	config := state.Get("config").(*Config)
+	ui := state.Get("ui").(packer.Ui)
+	comm := &config.Comm
+
+	if comm.SSHPassword == "" {
+		if comm.SSHPrivateKeyFile != "" {
+			ui.Say("Using existing SSH private key for cloud-init...")
+			privateKeyBytes, err := comm.ReadSSHPrivateKeyFile()
+			if err != nil {
+				state.Put("error", err)
+				return multistep.ActionHalt
+			}
+
+			kp, err := ssh.KeyPairFromPrivateKey(ssh.FromPrivateKeyConfig{
+				RawPrivateKeyPemBlock: privateKeyBytes,
+				Comment:               fmt.Sprintf("packer_%s", uuid.TimeOrderedUUID()),
+			})
+			if err != nil {
+				state.Put("error", err)
+				return multistep.ActionHalt
+			}
+
+			comm.SSHPrivateKey = privateKeyBytes
+			comm.SSHPublicKey = kp.PublicKeyAuthorizedKeysLine
+		} else {
+			ui.Say("Creating ephemeral SSH key pair for cloud-init...")
+			kp, err := ssh.NewKeyPair(ssh.CreateKeyPairConfig{
+				Comment: fmt.Sprintf("packer_%s", uuid.TimeOrderedUUID()),
+			})
+			if err != nil {
+				state.Put("error", fmt.Errorf("Error creating temporary keypair: %s", err))
+				return multistep.ActionHalt
+			}
+
+			comm.SSHPrivateKey = kp.PrivateKeyPemBlock
+			comm.SSHPublicKey = kp.PublicKeyAuthorizedKeysLine
+			comm.SSHClearAuthorizedKeys = true
+		}
+	}
+
+	// The seed is written outside of the output directory on purpose: the
+	// output directory is walked to build the artifact file list and the
+	// seed must not leak the private credentials into the artifact.
+	seedDir, err := ioutil.TempDir("", "packer-qemu-cloudinit")
+	if err != nil {
+		state.Put("error", fmt.Errorf("Error creating seed directory: %s", err))
+		return multistep.ActionHalt
+	}
+	s.seedDir = seedDir
+
+	userData := s.userData(config)
+	metaData := fmt.Sprintf(
+		"instance-id: packer-%s\nlocal-hostname: %s\n",
+		config.VMName, config.VMName)
+
+	if err := ioutil.WriteFile(
+		filepath.Join(seedDir, "user-data"), []byte(userData), 0600); err != nil {
+		state.Put("error", fmt.Errorf("Error writing user-data: %s", err))
+		return multistep.ActionHalt
+	}
+	if err := ioutil.WriteFile(
+		filepath.Join(seedDir, "meta-data"), []byte(metaData), 0600); err != nil {
+		state.Put("error", fmt.Errorf("Error writing meta-data: %s", err))
+		return multistep.ActionHalt
+	}
+
+	ui.Say("Creating cloud-init NoCloud seed ISO...")
+	seedPath := filepath.Join(seedDir, "seed.iso")
+	if err := createSeedISO(seedDir, seedPath); err != nil {
+		state.Put("error", fmt.Errorf("Error creating seed ISO: %s", err))
+		return multistep.ActionHalt
+	}
+
+	state.Put("cloud_init_seed_path", seedPath)
+	return multistep.ActionContinue
+}
+
+// userData renders the #cloud-config document installing the communicator
+// credentials for the configured user.
+func (s *stepCloudInitSeed) userData(config *Config) string {
+	comm := &config.Comm
+
+	var b strings.Builder
+	b.WriteString("#cloud-config\n")
+	if comm.SSHPassword != "" {
+		b.WriteString("ssh_pwauth: true\n")
+	}
+	b.WriteString("users:\n")
+	fmt.Fprintf(&b, "  - name: %s\n", comm.User())
+	b.WriteString("    sudo: ALL=(ALL) NOPASSWD:ALL\n")
+	b.WriteString("    shell: /bin/bash\n")
+	if comm.SSHPassword != "" {
+		fmt.Fprintf(&b, "    plain_text_passwd: %s\n", comm.SSHPassword)
+		b.WriteString("    lock_passwd: false\n")
+	} else {
+		b.WriteString("    lock_passwd: true\n")
+		b.WriteString("    ssh_authorized_keys:\n")
+		fmt.Fprintf(&b, "      - %s\n",
+			strings.TrimSpace(string(comm.SSHPublicKey)))
+	}
+
+	return b.String()
+}
+
+// createSeedISO builds the seed ISO from the user-data and meta-data files
+// in dir using whichever supported ISO creation tool is installed.
+func createSeedISO(dir, path string) error {
+	if runtime.GOOS == "darwin" {
+		hdiutil, err := exec.LookPath("hdiutil")
+		if err != nil {
+			return fmt.Errorf("hdiutil not found: %s", err)
+		}
+		cmd := exec.Command(hdiutil, "makehybrid", "-o", path,
+			"-hfs", "-joliet", "-iso", "-default-volume-name", "cidata", dir)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			return fmt.Errorf("%s: %s", err, out)
+		}
+		return nil
+	}
+
+	var isoCmd string
+	var isoArgs []string
+	for _, bin := range []string{"genisoimage", "mkisofs", "xorriso"} {
+		cmdPath, err := exec.LookPath(bin)
+		if err != nil {
+			continue
+		}
+		isoCmd = cmdPath
+		if bin == "xorriso" {
+			isoArgs = []string{"-as", "genisoimage"}
+		}
+		break
+	}
+	if isoCmd == "" {
+		return fmt.Errorf(
+			"no supported ISO creation tool found; install genisoimage, mkisofs or xorriso")
+	}
+
+	isoArgs = append(isoArgs,
+		"-output", path, "-volid", "cidata", "-joliet", "-rock",
+		filepath.Join(dir, "user-data"), filepath.Join(dir, "meta-data"))
+	cmd := exec.Command(isoCmd, isoArgs...)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("%s: %s", err, out)
+	}
+
+	return nil
+}
+
+func (s *stepCloudInitSeed) Cleanup(state multistep.StateBag) {
+	if s.seedDir != "" {
+		os.RemoveAll(s.seedDir)
+	}
+}
@@ -145,6 +145,11 @@ func getCommandArgs(bootDrive string, state multistep.StateBag) ([]string, error
 	}
 	deviceArgs = append(deviceArgs, fmt.Sprintf("%s,netdev=user.0", config.NetDevice))
 
+	// Attach any additional ISOs as extra CD-ROM drives, after the boot ISO.
+	for _, attachPath := range config.AttachISOs {
+		driveArgs = append(driveArgs, fmt.Sprintf("file=%s,media=cdrom", attachPath))
+	}
+
 	if config.Headless == true {
 		vncPortRaw, vncPortOk := state.GetOk("vnc_port")
 		vncPass := state.Get("vnc_password")
@@ -188,6 +193,9 @@ func getCommandArgs(bootDrive string, state multistep.StateBag) ([]string, error
 
 	if !config.DiskImage {
 		defaultArgs["-cdrom"] = isoPath
+	} else if seedPath, ok := state.GetOk("cloud_init_seed_path"); ok {
+		// Attach the cloud-init NoCloud seed generated by stepCloudInitSeed.
+		defaultArgs["-cdrom"] = seedPath.(string)
 	}
 	defaultArgs["-boot"] = bootDrive
 	defaultArgs["-m"] = fmt.Sprintf("%dM", config.MemorySize)
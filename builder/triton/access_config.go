@@ -27,8 +27,11 @@ type AccessConfig struct {
 	// The username of the Triton account to use when
 	// using the Triton Cloud API.
 	Account string `mapstructure:"triton_account" required:"true"`
-	// The username of a user who has access to your
-	// Triton account.
+	// The username of an RBAC user who has access to
+	// your Triton account. When set, Packer authenticates as this subuser
+	// and requests are signed with the subuser's key
+	// (`/$account/users/$user/keys/$fingerprint`) rather than the key of
+	// the account owner.
 	Username string `mapstructure:"triton_user" required:"false"`
 	// The fingerprint of the public key of the SSH key
 	// pair to use for authentication with the Triton Cloud API. If
@@ -45,6 +48,17 @@ type AccessConfig struct {
 	// installation such as Cloud-On-A-Laptop which does not generally use a
 	// certificate signed by a trusted root CA. The default is false.
 	InsecureSkipTLSVerify bool `mapstructure:"insecure_skip_tls_verify" required:"false"`
+	// The host of the Triton Docker-compatible endpoint to use when building
+	// 'docker' brand images, for example
+	// tcp://us-sw-1.docker.joyent.com:2376. If omitted it defaults to the
+	// DOCKER_HOST environment variable. Only used (and required) when
+	// source_docker_image is set.
+	DockerEndpoint string `mapstructure:"triton_docker_endpoint" required:"false"`
+	// Path to the directory holding the TLS client certificates for the
+	// Docker endpoint (ca.pem, cert.pem and key.pem), as generated by
+	// sdc-docker-setup.sh. If omitted it defaults to the DOCKER_CERT_PATH
+	// environment variable. Only used when source_docker_image is set.
+	DockerCertPath string `mapstructure:"triton_docker_cert_path" required:"false"`
 
 	signer authentication.Signer
 }
@@ -54,6 +68,33 @@ type AccessConfig struct {
 func (c *AccessConfig) Prepare(ctx *interpolate.Context) []error {
 	var errs []error
 
+	// Fall back to the TRITON_* (or legacy SDC_*) environment variables
+	// for anything not set in the template, mirroring the behavior of the
+	// triton CLI tooling. Combined with SSH agent signing this means no
+	// credentials or key material have to be stored in the template or on
+	// disk at all.
+	if c.Endpoint == "" {
+		c.Endpoint = tgo.GetEnv("URL")
+	}
+	if c.Account == "" {
+		c.Account = tgo.GetEnv("ACCOUNT")
+	}
+	if c.Username == "" {
+		c.Username = tgo.GetEnv("USER")
+	}
+	if c.KeyID == "" {
+		c.KeyID = tgo.GetEnv("KEY_ID")
+	}
+	if c.KeyMaterial == "" {
+		c.KeyMaterial = tgo.GetEnv("KEY_MATERIAL")
+	}
+	if c.DockerEndpoint == "" {
+		c.DockerEndpoint = os.Getenv("DOCKER_HOST")
+	}
+	if c.DockerCertPath == "" {
+		c.DockerCertPath = os.Getenv("DOCKER_CERT_PATH")
+	}
+
 	if c.Endpoint == "" {
 		// Use Joyent public cloud as the default endpoint if none is specified
 		c.Endpoint = "https://us-sw-1.api.joyent.com"
@@ -1,6 +1,7 @@
 package triton
 
 import (
+	"os"
 	"testing"
 )
 
@@ -26,6 +27,33 @@ func TestAccessConfig_Prepare(t *testing.T) {
 	}
 }
 
+func TestAccessConfig_PrepareFromEnvironment(t *testing.T) {
+	env := map[string]string{
+		"TRITON_URL":          "test-endpoint",
+		"TRITON_ACCOUNT":      "test-account",
+		"TRITON_USER":         "test-subuser",
+		"TRITON_KEY_ID":       "c5:9d:37:d2:28:d3:ef:39:1b:0a:0e:37:d5:b4:7c:59",
+		"TRITON_KEY_MATERIAL": testKeyMaterial,
+	}
+	for k, v := range env {
+		os.Setenv(k, v)
+		defer os.Unsetenv(k)
+	}
+
+	ac := AccessConfig{}
+	errs := ac.Prepare(nil)
+	if errs != nil {
+		t.Fatalf("should not error: %v", errs)
+	}
+
+	if ac.Account != "test-account" {
+		t.Fatalf("bad account: %s", ac.Account)
+	}
+	if ac.Username != "test-subuser" {
+		t.Fatalf("bad user: %s", ac.Username)
+	}
+}
+
 func testAccessConfig() AccessConfig {
 	return AccessConfig{
 		Endpoint:    "test-endpoint",
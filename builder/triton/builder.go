@@ -2,6 +2,7 @@ package triton
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/hashicorp/go-multierror"
 	"github.com/hashicorp/hcl/v2/hcldec"
@@ -39,6 +40,17 @@ func (b *Builder) Prepare(raws ...interface{}) ([]string, []string, error) {
 	errs = multierror.Append(errs, b.config.Comm.Prepare(&b.config.ctx)...)
 	errs = multierror.Append(errs, b.config.TargetImageConfig.Prepare(&b.config.ctx)...)
 
+	if b.config.SourceDockerImage != "" {
+		if b.config.AccessConfig.DockerEndpoint == "" {
+			errs = multierror.Append(errs, fmt.Errorf(
+				"triton_docker_endpoint is required when using a source_docker_image"))
+		}
+		if b.config.BastionMachineName != "" || len(b.config.BastionMachineTags) > 0 {
+			errs = multierror.Append(errs, fmt.Errorf(
+				"An SSH bastion cannot be used with a source_docker_image"))
+		}
+	}
+
 	// If we are using an SSH agent to sign requests, and no private key has been
 	// specified for SSH, use the agent for connecting for provisioning.
 	if b.config.AccessConfig.KeyMaterial == "" && b.config.Comm.SSHPrivateKeyFile == "" {
@@ -51,7 +63,15 @@ func (b *Builder) Prepare(raws ...interface{}) ([]string, []string, error) {
 func (b *Builder) Run(ctx context.Context, ui packer.Ui, hook packer.Hook) (packer.Artifact, error) {
 	config := b.config
 
-	driver, err := NewDriverTriton(ui, config)
+	// A source_docker_image switches the build over to Triton's
+	// Docker-compatible endpoint, producing a docker brand image.
+	var driver Driver
+	var err error
+	if config.SourceDockerImage != "" {
+		driver, err = NewDriverDocker(ui, config)
+	} else {
+		driver, err = NewDriverTriton(ui, config)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -65,6 +85,9 @@ func (b *Builder) Run(ctx context.Context, ui packer.Ui, hook packer.Hook) (pack
 
 	steps := []multistep.Step{
 		&StepCreateSourceMachine{},
+		&StepResolveBastion{
+			Comm: &config.Comm,
+		},
 		&communicator.StepConnect{
 			Config:    &config.Comm,
 			Host:      commHost(b.config.Comm.Host()),
@@ -74,6 +97,7 @@ func (b *Builder) Run(ctx context.Context, ui packer.Ui, hook packer.Hook) (pack
 		&common.StepCleanupTempKeys{
 			Comm: &config.Comm,
 		},
+		&StepDeleteMachineMetadata{},
 		&StepStopMachine{},
 		&StepCreateImageFromMachine{},
 		&StepDeleteMachine{},
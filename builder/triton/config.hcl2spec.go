@@ -23,15 +23,22 @@ type FlatConfig struct {
 	KeyID                     *string                      `mapstructure:"triton_key_id" required:"true" cty:"triton_key_id"`
 	KeyMaterial               *string                      `mapstructure:"triton_key_material" required:"false" cty:"triton_key_material"`
 	InsecureSkipTLSVerify     *bool                        `mapstructure:"insecure_skip_tls_verify" required:"false" cty:"insecure_skip_tls_verify"`
+	DockerEndpoint            *string                      `mapstructure:"triton_docker_endpoint" required:"false" cty:"triton_docker_endpoint"`
+	DockerCertPath            *string                      `mapstructure:"triton_docker_cert_path" required:"false" cty:"triton_docker_cert_path"`
 	MachineName               *string                      `mapstructure:"source_machine_name" required:"false" cty:"source_machine_name"`
 	MachinePackage            *string                      `mapstructure:"source_machine_package" required:"true" cty:"source_machine_package"`
 	MachineImage              *string                      `mapstructure:"source_machine_image" required:"true" cty:"source_machine_image"`
+	SourceDockerImage         *string                      `mapstructure:"source_docker_image" required:"false" cty:"source_docker_image"`
 	MachineNetworks           []string                     `mapstructure:"source_machine_networks" required:"false" cty:"source_machine_networks"`
 	MachineMetadata           map[string]string            `mapstructure:"source_machine_metadata" required:"false" cty:"source_machine_metadata"`
+	MachineUserScriptFile     *string                      `mapstructure:"source_machine_user_script_file" required:"false" cty:"source_machine_user_script_file"`
 	MachineTags               map[string]string            `mapstructure:"source_machine_tags" required:"false" cty:"source_machine_tags"`
 	MachineTag                []hcl2template.FlatKeyValue  `mapstructure:"source_machine_tag" required:"false" cty:"source_machine_tag"`
 	MachineFirewallEnabled    *bool                        `mapstructure:"source_machine_firewall_enabled" required:"false" cty:"source_machine_firewall_enabled"`
+	KeepInstanceOnFailure     *bool                        `mapstructure:"keep_instance_on_failure" required:"false" cty:"keep_instance_on_failure"`
 	MachineImageFilters       *FlatMachineImageFilter      `mapstructure:"source_machine_image_filter" required:"false" cty:"source_machine_image_filter"`
+	BastionMachineName        *string                      `mapstructure:"bastion_machine_name" required:"false" cty:"bastion_machine_name"`
+	BastionMachineTags        map[string]string            `mapstructure:"bastion_machine_tags" required:"false" cty:"bastion_machine_tags"`
 	ImageName                 *string                      `mapstructure:"image_name" required:"true" cty:"image_name"`
 	ImageVersion              *string                      `mapstructure:"image_version" required:"true" cty:"image_version"`
 	ImageDescription          *string                      `mapstructure:"image_description" required:"false" cty:"image_description"`
@@ -109,15 +116,22 @@ func (*FlatConfig) HCL2Spec() map[string]hcldec.Spec {
 		"triton_key_id":                   &hcldec.AttrSpec{Name: "triton_key_id", Type: cty.String, Required: false},
 		"triton_key_material":             &hcldec.AttrSpec{Name: "triton_key_material", Type: cty.String, Required: false},
 		"insecure_skip_tls_verify":        &hcldec.AttrSpec{Name: "insecure_skip_tls_verify", Type: cty.Bool, Required: false},
+		"triton_docker_endpoint":          &hcldec.AttrSpec{Name: "triton_docker_endpoint", Type: cty.String, Required: false},
+		"triton_docker_cert_path":         &hcldec.AttrSpec{Name: "triton_docker_cert_path", Type: cty.String, Required: false},
 		"source_machine_name":             &hcldec.AttrSpec{Name: "source_machine_name", Type: cty.String, Required: false},
 		"source_machine_package":          &hcldec.AttrSpec{Name: "source_machine_package", Type: cty.String, Required: false},
 		"source_machine_image":            &hcldec.AttrSpec{Name: "source_machine_image", Type: cty.String, Required: false},
+		"source_docker_image":             &hcldec.AttrSpec{Name: "source_docker_image", Type: cty.String, Required: false},
 		"source_machine_networks":         &hcldec.AttrSpec{Name: "source_machine_networks", Type: cty.List(cty.String), Required: false},
 		"source_machine_metadata":         &hcldec.AttrSpec{Name: "source_machine_metadata", Type: cty.Map(cty.String), Required: false},
+		"source_machine_user_script_file": &hcldec.AttrSpec{Name: "source_machine_user_script_file", Type: cty.String, Required: false},
 		"source_machine_tags":             &hcldec.AttrSpec{Name: "source_machine_tags", Type: cty.Map(cty.String), Required: false},
 		"source_machine_tag":              &hcldec.BlockListSpec{TypeName: "source_machine_tag", Nested: hcldec.ObjectSpec((*hcl2template.FlatKeyValue)(nil).HCL2Spec())},
 		"source_machine_firewall_enabled": &hcldec.AttrSpec{Name: "source_machine_firewall_enabled", Type: cty.Bool, Required: false},
+		"keep_instance_on_failure":        &hcldec.AttrSpec{Name: "keep_instance_on_failure", Type: cty.Bool, Required: false},
 		"source_machine_image_filter":     &hcldec.BlockSpec{TypeName: "source_machine_image_filter", Nested: hcldec.ObjectSpec((*FlatMachineImageFilter)(nil).HCL2Spec())},
+		"bastion_machine_name":            &hcldec.AttrSpec{Name: "bastion_machine_name", Type: cty.String, Required: false},
+		"bastion_machine_tags":            &hcldec.AttrSpec{Name: "bastion_machine_tags", Type: cty.Map(cty.String), Required: false},
 		"image_name":                      &hcldec.AttrSpec{Name: "image_name", Type: cty.String, Required: false},
 		"image_version":                   &hcldec.AttrSpec{Name: "image_version", Type: cty.String, Required: false},
 		"image_description":               &hcldec.AttrSpec{Name: "image_description", Type: cty.String, Required: false},
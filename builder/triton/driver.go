@@ -10,6 +10,8 @@ type Driver interface {
 	CreateMachine(config Config) (string, error)
 	DeleteImage(imageId string) error
 	DeleteMachine(machineId string) error
+	DeleteMachineMetadata(machineId string, key string) error
+	GetBastionIP(name string, tags map[string]string) (string, error)
 	GetMachineIP(machineId string) (string, error)
 	StopMachine(machineId string) error
 	WaitForImageCreation(imageId string, timeout time.Duration) error
@@ -0,0 +1,180 @@
+package triton
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/packer/packer"
+)
+
+// driverDocker builds 'docker' brand images by driving the docker CLI
+// against Triton's Docker-compatible endpoint. CloudAPI cannot create
+// docker containers or commit them into images, so this driver covers the
+// same Driver operations with their docker equivalents instead.
+type driverDocker struct {
+	endpoint string
+	certPath string
+	ui       packer.Ui
+}
+
+func NewDriverDocker(ui packer.Ui, config Config) (Driver, error) {
+	if _, err := exec.LookPath("docker"); err != nil {
+		return nil, fmt.Errorf(
+			"Building a docker brand image requires the docker CLI in the path: %s", err)
+	}
+
+	return &driverDocker{
+		endpoint: config.AccessConfig.DockerEndpoint,
+		certPath: config.AccessConfig.DockerCertPath,
+		ui:       ui,
+	}, nil
+}
+
+// dockerArgs prefixes the given docker subcommand with the connection
+// arguments for the Triton Docker endpoint.
+func (d *driverDocker) dockerArgs(args ...string) []string {
+	connArgs := []string{"--host", d.endpoint}
+	if d.certPath != "" {
+		connArgs = append(connArgs, "--tlsverify", "--tlscacert", d.certPath+"/ca.pem",
+			"--tlscert", d.certPath+"/cert.pem", "--tlskey", d.certPath+"/key.pem")
+	}
+	return append(connArgs, args...)
+}
+
+// docker runs a docker CLI subcommand against the Triton Docker endpoint
+// and returns its trimmed standard output.
+func (d *driverDocker) docker(args ...string) (string, error) {
+	var stdout, stderr strings.Builder
+
+	cmd := exec.Command("docker", d.dockerArgs(args...)...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if message := strings.TrimSpace(stderr.String()); message != "" {
+			return "", fmt.Errorf("%s", message)
+		}
+		return "", err
+	}
+
+	return strings.TrimSpace(stdout.String()), nil
+}
+
+func (d *driverDocker) GetImage(config Config) (string, error) {
+	return "", fmt.Errorf("source_machine_image_filter is not supported when building docker brand images")
+}
+
+func (d *driverDocker) CreateImageFromMachine(machineId string, config Config) (string, error) {
+	// Unlike CloudAPI image creation, a docker commit through the Triton
+	// Docker endpoint is synchronous.
+	name := config.ImageName + ":" + config.ImageVersion
+	imageId, err := d.docker("commit", machineId, name)
+	if err != nil {
+		return "", err
+	}
+
+	d.ui.Message(fmt.Sprintf("Committed container as image %s (%s)", name, imageId))
+	return imageId, nil
+}
+
+func (d *driverDocker) CreateMachine(config Config) (string, error) {
+	name := config.MachineName
+	if name == "" {
+		name = "packer-builder-" + config.ImageName
+	}
+
+	args := []string{"run", "--detach", "--name", name}
+	// Triton stores docker labels as machine tags.
+	for key, value := range config.MachineTags {
+		args = append(args, "--label", key+"="+value)
+	}
+
+	// Triton packages take the place of docker resource limits; the
+	// endpoint picks a default package when none is given via the
+	// com.joyent.package label.
+	if config.MachinePackage != "" {
+		args = append(args, "--label", "com.joyent.package="+config.MachinePackage)
+	}
+
+	args = append(args, config.SourceDockerImage)
+
+	return d.docker(args...)
+}
+
+func (d *driverDocker) DeleteImage(imageId string) error {
+	_, err := d.docker("rmi", imageId)
+	return err
+}
+
+func (d *driverDocker) DeleteMachine(machineId string) error {
+	_, err := d.docker("rm", machineId)
+	return err
+}
+
+func (d *driverDocker) DeleteMachineMetadata(machineId string, key string) error {
+	// Docker mode never sets machine metadata, so there is nothing to
+	// remove.
+	return nil
+}
+
+func (d *driverDocker) GetBastionIP(name string, tags map[string]string) (string, error) {
+	return "", fmt.Errorf("An SSH bastion is not supported when building docker brand images")
+}
+
+func (d *driverDocker) GetMachineIP(machineId string) (string, error) {
+	// Triton assigns each docker container a real IP, which the endpoint
+	// reports as the container's primary address.
+	return d.docker("inspect", "--format", "{{.NetworkSettings.IPAddress}}", machineId)
+}
+
+func (d *driverDocker) StopMachine(machineId string) error {
+	_, err := d.docker("stop", machineId)
+	return err
+}
+
+func (d *driverDocker) WaitForImageCreation(imageId string, timeout time.Duration) error {
+	// The image was committed synchronously in CreateImageFromMachine, so
+	// there is no creation to wait for.
+	return nil
+}
+
+func (d *driverDocker) WaitForMachineDeletion(machineId string, timeout time.Duration) error {
+	return waitFor(
+		func() (bool, error) {
+			// Inspecting a removed container is the only failure we
+			// expect here, so any error counts as deleted.
+			if _, err := d.docker("inspect", "--format", "{{.State.Status}}", machineId); err != nil {
+				return true, nil
+			}
+			return false, nil
+		},
+		3*time.Second,
+		timeout,
+	)
+}
+
+func (d *driverDocker) WaitForMachineState(machineId string, state string, timeout time.Duration) error {
+	return waitFor(
+		func() (bool, error) {
+			status, err := d.docker("inspect", "--format", "{{.State.Status}}", machineId)
+			if err != nil {
+				return false, err
+			}
+			return dockerStateMatches(state, status), nil
+		},
+		3*time.Second,
+		timeout,
+	)
+}
+
+// dockerStateMatches reports whether the container status reported by the
+// docker CLI corresponds to the requested CloudAPI machine state, as the
+// two use slightly different vocabularies for stopped containers.
+func dockerStateMatches(state string, status string) bool {
+	if state == "stopped" {
+		return status == "stopped" || status == "exited"
+	}
+	return status == state
+}
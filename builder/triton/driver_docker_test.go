@@ -0,0 +1,52 @@
+package triton
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDriverDocker_dockerArgs(t *testing.T) {
+	d := &driverDocker{
+		endpoint: "tcp://us-sw-1.docker.joyent.com:2376",
+	}
+
+	args := d.dockerArgs("stop", "machine-id")
+	expected := []string{"--host", "tcp://us-sw-1.docker.joyent.com:2376", "stop", "machine-id"}
+	if !reflect.DeepEqual(args, expected) {
+		t.Fatalf("bad args: %#v", args)
+	}
+
+	d.certPath = "/home/soandso/.triton/docker"
+	args = d.dockerArgs("stop", "machine-id")
+	expected = []string{
+		"--host", "tcp://us-sw-1.docker.joyent.com:2376",
+		"--tlsverify",
+		"--tlscacert", "/home/soandso/.triton/docker/ca.pem",
+		"--tlscert", "/home/soandso/.triton/docker/cert.pem",
+		"--tlskey", "/home/soandso/.triton/docker/key.pem",
+		"stop", "machine-id",
+	}
+	if !reflect.DeepEqual(args, expected) {
+		t.Fatalf("bad args: %#v", args)
+	}
+}
+
+func TestDockerStateMatches(t *testing.T) {
+	cases := []struct {
+		State    string
+		Status   string
+		Expected bool
+	}{
+		{"running", "running", true},
+		{"running", "exited", false},
+		{"stopped", "stopped", true},
+		{"stopped", "exited", true},
+		{"stopped", "running", false},
+	}
+
+	for _, tc := range cases {
+		if matches := dockerStateMatches(tc.State, tc.Status); matches != tc.Expected {
+			t.Errorf("dockerStateMatches(%q, %q) should be %t", tc.State, tc.Status, tc.Expected)
+		}
+	}
+}
@@ -17,9 +17,14 @@ type DriverMock struct {
 	DeleteMachineId  string
 	DeleteMachineErr error
 
+	DeleteMachineMetadataKeys []string
+	DeleteMachineMetadataErr  error
+
 	GetImageId  string
 	GetImageErr error
 
+	GetBastionIPErr error
+
 	GetMachineErr error
 
 	StopMachineId  string
@@ -80,6 +85,24 @@ func (d *DriverMock) DeleteMachine(machineId string) error {
 	return nil
 }
 
+func (d *DriverMock) DeleteMachineMetadata(machineId string, key string) error {
+	if d.DeleteMachineMetadataErr != nil {
+		return d.DeleteMachineMetadataErr
+	}
+
+	d.DeleteMachineMetadataKeys = append(d.DeleteMachineMetadataKeys, key)
+
+	return nil
+}
+
+func (d *DriverMock) GetBastionIP(name string, tags map[string]string) (string, error) {
+	if d.GetBastionIPErr != nil {
+		return "", d.GetBastionIPErr
+	}
+
+	return "bastion_ip", nil
+}
+
 func (d *DriverMock) GetMachineIP(machineId string) (string, error) {
 	if d.GetMachineErr != nil {
 		return "", d.GetMachineErr
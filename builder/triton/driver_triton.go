@@ -3,6 +3,7 @@ package triton
 import (
 	"context"
 	"errors"
+	"fmt"
 	"net/http"
 	"sort"
 	"time"
@@ -122,6 +123,44 @@ func (d *driverTriton) DeleteMachine(machineId string) error {
 	})
 }
 
+func (d *driverTriton) DeleteMachineMetadata(machineId string, key string) error {
+	computeClient, _ := d.client.Compute()
+	return computeClient.Instances().DeleteMetadata(context.Background(), &compute.DeleteMetadataInput{
+		ID:  machineId,
+		Key: key,
+	})
+}
+
+// GetBastionIP looks up a running machine to use as an SSH bastion, either
+// by its exact name or by tags, and returns its primary IP.
+func (d *driverTriton) GetBastionIP(name string, tags map[string]string) (string, error) {
+	computeClient, _ := d.client.Compute()
+
+	input := &compute.ListInstancesInput{
+		State: "running",
+		Name:  name,
+	}
+	if len(tags) > 0 {
+		input.Tags = make(map[string]interface{}, len(tags))
+		for key, value := range tags {
+			input.Tags[key] = value
+		}
+	}
+
+	machines, err := computeClient.Instances().List(context.Background(), input)
+	if err != nil {
+		return "", err
+	}
+
+	for _, machine := range machines {
+		if machine.PrimaryIP != "" {
+			return machine.PrimaryIP, nil
+		}
+	}
+
+	return "", fmt.Errorf("No running machine with a primary IP matched the bastion selection")
+}
+
 func (d *driverTriton) GetMachineIP(machineId string) (string, error) {
 	computeClient, _ := d.client.Compute()
 	machine, err := computeClient.Instances().Get(context.Background(), &compute.GetInstanceInput{
@@ -189,7 +228,14 @@ func (d *driverTriton) WaitForMachineDeletion(machineId string, timeout time.Dur
 	)
 }
 
+// WaitForImageCreation polls the image with the given ID until it reaches
+// the "active" state. CloudAPI does not expose its internal changefeed for
+// images, so each observed state transition (queued, importing, ...) is
+// reported through the UI to give some insight into long-running or stuck
+// image creation. A "failed" state aborts the wait immediately rather than
+// running out the timeout.
 func (d *driverTriton) WaitForImageCreation(imageId string, timeout time.Duration) error {
+	lastState := ""
 	return waitFor(
 		func() (bool, error) {
 			computeClient, _ := d.client.Compute()
@@ -199,6 +245,13 @@ func (d *driverTriton) WaitForImageCreation(imageId string, timeout time.Duratio
 			if image == nil {
 				return false, err
 			}
+			if image.State != lastState {
+				d.ui.Message(fmt.Sprintf("Image %s is now in state '%s'", imageId, image.State))
+				lastState = image.State
+			}
+			if image.State == "failed" {
+				return false, fmt.Errorf("Image %s entered state 'failed'", imageId)
+			}
 			return image.State == "active", err
 		},
 		3*time.Second,
@@ -5,6 +5,7 @@ package triton
 
 import (
 	"fmt"
+	"os"
 
 	"github.com/hashicorp/packer/hcl2template"
 	"github.com/hashicorp/packer/template/interpolate"
@@ -35,8 +36,19 @@ type SourceMachineConfig struct {
 	// cloud a valid source_machine_image could for example be
 	// 70e3ae72-96b6-11e6-9056-9737fd4d0764 for version 16.3.1 of the 64bit
 	// SmartOS base image (a 'joyent' brand image). source_machine_image_filter
-	// can be used to populate this UUID.
+	// can be used to populate this UUID. For 'docker' brand images use
+	// source_docker_image instead.
 	MachineImage string `mapstructure:"source_machine_image" required:"true"`
+	// The name (and optionally tag) of a Docker image to base the new image
+	// on, for example ubuntu:16.04. Setting this switches the builder into
+	// Docker mode: the source container and the resulting image are managed
+	// through Triton's Docker-compatible endpoint (see
+	// triton_docker_endpoint) with the docker CLI, and the resulting image
+	// is a 'docker' brand image usable by Triton's container service. The
+	// image must keep its container running and reachable over SSH for
+	// provisioning. Cannot be combined with source_machine_image or
+	// source_machine_image_filter.
+	SourceDockerImage string `mapstructure:"source_docker_image" required:"false"`
 	// The UUID's of Triton
 	// networks added to the source machine used for creating the image. For
 	// example if any of the provisioners which are run need Internet access you
@@ -53,6 +65,15 @@ type SourceMachineConfig struct {
 	// set the user-script metadata key to have Triton start a user supplied
 	// script after the VM has booted.
 	MachineMetadata map[string]string `mapstructure:"source_machine_metadata" required:"false"`
+	// Path to a shell script that is passed to the VM through the
+	// user-script metadata key and run by Triton when the VM boots. This is
+	// useful for images where SSH is not enabled until a bootstrap script
+	// has run. Scripts larger than a single metadata value allows are
+	// automatically split over several user-script-part-N keys and
+	// reassembled by a small loader script on the VM. All of these keys are
+	// removed from the machine again after provisioning, before the image
+	// is created.
+	MachineUserScriptFile string `mapstructure:"source_machine_user_script_file" required:"false"`
 	// Key/value pair tags applied to the VM used to create the image.
 	MachineTags map[string]string `mapstructure:"source_machine_tags" required:"false"`
 	// Same as [`source_machine_tags`](#source_machine_tags) but defined as a
@@ -68,9 +89,26 @@ type SourceMachineConfig struct {
 	// allows SSH traffic enabling the firewall will interfere with the SSH
 	// provisioner. The default is false.
 	MachineFirewallEnabled bool `mapstructure:"source_machine_firewall_enabled" required:"false"`
+	// Keep the VM used to create the image around if the build fails during
+	// provisioning, so that it can be inspected for debugging. The machine
+	// then has to be deleted manually. The default is false.
+	KeepInstanceOnFailure bool `mapstructure:"keep_instance_on_failure" required:"false"`
 	// Filters used to populate the
 	// source_machine_image field. Example:
 	MachineImageFilters MachineImageFilter `mapstructure:"source_machine_image_filter" required:"false"`
+	// Name of an existing, running machine to use as an SSH bastion for
+	// the communicator. This makes it possible to build images on machines
+	// that are only attached to private fabric networks, without attaching
+	// the public network. The bastion machine's primary IP is filled into
+	// ssh_bastion_host; the remaining ssh_bastion_* options (username, key,
+	// ...) are configured as usual on the communicator. Cannot be combined
+	// with bastion_machine_tags.
+	BastionMachineName string `mapstructure:"bastion_machine_name" required:"false"`
+	// Key/value pair tags used to look up a running machine to use as an
+	// SSH bastion, as an alternative to naming one explicitly with
+	// bastion_machine_name. The first running machine matching all tags and
+	// having a primary IP is used.
+	BastionMachineTags map[string]string `mapstructure:"bastion_machine_tags" required:"false"`
 }
 
 type MachineImageFilter struct {
@@ -100,6 +138,34 @@ func (c *SourceMachineConfig) Prepare(ctx *interpolate.Context) []error {
 		errs = append(errs, fmt.Errorf("You cannot specify a Machine Image and also Machine Name filter"))
 	}
 
+	if c.SourceDockerImage != "" {
+		if c.MachineImage != "" {
+			errs = append(errs, fmt.Errorf("You cannot specify both a source_machine_image and a source_docker_image"))
+		}
+		if !c.MachineImageFilters.Empty() {
+			errs = append(errs, fmt.Errorf("You cannot specify a source_machine_image_filter and also a source_docker_image"))
+		}
+		// These options configure the source machine through CloudAPI,
+		// which does not apply to containers managed through the Docker
+		// endpoint.
+		if len(c.MachineNetworks) > 0 {
+			errs = append(errs, fmt.Errorf("source_machine_networks cannot be used with a source_docker_image"))
+		}
+		if len(c.MachineMetadata) > 0 {
+			errs = append(errs, fmt.Errorf("source_machine_metadata cannot be used with a source_docker_image"))
+		}
+		if c.MachineUserScriptFile != "" {
+			errs = append(errs, fmt.Errorf("source_machine_user_script_file cannot be used with a source_docker_image"))
+		}
+		if c.MachineFirewallEnabled {
+			errs = append(errs, fmt.Errorf("source_machine_firewall_enabled cannot be used with a source_docker_image"))
+		}
+	}
+
+	if c.BastionMachineName != "" && len(c.BastionMachineTags) > 0 {
+		errs = append(errs, fmt.Errorf("You cannot specify both a bastion_machine_name and bastion_machine_tags"))
+	}
+
 	if c.MachineNetworks == nil {
 		c.MachineNetworks = []string{}
 	}
@@ -108,6 +174,17 @@ func (c *SourceMachineConfig) Prepare(ctx *interpolate.Context) []error {
 		c.MachineMetadata = make(map[string]string)
 	}
 
+	if c.MachineUserScriptFile != "" {
+		if _, ok := c.MachineMetadata["user-script"]; ok {
+			errs = append(errs, fmt.Errorf("You cannot specify a source_machine_user_script_file and also a user-script metadata key"))
+		}
+		if info, err := os.Stat(c.MachineUserScriptFile); err != nil {
+			errs = append(errs, fmt.Errorf("source_machine_user_script_file is invalid: %s", err))
+		} else if info.IsDir() {
+			errs = append(errs, fmt.Errorf("source_machine_user_script_file must point to a file"))
+		}
+	}
+
 	if c.MachineTags == nil {
 		c.MachineTags = make(map[string]string)
 	}
@@ -26,6 +26,27 @@ func TestSourceMachineConfig_Prepare(t *testing.T) {
 	}
 }
 
+func TestSourceMachineConfig_PrepareDockerImage(t *testing.T) {
+	sc := testSourceMachineConfig(t)
+	sc.MachineImage = ""
+	sc.MachineNetworks = nil
+	sc.MachineMetadata = nil
+	sc.SourceDockerImage = "ubuntu:16.04"
+	errs := sc.Prepare(nil)
+	if errs != nil {
+		t.Fatalf("should not error: %#v", sc)
+	}
+
+	// The CloudAPI source machine options cannot be combined with a
+	// docker source image.
+	sc = testSourceMachineConfig(t)
+	sc.SourceDockerImage = "ubuntu:16.04"
+	errs = sc.Prepare(nil)
+	if errs == nil {
+		t.Fatalf("should error: %#v", sc)
+	}
+}
+
 func testSourceMachineConfig(t *testing.T) SourceMachineConfig {
 	return SourceMachineConfig{
 		MachineName:    "test-machine",
@@ -3,6 +3,7 @@ package triton
 import (
 	"context"
 	"fmt"
+	"io/ioutil"
 	"time"
 
 	"github.com/hashicorp/packer/helper/multistep"
@@ -29,6 +30,26 @@ func (s *StepCreateSourceMachine) Run(ctx context.Context, state multistep.State
 		config.MachineImage = imageId
 	}
 
+	if config.MachineUserScriptFile != "" {
+		script, err := ioutil.ReadFile(config.MachineUserScriptFile)
+		if err != nil {
+			state.Put("error", fmt.Errorf("Problem reading user script file: %s", err))
+			return multistep.ActionHalt
+		}
+
+		metadata := userScriptMetadata(string(script))
+		if len(metadata) > 1 {
+			ui.Say(fmt.Sprintf("User script is larger than a single metadata value; splitting it over %d keys", len(metadata)-1))
+		}
+		for key, value := range metadata {
+			config.MachineMetadata[key] = value
+		}
+
+		// Remembered so the metadata can be removed again after
+		// provisioning, before the image is created.
+		state.Put("user_script_metadata_keys", sortedMetadataKeys(metadata))
+	}
+
 	machineId, err := driver.CreateMachine(*config)
 	if err != nil {
 		state.Put("error", fmt.Errorf("Problem creating source machine: %s", err))
@@ -56,6 +77,20 @@ func (s *StepCreateSourceMachine) Cleanup(state multistep.StateBag) {
 	machineIdRaw, ok := state.GetOk("machine")
 	if ok && machineIdRaw.(string) != "" {
 		machineId := machineIdRaw.(string)
+
+		config := state.Get("config").(*Config)
+		_, cancelled := state.GetOk(multistep.StateCancelled)
+		_, halted := state.GetOk(multistep.StateHalted)
+		if config.KeepInstanceOnFailure && (cancelled || halted) {
+			ui.Say(fmt.Sprintf("Build failed; keeping source machine (%s) around "+
+				"for debugging because keep_instance_on_failure is set.", machineId))
+			if ip, err := driver.GetMachineIP(machineId); err == nil && ip != "" {
+				ui.Message(fmt.Sprintf("IP address: %s", ip))
+			}
+			ui.Message("Delete the machine manually once you are done with it.")
+			return
+		}
+
 		ui.Say(fmt.Sprintf("Stopping source machine (%s)...", machineId))
 		err := driver.StopMachine(machineId)
 		if err != nil {
@@ -0,0 +1,39 @@
+package triton
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/packer/helper/multistep"
+	"github.com/hashicorp/packer/packer"
+)
+
+// StepDeleteMachineMetadata removes the user-script metadata keys from
+// the source machine after provisioning, so they do not linger on the
+// machine the image is created from.
+type StepDeleteMachineMetadata struct{}
+
+func (s *StepDeleteMachineMetadata) Run(ctx context.Context, state multistep.StateBag) multistep.StepAction {
+	driver := state.Get("driver").(Driver)
+	ui := state.Get("ui").(packer.Ui)
+	machineId := state.Get("machine").(string)
+
+	keysRaw, ok := state.GetOk("user_script_metadata_keys")
+	if !ok {
+		return multistep.ActionContinue
+	}
+
+	ui.Say("Removing user script metadata from source machine...")
+	for _, key := range keysRaw.([]string) {
+		if err := driver.DeleteMachineMetadata(machineId, key); err != nil {
+			state.Put("error", fmt.Errorf("Problem removing machine metadata key %q: %s", key, err))
+			return multistep.ActionHalt
+		}
+	}
+
+	return multistep.ActionContinue
+}
+
+func (s *StepDeleteMachineMetadata) Cleanup(state multistep.StateBag) {
+	// No cleanup
+}
@@ -0,0 +1,70 @@
+package triton
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/packer/helper/multistep"
+)
+
+func TestStepDeleteMachineMetadata(t *testing.T) {
+	state := testState(t)
+	step := new(StepDeleteMachineMetadata)
+	defer step.Cleanup(state)
+
+	driver := state.Get("driver").(*DriverMock)
+
+	machineId := "test-machine-id"
+	state.Put("machine", machineId)
+	state.Put("user_script_metadata_keys", []string{"user-script", "user-script-part-0"})
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	if len(driver.DeleteMachineMetadataKeys) != 2 {
+		t.Fatalf("should've deleted 2 metadata keys, got %v", driver.DeleteMachineMetadataKeys)
+	}
+}
+
+func TestStepDeleteMachineMetadata_NoKeys(t *testing.T) {
+	state := testState(t)
+	step := new(StepDeleteMachineMetadata)
+	defer step.Cleanup(state)
+
+	driver := state.Get("driver").(*DriverMock)
+
+	machineId := "test-machine-id"
+	state.Put("machine", machineId)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	if len(driver.DeleteMachineMetadataKeys) != 0 {
+		t.Fatalf("should not have deleted metadata keys, got %v", driver.DeleteMachineMetadataKeys)
+	}
+}
+
+func TestStepDeleteMachineMetadata_DeleteMachineMetadataError(t *testing.T) {
+	state := testState(t)
+	step := new(StepDeleteMachineMetadata)
+	defer step.Cleanup(state)
+
+	driver := state.Get("driver").(*DriverMock)
+
+	machineId := "test-machine-id"
+	state.Put("machine", machineId)
+	state.Put("user_script_metadata_keys", []string{"user-script"})
+
+	driver.DeleteMachineMetadataErr = errors.New("error")
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionHalt {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	if _, ok := state.GetOk("error"); !ok {
+		t.Fatalf("should have error")
+	}
+}
@@ -0,0 +1,49 @@
+package triton
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/packer/helper/communicator"
+	"github.com/hashicorp/packer/helper/multistep"
+	"github.com/hashicorp/packer/packer"
+)
+
+// StepResolveBastion fills in the communicator's bastion host from either
+// an explicit machine name or a tag lookup, so that machines attached only
+// to private fabric networks can be reached through an existing bastion.
+type StepResolveBastion struct {
+	Comm *communicator.Config
+}
+
+func (s *StepResolveBastion) Run(ctx context.Context, state multistep.StateBag) multistep.StepAction {
+	config := state.Get("config").(*Config)
+	driver := state.Get("driver").(Driver)
+	ui := state.Get("ui").(packer.Ui)
+
+	if config.BastionMachineName == "" && len(config.BastionMachineTags) == 0 {
+		return multistep.ActionContinue
+	}
+
+	// An explicitly configured bastion host wins over the lookup.
+	if s.Comm.SSHBastionHost != "" {
+		return multistep.ActionContinue
+	}
+
+	ui.Say("Selecting a bastion machine...")
+
+	bastionIP, err := driver.GetBastionIP(config.BastionMachineName, config.BastionMachineTags)
+	if err != nil {
+		state.Put("error", fmt.Errorf("Problem selecting a bastion machine: %s", err))
+		return multistep.ActionHalt
+	}
+
+	ui.Message(fmt.Sprintf("Using bastion host: %s", bastionIP))
+	s.Comm.SSHBastionHost = bastionIP
+
+	return multistep.ActionContinue
+}
+
+func (s *StepResolveBastion) Cleanup(state multistep.StateBag) {
+	// No cleanup
+}
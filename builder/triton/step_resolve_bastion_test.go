@@ -0,0 +1,80 @@
+package triton
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/packer/helper/communicator"
+	"github.com/hashicorp/packer/helper/multistep"
+)
+
+func TestStepResolveBastion(t *testing.T) {
+	state := testState(t)
+	config := state.Get("config").(*Config)
+	config.BastionMachineName = "test-bastion"
+
+	step := &StepResolveBastion{Comm: &communicator.Config{}}
+	defer step.Cleanup(state)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	if step.Comm.SSHBastionHost != "bastion_ip" {
+		t.Fatalf("bad bastion host: %s", step.Comm.SSHBastionHost)
+	}
+}
+
+func TestStepResolveBastion_notConfigured(t *testing.T) {
+	state := testState(t)
+
+	step := &StepResolveBastion{Comm: &communicator.Config{}}
+	defer step.Cleanup(state)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	if step.Comm.SSHBastionHost != "" {
+		t.Fatalf("bastion host should not be set: %s", step.Comm.SSHBastionHost)
+	}
+}
+
+func TestStepResolveBastion_explicitHostWins(t *testing.T) {
+	state := testState(t)
+	config := state.Get("config").(*Config)
+	config.BastionMachineTags = map[string]string{"role": "bastion"}
+
+	step := &StepResolveBastion{Comm: &communicator.Config{}}
+	step.Comm.SSHBastionHost = "explicit-host"
+	defer step.Cleanup(state)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	if step.Comm.SSHBastionHost != "explicit-host" {
+		t.Fatalf("bad bastion host: %s", step.Comm.SSHBastionHost)
+	}
+}
+
+func TestStepResolveBastion_lookupError(t *testing.T) {
+	state := testState(t)
+	config := state.Get("config").(*Config)
+	config.BastionMachineName = "test-bastion"
+
+	driver := state.Get("driver").(*DriverMock)
+	driver.GetBastionIPErr = errors.New("error")
+
+	step := &StepResolveBastion{Comm: &communicator.Config{}}
+	defer step.Cleanup(state)
+
+	if action := step.Run(context.Background(), state); action != multistep.ActionHalt {
+		t.Fatalf("bad action: %#v", action)
+	}
+
+	if _, ok := state.GetOk("error"); !ok {
+		t.Fatalf("should have error")
+	}
+}
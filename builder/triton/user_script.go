@@ -0,0 +1,58 @@
+package triton
+
+import (
+	"fmt"
+	"sort"
+)
+
+// userScriptChunkLimit is the maximum number of bytes of script placed in
+// a single metadata value. CloudAPI caps individual metadata values well
+// below the total metadata size limit, so larger scripts have to be
+// spread over several keys.
+const userScriptChunkLimit = 32 * 1024
+
+// userScriptLoader is used as the user-script when the real script is too
+// large for a single metadata value. It reassembles the chunks stored in
+// the user-script-part-N keys and runs the result.
+const userScriptLoader = `#!/bin/sh
+set -e
+script=/var/tmp/packer-user-script
+rm -f "$script"
+for part in $(mdata-list | grep '^user-script-part-' | sort -t - -k 4 -n); do
+  mdata-get "$part" >> "$script"
+done
+chmod +x "$script"
+exec "$script"
+`
+
+// userScriptMetadata returns the metadata needed to run the given script
+// when the source machine boots. Scripts that fit in a single metadata
+// value are passed directly as user-script; larger ones are split into
+// user-script-part-N chunks plus a loader script that reassembles them.
+func userScriptMetadata(script string) map[string]string {
+	if len(script) <= userScriptChunkLimit {
+		return map[string]string{"user-script": script}
+	}
+
+	metadata := map[string]string{"user-script": userScriptLoader}
+	for i := 0; len(script) > 0; i++ {
+		n := userScriptChunkLimit
+		if n > len(script) {
+			n = len(script)
+		}
+		metadata[fmt.Sprintf("user-script-part-%d", i)] = script[:n]
+		script = script[n:]
+	}
+	return metadata
+}
+
+// sortedMetadataKeys returns the keys of the given metadata in a stable
+// order, so the cleanup step and log output are deterministic.
+func sortedMetadataKeys(metadata map[string]string) []string {
+	keys := make([]string, 0, len(metadata))
+	for key := range metadata {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
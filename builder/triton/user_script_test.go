@@ -0,0 +1,43 @@
+package triton
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestUserScriptMetadata_Small(t *testing.T) {
+	script := "#!/bin/sh\necho hello\n"
+	metadata := userScriptMetadata(script)
+
+	if len(metadata) != 1 {
+		t.Fatalf("small script should use a single key, got %v", sortedMetadataKeys(metadata))
+	}
+	if metadata["user-script"] != script {
+		t.Fatalf("user-script should contain the script itself")
+	}
+}
+
+func TestUserScriptMetadata_Chunked(t *testing.T) {
+	script := strings.Repeat("a", userScriptChunkLimit*2+1)
+	metadata := userScriptMetadata(script)
+
+	if metadata["user-script"] != userScriptLoader {
+		t.Fatalf("user-script should contain the loader script")
+	}
+
+	var reassembled string
+	for i := 0; ; i++ {
+		part, ok := metadata[fmt.Sprintf("user-script-part-%d", i)]
+		if !ok {
+			break
+		}
+		reassembled += part
+	}
+	if reassembled != script {
+		t.Fatalf("reassembled chunks should equal the original script")
+	}
+	if len(metadata) != 4 {
+		t.Fatalf("expected loader plus 3 chunks, got %v", sortedMetadataKeys(metadata))
+	}
+}
@@ -82,7 +82,7 @@ type Driver interface {
 	DeleteSnapshot(string, *VBoxSnapshot) error
 }
 
-func NewDriver() (Driver, error) {
+func NewDriver(commandWrapper string) (Driver, error) {
 	var vboxmanagePath string
 
 	// On Windows, we check VBOX_INSTALL_PATH env var for the path
@@ -101,6 +101,13 @@ func NewDriver() (Driver, error) {
 		}
 	}
 
+	// With a custom command wrapper the binary does not have to exist on
+	// this machine; it only has to be resolvable wherever the wrapper
+	// runs it.
+	if vboxmanagePath == "" && wrapsCommand(commandWrapper) {
+		vboxmanagePath = "VBoxManage"
+	}
+
 	if vboxmanagePath == "" {
 		var err error
 		vboxmanagePath, err = exec.LookPath("VBoxManage")
@@ -110,7 +117,10 @@ func NewDriver() (Driver, error) {
 	}
 
 	log.Printf("VBoxManage path: %s", vboxmanagePath)
-	driver := &VBox42Driver{vboxmanagePath}
+	driver := &VBox42Driver{
+		VBoxManagePath: vboxmanagePath,
+		CommandWrapper: commandWrapper,
+	}
 	if err := driver.Verify(); err != nil {
 		return nil, err
 	}
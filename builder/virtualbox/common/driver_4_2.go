@@ -13,11 +13,55 @@ import (
 
 	versionUtil "github.com/hashicorp/go-version"
 	"github.com/hashicorp/packer/common/retry"
+	"github.com/hashicorp/packer/template/interpolate"
 )
 
 type VBox42Driver struct {
 	// This is the path to the "VBoxManage" application.
 	VBoxManagePath string
+
+	// CommandWrapper wraps every VBoxManage invocation when set to
+	// anything but the default "{{.Command}}", so that VBoxManage can
+	// for example be run over ssh on a dedicated VirtualBox host.
+	CommandWrapper string
+}
+
+type vboxCommandTemplate struct {
+	Command string
+}
+
+// wrapsCommand says whether the given vboxmanage_command_wrapper value
+// actually wraps the command, rather than being empty or the identity
+// default.
+func wrapsCommand(wrapper string) bool {
+	return wrapper != "" && wrapper != "{{.Command}}"
+}
+
+// vboxCmd builds the command for a VBoxManage invocation. With the
+// default wrapper the binary is executed directly; any other wrapper is
+// rendered with the full, quoted command line as the Command variable
+// and executed through a shell.
+func (d *VBox42Driver) vboxCmd(args ...string) (*exec.Cmd, error) {
+	if !wrapsCommand(d.CommandWrapper) {
+		return exec.Command(d.VBoxManagePath, args...), nil
+	}
+
+	quoted := make([]string, 0, len(args)+1)
+	for _, arg := range append([]string{d.VBoxManagePath}, args...) {
+		quoted = append(quoted, fmt.Sprintf("%q", arg))
+	}
+
+	command, err := interpolate.Render(d.CommandWrapper, &interpolate.Context{
+		Data: &vboxCommandTemplate{
+			Command: strings.Join(quoted, " "),
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Error rendering vboxmanage_command_wrapper: %s", err)
+	}
+
+	log.Printf("Executing wrapped VBoxManage command: %s", command)
+	return exec.Command("/bin/sh", "-c", command), nil
 }
 
 func (d *VBox42Driver) CreateSATAController(vmName string, name string, portcount int) error {
@@ -78,7 +122,10 @@ func (d *VBox42Driver) CreateSCSIController(vmName string, name string) error {
 func (d *VBox42Driver) RemoveFloppyControllers(vmName string) error {
 	var stdout bytes.Buffer
 
-	cmd := exec.Command(d.VBoxManagePath, "showvminfo", vmName, "--machinereadable")
+	cmd, err := d.vboxCmd("showvminfo", vmName, "--machinereadable")
+	if err != nil {
+		return err
+	}
 	cmd.Stdout = &stdout
 	if err := cmd.Run(); err != nil {
 		return err
@@ -131,7 +178,10 @@ func (d *VBox42Driver) Delete(name string) error {
 func (d *VBox42Driver) Iso() (string, error) {
 	var stdout bytes.Buffer
 
-	cmd := exec.Command(d.VBoxManagePath, "list", "systemproperties")
+	cmd, err := d.vboxCmd("list", "systemproperties")
+	if err != nil {
+		return "", err
+	}
 	cmd.Stdout = &stdout
 	if err := cmd.Run(); err != nil {
 		return "", err
@@ -172,7 +222,10 @@ func (d *VBox42Driver) Import(name string, path string, flags []string) error {
 func (d *VBox42Driver) IsRunning(name string) (bool, error) {
 	var stdout bytes.Buffer
 
-	cmd := exec.Command(d.VBoxManagePath, "showvminfo", name, "--machinereadable")
+	cmd, err := d.vboxCmd("showvminfo", name, "--machinereadable")
+	if err != nil {
+		return false, err
+	}
 	cmd.Stdout = &stdout
 	if err := cmd.Run(); err != nil {
 		return false, err
@@ -255,10 +308,13 @@ func (d *VBox42Driver) VBoxManageWithOutput(args ...string) (string, error) {
 	var stdout, stderr bytes.Buffer
 
 	log.Printf("Executing VBoxManage: %#v", args)
-	cmd := exec.Command(d.VBoxManagePath, args...)
+	cmd, err := d.vboxCmd(args...)
+	if err != nil {
+		return "", err
+	}
 	cmd.Stdout = &stdout
 	cmd.Stderr = &stderr
-	err := cmd.Run()
+	err = cmd.Run()
 
 	stdoutString := strings.TrimSpace(stdout.String())
 	stderrString := strings.TrimSpace(stderr.String())
@@ -289,7 +345,10 @@ func (d *VBox42Driver) Verify() error {
 func (d *VBox42Driver) Version() (string, error) {
 	var stdout bytes.Buffer
 
-	cmd := exec.Command(d.VBoxManagePath, "--version")
+	cmd, err := d.vboxCmd("--version")
+	if err != nil {
+		return "", err
+	}
 	cmd.Stdout = &stdout
 	if err := cmd.Run(); err != nil {
 		return "", err
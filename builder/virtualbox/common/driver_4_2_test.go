@@ -7,3 +7,37 @@ import (
 func TestVBox42Driver_impl(t *testing.T) {
 	var _ Driver = new(VBox42Driver)
 }
+
+func TestVBox42Driver_vboxCmd(t *testing.T) {
+	// Without a wrapper (or with the identity default), VBoxManage is
+	// executed directly.
+	for _, wrapper := range []string{"", "{{.Command}}"} {
+		d := &VBox42Driver{VBoxManagePath: "VBoxManage", CommandWrapper: wrapper}
+		cmd, err := d.vboxCmd("list", "vms")
+		if err != nil {
+			t.Fatalf("err: %s", err)
+		}
+		if len(cmd.Args) != 3 || cmd.Args[1] != "list" || cmd.Args[2] != "vms" {
+			t.Fatalf("bad args: %#v", cmd.Args)
+		}
+	}
+
+	// A real wrapper routes the quoted command line through a shell.
+	d := &VBox42Driver{
+		VBoxManagePath: "VBoxManage",
+		CommandWrapper: "ssh vbox-host {{.Command}}",
+	}
+	cmd, err := d.vboxCmd("list", "vms")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	expected := []string{"/bin/sh", "-c", `ssh vbox-host "VBoxManage" "list" "vms"`}
+	if len(cmd.Args) != len(expected) {
+		t.Fatalf("bad args: %#v", cmd.Args)
+	}
+	for i, arg := range expected {
+		if cmd.Args[i] != arg {
+			t.Fatalf("bad arg %d: %q", i, cmd.Args[i])
+		}
+	}
+}
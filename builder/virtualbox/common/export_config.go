@@ -52,6 +52,22 @@ type ExportConfig struct {
 	//     "packer_conf.json"
 	// ```
 	ExportOpts []string `mapstructure:"export_opts" required:"false"`
+	// The OVF specification version to export with. Accepts `0.9`, `1.0`
+	// and `2.0`; some appliance distribution channels require `2.0`. When
+	// unset, the VirtualBox default (`1.0`) is used.
+	OVFVersion string `mapstructure:"ovf_version" required:"false"`
+	// Include a manifest file (.mf) with digests of the exported files in
+	// the appliance. This defaults to false.
+	Manifest bool `mapstructure:"manifest" required:"false"`
+	// Path to a PEM encoded X.509 certificate used to sign the appliance
+	// manifest after export. Must be set together with
+	// signing_private_key_path, requires manifest to be enabled and is only
+	// supported for the ovf format.
+	SigningCertificatePath string `mapstructure:"signing_certificate_path" required:"false"`
+	// Path to the PEM encoded RSA private key matching
+	// signing_certificate_path. The signature is written next to the
+	// manifest as a .cert file.
+	SigningPrivateKeyPath string `mapstructure:"signing_private_key_path" required:"false"`
 }
 
 func (c *ExportConfig) Prepare(ctx *interpolate.Context) []error {
@@ -65,6 +81,27 @@ func (c *ExportConfig) Prepare(ctx *interpolate.Context) []error {
 			errors.New("invalid format, only 'ovf' or 'ova' are allowed"))
 	}
 
+	switch c.OVFVersion {
+	case "", "0.9", "1.0", "2.0":
+	default:
+		errs = append(errs,
+			errors.New("invalid ovf_version, only '0.9', '1.0' or '2.0' are allowed"))
+	}
+
+	if (c.SigningCertificatePath != "") != (c.SigningPrivateKeyPath != "") {
+		errs = append(errs, errors.New("signing_certificate_path and "+
+			"signing_private_key_path must be set together"))
+	} else if c.SigningCertificatePath != "" {
+		if !c.Manifest {
+			errs = append(errs, errors.New("signing the appliance requires "+
+				"manifest to be enabled"))
+		}
+		if c.Format != "ovf" {
+			errs = append(errs, errors.New("signing the appliance is only "+
+				"supported for the 'ovf' format"))
+		}
+	}
+
 	if c.ExportOpts == nil {
 		c.ExportOpts = make([]string, 0)
 	}
@@ -49,3 +49,68 @@ func TestExportConfigPrepare_Opts(t *testing.T) {
 		t.Fatalf("should not have error: %s", errs)
 	}
 }
+
+func TestExportConfigPrepare_OVFVersion(t *testing.T) {
+	var c *ExportConfig
+	var errs []error
+
+	// Bad
+	c = new(ExportConfig)
+	c.OVFVersion = "3.0"
+	errs = c.Prepare(interpolate.NewContext())
+	if len(errs) == 0 {
+		t.Fatalf("bad: %#v", errs)
+	}
+
+	// Good
+	c = new(ExportConfig)
+	c.OVFVersion = "2.0"
+	errs = c.Prepare(interpolate.NewContext())
+	if len(errs) > 0 {
+		t.Fatalf("should not have error: %s", errs)
+	}
+}
+
+func TestExportConfigPrepare_Signing(t *testing.T) {
+	var c *ExportConfig
+	var errs []error
+
+	// Bad: key without certificate
+	c = new(ExportConfig)
+	c.Manifest = true
+	c.SigningPrivateKeyPath = "key.pem"
+	errs = c.Prepare(interpolate.NewContext())
+	if len(errs) == 0 {
+		t.Fatalf("bad: %#v", errs)
+	}
+
+	// Bad: signing without manifest
+	c = new(ExportConfig)
+	c.SigningCertificatePath = "cert.pem"
+	c.SigningPrivateKeyPath = "key.pem"
+	errs = c.Prepare(interpolate.NewContext())
+	if len(errs) == 0 {
+		t.Fatalf("bad: %#v", errs)
+	}
+
+	// Bad: signing an ova
+	c = new(ExportConfig)
+	c.Format = "ova"
+	c.Manifest = true
+	c.SigningCertificatePath = "cert.pem"
+	c.SigningPrivateKeyPath = "key.pem"
+	errs = c.Prepare(interpolate.NewContext())
+	if len(errs) == 0 {
+		t.Fatalf("bad: %#v", errs)
+	}
+
+	// Good
+	c = new(ExportConfig)
+	c.Manifest = true
+	c.SigningCertificatePath = "cert.pem"
+	c.SigningPrivateKeyPath = "key.pem"
+	errs = c.Prepare(interpolate.NewContext())
+	if len(errs) > 0 {
+		t.Fatalf("should not have error: %s", errs)
+	}
+}
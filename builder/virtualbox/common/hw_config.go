@@ -22,6 +22,21 @@ type HWConfig struct {
 	// Specifies whether or not to enable the USB bus when
 	// building the VM. Defaults to false.
 	USB bool `mapstructure:"usb" required:"false"`
+	// The paravirtualization interface announced to the
+	// guest, one of `none`, `default`, `legacy`, `minimal`, `hyperv` or
+	// `kvm`. Defaults to empty; the VirtualBox default is kept.
+	ParavirtProvider string `mapstructure:"paravirt_provider" required:"false"`
+	// The CPU profile presented to the guest, either
+	// `host` or one of the profiles known to VBoxManage such as
+	// `Intel Xeon X5482 3.20GHz`. Masking the CPUID this way helps produce
+	// images that boot on hosts with different CPU generations. Requires
+	// VirtualBox 6.1 or later. Defaults to empty; the VirtualBox default
+	// (`host`) is kept.
+	CpuProfile string `mapstructure:"cpu_profile" required:"false"`
+	// Whether to use hardware virtualization
+	// (VT-x/AMD-V) for the build VM, either `on` or `off`. Defaults to
+	// empty; the VirtualBox default is kept.
+	HWVirtEx string `mapstructure:"hwvirtex" required:"false"`
 }
 
 func (c *HWConfig) Prepare(ctx *interpolate.Context) []error {
@@ -47,5 +62,15 @@ func (c *HWConfig) Prepare(ctx *interpolate.Context) []error {
 		c.Sound = "none"
 	}
 
+	switch c.ParavirtProvider {
+	case "", "none", "default", "legacy", "minimal", "hyperv", "kvm":
+	default:
+		errs = append(errs, fmt.Errorf("An invalid paravirt_provider was specified (valid providers: none, default, legacy, minimal, hyperv, kvm): %s", c.ParavirtProvider))
+	}
+
+	if c.HWVirtEx != "" && c.HWVirtEx != "on" && c.HWVirtEx != "off" {
+		errs = append(errs, fmt.Errorf("An invalid hwvirtex value was specified (must be on or off): %s", c.HWVirtEx))
+	}
+
 	return errs
 }
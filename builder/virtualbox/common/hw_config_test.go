@@ -20,3 +20,35 @@ func TestHWConfigPrepare(t *testing.T) {
 		t.Errorf("bad memory size: %d", c.MemorySize)
 	}
 }
+
+func TestHWConfigPrepare_ParavirtProvider(t *testing.T) {
+	for _, provider := range []string{"", "none", "default", "legacy", "minimal", "hyperv", "kvm"} {
+		c := new(HWConfig)
+		c.ParavirtProvider = provider
+		if errs := c.Prepare(interpolate.NewContext()); len(errs) > 0 {
+			t.Fatalf("should not have error for %q: %#v", provider, errs)
+		}
+	}
+
+	c := new(HWConfig)
+	c.ParavirtProvider = "foo"
+	if errs := c.Prepare(interpolate.NewContext()); len(errs) == 0 {
+		t.Fatal("should have error")
+	}
+}
+
+func TestHWConfigPrepare_HWVirtEx(t *testing.T) {
+	for _, value := range []string{"", "on", "off"} {
+		c := new(HWConfig)
+		c.HWVirtEx = value
+		if errs := c.Prepare(interpolate.NewContext()); len(errs) > 0 {
+			t.Fatalf("should not have error for %q: %#v", value, errs)
+		}
+	}
+
+	c := new(HWConfig)
+	c.HWVirtEx = "yes"
+	if errs := c.Prepare(interpolate.NewContext()); len(errs) == 0 {
+		t.Fatal("should have error")
+	}
+}
@@ -0,0 +1,131 @@
+//go:generate struct-markdown
+//go:generate mapstructure-to-hcl2 -type NetworkAdapter,NATForward
+
+package common
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/packer/template/interpolate"
+)
+
+// A NAT port forwarding rule for a network adapter attached to NAT.
+type NATForward struct {
+	// Name of the rule. Required and must be unique on the adapter.
+	Name string `mapstructure:"name" required:"true"`
+	// Protocol to forward, either tcp or udp. Defaults to tcp.
+	Protocol string `mapstructure:"protocol" required:"false"`
+	// Host IP to bind the forwarded port to. Defaults to all interfaces.
+	HostIP string `mapstructure:"host_ip" required:"false"`
+	// Port on the host to forward. Required.
+	HostPort int `mapstructure:"host_port" required:"true"`
+	// Guest IP to forward to. Usually left empty so VirtualBox picks the
+	// address of the guest on the NAT network.
+	GuestIP string `mapstructure:"guest_ip" required:"false"`
+	// Port on the guest to forward to. Required.
+	GuestPort int `mapstructure:"guest_port" required:"true"`
+}
+
+// A network adapter of the VM, in the order they are defined; the first
+// entry configures nic1, the second nic2 and so on.
+type NetworkAdapter struct {
+	// How the adapter is attached to the host network. One of nat,
+	// hostonly, bridged, intnet or none. Defaults to nat.
+	Attachment string `mapstructure:"attachment" required:"false"`
+	// The type of NIC emulated for the adapter, for example 82540EM or
+	// virtio. When unset VirtualBox uses its default for the guest OS type.
+	Type string `mapstructure:"type" required:"false"`
+	// Name of the host-only network to attach to. Required when attachment
+	// is hostonly.
+	HostOnlyNetwork string `mapstructure:"host_only_network" required:"false"`
+	// Name of the host interface or internal network to attach to. Required
+	// when attachment is bridged or intnet.
+	Network string `mapstructure:"network" required:"false"`
+	// Additional NAT port forwarding rules for the adapter. Only valid when
+	// attachment is nat. The rule Packer creates for the communicator is
+	// managed separately, see communicator_adapter.
+	NATForwards []NATForward `mapstructure:"nat_forwards" required:"false"`
+}
+
+type NetworkAdapterConfig struct {
+	// A list of network adapters to configure on the VM, starting at nic1.
+	// When unset the VM keeps the single NAT adapter that VirtualBox
+	// creates by default.
+	NetworkAdapters []NetworkAdapter `mapstructure:"network_adapters" required:"false"`
+	// The 1-based index of the adapter the communicator (SSH, WinRM, etc)
+	// connects through. The adapter must be attached to NAT unless
+	// skip_nat_mapping is set. Defaults to the first NAT adapter, or 1.
+	CommAdapter int `mapstructure:"communicator_adapter" required:"false"`
+}
+
+func (c *NetworkAdapterConfig) Prepare(ctx *interpolate.Context) (errs []error) {
+	for i := range c.NetworkAdapters {
+		adapter := &c.NetworkAdapters[i]
+		if adapter.Attachment == "" {
+			adapter.Attachment = "nat"
+		}
+
+		switch adapter.Attachment {
+		case "nat", "hostonly", "bridged", "intnet", "none":
+		default:
+			errs = append(errs, fmt.Errorf("network_adapters[%d]: attachment "+
+				"must be one of nat, hostonly, bridged, intnet or none", i))
+		}
+
+		if adapter.Attachment == "hostonly" && adapter.HostOnlyNetwork == "" {
+			errs = append(errs, fmt.Errorf("network_adapters[%d]: "+
+				"host_only_network is required for a hostonly adapter", i))
+		}
+
+		if (adapter.Attachment == "bridged" || adapter.Attachment == "intnet") && adapter.Network == "" {
+			errs = append(errs, fmt.Errorf("network_adapters[%d]: network is "+
+				"required for a %s adapter", i, adapter.Attachment))
+		}
+
+		if len(adapter.NATForwards) > 0 && adapter.Attachment != "nat" {
+			errs = append(errs, fmt.Errorf("network_adapters[%d]: nat_forwards "+
+				"are only valid for a nat adapter", i))
+		}
+
+		names := make(map[string]struct{})
+		for j := range adapter.NATForwards {
+			forward := &adapter.NATForwards[j]
+			if forward.Protocol == "" {
+				forward.Protocol = "tcp"
+			}
+			if forward.Protocol != "tcp" && forward.Protocol != "udp" {
+				errs = append(errs, fmt.Errorf("network_adapters[%d]: forward "+
+					"protocol must be tcp or udp", i))
+			}
+			if forward.Name == "" {
+				errs = append(errs, fmt.Errorf("network_adapters[%d]: every "+
+					"nat_forward must have a name", i))
+			} else if _, ok := names[forward.Name]; ok {
+				errs = append(errs, fmt.Errorf("network_adapters[%d]: duplicate "+
+					"nat_forward name %q", i, forward.Name))
+			} else {
+				names[forward.Name] = struct{}{}
+			}
+			if forward.HostPort == 0 || forward.GuestPort == 0 {
+				errs = append(errs, fmt.Errorf("network_adapters[%d]: nat_forwards "+
+					"must set both host_port and guest_port", i))
+			}
+		}
+	}
+
+	if c.CommAdapter == 0 {
+		c.CommAdapter = 1
+		for i, adapter := range c.NetworkAdapters {
+			if adapter.Attachment == "nat" {
+				c.CommAdapter = i + 1
+				break
+			}
+		}
+	} else if c.CommAdapter < 0 ||
+		(len(c.NetworkAdapters) > 0 && c.CommAdapter > len(c.NetworkAdapters)) {
+		errs = append(errs, fmt.Errorf("communicator_adapter %d is not a "+
+			"configured network adapter", c.CommAdapter))
+	}
+
+	return errs
+}
@@ -0,0 +1,131 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/hashicorp/packer/template/interpolate"
+)
+
+func TestNetworkAdapterConfigPrepare_Defaults(t *testing.T) {
+	c := new(NetworkAdapterConfig)
+	errs := c.Prepare(interpolate.NewContext())
+	if len(errs) > 0 {
+		t.Fatalf("err: %#v", errs)
+	}
+
+	if c.CommAdapter != 1 {
+		t.Errorf("bad communicator adapter: %d", c.CommAdapter)
+	}
+}
+
+func TestNetworkAdapterConfigPrepare_Adapters(t *testing.T) {
+	c := new(NetworkAdapterConfig)
+	c.NetworkAdapters = []NetworkAdapter{
+		{Attachment: "hostonly", HostOnlyNetwork: "vboxnet0"},
+		{},
+	}
+	errs := c.Prepare(interpolate.NewContext())
+	if len(errs) > 0 {
+		t.Fatalf("err: %#v", errs)
+	}
+
+	if c.NetworkAdapters[1].Attachment != "nat" {
+		t.Errorf("bad attachment: %s", c.NetworkAdapters[1].Attachment)
+	}
+
+	// The communicator should pick the first NAT adapter.
+	if c.CommAdapter != 2 {
+		t.Errorf("bad communicator adapter: %d", c.CommAdapter)
+	}
+}
+
+func TestNetworkAdapterConfigPrepare_BadAttachment(t *testing.T) {
+	c := new(NetworkAdapterConfig)
+	c.NetworkAdapters = []NetworkAdapter{
+		{Attachment: "floppynet"},
+	}
+	errs := c.Prepare(interpolate.NewContext())
+	if len(errs) == 0 {
+		t.Fatal("should have error")
+	}
+}
+
+func TestNetworkAdapterConfigPrepare_HostOnlyNetworkRequired(t *testing.T) {
+	c := new(NetworkAdapterConfig)
+	c.NetworkAdapters = []NetworkAdapter{
+		{Attachment: "hostonly"},
+	}
+	errs := c.Prepare(interpolate.NewContext())
+	if len(errs) == 0 {
+		t.Fatal("should have error")
+	}
+}
+
+func TestNetworkAdapterConfigPrepare_NATForwards(t *testing.T) {
+	c := new(NetworkAdapterConfig)
+	c.NetworkAdapters = []NetworkAdapter{
+		{
+			NATForwards: []NATForward{
+				{Name: "http", HostPort: 8080, GuestPort: 80},
+			},
+		},
+	}
+	errs := c.Prepare(interpolate.NewContext())
+	if len(errs) > 0 {
+		t.Fatalf("err: %#v", errs)
+	}
+
+	if c.NetworkAdapters[0].NATForwards[0].Protocol != "tcp" {
+		t.Errorf("bad protocol: %s", c.NetworkAdapters[0].NATForwards[0].Protocol)
+	}
+
+	// Forwards are only valid on a NAT adapter
+	c = new(NetworkAdapterConfig)
+	c.NetworkAdapters = []NetworkAdapter{
+		{
+			Attachment:      "hostonly",
+			HostOnlyNetwork: "vboxnet0",
+			NATForwards: []NATForward{
+				{Name: "http", HostPort: 8080, GuestPort: 80},
+			},
+		},
+	}
+	errs = c.Prepare(interpolate.NewContext())
+	if len(errs) == 0 {
+		t.Fatal("should have error")
+	}
+
+	// Duplicate rule names
+	c = new(NetworkAdapterConfig)
+	c.NetworkAdapters = []NetworkAdapter{
+		{
+			NATForwards: []NATForward{
+				{Name: "http", HostPort: 8080, GuestPort: 80},
+				{Name: "http", HostPort: 8081, GuestPort: 81},
+			},
+		},
+	}
+	errs = c.Prepare(interpolate.NewContext())
+	if len(errs) == 0 {
+		t.Fatal("should have error")
+	}
+}
+
+func TestNetworkAdapterConfigPrepare_CommAdapter(t *testing.T) {
+	c := new(NetworkAdapterConfig)
+	c.NetworkAdapters = []NetworkAdapter{
+		{},
+		{Attachment: "intnet", Network: "intnet0"},
+	}
+	c.CommAdapter = 2
+	errs := c.Prepare(interpolate.NewContext())
+	if len(errs) > 0 {
+		t.Fatalf("err: %#v", errs)
+	}
+
+	c.CommAdapter = 3
+	errs = c.Prepare(interpolate.NewContext())
+	if len(errs) == 0 {
+		t.Fatal("should have error")
+	}
+}
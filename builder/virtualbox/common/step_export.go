@@ -1,8 +1,17 @@
 package common
 
 import (
+	"bytes"
 	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
 	"fmt"
+	"io/ioutil"
 	"path/filepath"
 	"strings"
 
@@ -15,15 +24,20 @@ import (
 // Uses:
 //
 // Produces:
-//   exportPath string - The path to the resulting export.
+//
+//	exportPath string - The path to the resulting export.
 type StepExport struct {
-	Format         string
-	OutputDir      string
-	OutputFilename string
-	ExportOpts     []string
-	Bundling       VBoxBundleConfig
-	SkipNatMapping bool
-	SkipExport     bool
+	Format                 string
+	OutputDir              string
+	OutputFilename         string
+	ExportOpts             []string
+	OVFVersion             string
+	Manifest               bool
+	SigningCertificatePath string
+	SigningPrivateKeyPath  string
+	Bundling               VBoxBundleConfig
+	SkipNatMapping         bool
+	SkipExport             bool
 }
 
 func (s *StepExport) Run(ctx context.Context, state multistep.StateBag) multistep.StepAction {
@@ -73,6 +87,17 @@ func (s *StepExport) Run(ctx context.Context, state multistep.StateBag) multiste
 		"--output",
 		outputPath,
 	}
+	switch s.OVFVersion {
+	case "0.9":
+		command = append(command, "--ovf09")
+	case "1.0":
+		command = append(command, "--ovf10")
+	case "2.0":
+		command = append(command, "--ovf20")
+	}
+	if s.Manifest {
+		command = append(command, "--manifest")
+	}
 	command = append(command, s.ExportOpts...)
 
 	ui.Say("Exporting virtual machine...")
@@ -85,9 +110,82 @@ func (s *StepExport) Run(ctx context.Context, state multistep.StateBag) multiste
 		return multistep.ActionHalt
 	}
 
+	if s.SigningCertificatePath != "" {
+		ui.Say("Signing the appliance manifest...")
+		certPath, err := s.signManifest()
+		if err != nil {
+			err := fmt.Errorf("Error signing the appliance manifest: %s", err)
+			state.Put("error", err)
+			ui.Error(err.Error())
+			return multistep.ActionHalt
+		}
+		ui.Message(fmt.Sprintf("Signature written to %s", certPath))
+	}
+
 	state.Put("exportPath", outputPath)
 
 	return multistep.ActionContinue
 }
 
+// signManifest signs the exported manifest (.mf) with the configured X.509
+// key pair and writes the signature and certificate to a .cert file next to
+// it, following the OVF specification's appliance signing scheme.
+func (s *StepExport) signManifest() (string, error) {
+	manifestPath := filepath.Join(s.OutputDir, s.OutputFilename+".mf")
+	manifest, err := ioutil.ReadFile(manifestPath)
+	if err != nil {
+		return "", fmt.Errorf("could not read manifest %s: %s", manifestPath, err)
+	}
+
+	keyPem, err := ioutil.ReadFile(s.SigningPrivateKeyPath)
+	if err != nil {
+		return "", fmt.Errorf("could not read private key: %s", err)
+	}
+	block, _ := pem.Decode(keyPem)
+	if block == nil {
+		return "", fmt.Errorf("no PEM data found in %s", s.SigningPrivateKeyPath)
+	}
+
+	var key *rsa.PrivateKey
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "PRIVATE KEY":
+		var parsed interface{}
+		parsed, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err == nil {
+			var ok bool
+			if key, ok = parsed.(*rsa.PrivateKey); !ok {
+				err = fmt.Errorf("only RSA private keys are supported")
+			}
+		}
+	default:
+		err = fmt.Errorf("unsupported PEM block type %q", block.Type)
+	}
+	if err != nil {
+		return "", fmt.Errorf("could not parse private key: %s", err)
+	}
+
+	certificate, err := ioutil.ReadFile(s.SigningCertificatePath)
+	if err != nil {
+		return "", fmt.Errorf("could not read certificate: %s", err)
+	}
+
+	digest := sha256.Sum256(manifest)
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", fmt.Errorf("could not sign manifest: %s", err)
+	}
+
+	var cert bytes.Buffer
+	fmt.Fprintf(&cert, "SHA256(%s.mf)= %s\n", s.OutputFilename, hex.EncodeToString(signature))
+	cert.Write(certificate)
+
+	certPath := filepath.Join(s.OutputDir, s.OutputFilename+".cert")
+	if err := ioutil.WriteFile(certPath, cert.Bytes(), 0644); err != nil {
+		return "", fmt.Errorf("could not write %s: %s", certPath, err)
+	}
+	return certPath, nil
+}
+
 func (s *StepExport) Cleanup(state multistep.StateBag) {}
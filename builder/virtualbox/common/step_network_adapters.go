@@ -0,0 +1,81 @@
+package common
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/packer/helper/multistep"
+	"github.com/hashicorp/packer/packer"
+)
+
+// This step configures the network adapters of the VM from the
+// network_adapters config.
+//
+// Uses:
+//   driver Driver
+//   ui packer.Ui
+//   vmName string
+//
+// Produces:
+type StepNetworkAdapters struct {
+	NetworkAdapters []NetworkAdapter
+}
+
+func (s *StepNetworkAdapters) Run(ctx context.Context, state multistep.StateBag) multistep.StepAction {
+	driver := state.Get("driver").(Driver)
+	ui := state.Get("ui").(packer.Ui)
+	vmName := state.Get("vmName").(string)
+
+	if len(s.NetworkAdapters) == 0 {
+		return multistep.ActionContinue
+	}
+
+	ui.Say("Configuring network adapters...")
+	for i, adapter := range s.NetworkAdapters {
+		nic := i + 1
+
+		command := []string{
+			"modifyvm", vmName,
+			fmt.Sprintf("--nic%d", nic), adapter.Attachment,
+		}
+		if adapter.Type != "" {
+			command = append(command, fmt.Sprintf("--nictype%d", nic), adapter.Type)
+		}
+		switch adapter.Attachment {
+		case "hostonly":
+			command = append(command, fmt.Sprintf("--hostonlyadapter%d", nic), adapter.HostOnlyNetwork)
+		case "bridged":
+			command = append(command, fmt.Sprintf("--bridgeadapter%d", nic), adapter.Network)
+		case "intnet":
+			command = append(command, fmt.Sprintf("--intnet%d", nic), adapter.Network)
+		}
+
+		if err := driver.VBoxManage(command...); err != nil {
+			err := fmt.Errorf("Error configuring network adapter %d: %s", nic, err)
+			state.Put("error", err)
+			ui.Error(err.Error())
+			return multistep.ActionHalt
+		}
+
+		for _, forward := range adapter.NATForwards {
+			command := []string{
+				"modifyvm", vmName,
+				fmt.Sprintf("--natpf%d", nic),
+				fmt.Sprintf("%s,%s,%s,%d,%s,%d",
+					forward.Name, forward.Protocol,
+					forward.HostIP, forward.HostPort,
+					forward.GuestIP, forward.GuestPort),
+			}
+			if err := driver.VBoxManage(command...); err != nil {
+				err := fmt.Errorf("Error creating NAT forwarding rule %s: %s", forward.Name, err)
+				state.Put("error", err)
+				ui.Error(err.Error())
+				return multistep.ActionHalt
+			}
+		}
+	}
+
+	return multistep.ActionContinue
+}
+
+func (s *StepNetworkAdapters) Cleanup(state multistep.StateBag) {}
@@ -26,6 +26,7 @@ type StepPortForwarding struct {
 	HostPortMin    int
 	HostPortMax    int
 	SkipNatMapping bool
+	CommAdapter    int
 
 	l *net.Listener
 }
@@ -41,6 +42,11 @@ func (s *StepPortForwarding) Run(ctx context.Context, state multistep.StateBag)
 		return multistep.ActionContinue
 	}
 
+	commAdapter := s.CommAdapter
+	if commAdapter == 0 {
+		commAdapter = 1
+	}
+
 	guestPort := s.CommConfig.Port()
 	commHostPort := guestPort
 	if !s.SkipNatMapping {
@@ -66,7 +72,7 @@ func (s *StepPortForwarding) Run(ctx context.Context, state multistep.StateBag)
 		// Make sure to configure the network interface to NAT
 		command := []string{
 			"modifyvm", vmName,
-			"--nic1",
+			fmt.Sprintf("--nic%d", commAdapter),
 			"nat",
 		}
 		if err := driver.VBoxManage(command...); err != nil {
@@ -80,7 +86,7 @@ func (s *StepPortForwarding) Run(ctx context.Context, state multistep.StateBag)
 		ui.Say(fmt.Sprintf("Creating forwarded port mapping for communicator (SSH, WinRM, etc) (host port %d)", commHostPort))
 		command = []string{
 			"modifyvm", vmName,
-			"--natpf1",
+			fmt.Sprintf("--natpf%d", commAdapter),
 			fmt.Sprintf("packercomm,tcp,127.0.0.1,%d,,%d", commHostPort, guestPort),
 		}
 		retried := false
@@ -95,7 +101,7 @@ func (s *StepPortForwarding) Run(ctx context.Context, state multistep.StateBag)
 				log.Printf("A packer NAT rule already exists. Trying to delete ...")
 				delcommand := []string{
 					"modifyvm", vmName,
-					"--natpf1",
+					fmt.Sprintf("--natpf%d", commAdapter),
 					"delete", "packercomm",
 				}
 				if err := driver.VBoxManage(delcommand...); err != nil {
@@ -101,6 +101,26 @@ func (s *StepRemoveDevices) Run(ctx context.Context, state multistep.StateBag) m
 		}
 	}
 
+	// Remove any additional ISOs that were attached alongside the boot ISO.
+	if extraRaw, ok := state.GetOk("attachedExtraIsos"); ok {
+		for _, position := range extraRaw.([][]string) {
+			command := []string{
+				"storageattach", vmName,
+				"--storagectl", position[0],
+				"--port", position[1],
+				"--device", position[2],
+				"--medium", "none",
+			}
+
+			if err := driver.VBoxManage(command...); err != nil {
+				err := fmt.Errorf("Error detaching ISO: %s", err)
+				state.Put("error", err)
+				ui.Error(err.Error())
+				return multistep.ActionHalt
+			}
+		}
+	}
+
 	if _, ok := state.GetOk("guest_additions_attached"); ok {
 		ui.Message("Removing guest additions drive...")
 		controllerName := "IDE Controller"
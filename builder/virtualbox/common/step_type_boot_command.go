@@ -3,9 +3,11 @@ package common
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/packer/common/bootcommand"
+	"github.com/hashicorp/packer/common/retry"
 	"github.com/hashicorp/packer/helper/communicator"
 	"github.com/hashicorp/packer/helper/multistep"
 	"github.com/hashicorp/packer/packer"
@@ -74,7 +76,19 @@ func (s *StepTypeBootCommand) Run(ctx context.Context, state multistep.StateBag)
 		args := []string{"controlvm", vmName, "keyboardputscancode"}
 		args = append(args, codes...)
 
-		return driver.VBoxManage(args...)
+		// On a loaded host the VM keyboard queue can fill up faster than
+		// the guest drains it, in which case VBoxManage fails with
+		// VERR_PDM_NO_QUEUE_ITEMS and the whole batch is dropped. Give the
+		// guest a moment to catch up and retry the batch.
+		return retry.Config{
+			Tries: 10,
+			ShouldRetry: func(err error) bool {
+				return strings.Contains(err.Error(), "VERR_PDM_NO_QUEUE_ITEMS")
+			},
+			RetryDelay: func() time.Duration { return 250 * time.Millisecond },
+		}.Run(ctx, func(context.Context) error {
+			return driver.VBoxManage(args...)
+		})
 	}
 	d := bootcommand.NewPCXTDriver(sendCodes, 25, s.GroupInterval)
 
@@ -22,6 +22,15 @@ type VBoxManageConfig struct {
 	// except that it is run after the virtual machine is shutdown, and before the
 	// virtual machine is exported.
 	VBoxManagePost [][]string `mapstructure:"vboxmanage_post" required:"false"`
+	// A command wrapper for every `VBoxManage` invocation, in the same
+	// spirit as the lxc builder's `command_wrapper`. The value is a
+	// [configuration template](/docs/templates/engine) where the
+	// `Command` variable is replaced with the full, quoted VBoxManage
+	// command line. With a wrapper such as `ssh vbox-host {{.Command}}`,
+	// builds can drive VirtualBox on a dedicated host while Packer runs
+	// elsewhere. The wrapped command is executed through `/bin/sh`.
+	// Defaults to `{{.Command}}`, which runs VBoxManage directly.
+	VBoxManageCommandWrapper string `mapstructure:"vboxmanage_command_wrapper" required:"false"`
 }
 
 func (c *VBoxManageConfig) Prepare(ctx *interpolate.Context) []error {
@@ -33,5 +42,9 @@ func (c *VBoxManageConfig) Prepare(ctx *interpolate.Context) []error {
 		c.VBoxManagePost = make([][]string, 0)
 	}
 
+	if c.VBoxManageCommandWrapper == "" {
+		c.VBoxManageCommandWrapper = "{{.Command}}"
+	}
+
 	return nil
 }
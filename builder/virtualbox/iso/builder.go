@@ -7,6 +7,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"os"
 	"strings"
 
 	"github.com/hashicorp/hcl/v2/hcldec"
@@ -38,6 +39,7 @@ type Config struct {
 	vboxcommon.RunConfig            `mapstructure:",squash"`
 	vboxcommon.ShutdownConfig       `mapstructure:",squash"`
 	vboxcommon.CommConfig           `mapstructure:",squash"`
+	vboxcommon.NetworkAdapterConfig `mapstructure:",squash"`
 	vboxcommon.HWConfig             `mapstructure:",squash"`
 	vboxcommon.VBoxManageConfig     `mapstructure:",squash"`
 	vboxcommon.VBoxVersionConfig    `mapstructure:",squash"`
@@ -121,6 +123,14 @@ type Config struct {
 	// The type of controller that the ISO is attached to, defaults to ide.
 	// When set to sata, the drive is attached to an AHCI SATA controller.
 	ISOInterface string `mapstructure:"iso_interface" required:"false"`
+	// Additional ISO files to attach to the virtual machine alongside the
+	// boot ISO, for example driver disks or a kickstart ISO. Each entry is a
+	// path to an ISO on the local filesystem; the files are attached as-is
+	// and are not downloaded or checksummed. With the default ide
+	// iso_interface there is only room for one additional ISO; set
+	// iso_interface to sata (and raise sata_port_count accordingly) to
+	// attach more. The ISOs are detached before the VM is exported.
+	AttachISOs []string `mapstructure:"attach_isos" required:"false"`
 	// Set this to true if you would like to keep the VM registered with
 	// virtualbox. Defaults to false.
 	KeepRegistered bool `mapstructure:"keep_registered" required:"false"`
@@ -173,6 +183,7 @@ func (b *Builder) Prepare(raws ...interface{}) ([]string, []string, error) {
 	errs = packer.MultiErrorAppend(errs, b.config.RunConfig.Prepare(&b.config.ctx)...)
 	errs = packer.MultiErrorAppend(errs, b.config.ShutdownConfig.Prepare(&b.config.ctx)...)
 	errs = packer.MultiErrorAppend(errs, b.config.CommConfig.Prepare(&b.config.ctx)...)
+	errs = packer.MultiErrorAppend(errs, b.config.NetworkAdapterConfig.Prepare(&b.config.ctx)...)
 	errs = packer.MultiErrorAppend(errs, b.config.HWConfig.Prepare(&b.config.ctx)...)
 	errs = packer.MultiErrorAppend(errs, b.config.VBoxBundleConfig.Prepare(&b.config.ctx)...)
 	errs = packer.MultiErrorAppend(errs, b.config.VBoxManageConfig.Prepare(&b.config.ctx)...)
@@ -244,6 +255,19 @@ func (b *Builder) Prepare(raws ...interface{}) ([]string, []string, error) {
 			errs, errors.New("iso_interface can only be ide or sata"))
 	}
 
+	if b.config.ISOInterface == "ide" && len(b.config.AttachISOs) > 1 {
+		errs = packer.MultiErrorAppend(
+			errs, errors.New("the ide iso_interface only has room for one "+
+				"additional ISO; set iso_interface to sata to attach more"))
+	}
+
+	for _, attachPath := range b.config.AttachISOs {
+		if _, err := os.Stat(attachPath); err != nil {
+			errs = packer.MultiErrorAppend(
+				errs, fmt.Errorf("attach_isos: %s: %s", attachPath, err))
+		}
+	}
+
 	validMode := false
 	validModes := []string{
 		vboxcommon.GuestAdditionsModeDisable,
@@ -283,7 +307,7 @@ func (b *Builder) Prepare(raws ...interface{}) ([]string, []string, error) {
 
 func (b *Builder) Run(ctx context.Context, ui packer.Ui, hook packer.Hook) (packer.Artifact, error) {
 	// Create the driver that we'll use to communicate with VirtualBox
-	driver, err := vboxcommon.NewDriver()
+	driver, err := vboxcommon.NewDriver(b.config.VBoxManageCommandWrapper)
 	if err != nil {
 		return nil, fmt.Errorf("Failed creating VirtualBox driver: %s", err)
 	}
@@ -338,11 +362,15 @@ func (b *Builder) Run(ctx context.Context, ui packer.Ui, hook packer.Hook) (pack
 			VRDPPortMax:     b.config.VRDPPortMax,
 		},
 		new(vboxcommon.StepAttachFloppy),
+		&vboxcommon.StepNetworkAdapters{
+			NetworkAdapters: b.config.NetworkAdapters,
+		},
 		&vboxcommon.StepPortForwarding{
 			CommConfig:     &b.config.CommConfig.Comm,
 			HostPortMin:    b.config.HostPortMin,
 			HostPortMax:    b.config.HostPortMax,
 			SkipNatMapping: b.config.SkipNatMapping,
+			CommAdapter:    b.config.CommAdapter,
 		},
 		&vboxcommon.StepVBoxManage{
 			Commands: b.config.VBoxManage,
@@ -394,13 +422,17 @@ func (b *Builder) Run(ctx context.Context, ui packer.Ui, hook packer.Hook) (pack
 			Ctx:      b.config.ctx,
 		},
 		&vboxcommon.StepExport{
-			Format:         b.config.Format,
-			OutputDir:      b.config.OutputDir,
-			OutputFilename: b.config.OutputFilename,
-			ExportOpts:     b.config.ExportConfig.ExportOpts,
-			Bundling:       b.config.VBoxBundleConfig,
-			SkipNatMapping: b.config.SkipNatMapping,
-			SkipExport:     b.config.SkipExport,
+			Format:                 b.config.Format,
+			OutputDir:              b.config.OutputDir,
+			OutputFilename:         b.config.OutputFilename,
+			ExportOpts:             b.config.ExportConfig.ExportOpts,
+			OVFVersion:             b.config.ExportConfig.OVFVersion,
+			Manifest:               b.config.ExportConfig.Manifest,
+			SigningCertificatePath: b.config.ExportConfig.SigningCertificatePath,
+			SigningPrivateKeyPath:  b.config.ExportConfig.SigningPrivateKeyPath,
+			Bundling:               b.config.VBoxBundleConfig,
+			SkipNatMapping:         b.config.SkipNatMapping,
+			SkipExport:             b.config.SkipExport,
 		},
 	}
 
@@ -3,120 +3,132 @@ package iso
 
 import (
 	"github.com/hashicorp/hcl/v2/hcldec"
+	"github.com/hashicorp/packer/builder/virtualbox/common"
 	"github.com/zclconf/go-cty/cty"
 )
 
 // FlatConfig is an auto-generated flat version of Config.
 // Where the contents of a field with a `mapstructure:,squash` tag are bubbled up.
 type FlatConfig struct {
-	PackerBuildName           *string           `mapstructure:"packer_build_name" cty:"packer_build_name"`
-	PackerBuilderType         *string           `mapstructure:"packer_builder_type" cty:"packer_builder_type"`
-	PackerDebug               *bool             `mapstructure:"packer_debug" cty:"packer_debug"`
-	PackerForce               *bool             `mapstructure:"packer_force" cty:"packer_force"`
-	PackerOnError             *string           `mapstructure:"packer_on_error" cty:"packer_on_error"`
-	PackerUserVars            map[string]string `mapstructure:"packer_user_variables" cty:"packer_user_variables"`
-	PackerSensitiveVars       []string          `mapstructure:"packer_sensitive_variables" cty:"packer_sensitive_variables"`
-	HTTPDir                   *string           `mapstructure:"http_directory" cty:"http_directory"`
-	HTTPPortMin               *int              `mapstructure:"http_port_min" cty:"http_port_min"`
-	HTTPPortMax               *int              `mapstructure:"http_port_max" cty:"http_port_max"`
-	ISOChecksum               *string           `mapstructure:"iso_checksum" required:"true" cty:"iso_checksum"`
-	ISOChecksumURL            *string           `mapstructure:"iso_checksum_url" cty:"iso_checksum_url"`
-	ISOChecksumType           *string           `mapstructure:"iso_checksum_type" cty:"iso_checksum_type"`
-	RawSingleISOUrl           *string           `mapstructure:"iso_url" required:"true" cty:"iso_url"`
-	ISOUrls                   []string          `mapstructure:"iso_urls" cty:"iso_urls"`
-	TargetPath                *string           `mapstructure:"iso_target_path" cty:"iso_target_path"`
-	TargetExtension           *string           `mapstructure:"iso_target_extension" cty:"iso_target_extension"`
-	FloppyFiles               []string          `mapstructure:"floppy_files" cty:"floppy_files"`
-	FloppyDirectories         []string          `mapstructure:"floppy_dirs" cty:"floppy_dirs"`
-	FloppyLabel               *string           `mapstructure:"floppy_label" cty:"floppy_label"`
-	BootGroupInterval         *string           `mapstructure:"boot_keygroup_interval" cty:"boot_keygroup_interval"`
-	BootWait                  *string           `mapstructure:"boot_wait" cty:"boot_wait"`
-	BootCommand               []string          `mapstructure:"boot_command" cty:"boot_command"`
-	Format                    *string           `mapstructure:"format" required:"false" cty:"format"`
-	ExportOpts                []string          `mapstructure:"export_opts" required:"false" cty:"export_opts"`
-	OutputDir                 *string           `mapstructure:"output_directory" required:"false" cty:"output_directory"`
-	OutputFilename            *string           `mapstructure:"output_filename" required:"false" cty:"output_filename"`
-	Headless                  *bool             `mapstructure:"headless" required:"false" cty:"headless"`
-	VRDPBindAddress           *string           `mapstructure:"vrdp_bind_address" required:"false" cty:"vrdp_bind_address"`
-	VRDPPortMin               *int              `mapstructure:"vrdp_port_min" required:"false" cty:"vrdp_port_min"`
-	VRDPPortMax               *int              `mapstructure:"vrdp_port_max" cty:"vrdp_port_max"`
-	ShutdownCommand           *string           `mapstructure:"shutdown_command" required:"false" cty:"shutdown_command"`
-	ShutdownTimeout           *string           `mapstructure:"shutdown_timeout" required:"false" cty:"shutdown_timeout"`
-	PostShutdownDelay         *string           `mapstructure:"post_shutdown_delay" required:"false" cty:"post_shutdown_delay"`
-	DisableShutdown           *bool             `mapstructure:"disable_shutdown" required:"false" cty:"disable_shutdown"`
-	ACPIShutdown              *bool             `mapstructure:"acpi_shutdown" required:"false" cty:"acpi_shutdown"`
-	Type                      *string           `mapstructure:"communicator" cty:"communicator"`
-	PauseBeforeConnect        *string           `mapstructure:"pause_before_connecting" cty:"pause_before_connecting"`
-	SSHHost                   *string           `mapstructure:"ssh_host" cty:"ssh_host"`
-	SSHPort                   *int              `mapstructure:"ssh_port" cty:"ssh_port"`
-	SSHUsername               *string           `mapstructure:"ssh_username" cty:"ssh_username"`
-	SSHPassword               *string           `mapstructure:"ssh_password" cty:"ssh_password"`
-	SSHKeyPairName            *string           `mapstructure:"ssh_keypair_name" cty:"ssh_keypair_name"`
-	SSHTemporaryKeyPairName   *string           `mapstructure:"temporary_key_pair_name" cty:"temporary_key_pair_name"`
-	SSHClearAuthorizedKeys    *bool             `mapstructure:"ssh_clear_authorized_keys" cty:"ssh_clear_authorized_keys"`
-	SSHPrivateKeyFile         *string           `mapstructure:"ssh_private_key_file" cty:"ssh_private_key_file"`
-	SSHPty                    *bool             `mapstructure:"ssh_pty" cty:"ssh_pty"`
-	SSHTimeout                *string           `mapstructure:"ssh_timeout" cty:"ssh_timeout"`
-	SSHWaitTimeout            *string           `mapstructure:"ssh_wait_timeout" undocumented:"true" cty:"ssh_wait_timeout"`
-	SSHAgentAuth              *bool             `mapstructure:"ssh_agent_auth" cty:"ssh_agent_auth"`
-	SSHDisableAgentForwarding *bool             `mapstructure:"ssh_disable_agent_forwarding" cty:"ssh_disable_agent_forwarding"`
-	SSHHandshakeAttempts      *int              `mapstructure:"ssh_handshake_attempts" cty:"ssh_handshake_attempts"`
-	SSHBastionHost            *string           `mapstructure:"ssh_bastion_host" cty:"ssh_bastion_host"`
-	SSHBastionPort            *int              `mapstructure:"ssh_bastion_port" cty:"ssh_bastion_port"`
-	SSHBastionAgentAuth       *bool             `mapstructure:"ssh_bastion_agent_auth" cty:"ssh_bastion_agent_auth"`
-	SSHBastionUsername        *string           `mapstructure:"ssh_bastion_username" cty:"ssh_bastion_username"`
-	SSHBastionPassword        *string           `mapstructure:"ssh_bastion_password" cty:"ssh_bastion_password"`
-	SSHBastionInteractive     *bool             `mapstructure:"ssh_bastion_interactive" cty:"ssh_bastion_interactive"`
-	SSHBastionPrivateKeyFile  *string           `mapstructure:"ssh_bastion_private_key_file" cty:"ssh_bastion_private_key_file"`
-	SSHFileTransferMethod     *string           `mapstructure:"ssh_file_transfer_method" cty:"ssh_file_transfer_method"`
-	SSHProxyHost              *string           `mapstructure:"ssh_proxy_host" cty:"ssh_proxy_host"`
-	SSHProxyPort              *int              `mapstructure:"ssh_proxy_port" cty:"ssh_proxy_port"`
-	SSHProxyUsername          *string           `mapstructure:"ssh_proxy_username" cty:"ssh_proxy_username"`
-	SSHProxyPassword          *string           `mapstructure:"ssh_proxy_password" cty:"ssh_proxy_password"`
-	SSHKeepAliveInterval      *string           `mapstructure:"ssh_keep_alive_interval" cty:"ssh_keep_alive_interval"`
-	SSHReadWriteTimeout       *string           `mapstructure:"ssh_read_write_timeout" cty:"ssh_read_write_timeout"`
-	SSHRemoteTunnels          []string          `mapstructure:"ssh_remote_tunnels" cty:"ssh_remote_tunnels"`
-	SSHLocalTunnels           []string          `mapstructure:"ssh_local_tunnels" cty:"ssh_local_tunnels"`
-	SSHPublicKey              []byte            `mapstructure:"ssh_public_key" cty:"ssh_public_key"`
-	SSHPrivateKey             []byte            `mapstructure:"ssh_private_key" cty:"ssh_private_key"`
-	WinRMUser                 *string           `mapstructure:"winrm_username" cty:"winrm_username"`
-	WinRMPassword             *string           `mapstructure:"winrm_password" cty:"winrm_password"`
-	WinRMHost                 *string           `mapstructure:"winrm_host" cty:"winrm_host"`
-	WinRMPort                 *int              `mapstructure:"winrm_port" cty:"winrm_port"`
-	WinRMTimeout              *string           `mapstructure:"winrm_timeout" cty:"winrm_timeout"`
-	WinRMUseSSL               *bool             `mapstructure:"winrm_use_ssl" cty:"winrm_use_ssl"`
-	WinRMInsecure             *bool             `mapstructure:"winrm_insecure" cty:"winrm_insecure"`
-	WinRMUseNTLM              *bool             `mapstructure:"winrm_use_ntlm" cty:"winrm_use_ntlm"`
-	HostPortMin               *int              `mapstructure:"host_port_min" required:"false" cty:"host_port_min"`
-	HostPortMax               *int              `mapstructure:"host_port_max" required:"false" cty:"host_port_max"`
-	SkipNatMapping            *bool             `mapstructure:"skip_nat_mapping" required:"false" cty:"skip_nat_mapping"`
-	SSHHostPortMin            *int              `mapstructure:"ssh_host_port_min" required:"false" cty:"ssh_host_port_min"`
-	SSHHostPortMax            *int              `mapstructure:"ssh_host_port_max" cty:"ssh_host_port_max"`
-	SSHSkipNatMapping         *bool             `mapstructure:"ssh_skip_nat_mapping" required:"false" cty:"ssh_skip_nat_mapping"`
-	CpuCount                  *int              `mapstructure:"cpus" required:"false" cty:"cpus"`
-	MemorySize                *int              `mapstructure:"memory" required:"false" cty:"memory"`
-	Sound                     *string           `mapstructure:"sound" required:"false" cty:"sound"`
-	USB                       *bool             `mapstructure:"usb" required:"false" cty:"usb"`
-	VBoxManage                [][]string        `mapstructure:"vboxmanage" required:"false" cty:"vboxmanage"`
-	VBoxManagePost            [][]string        `mapstructure:"vboxmanage_post" required:"false" cty:"vboxmanage_post"`
-	VBoxVersionFile           *string           `mapstructure:"virtualbox_version_file" required:"false" cty:"virtualbox_version_file"`
-	BundleISO                 *bool             `mapstructure:"bundle_iso" required:"false" cty:"bundle_iso"`
-	GuestAdditionsMode        *string           `mapstructure:"guest_additions_mode" required:"false" cty:"guest_additions_mode"`
-	DiskSize                  *uint             `mapstructure:"disk_size" required:"false" cty:"disk_size"`
-	GuestAdditionsPath        *string           `mapstructure:"guest_additions_path" required:"false" cty:"guest_additions_path"`
-	GuestAdditionsSHA256      *string           `mapstructure:"guest_additions_sha256" required:"false" cty:"guest_additions_sha256"`
-	GuestAdditionsURL         *string           `mapstructure:"guest_additions_url" required:"false" cty:"guest_additions_url"`
-	GuestAdditionsInterface   *string           `mapstructure:"guest_additions_interface" required:"false" cty:"guest_additions_interface"`
-	GuestOSType               *string           `mapstructure:"guest_os_type" required:"false" cty:"guest_os_type"`
-	HardDriveDiscard          *bool             `mapstructure:"hard_drive_discard" required:"false" cty:"hard_drive_discard"`
-	HardDriveInterface        *string           `mapstructure:"hard_drive_interface" required:"false" cty:"hard_drive_interface"`
-	SATAPortCount             *int              `mapstructure:"sata_port_count" required:"false" cty:"sata_port_count"`
-	NVMePortCount             *int              `mapstructure:"nvme_port_count" required:"false" cty:"nvme_port_count"`
-	HardDriveNonrotational    *bool             `mapstructure:"hard_drive_nonrotational" required:"false" cty:"hard_drive_nonrotational"`
-	ISOInterface              *string           `mapstructure:"iso_interface" required:"false" cty:"iso_interface"`
-	KeepRegistered            *bool             `mapstructure:"keep_registered" required:"false" cty:"keep_registered"`
-	SkipExport                *bool             `mapstructure:"skip_export" required:"false" cty:"skip_export"`
-	VMName                    *string           `mapstructure:"vm_name" required:"false" cty:"vm_name"`
+	PackerBuildName           *string                     `mapstructure:"packer_build_name" cty:"packer_build_name"`
+	PackerBuilderType         *string                     `mapstructure:"packer_builder_type" cty:"packer_builder_type"`
+	PackerDebug               *bool                       `mapstructure:"packer_debug" cty:"packer_debug"`
+	PackerForce               *bool                       `mapstructure:"packer_force" cty:"packer_force"`
+	PackerOnError             *string                     `mapstructure:"packer_on_error" cty:"packer_on_error"`
+	PackerUserVars            map[string]string           `mapstructure:"packer_user_variables" cty:"packer_user_variables"`
+	PackerSensitiveVars       []string                    `mapstructure:"packer_sensitive_variables" cty:"packer_sensitive_variables"`
+	HTTPDir                   *string                     `mapstructure:"http_directory" cty:"http_directory"`
+	HTTPPortMin               *int                        `mapstructure:"http_port_min" cty:"http_port_min"`
+	HTTPPortMax               *int                        `mapstructure:"http_port_max" cty:"http_port_max"`
+	ISOChecksum               *string                     `mapstructure:"iso_checksum" required:"true" cty:"iso_checksum"`
+	ISOChecksumURL            *string                     `mapstructure:"iso_checksum_url" cty:"iso_checksum_url"`
+	ISOChecksumType           *string                     `mapstructure:"iso_checksum_type" cty:"iso_checksum_type"`
+	RawSingleISOUrl           *string                     `mapstructure:"iso_url" required:"true" cty:"iso_url"`
+	ISOUrls                   []string                    `mapstructure:"iso_urls" cty:"iso_urls"`
+	TargetPath                *string                     `mapstructure:"iso_target_path" cty:"iso_target_path"`
+	TargetExtension           *string                     `mapstructure:"iso_target_extension" cty:"iso_target_extension"`
+	FloppyFiles               []string                    `mapstructure:"floppy_files" cty:"floppy_files"`
+	FloppyDirectories         []string                    `mapstructure:"floppy_dirs" cty:"floppy_dirs"`
+	FloppyLabel               *string                     `mapstructure:"floppy_label" cty:"floppy_label"`
+	BootGroupInterval         *string                     `mapstructure:"boot_keygroup_interval" cty:"boot_keygroup_interval"`
+	BootWait                  *string                     `mapstructure:"boot_wait" cty:"boot_wait"`
+	BootCommand               []string                    `mapstructure:"boot_command" cty:"boot_command"`
+	Format                    *string                     `mapstructure:"format" required:"false" cty:"format"`
+	ExportOpts                []string                    `mapstructure:"export_opts" required:"false" cty:"export_opts"`
+	OVFVersion                *string                     `mapstructure:"ovf_version" required:"false" cty:"ovf_version"`
+	Manifest                  *bool                       `mapstructure:"manifest" required:"false" cty:"manifest"`
+	SigningCertificatePath    *string                     `mapstructure:"signing_certificate_path" required:"false" cty:"signing_certificate_path"`
+	SigningPrivateKeyPath     *string                     `mapstructure:"signing_private_key_path" required:"false" cty:"signing_private_key_path"`
+	OutputDir                 *string                     `mapstructure:"output_directory" required:"false" cty:"output_directory"`
+	OutputFilename            *string                     `mapstructure:"output_filename" required:"false" cty:"output_filename"`
+	Headless                  *bool                       `mapstructure:"headless" required:"false" cty:"headless"`
+	VRDPBindAddress           *string                     `mapstructure:"vrdp_bind_address" required:"false" cty:"vrdp_bind_address"`
+	VRDPPortMin               *int                        `mapstructure:"vrdp_port_min" required:"false" cty:"vrdp_port_min"`
+	VRDPPortMax               *int                        `mapstructure:"vrdp_port_max" cty:"vrdp_port_max"`
+	ShutdownCommand           *string                     `mapstructure:"shutdown_command" required:"false" cty:"shutdown_command"`
+	ShutdownTimeout           *string                     `mapstructure:"shutdown_timeout" required:"false" cty:"shutdown_timeout"`
+	PostShutdownDelay         *string                     `mapstructure:"post_shutdown_delay" required:"false" cty:"post_shutdown_delay"`
+	DisableShutdown           *bool                       `mapstructure:"disable_shutdown" required:"false" cty:"disable_shutdown"`
+	ACPIShutdown              *bool                       `mapstructure:"acpi_shutdown" required:"false" cty:"acpi_shutdown"`
+	Type                      *string                     `mapstructure:"communicator" cty:"communicator"`
+	PauseBeforeConnect        *string                     `mapstructure:"pause_before_connecting" cty:"pause_before_connecting"`
+	SSHHost                   *string                     `mapstructure:"ssh_host" cty:"ssh_host"`
+	SSHPort                   *int                        `mapstructure:"ssh_port" cty:"ssh_port"`
+	SSHUsername               *string                     `mapstructure:"ssh_username" cty:"ssh_username"`
+	SSHPassword               *string                     `mapstructure:"ssh_password" cty:"ssh_password"`
+	SSHKeyPairName            *string                     `mapstructure:"ssh_keypair_name" cty:"ssh_keypair_name"`
+	SSHTemporaryKeyPairName   *string                     `mapstructure:"temporary_key_pair_name" cty:"temporary_key_pair_name"`
+	SSHClearAuthorizedKeys    *bool                       `mapstructure:"ssh_clear_authorized_keys" cty:"ssh_clear_authorized_keys"`
+	SSHPrivateKeyFile         *string                     `mapstructure:"ssh_private_key_file" cty:"ssh_private_key_file"`
+	SSHPty                    *bool                       `mapstructure:"ssh_pty" cty:"ssh_pty"`
+	SSHTimeout                *string                     `mapstructure:"ssh_timeout" cty:"ssh_timeout"`
+	SSHWaitTimeout            *string                     `mapstructure:"ssh_wait_timeout" undocumented:"true" cty:"ssh_wait_timeout"`
+	SSHAgentAuth              *bool                       `mapstructure:"ssh_agent_auth" cty:"ssh_agent_auth"`
+	SSHDisableAgentForwarding *bool                       `mapstructure:"ssh_disable_agent_forwarding" cty:"ssh_disable_agent_forwarding"`
+	SSHHandshakeAttempts      *int                        `mapstructure:"ssh_handshake_attempts" cty:"ssh_handshake_attempts"`
+	SSHBastionHost            *string                     `mapstructure:"ssh_bastion_host" cty:"ssh_bastion_host"`
+	SSHBastionPort            *int                        `mapstructure:"ssh_bastion_port" cty:"ssh_bastion_port"`
+	SSHBastionAgentAuth       *bool                       `mapstructure:"ssh_bastion_agent_auth" cty:"ssh_bastion_agent_auth"`
+	SSHBastionUsername        *string                     `mapstructure:"ssh_bastion_username" cty:"ssh_bastion_username"`
+	SSHBastionPassword        *string                     `mapstructure:"ssh_bastion_password" cty:"ssh_bastion_password"`
+	SSHBastionInteractive     *bool                       `mapstructure:"ssh_bastion_interactive" cty:"ssh_bastion_interactive"`
+	SSHBastionPrivateKeyFile  *string                     `mapstructure:"ssh_bastion_private_key_file" cty:"ssh_bastion_private_key_file"`
+	SSHFileTransferMethod     *string                     `mapstructure:"ssh_file_transfer_method" cty:"ssh_file_transfer_method"`
+	SSHProxyHost              *string                     `mapstructure:"ssh_proxy_host" cty:"ssh_proxy_host"`
+	SSHProxyPort              *int                        `mapstructure:"ssh_proxy_port" cty:"ssh_proxy_port"`
+	SSHProxyUsername          *string                     `mapstructure:"ssh_proxy_username" cty:"ssh_proxy_username"`
+	SSHProxyPassword          *string                     `mapstructure:"ssh_proxy_password" cty:"ssh_proxy_password"`
+	SSHKeepAliveInterval      *string                     `mapstructure:"ssh_keep_alive_interval" cty:"ssh_keep_alive_interval"`
+	SSHReadWriteTimeout       *string                     `mapstructure:"ssh_read_write_timeout" cty:"ssh_read_write_timeout"`
+	SSHRemoteTunnels          []string                    `mapstructure:"ssh_remote_tunnels" cty:"ssh_remote_tunnels"`
+	SSHLocalTunnels           []string                    `mapstructure:"ssh_local_tunnels" cty:"ssh_local_tunnels"`
+	SSHPublicKey              []byte                      `mapstructure:"ssh_public_key" cty:"ssh_public_key"`
+	SSHPrivateKey             []byte                      `mapstructure:"ssh_private_key" cty:"ssh_private_key"`
+	WinRMUser                 *string                     `mapstructure:"winrm_username" cty:"winrm_username"`
+	WinRMPassword             *string                     `mapstructure:"winrm_password" cty:"winrm_password"`
+	WinRMHost                 *string                     `mapstructure:"winrm_host" cty:"winrm_host"`
+	WinRMPort                 *int                        `mapstructure:"winrm_port" cty:"winrm_port"`
+	WinRMTimeout              *string                     `mapstructure:"winrm_timeout" cty:"winrm_timeout"`
+	WinRMUseSSL               *bool                       `mapstructure:"winrm_use_ssl" cty:"winrm_use_ssl"`
+	WinRMInsecure             *bool                       `mapstructure:"winrm_insecure" cty:"winrm_insecure"`
+	WinRMUseNTLM              *bool                       `mapstructure:"winrm_use_ntlm" cty:"winrm_use_ntlm"`
+	HostPortMin               *int                        `mapstructure:"host_port_min" required:"false" cty:"host_port_min"`
+	HostPortMax               *int                        `mapstructure:"host_port_max" required:"false" cty:"host_port_max"`
+	SkipNatMapping            *bool                       `mapstructure:"skip_nat_mapping" required:"false" cty:"skip_nat_mapping"`
+	SSHHostPortMin            *int                        `mapstructure:"ssh_host_port_min" required:"false" cty:"ssh_host_port_min"`
+	SSHHostPortMax            *int                        `mapstructure:"ssh_host_port_max" cty:"ssh_host_port_max"`
+	SSHSkipNatMapping         *bool                       `mapstructure:"ssh_skip_nat_mapping" required:"false" cty:"ssh_skip_nat_mapping"`
+	NetworkAdapters           []common.FlatNetworkAdapter `mapstructure:"network_adapters" required:"false" cty:"network_adapters"`
+	CommAdapter               *int                        `mapstructure:"communicator_adapter" required:"false" cty:"communicator_adapter"`
+	CpuCount                  *int                        `mapstructure:"cpus" required:"false" cty:"cpus"`
+	MemorySize                *int                        `mapstructure:"memory" required:"false" cty:"memory"`
+	Sound                     *string                     `mapstructure:"sound" required:"false" cty:"sound"`
+	USB                       *bool                       `mapstructure:"usb" required:"false" cty:"usb"`
+	ParavirtProvider          *string                     `mapstructure:"paravirt_provider" required:"false" cty:"paravirt_provider"`
+	CpuProfile                *string                     `mapstructure:"cpu_profile" required:"false" cty:"cpu_profile"`
+	HWVirtEx                  *string                     `mapstructure:"hwvirtex" required:"false" cty:"hwvirtex"`
+	VBoxManage                [][]string                  `mapstructure:"vboxmanage" required:"false" cty:"vboxmanage"`
+	VBoxManagePost            [][]string                  `mapstructure:"vboxmanage_post" required:"false" cty:"vboxmanage_post"`
+	VBoxManageCommandWrapper  *string                     `mapstructure:"vboxmanage_command_wrapper" required:"false" cty:"vboxmanage_command_wrapper"`
+	VBoxVersionFile           *string                     `mapstructure:"virtualbox_version_file" required:"false" cty:"virtualbox_version_file"`
+	BundleISO                 *bool                       `mapstructure:"bundle_iso" required:"false" cty:"bundle_iso"`
+	GuestAdditionsMode        *string                     `mapstructure:"guest_additions_mode" required:"false" cty:"guest_additions_mode"`
+	DiskSize                  *uint                       `mapstructure:"disk_size" required:"false" cty:"disk_size"`
+	GuestAdditionsPath        *string                     `mapstructure:"guest_additions_path" required:"false" cty:"guest_additions_path"`
+	GuestAdditionsSHA256      *string                     `mapstructure:"guest_additions_sha256" required:"false" cty:"guest_additions_sha256"`
+	GuestAdditionsURL         *string                     `mapstructure:"guest_additions_url" required:"false" cty:"guest_additions_url"`
+	GuestAdditionsInterface   *string                     `mapstructure:"guest_additions_interface" required:"false" cty:"guest_additions_interface"`
+	GuestOSType               *string                     `mapstructure:"guest_os_type" required:"false" cty:"guest_os_type"`
+	HardDriveDiscard          *bool                       `mapstructure:"hard_drive_discard" required:"false" cty:"hard_drive_discard"`
+	HardDriveInterface        *string                     `mapstructure:"hard_drive_interface" required:"false" cty:"hard_drive_interface"`
+	SATAPortCount             *int                        `mapstructure:"sata_port_count" required:"false" cty:"sata_port_count"`
+	NVMePortCount             *int                        `mapstructure:"nvme_port_count" required:"false" cty:"nvme_port_count"`
+	HardDriveNonrotational    *bool                       `mapstructure:"hard_drive_nonrotational" required:"false" cty:"hard_drive_nonrotational"`
+	ISOInterface              *string                     `mapstructure:"iso_interface" required:"false" cty:"iso_interface"`
+	AttachISOs                []string                    `mapstructure:"attach_isos" required:"false" cty:"attach_isos"`
+	KeepRegistered            *bool                       `mapstructure:"keep_registered" required:"false" cty:"keep_registered"`
+	SkipExport                *bool                       `mapstructure:"skip_export" required:"false" cty:"skip_export"`
+	VMName                    *string                     `mapstructure:"vm_name" required:"false" cty:"vm_name"`
 }
 
 // FlatMapstructure returns a new FlatConfig.
@@ -156,6 +168,10 @@ func (*FlatConfig) HCL2Spec() map[string]hcldec.Spec {
 		"boot_command":                 &hcldec.AttrSpec{Name: "boot_command", Type: cty.List(cty.String), Required: false},
 		"format":                       &hcldec.AttrSpec{Name: "format", Type: cty.String, Required: false},
 		"export_opts":                  &hcldec.AttrSpec{Name: "export_opts", Type: cty.List(cty.String), Required: false},
+		"ovf_version":                  &hcldec.AttrSpec{Name: "ovf_version", Type: cty.String, Required: false},
+		"manifest":                     &hcldec.AttrSpec{Name: "manifest", Type: cty.Bool, Required: false},
+		"signing_certificate_path":     &hcldec.AttrSpec{Name: "signing_certificate_path", Type: cty.String, Required: false},
+		"signing_private_key_path":     &hcldec.AttrSpec{Name: "signing_private_key_path", Type: cty.String, Required: false},
 		"output_directory":             &hcldec.AttrSpec{Name: "output_directory", Type: cty.String, Required: false},
 		"output_filename":              &hcldec.AttrSpec{Name: "output_filename", Type: cty.String, Required: false},
 		"headless":                     &hcldec.AttrSpec{Name: "headless", Type: cty.Bool, Required: false},
@@ -215,12 +231,18 @@ func (*FlatConfig) HCL2Spec() map[string]hcldec.Spec {
 		"ssh_host_port_min":            &hcldec.AttrSpec{Name: "ssh_host_port_min", Type: cty.Number, Required: false},
 		"ssh_host_port_max":            &hcldec.AttrSpec{Name: "ssh_host_port_max", Type: cty.Number, Required: false},
 		"ssh_skip_nat_mapping":         &hcldec.AttrSpec{Name: "ssh_skip_nat_mapping", Type: cty.Bool, Required: false},
+		"network_adapters":             &hcldec.BlockListSpec{TypeName: "network_adapters", Nested: hcldec.ObjectSpec((*common.FlatNetworkAdapter)(nil).HCL2Spec())},
+		"communicator_adapter":         &hcldec.AttrSpec{Name: "communicator_adapter", Type: cty.Number, Required: false},
 		"cpus":                         &hcldec.AttrSpec{Name: "cpus", Type: cty.Number, Required: false},
 		"memory":                       &hcldec.AttrSpec{Name: "memory", Type: cty.Number, Required: false},
 		"sound":                        &hcldec.AttrSpec{Name: "sound", Type: cty.String, Required: false},
 		"usb":                          &hcldec.AttrSpec{Name: "usb", Type: cty.Bool, Required: false},
+		"paravirt_provider":            &hcldec.AttrSpec{Name: "paravirt_provider", Type: cty.String, Required: false},
+		"cpu_profile":                  &hcldec.AttrSpec{Name: "cpu_profile", Type: cty.String, Required: false},
+		"hwvirtex":                     &hcldec.AttrSpec{Name: "hwvirtex", Type: cty.String, Required: false},
 		"vboxmanage":                   &hcldec.AttrSpec{Name: "vboxmanage", Type: cty.List(cty.List(cty.String)), Required: false},
 		"vboxmanage_post":              &hcldec.AttrSpec{Name: "vboxmanage_post", Type: cty.List(cty.List(cty.String)), Required: false},
+		"vboxmanage_command_wrapper":   &hcldec.AttrSpec{Name: "vboxmanage_command_wrapper", Type: cty.String, Required: false},
 		"virtualbox_version_file":      &hcldec.AttrSpec{Name: "virtualbox_version_file", Type: cty.String, Required: false},
 		"bundle_iso":                   &hcldec.AttrSpec{Name: "bundle_iso", Type: cty.Bool, Required: false},
 		"guest_additions_mode":         &hcldec.AttrSpec{Name: "guest_additions_mode", Type: cty.String, Required: false},
@@ -236,6 +258,7 @@ func (*FlatConfig) HCL2Spec() map[string]hcldec.Spec {
 		"nvme_port_count":              &hcldec.AttrSpec{Name: "nvme_port_count", Type: cty.Number, Required: false},
 		"hard_drive_nonrotational":     &hcldec.AttrSpec{Name: "hard_drive_nonrotational", Type: cty.Bool, Required: false},
 		"iso_interface":                &hcldec.AttrSpec{Name: "iso_interface", Type: cty.String, Required: false},
+		"attach_isos":                  &hcldec.AttrSpec{Name: "attach_isos", Type: cty.List(cty.String), Required: false},
 		"keep_registered":              &hcldec.AttrSpec{Name: "keep_registered", Type: cty.Bool, Required: false},
 		"skip_export":                  &hcldec.AttrSpec{Name: "skip_export", Type: cty.Bool, Required: false},
 		"vm_name":                      &hcldec.AttrSpec{Name: "vm_name", Type: cty.String, Required: false},
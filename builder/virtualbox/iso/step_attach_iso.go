@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"path/filepath"
+	"strconv"
 
 	vboxcommon "github.com/hashicorp/packer/builder/virtualbox/common"
 	"github.com/hashicorp/packer/helper/multistep"
@@ -70,9 +71,56 @@ func (s *stepAttachISO) Run(ctx context.Context, state multistep.StateBag) multi
 		state.Put("attachedIsoOnSata", true)
 	}
 
+	// Attach any additional ISOs after the boot ISO. The positions are
+	// remembered in the state bag so that StepRemoveDevices can detach
+	// them before the VM is exported.
+	extraPositions := make([][]string, 0, len(config.AttachISOs))
+	for i, attachPath := range config.AttachISOs {
+		resolvedPath, err := filepath.EvalSymlinks(attachPath)
+		if err != nil {
+			err := fmt.Errorf("Error resolving symlink for ISO: %s", err)
+			state.Put("error", err)
+			ui.Error(err.Error())
+			return multistep.ActionHalt
+		}
+
+		controllerName, port, device := extraIsoPosition(config.ISOInterface, i)
+		command := []string{
+			"storageattach", vmName,
+			"--storagectl", controllerName,
+			"--port", port,
+			"--device", device,
+			"--type", "dvddrive",
+			"--medium", resolvedPath,
+		}
+		if err := driver.VBoxManage(command...); err != nil {
+			err := fmt.Errorf("Error attaching ISO: %s", err)
+			state.Put("error", err)
+			ui.Error(err.Error())
+			return multistep.ActionHalt
+		}
+
+		extraPositions = append(extraPositions, []string{controllerName, port, device})
+	}
+	if len(extraPositions) > 0 {
+		state.Put("attachedExtraIsos", extraPositions)
+	}
+
 	return multistep.ActionContinue
 }
 
+// extraIsoPosition returns the controller position for the i'th additional
+// ISO. On IDE the only free slot is the secondary slave; on SATA the boot
+// ISO sits on port 1 and the guest additions ISO on port 2, so additional
+// ISOs start at port 3.
+func extraIsoPosition(isoInterface string, i int) (string, string, string) {
+	if isoInterface == "sata" {
+		return "SATA Controller", strconv.Itoa(3 + i), "0"
+	}
+
+	return "IDE Controller", "1", "1"
+}
+
 func (s *stepAttachISO) Cleanup(state multistep.StateBag) {
 	if s.diskPath == "" {
 		return
@@ -102,4 +150,17 @@ func (s *stepAttachISO) Cleanup(state multistep.StateBag) {
 	// Remove the ISO. Note that this will probably fail since
 	// stepRemoveDevices does this as well. No big deal.
 	driver.VBoxManage(command...)
+
+	if extraRaw, ok := state.GetOk("attachedExtraIsos"); ok {
+		for _, position := range extraRaw.([][]string) {
+			command := []string{
+				"storageattach", vmName,
+				"--storagectl", position[0],
+				"--port", position[1],
+				"--device", position[2],
+				"--medium", "none",
+			}
+			driver.VBoxManage(command...)
+		}
+	}
 }
@@ -45,6 +45,34 @@ func (s *stepCreateVM) Run(ctx context.Context, state multistep.StateBag) multis
 		commands[5] = []string{"modifyvm", name, "--audio", config.HWConfig.Sound, "--audioin", "on", "--audioout", "on"}
 	}
 
+	if config.HWConfig.ParavirtProvider != "" {
+		commands = append(commands, []string{"modifyvm", name, "--paravirtprovider", config.HWConfig.ParavirtProvider})
+	}
+
+	if config.HWConfig.HWVirtEx != "" {
+		commands = append(commands, []string{"modifyvm", name, "--hwvirtex", config.HWConfig.HWVirtEx})
+	}
+
+	if config.HWConfig.CpuProfile != "" {
+		// --cpu-profile is only understood by VirtualBox 6.1 and later, so
+		// check the version up front to fail with a clear message instead
+		// of an obscure VBoxManage usage error.
+		version, err := driver.Version()
+		if err != nil {
+			err := fmt.Errorf("Error reading VirtualBox version: %s", err)
+			state.Put("error", err)
+			ui.Error(err.Error())
+			return multistep.ActionHalt
+		}
+		if !vboxVersionAtLeast(version, 6, 1) {
+			err := fmt.Errorf("cpu_profile requires VirtualBox 6.1 or later, have %s", version)
+			state.Put("error", err)
+			ui.Error(err.Error())
+			return multistep.ActionHalt
+		}
+		commands = append(commands, []string{"modifyvm", name, "--cpu-profile", config.HWConfig.CpuProfile})
+	}
+
 	ui.Say("Creating virtual machine...")
 	for _, command := range commands {
 		err := driver.VBoxManage(command...)
@@ -67,6 +95,25 @@ func (s *stepCreateVM) Run(ctx context.Context, state multistep.StateBag) multis
 	return multistep.ActionContinue
 }
 
+// vboxVersionAtLeast says whether a VBoxManage version string such as
+// "6.1.4" is at least major.minor. Unparseable versions are assumed to be
+// recent enough, which matches the old behavior of just trying the command.
+func vboxVersionAtLeast(version string, major, minor int) bool {
+	parts := strings.Split(version, ".")
+	if len(parts) < 2 {
+		return true
+	}
+	haveMajor, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return true
+	}
+	haveMinor, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return true
+	}
+	return haveMajor > major || (haveMajor == major && haveMinor >= minor)
+}
+
 func (s *stepCreateVM) Cleanup(state multistep.StateBag) {
 	if s.vmName == "" {
 		return
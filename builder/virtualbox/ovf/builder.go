@@ -35,7 +35,7 @@ func (b *Builder) Prepare(raws ...interface{}) ([]string, []string, error) {
 // a VirtualBox appliance.
 func (b *Builder) Run(ctx context.Context, ui packer.Ui, hook packer.Hook) (packer.Artifact, error) {
 	// Create the driver that we'll use to communicate with VirtualBox
-	driver, err := vboxcommon.NewDriver()
+	driver, err := vboxcommon.NewDriver(b.config.VBoxManageCommandWrapper)
 	if err != nil {
 		return nil, fmt.Errorf("Failed creating VirtualBox driver: %s", err)
 	}
@@ -101,11 +101,15 @@ func (b *Builder) Run(ctx context.Context, ui packer.Ui, hook packer.Hook) (pack
 			VRDPPortMax:     b.config.VRDPPortMax,
 		},
 		new(vboxcommon.StepAttachFloppy),
+		&vboxcommon.StepNetworkAdapters{
+			NetworkAdapters: b.config.NetworkAdapters,
+		},
 		&vboxcommon.StepPortForwarding{
 			CommConfig:     &b.config.CommConfig.Comm,
 			HostPortMin:    b.config.HostPortMin,
 			HostPortMax:    b.config.HostPortMax,
 			SkipNatMapping: b.config.SkipNatMapping,
+			CommAdapter:    b.config.CommAdapter,
 		},
 		&vboxcommon.StepVBoxManage{
 			Commands: b.config.VBoxManage,
@@ -156,12 +160,16 @@ func (b *Builder) Run(ctx context.Context, ui packer.Ui, hook packer.Hook) (pack
 			Ctx:      b.config.ctx,
 		},
 		&vboxcommon.StepExport{
-			Format:         b.config.Format,
-			OutputDir:      b.config.OutputDir,
-			OutputFilename: b.config.OutputFilename,
-			ExportOpts:     b.config.ExportConfig.ExportOpts,
-			SkipNatMapping: b.config.SkipNatMapping,
-			SkipExport:     b.config.SkipExport,
+			Format:                 b.config.Format,
+			OutputDir:              b.config.OutputDir,
+			OutputFilename:         b.config.OutputFilename,
+			ExportOpts:             b.config.ExportConfig.ExportOpts,
+			OVFVersion:             b.config.ExportConfig.OVFVersion,
+			Manifest:               b.config.ExportConfig.Manifest,
+			SigningCertificatePath: b.config.ExportConfig.SigningCertificatePath,
+			SigningPrivateKeyPath:  b.config.ExportConfig.SigningPrivateKeyPath,
+			SkipNatMapping:         b.config.SkipNatMapping,
+			SkipExport:             b.config.SkipExport,
 		},
 	}
 
@@ -88,12 +88,17 @@ func NewArtifact(remoteType string, format string, exportOutputPath string, vmNa
 	config[ArtifactConfFormat] = format
 	config[ArtifactConfSkipExport] = strconv.FormatBool(skipExport)
 
+	stateData := map[string]interface{}{"generated_data": state.Get("generated_data")}
+	if checksums, ok := state.GetOk("export_checksums"); ok {
+		stateData["export_checksums"] = checksums
+	}
+
 	return &artifact{
 		builderId: builderId,
 		id:        vmName,
 		dir:       dir,
 		f:         files,
 		config:    config,
-		StateData: map[string]interface{}{"generated_data": state.Get("generated_data")},
+		StateData: stateData,
 	}, nil
 }
@@ -3,12 +3,9 @@
 package common
 
 import (
-	"bytes"
 	"context"
 	"fmt"
-	"net/url"
 	"os"
-	"os/exec"
 	"strings"
 	"time"
 
@@ -98,32 +95,26 @@ func (c *DriverConfig) Validate(SkipExport bool) error {
 	// check that password is valid by sending a dummy ovftool command
 	// now, so that we don't fail for a simple mistake after a long
 	// build
-	ovftool := GetOVFTool()
-	ovfToolArgs := []string{"--noSSLVerify", "--verifyOnly", fmt.Sprintf("vi://%s:%s@%s",
-		url.QueryEscape(c.RemoteUser),
-		url.QueryEscape(c.RemotePassword),
-		c.RemoteHost)}
+	ovftool, err := NewOvftoolDriver()
+	if err != nil {
+		return err
+	}
 
-	var out bytes.Buffer
 	cmdCtx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
 	defer cancel()
-	cmd := exec.CommandContext(cmdCtx, ovftool, ovfToolArgs...)
-	cmd.Stdout = &out
-
-	// Need to manually close stdin or else the ofvtool call will hang
-	// forever in a situation where the user has provided an invalid
-	// password or username
-	stdin, _ := cmd.StdinPipe()
-	defer stdin.Close()
 
-	if err := cmd.Run(); err != nil {
-		outString := out.String()
+	out, err := ovftool.Verify(cmdCtx, ViLocator{
+		Username: c.RemoteUser,
+		Password: c.RemotePassword,
+		Host:     c.RemoteHost,
+	})
+	if err != nil {
 		// The command *should* fail with this error, if it
 		// authenticates properly.
-		if !strings.Contains(outString, "Found wrong kind of object") {
+		if !strings.Contains(out, "Found wrong kind of object") {
 			err := fmt.Errorf("ovftool validation error: %s; %s",
-				err, outString)
-			if strings.Contains(outString,
+				err, out)
+			if strings.Contains(out,
 				"Enter login information for source") {
 				err = fmt.Errorf("The username or password you " +
 					"provided to ovftool is invalid.")
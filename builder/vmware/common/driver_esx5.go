@@ -10,6 +10,7 @@ import (
 	"io"
 	"log"
 	"net"
+	"net/url"
 	"os"
 	"path"
 	"path/filepath"
@@ -22,6 +23,9 @@ import (
 	"github.com/hashicorp/packer/helper/multistep"
 	helperssh "github.com/hashicorp/packer/helper/ssh"
 	"github.com/hashicorp/packer/packer"
+	"github.com/vmware/govmomi"
+	"github.com/vmware/govmomi/find"
+	"github.com/vmware/govmomi/vim25/types"
 	gossh "golang.org/x/crypto/ssh"
 )
 
@@ -637,6 +641,33 @@ func (d *ESX5Driver) checkSystemVersion() error {
 	return nil
 }
 
+// AcquireVNCOverWebsocketTicket acquires a webmks ticket for the VM, which
+// allows the console keyboard to be driven over a websocket connection to
+// the ESXi host instead of a classic VNC port.
+func (d *ESX5Driver) AcquireVNCOverWebsocketTicket() (*types.VirtualMachineTicket, error) {
+	ctx := context.TODO()
+
+	sdkURL := &url.URL{
+		Scheme: "https",
+		Host:   d.Host,
+		Path:   "/sdk",
+		User:   url.UserPassword(d.Username, d.Password),
+	}
+	client, err := govmomi.NewClient(ctx, sdkURL, true)
+	if err != nil {
+		return nil, fmt.Errorf("Error connecting to the ESXi SDK endpoint: %s", err)
+	}
+	defer client.Logout(ctx)
+
+	finder := find.NewFinder(client.Client, false)
+	vm, err := finder.VirtualMachine(ctx, d.VMName)
+	if err != nil {
+		return nil, fmt.Errorf("Error finding the virtual machine %s: %s", d.VMName, err)
+	}
+
+	return vm.AcquireTicket(ctx, string(types.VirtualMachineTicketTypeWebmks))
+}
+
 func (d *ESX5Driver) checkGuestIPHackEnabled() error {
 	r, err := d.esxcli("system", "settings", "advanced", "list", "-o", "/Net/GuestIPHack")
 	if err != nil {
@@ -735,6 +766,29 @@ func (d *ESX5Driver) sh(args ...string) error {
 	return err
 }
 
+func (d *ESX5Driver) HostNetworks() ([]string, error) {
+	r, err := d.esxcli("network", "vswitch", "standard", "portgroup", "list")
+	if err != nil {
+		return nil, err
+	}
+
+	var networks []string
+	for {
+		record, err := r.read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if record["Name"] != "" {
+			networks = append(networks, record["Name"])
+		}
+	}
+
+	return networks, nil
+}
+
 func (d *ESX5Driver) esxcli(args ...string) (*esxcliReader, error) {
 	stdout, err := d.ssh("esxcli --formatter csv "+strings.Join(args, " "), nil)
 	if err != nil {
@@ -31,6 +31,12 @@ type HWConfig struct {
 	// machine](https://kb.vmware.com/s/article/1001805) for desktop VMware
 	// clients. For ESXi, refer to the proper ESXi documentation.
 	NetworkAdapterType string `mapstructure:"network_adapter_type" required:"false"`
+	// The name of the ESXi port group to connect the virtual machine to.
+	// Only used when building on a remote (ESXi) hypervisor. The port group
+	// is validated against the host before the VM boots; when no exact match
+	// exists, the builder falls back to a case-insensitive match before
+	// giving up with a list of the available port groups.
+	NetworkName string `mapstructure:"network_name" required:"false"`
 	// Specify whether to enable VMware's virtual soundcard device when
 	// building the VM. Defaults to false.
 	Sound bool `mapstructure:"sound" required:"false"`
@@ -118,6 +124,17 @@ func (c *HWConfig) Prepare(ctx *interpolate.Context) []error {
 		errs = append(errs, fmt.Errorf("An invalid number of cores was specified (cores < 0): %d", c.CoreCount))
 	}
 
+	// Network
+	if c.NetworkAdapterType != "" {
+		adapter := strings.ToLower(c.NetworkAdapterType)
+		switch adapter {
+		case "vlance", "e1000", "e1000e", "vmxnet", "vmxnet2", "vmxnet3":
+		default:
+			errs = append(errs, fmt.Errorf("An invalid network adapter type was specified: %s. "+
+				"Valid types are vlance, e1000, e1000e, vmxnet, vmxnet2 and vmxnet3", c.NetworkAdapterType))
+		}
+	}
+
 	// Peripherals
 	if !c.Sound {
 		c.Sound = false
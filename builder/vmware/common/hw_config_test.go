@@ -43,6 +43,24 @@ func TestHWConfigPrepare(t *testing.T) {
 	}
 }
 
+func TestHWConfigPrepare_NetworkAdapterType(t *testing.T) {
+	for _, adapter := range []string{"", "e1000", "E1000e", "vmxnet3", "vlance"} {
+		c := new(HWConfig)
+		c.NetworkAdapterType = adapter
+
+		if errs := c.Prepare(interpolate.NewContext()); len(errs) > 0 {
+			t.Fatalf("should not have error for adapter %q: %#v", adapter, errs)
+		}
+	}
+
+	c := new(HWConfig)
+	c.NetworkAdapterType = "rtl8139"
+
+	if errs := c.Prepare(interpolate.NewContext()); len(errs) == 0 {
+		t.Fatal("should have error for an unknown adapter type")
+	}
+}
+
 func TestHWConfigParallel_File(t *testing.T) {
 	c := new(HWConfig)
 
@@ -0,0 +1,175 @@
+package common
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"net/url"
+	"os/exec"
+	"strings"
+
+	"github.com/hashicorp/packer/packer"
+)
+
+// ViLocator identifies a VM on a remote ESXi or vCenter host in the vi://
+// URL form that ovftool expects. Credentials are query-escaped when the URL
+// is built and the password is masked in anything shown to the user.
+type ViLocator struct {
+	Username string
+	Password string
+	Host     string
+	Path     string
+}
+
+func (l ViLocator) url(hidePassword bool) string {
+	password := url.QueryEscape(l.Password)
+	if hidePassword {
+		password = "****"
+	}
+
+	u := "vi://" + url.QueryEscape(l.Username) + ":" + password + "@" + l.Host
+	if l.Path != "" {
+		u += "/" + l.Path
+	}
+	return u
+}
+
+// OvftoolOptions describes a single ovftool invocation. Arguments are
+// always built from these fields rather than spliced together as strings.
+type OvftoolOptions struct {
+	NoSSLVerify       bool
+	SkipManifestCheck bool
+	VerifyOnly        bool
+	// TargetType is the value passed to -tt, e.g. "ovf" or "ova".
+	TargetType string
+	// ExtraOptions are passed through verbatim, before the generated
+	// arguments, mirroring how ovftool_options has always been handled.
+	ExtraOptions []string
+	Source       ViLocator
+	Destination  string
+}
+
+func (o *OvftoolOptions) args(hidePassword bool) []string {
+	args := append([]string{}, o.ExtraOptions...)
+	if o.NoSSLVerify {
+		args = append(args, "--noSSLVerify=true")
+	}
+	if o.SkipManifestCheck {
+		args = append(args, "--skipManifestCheck")
+	}
+	if o.VerifyOnly {
+		args = append(args, "--verifyOnly")
+	}
+	if o.TargetType != "" {
+		args = append(args, "-tt="+o.TargetType)
+	}
+	args = append(args, o.Source.url(hidePassword))
+	if o.Destination != "" {
+		args = append(args, o.Destination)
+	}
+	return args
+}
+
+// OvftoolDriver wraps invocations of VMware's ovftool binary so that the
+// steps using it do not have to deal with argument quoting, credential
+// masking or output handling themselves.
+type OvftoolDriver interface {
+	// Export runs ovftool with the given options and forwards its progress
+	// output to the UI as it arrives.
+	Export(ctx context.Context, ui packer.Ui, opts *OvftoolOptions) error
+	// Verify runs ovftool in --verifyOnly mode against the given locator
+	// and returns the combined output for the caller to interpret.
+	Verify(ctx context.Context, locator ViLocator) (string, error)
+}
+
+type ovftoolDriver struct {
+	path string
+}
+
+// NewOvftoolDriver locates the ovftool binary and returns a driver using
+// it. An error is returned when ovftool cannot be found on the PATH.
+func NewOvftoolDriver() (OvftoolDriver, error) {
+	path := GetOVFTool()
+	if path == "" {
+		return nil, fmt.Errorf("Error ovftool not found")
+	}
+	return &ovftoolDriver{path: path}, nil
+}
+
+func (d *ovftoolDriver) Export(ctx context.Context, ui packer.Ui, opts *OvftoolOptions) error {
+	ui.Message(fmt.Sprintf("Executing: %s %s", d.path, strings.Join(opts.args(true), " ")))
+
+	cmd := exec.CommandContext(ctx, d.path, opts.args(false)...)
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+
+	// Need to manually close stdin or else the ovftool call will hang
+	// forever when it prompts for credentials.
+	stdin, _ := cmd.StdinPipe()
+	defer stdin.Close()
+
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+
+	// ovftool redraws its progress on the current line using carriage
+	// returns, so treat those as line breaks and forward each new line of
+	// output to the UI.
+	scanner := bufio.NewScanner(stdout)
+	scanner.Split(scanOvftoolLines)
+	last := ""
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line != "" && line != last {
+			ui.Message(line)
+			last = line
+		}
+	}
+
+	if err := cmd.Wait(); err != nil {
+		return fmt.Errorf("ovftool exited with an error: %s\n%s", err, stderr.String())
+	}
+	return nil
+}
+
+func (d *ovftoolDriver) Verify(ctx context.Context, locator ViLocator) (string, error) {
+	opts := &OvftoolOptions{
+		NoSSLVerify: true,
+		VerifyOnly:  true,
+		Source:      locator,
+	}
+
+	var out bytes.Buffer
+	cmd := exec.CommandContext(ctx, d.path, opts.args(false)...)
+	cmd.Stdout = &out
+
+	// See Export: ovftool hangs waiting on stdin when the credentials it
+	// was given are invalid.
+	stdin, _ := cmd.StdinPipe()
+	defer stdin.Close()
+
+	err := cmd.Run()
+	return out.String(), err
+}
+
+// scanOvftoolLines is a bufio.SplitFunc that breaks on both newlines and
+// carriage returns, so that ovftool's in-place progress updates come
+// through as separate lines.
+func scanOvftoolLines(data []byte, atEOF bool) (advance int, token []byte, err error) {
+	if atEOF && len(data) == 0 {
+		return 0, nil, nil
+	}
+	if i := bytes.IndexAny(data, "\r\n"); i >= 0 {
+		return i + 1, data[:i], nil
+	}
+	if atEOF {
+		return len(data), data, nil
+	}
+	return 0, nil, nil
+}
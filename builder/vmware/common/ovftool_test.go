@@ -0,0 +1,59 @@
+package common
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestOvftoolOptions_args(t *testing.T) {
+	opts := &OvftoolOptions{
+		NoSSLVerify:       true,
+		SkipManifestCheck: true,
+		TargetType:        "ovf",
+		ExtraOptions:      []string{"--compress=9"},
+		Source: ViLocator{
+			Username: "user name",
+			Password: "p@ss:word",
+			Host:     "esxi.example.com",
+			Path:     "vm-name",
+		},
+		Destination: "output-dir",
+	}
+
+	args := opts.args(false)
+	expected := []string{
+		"--compress=9",
+		"--noSSLVerify=true",
+		"--skipManifestCheck",
+		"-tt=ovf",
+		"vi://user+name:p%40ss%3Aword@esxi.example.com/vm-name",
+		"output-dir",
+	}
+	if len(args) != len(expected) {
+		t.Fatalf("bad args: %#v", args)
+	}
+	for i, arg := range expected {
+		if args[i] != arg {
+			t.Fatalf("bad arg %d: %s, expected %s", i, args[i], arg)
+		}
+	}
+}
+
+func TestOvftoolOptions_argsHidePassword(t *testing.T) {
+	opts := &OvftoolOptions{
+		Source: ViLocator{
+			Username: "user",
+			Password: "secret",
+			Host:     "esxi.example.com",
+			Path:     "vm-name",
+		},
+	}
+
+	joined := strings.Join(opts.args(true), " ")
+	if strings.Contains(joined, "secret") {
+		t.Fatalf("password should be masked: %s", joined)
+	}
+	if !strings.Contains(joined, "vi://user:****@esxi.example.com/vm-name") {
+		t.Fatalf("bad masked locator: %s", joined)
+	}
+}
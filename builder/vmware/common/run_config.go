@@ -37,9 +37,28 @@ type RunConfig struct {
 	// true if building on ESXi 6.5 and 6.7 with VNC enabled. Defaults to
 	// false.
 	VNCDisablePassword bool `mapstructure:"vnc_disable_password" required:"false"`
+	// When set to true, Packer will connect to the remote machine via a
+	// websocket connection and type the boot command over the webmks
+	// protocol, instead of connecting to a VNC port on the host. This is
+	// required on ESXi 6.7 and later, where the classic VNC server is no
+	// longer available. Only applies to remote ESXi builds. When this is
+	// set, all other VNC options are ignored.
+	VNCOverWebsocket bool `mapstructure:"vnc_over_websocket" required:"false"`
+	// Do not validate the TLS certificate of the remote host when
+	// connecting with vnc_over_websocket. Defaults to false.
+	InsecureConnection bool `mapstructure:"insecure_connection" required:"false"`
 }
 
-func (c *RunConfig) Prepare(ctx *interpolate.Context) (errs []error) {
+func (c *RunConfig) Prepare(ctx *interpolate.Context, driverConfig *DriverConfig) (errs []error) {
+	if c.VNCOverWebsocket {
+		if driverConfig.RemoteType == "" {
+			errs = append(errs, fmt.Errorf("'vnc_over_websocket' can only be used with remote VMware builds"))
+		}
+		// The remaining VNC configuration is not used when connecting over
+		// the websocket, so don't bother validating it.
+		return
+	}
+
 	if c.VNCPortMin == 0 {
 		c.VNCPortMin = 5900
 	}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"path/filepath"
+	"regexp"
 	"strings"
 
 	"github.com/hashicorp/packer/helper/multistep"
@@ -21,6 +23,7 @@ import (
 //   <nothing>
 type StepCleanVMX struct {
 	RemoveEthernetInterfaces bool
+	StripIdentifiers         bool
 	VNCEnabled               bool
 }
 
@@ -120,6 +123,37 @@ func (s StepCleanVMX) Run(ctx context.Context, state multistep.StateBag) multist
 		}
 	}
 
+	// Strip host-specific data if necessary
+	if s.StripIdentifiers {
+		ui.Message("Stripping host-specific data from VMX...")
+
+		for k := range vmxData {
+			// Generated MAC addresses and machine UUIDs: deleting them
+			// makes the hypervisor generate fresh ones on the next boot
+			// instead of importing the ones from the build host.
+			if strings.Contains(k, ".generatedaddress") ||
+				k == "uuid.bios" || k == "uuid.location" ||
+				k == "vc.uuid" || k == "vmci0.id" {
+				log.Printf("Deleting host-specific key: %s", k)
+				delete(vmxData, k)
+			}
+		}
+
+		// Suppress the "moved or copied" question the missing UUIDs
+		// would otherwise trigger on the first boot.
+		vmxData["uuid.action"] = "create"
+
+		// Absolute paths in the VMX point at files on the build host and
+		// break imports elsewhere. Everything the machine still needs is
+		// exported next to the VMX, so only keep the file names.
+		for k, v := range vmxData {
+			if stripped := stripHostPath(v); stripped != v {
+				log.Printf("Rewriting absolute path in %s: %s", k, v)
+				vmxData[k] = stripped
+			}
+		}
+	}
+
 	// Rewrite the VMX
 	if err := WriteVMX(vmxPath, vmxData); err != nil {
 		state.Put("error", fmt.Errorf("Error writing VMX: %s", err))
@@ -130,3 +164,16 @@ func (s StepCleanVMX) Run(ctx context.Context, state multistep.StateBag) multist
 }
 
 func (StepCleanVMX) Cleanup(multistep.StateBag) {}
+
+// absWindowsPath matches values like `C:\...` or `C:/...`, which
+// filepath.IsAbs does not recognize when Packer runs on another OS.
+var absWindowsPath = regexp.MustCompile(`^[a-zA-Z]:[/\\]`)
+
+// stripHostPath reduces an absolute path to its base name; any other
+// value is returned unchanged.
+func stripHostPath(v string) string {
+	if !filepath.IsAbs(v) && !absWindowsPath.MatchString(v) {
+		return v
+	}
+	return v[strings.LastIndexAny(v, `/\`)+1:]
+}
@@ -196,6 +196,63 @@ func TestStepCleanVMX_ethernet(t *testing.T) {
 	}
 }
 
+func TestStepCleanVMX_stripIdentifiers(t *testing.T) {
+	state := testState(t)
+	step := &StepCleanVMX{
+		StripIdentifiers: true,
+	}
+
+	vmxPath := testVMXFile(t)
+	defer os.Remove(vmxPath)
+	if err := ioutil.WriteFile(vmxPath, []byte(testVMXIdentifiers), 0644); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	// Set the path to the temporary vmx
+	state.Put("vmx_path", vmxPath)
+
+	// Test the run
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+	if _, ok := state.GetOk("error"); ok {
+		t.Fatal("should NOT have error")
+	}
+
+	// Test the resulting data
+	vmxContents, err := ioutil.ReadFile(vmxPath)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	vmxData := ParseVMX(string(vmxContents))
+
+	cases := []struct {
+		Key   string
+		Value string
+	}{
+		{"ethernet0.generatedaddress", ""},
+		{"ethernet0.generatedaddressoffset", ""},
+		{"uuid.bios", ""},
+		{"uuid.location", ""},
+		{"uuid.action", "create"},
+		{"ide0:0.filename", "install.iso"},
+		{"scsi0:0.filename", "disk.vmdk"},
+		{"foo", "bar"},
+	}
+
+	for _, tc := range cases {
+		if tc.Value == "" {
+			if _, ok := vmxData[tc.Key]; ok {
+				t.Fatalf("should not have key: %s", tc.Key)
+			}
+		} else {
+			if vmxData[tc.Key] != tc.Value {
+				t.Fatalf("bad: %s %#v", tc.Key, vmxData[tc.Key])
+			}
+		}
+	}
+}
+
 const testVMXFloppyPath = `
 floppy0.present = "TRUE"
 floppy0.filetype = "file"
@@ -208,6 +265,16 @@ ide0:1.filename = "bar"
 foo = "bar"
 `
 
+const testVMXIdentifiers = `
+ethernet0.generatedAddress = "00:0c:29:aa:bb:cc"
+ethernet0.generatedAddressOffset = "0"
+uuid.bios = "56 4d 11 22 33 44 55 66-77 88 99 aa bb cc dd ee"
+uuid.location = "56 4d 11 22 33 44 55 66-77 88 99 aa bb cc dd ee"
+ide0:0.filename = "C:\Users\packer\install.iso"
+scsi0:0.filename = "/home/packer/output/disk.vmdk"
+foo = "bar"
+`
+
 const testVMXEthernet = `
 ethernet0.addresstype = "generated"
 ethernet0.bsdname = "en0"
@@ -1,14 +1,15 @@
 package common
 
 import (
-	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
-	"net/url"
+	"io"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"runtime"
-	"strings"
 
 	"github.com/hashicorp/packer/helper/multistep"
 	"github.com/hashicorp/packer/packer"
@@ -18,6 +19,10 @@ import (
 //
 // Uses:
 //   display_name string
+//
+// Produces:
+//   export_checksums map[string]string - SHA256 digests of the exported
+//   files, keyed by file name
 type StepExport struct {
 	Format         string
 	SkipExport     bool
@@ -38,24 +43,6 @@ func GetOVFTool() string {
 	return ovftool
 }
 
-func (s *StepExport) generateArgs(c *DriverConfig, displayName string, hidePassword bool) []string {
-	password := url.QueryEscape(c.RemotePassword)
-	username := url.QueryEscape(c.RemoteUser)
-
-	if hidePassword {
-		password = "****"
-	}
-	args := []string{
-		"--noSSLVerify=true",
-		"--skipManifestCheck",
-		"-tt=" + s.Format,
-
-		"vi://" + username + ":" + password + "@" + c.RemoteHost + "/" + displayName,
-		s.OutputDir,
-	}
-	return append(s.OVFToolOptions, args...)
-}
-
 func (s *StepExport) Run(ctx context.Context, state multistep.StateBag) multistep.StepAction {
 	c := state.Get("driverConfig").(*DriverConfig)
 	ui := state.Get("ui").(packer.Ui)
@@ -71,9 +58,8 @@ func (s *StepExport) Run(ctx context.Context, state multistep.StateBag) multiste
 		return multistep.ActionContinue
 	}
 
-	ovftool := GetOVFTool()
-	if ovftool == "" {
-		err := fmt.Errorf("Error ovftool not found")
+	ovftool, err := NewOvftoolDriver()
+	if err != nil {
 		state.Put("error", err)
 		ui.Error(err.Error())
 		return multistep.ActionHalt
@@ -91,20 +77,71 @@ func (s *StepExport) Run(ctx context.Context, state multistep.StateBag) multiste
 	if v, ok := state.GetOk("display_name"); ok {
 		displayName = v.(string)
 	}
-	ui.Message(fmt.Sprintf("Executing: %s %s", ovftool, strings.Join(s.generateArgs(c, displayName, true), " ")))
-	var out bytes.Buffer
-	cmd := exec.Command(ovftool, s.generateArgs(c, displayName, false)...)
-	cmd.Stdout = &out
-	if err := cmd.Run(); err != nil {
-		err := fmt.Errorf("Error exporting virtual machine: %s\n%s\n", err, out.String())
+
+	opts := &OvftoolOptions{
+		NoSSLVerify:       true,
+		SkipManifestCheck: true,
+		TargetType:        s.Format,
+		ExtraOptions:      s.OVFToolOptions,
+		Source: ViLocator{
+			Username: c.RemoteUser,
+			Password: c.RemotePassword,
+			Host:     c.RemoteHost,
+			Path:     displayName,
+		},
+		Destination: s.OutputDir,
+	}
+
+	if err := ovftool.Export(ctx, ui, opts); err != nil {
+		err := fmt.Errorf("Error exporting virtual machine: %s", err)
 		state.Put("error", err)
 		ui.Error(err.Error())
 		return multistep.ActionHalt
 	}
 
-	ui.Message(out.String())
+	checksums, err := s.checksumExportedFiles(ui)
+	if err != nil {
+		err := fmt.Errorf("Error checksumming exported files: %s", err)
+		state.Put("error", err)
+		ui.Error(err.Error())
+		return multistep.ActionHalt
+	}
+	state.Put("export_checksums", checksums)
 
 	return multistep.ActionContinue
 }
 
+// checksumExportedFiles computes a SHA256 digest for every file ovftool
+// wrote to the output directory, so the artifact can expose them to
+// post-processors and downstream tooling.
+func (s *StepExport) checksumExportedFiles(ui packer.Ui) (map[string]string, error) {
+	checksums := make(map[string]string)
+
+	err := filepath.Walk(s.OutputDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		hash := sha256.New()
+		if _, err := io.Copy(hash, f); err != nil {
+			return err
+		}
+
+		sum := hex.EncodeToString(hash.Sum(nil))
+		checksums[info.Name()] = sum
+		ui.Message(fmt.Sprintf("SHA256 of %s: %s", info.Name(), sum))
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return checksums, nil
+}
+
 func (s *StepExport) Cleanup(state multistep.StateBag) {}
@@ -0,0 +1,77 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/packer/helper/multistep"
+	"github.com/hashicorp/packer/packer"
+)
+
+// This step changes the virtual hardware version of a virtual machine by
+// rewriting the virtualHW.version setting in the VMX file. VMware only
+// supports upgrading virtual hardware, so a request to downgrade below the
+// version of the source VM fails the build instead of producing a machine
+// that will not boot.
+//
+// Uses:
+//   vmx_path string
+type StepSetHardwareVersion struct {
+	HardwareVersion int
+}
+
+func (s *StepSetHardwareVersion) Run(ctx context.Context, state multistep.StateBag) multistep.StepAction {
+	if s.HardwareVersion == 0 {
+		return multistep.ActionContinue
+	}
+
+	ui := state.Get("ui").(packer.Ui)
+
+	vmxPath := state.Get("vmx_path").(string)
+	vmxData, err := ReadVMX(vmxPath)
+	if err != nil {
+		err := fmt.Errorf("Error reading VMX file: %s", err)
+		state.Put("error", err)
+		ui.Error(err.Error())
+		return multistep.ActionHalt
+	}
+
+	current := 0
+	if v, ok := vmxData["virtualhw.version"]; ok {
+		if current, err = strconv.Atoi(v); err != nil {
+			err := fmt.Errorf("Error parsing virtual hardware version '%s': %s", v, err)
+			state.Put("error", err)
+			ui.Error(err.Error())
+			return multistep.ActionHalt
+		}
+	}
+
+	if s.HardwareVersion < current {
+		err := fmt.Errorf(
+			"Cannot downgrade the virtual hardware from version %d to %d: "+
+				"VMware only supports upgrading virtual hardware", current, s.HardwareVersion)
+		state.Put("error", err)
+		ui.Error(err.Error())
+		return multistep.ActionHalt
+	}
+
+	if s.HardwareVersion == current {
+		ui.Message(fmt.Sprintf("Virtual hardware is already at version %d", current))
+		return multistep.ActionContinue
+	}
+
+	ui.Say(fmt.Sprintf("Upgrading virtual hardware from version %d to %d...", current, s.HardwareVersion))
+	vmxData["virtualhw.version"] = strconv.Itoa(s.HardwareVersion)
+
+	if err := WriteVMX(vmxPath, vmxData); err != nil {
+		err := fmt.Errorf("Error writing VMX file: %s", err)
+		state.Put("error", err)
+		ui.Error(err.Error())
+		return multistep.ActionHalt
+	}
+
+	return multistep.ActionContinue
+}
+
+func (s *StepSetHardwareVersion) Cleanup(state multistep.StateBag) {}
@@ -0,0 +1,77 @@
+package common
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/packer/helper/multistep"
+)
+
+func TestStepSetHardwareVersion_impl(t *testing.T) {
+	var _ multistep.Step = new(StepSetHardwareVersion)
+}
+
+func TestStepSetHardwareVersion(t *testing.T) {
+	state := testState(t)
+	step := &StepSetHardwareVersion{HardwareVersion: 14}
+
+	vmxPath := testVMXFile(t)
+	defer os.Remove(vmxPath)
+	if err := ioutil.WriteFile(vmxPath, []byte(`virtualHW.version = "10"`+"\n"), 0644); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	state.Put("vmx_path", vmxPath)
+
+	// Test the run
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+	if _, ok := state.GetOk("error"); ok {
+		t.Fatal("should NOT have error")
+	}
+
+	// Test the resulting data
+	vmxData, err := ReadVMX(vmxPath)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if vmxData["virtualhw.version"] != "14" {
+		t.Fatalf("bad version: %s", vmxData["virtualhw.version"])
+	}
+}
+
+func TestStepSetHardwareVersion_downgrade(t *testing.T) {
+	state := testState(t)
+	step := &StepSetHardwareVersion{HardwareVersion: 9}
+
+	vmxPath := testVMXFile(t)
+	defer os.Remove(vmxPath)
+	if err := ioutil.WriteFile(vmxPath, []byte(`virtualHW.version = "10"`+"\n"), 0644); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	state.Put("vmx_path", vmxPath)
+
+	// Downgrading is not supported and should halt the build
+	if action := step.Run(context.Background(), state); action != multistep.ActionHalt {
+		t.Fatalf("bad action: %#v", action)
+	}
+	if _, ok := state.GetOk("error"); !ok {
+		t.Fatal("should have error")
+	}
+}
+
+func TestStepSetHardwareVersion_unset(t *testing.T) {
+	state := testState(t)
+	step := new(StepSetHardwareVersion)
+
+	// Without a configured version the step should do nothing, and in
+	// particular not require a vmx_path.
+	if action := step.Run(context.Background(), state); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+	if _, ok := state.GetOk("error"); ok {
+		t.Fatal("should NOT have error")
+	}
+}
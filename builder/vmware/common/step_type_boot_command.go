@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"log"
-	"net"
 	"time"
 
 	"github.com/hashicorp/packer/common/bootcommand"
@@ -19,17 +18,18 @@ import (
 // Uses:
 //   http_port int
 //   ui     packer.Ui
-//   vnc_port int
+//   vnc_conn *vnc.ClientConn
 //
 // Produces:
 //   <nothing>
 type StepTypeBootCommand struct {
-	BootCommand string
-	VNCEnabled  bool
-	BootWait    time.Duration
-	VMName      string
-	Ctx         interpolate.Context
-	KeyInterval time.Duration
+	BootCommand      string
+	VNCEnabled       bool
+	VNCOverWebsocket bool
+	BootWait         time.Duration
+	VMName           string
+	Ctx              interpolate.Context
+	KeyInterval      time.Duration
 }
 type bootCommandTemplateData struct {
 	HTTPIP   string
@@ -38,7 +38,7 @@ type bootCommandTemplateData struct {
 }
 
 func (s *StepTypeBootCommand) Run(ctx context.Context, state multistep.StateBag) multistep.StepAction {
-	if !s.VNCEnabled {
+	if !s.VNCEnabled && !s.VNCOverWebsocket {
 		log.Println("Skipping boot command step...")
 		return multistep.ActionContinue
 	}
@@ -46,9 +46,7 @@ func (s *StepTypeBootCommand) Run(ctx context.Context, state multistep.StateBag)
 	debug := state.Get("debug").(bool)
 	httpPort := state.Get("http_port").(int)
 	ui := state.Get("ui").(packer.Ui)
-	vncIp := state.Get("vnc_ip").(string)
-	vncPort := state.Get("vnc_port").(int)
-	vncPassword := state.Get("vnc_password")
+	c := state.Get("vnc_conn").(*vnc.ClientConn)
 
 	// Wait the for the vm to boot.
 	if int64(s.BootWait) > 0 {
@@ -66,35 +64,6 @@ func (s *StepTypeBootCommand) Run(ctx context.Context, state multistep.StateBag)
 		pauseFn = state.Get("pauseFn").(multistep.DebugPauseFn)
 	}
 
-	// Connect to VNC
-	ui.Say(fmt.Sprintf("Connecting to VM via VNC (%s:%d)", vncIp, vncPort))
-
-	nc, err := net.Dial("tcp", fmt.Sprintf("%s:%d", vncIp, vncPort))
-	if err != nil {
-		err := fmt.Errorf("Error connecting to VNC: %s", err)
-		state.Put("error", err)
-		ui.Error(err.Error())
-		return multistep.ActionHalt
-	}
-	defer nc.Close()
-
-	var auth []vnc.ClientAuth
-
-	if vncPassword != nil && len(vncPassword.(string)) > 0 {
-		auth = []vnc.ClientAuth{&vnc.PasswordAuth{Password: vncPassword.(string)}}
-	} else {
-		auth = []vnc.ClientAuth{new(vnc.ClientAuthNone)}
-	}
-
-	c, err := vnc.Client(nc, &vnc.ClientConfig{Auth: auth, Exclusive: true})
-	if err != nil {
-		err := fmt.Errorf("Error handshaking with VNC: %s", err)
-		state.Put("error", err)
-		ui.Error(err.Error())
-		return multistep.ActionHalt
-	}
-	defer c.Close()
-
 	log.Printf("Connected to VNC desktop: %s", c.DesktopName)
 
 	hostIP := state.Get("http_ip").(string)
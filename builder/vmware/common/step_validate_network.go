@@ -0,0 +1,123 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/packer/helper/multistep"
+	"github.com/hashicorp/packer/packer"
+)
+
+// A driver that can enumerate the networks available on the host, such as
+// the port groups of an ESXi hypervisor.
+type NetworkDiscoverer interface {
+	HostNetworks() ([]string, error)
+}
+
+// This step validates the configured network against the networks that
+// actually exist on the host, so a bad value fails the build before the VM
+// boots without connectivity.
+//
+// Uses:
+//   driver Driver
+//   ui packer.Ui
+//
+// Produces:
+//   network_name string - the resolved host network name, if any
+type StepValidateNetwork struct {
+	Network     string
+	NetworkName string
+}
+
+func (s *StepValidateNetwork) Run(ctx context.Context, state multistep.StateBag) multistep.StepAction {
+	driver := state.Get("driver").(Driver)
+	ui := state.Get("ui").(packer.Ui)
+
+	base := driver.GetVmwareDriver()
+	if base.NetworkMapper != nil {
+		return s.validateLocal(base, state, ui)
+	}
+
+	// The mapping of device<->network is handled by the remote hypervisor;
+	// validate the port group when the driver can enumerate them.
+	if discoverer, ok := driver.(NetworkDiscoverer); ok && s.NetworkName != "" {
+		return s.validateRemote(discoverer, state, ui)
+	}
+
+	return multistep.ActionContinue
+}
+
+func (s *StepValidateNetwork) validateLocal(base VmwareDriver, state multistep.StateBag, ui packer.Ui) multistep.StepAction {
+	if s.Network == "" {
+		return multistep.ActionContinue
+	}
+
+	netmap, err := base.NetworkMapper()
+	if err != nil {
+		// Not being able to read the network map is not fatal here; the
+		// address detection machinery will complain about it later on.
+		log.Printf("Unable to read the network map: %s", err)
+		return multistep.ActionContinue
+	}
+
+	if devices, err := netmap.NameIntoDevices(s.Network); err == nil && len(devices) > 0 {
+		log.Printf("Network %s maps to devices: %v", s.Network, devices)
+		return multistep.ActionContinue
+	}
+
+	// Not one of the generic types, so it has to name an actual host device
+	// like vmnet2 for the VM to come up with connectivity.
+	if name, err := netmap.DeviceIntoName(s.Network); err == nil && name != "" {
+		log.Printf("Network device %s maps to network: %s", s.Network, name)
+		return multistep.ActionContinue
+	}
+
+	err = fmt.Errorf("The network %q is neither a generic network type "+
+		"(nat, hostonly, bridged) nor a network device known to the host", s.Network)
+	state.Put("error", err)
+	ui.Error(err.Error())
+	return multistep.ActionHalt
+}
+
+func (s *StepValidateNetwork) validateRemote(discoverer NetworkDiscoverer, state multistep.StateBag, ui packer.Ui) multistep.StepAction {
+	networks, err := discoverer.HostNetworks()
+	if err != nil {
+		err := fmt.Errorf("Error discovering host networks: %s", err)
+		state.Put("error", err)
+		ui.Error(err.Error())
+		return multistep.ActionHalt
+	}
+
+	// Prefer an exact match, but fall back to a case-insensitive one since
+	// port group names are easy to get subtly wrong.
+	match := ""
+	for _, network := range networks {
+		if network == s.NetworkName {
+			match = network
+			break
+		}
+		if strings.EqualFold(network, s.NetworkName) && match == "" {
+			match = network
+		}
+	}
+
+	if match == "" {
+		err := fmt.Errorf("The network name %q does not match any port group "+
+			"on the remote host. Available port groups: %s",
+			s.NetworkName, strings.Join(networks, ", "))
+		state.Put("error", err)
+		ui.Error(err.Error())
+		return multistep.ActionHalt
+	}
+
+	if match != s.NetworkName {
+		ui.Message(fmt.Sprintf("Using port group %q for network name %q", match, s.NetworkName))
+	}
+	state.Put("network_name", match)
+
+	return multistep.ActionContinue
+}
+
+func (s *StepValidateNetwork) Cleanup(state multistep.StateBag) {}
@@ -0,0 +1,103 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/hashicorp/packer/helper/multistep"
+	"github.com/hashicorp/packer/packer"
+)
+
+type networkDiscovererMock struct {
+	Networks []string
+	Err      error
+}
+
+func (d *networkDiscovererMock) HostNetworks() ([]string, error) {
+	return d.Networks, d.Err
+}
+
+func testNetworkMap() VmwareDriver {
+	var driver VmwareDriver
+	driver.NetworkMapper = func() (NetworkNameMapper, error) {
+		return NetworkMap{
+			{"name": "nat", "device": "vmnet8"},
+			{"name": "hostonly", "device": "vmnet1"},
+			{"name": "lab", "device": "vmnet2"},
+		}, nil
+	}
+	return driver
+}
+
+func TestStepValidateNetwork_impl(t *testing.T) {
+	var _ multistep.Step = new(StepValidateNetwork)
+}
+
+func TestStepValidateNetwork_Local(t *testing.T) {
+	driver := testNetworkMap()
+
+	for _, network := range []string{"nat", "lab", "vmnet2"} {
+		state := testState(t)
+		step := &StepValidateNetwork{Network: network}
+		ui := state.Get("ui").(packer.Ui)
+
+		if action := step.validateLocal(driver, state, ui); action != multistep.ActionContinue {
+			t.Fatalf("bad action for network %s: %#v", network, action)
+		}
+		if _, ok := state.GetOk("error"); ok {
+			t.Fatalf("should NOT have error for network %s", network)
+		}
+	}
+
+	state := testState(t)
+	step := &StepValidateNetwork{Network: "nonexistent"}
+	ui := state.Get("ui").(packer.Ui)
+
+	if action := step.validateLocal(driver, state, ui); action != multistep.ActionHalt {
+		t.Fatalf("bad action: %#v", action)
+	}
+	if _, ok := state.GetOk("error"); !ok {
+		t.Fatal("should have error")
+	}
+}
+
+func TestStepValidateNetwork_Remote(t *testing.T) {
+	discoverer := &networkDiscovererMock{
+		Networks: []string{"VM Network", "Management Network"},
+	}
+
+	// Exact match
+	state := testState(t)
+	step := &StepValidateNetwork{NetworkName: "VM Network"}
+	ui := state.Get("ui").(packer.Ui)
+
+	if action := step.validateRemote(discoverer, state, ui); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+	if name := state.Get("network_name"); name != "VM Network" {
+		t.Fatalf("bad network name: %#v", name)
+	}
+
+	// Case-insensitive fallback
+	state = testState(t)
+	step = &StepValidateNetwork{NetworkName: "vm network"}
+	ui = state.Get("ui").(packer.Ui)
+
+	if action := step.validateRemote(discoverer, state, ui); action != multistep.ActionContinue {
+		t.Fatalf("bad action: %#v", action)
+	}
+	if name := state.Get("network_name"); name != "VM Network" {
+		t.Fatalf("bad network name: %#v", name)
+	}
+
+	// No match
+	state = testState(t)
+	step = &StepValidateNetwork{NetworkName: "nonexistent"}
+	ui = state.Get("ui").(packer.Ui)
+
+	if action := step.validateRemote(discoverer, state, ui); action != multistep.ActionHalt {
+		t.Fatalf("bad action: %#v", action)
+	}
+	if _, ok := state.GetOk("error"); !ok {
+		t.Fatal("should have error")
+	}
+}
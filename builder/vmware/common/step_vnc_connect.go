@@ -0,0 +1,184 @@
+package common
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/hashicorp/packer/helper/multistep"
+	"github.com/hashicorp/packer/packer"
+	"github.com/mitchellh/go-vnc"
+)
+
+// This step establishes the VNC connection that is later used to type the
+// boot command, either directly to a VNC port on the host or over the ESXi
+// webmks websocket endpoint.
+//
+// Uses:
+//   driver Driver
+//   ui     packer.Ui
+//   vnc_ip string
+//   vnc_port int
+//   vnc_password string
+//
+// Produces:
+//   vnc_conn *vnc.ClientConn
+type StepVNCConnect struct {
+	VNCEnabled         bool
+	VNCOverWebsocket   bool
+	InsecureConnection bool
+	Driver             Driver
+}
+
+func (s *StepVNCConnect) Run(ctx context.Context, state multistep.StateBag) multistep.StepAction {
+	if !s.VNCEnabled && !s.VNCOverWebsocket {
+		return multistep.ActionContinue
+	}
+	ui := state.Get("ui").(packer.Ui)
+
+	var c *vnc.ClientConn
+	var err error
+	if s.VNCOverWebsocket {
+		ui.Say("Connecting to VNC over websocket...")
+		c, err = s.ConnectVNCOverWebsocket(state)
+	} else {
+		c, err = s.ConnectVNC(state)
+	}
+	if err != nil {
+		state.Put("error", err)
+		ui.Error(err.Error())
+		return multistep.ActionHalt
+	}
+
+	state.Put("vnc_conn", c)
+	return multistep.ActionContinue
+}
+
+func (s *StepVNCConnect) ConnectVNCOverWebsocket(state multistep.StateBag) (*vnc.ClientConn, error) {
+	driver := s.Driver.(*ESX5Driver)
+
+	ticket, err := driver.AcquireVNCOverWebsocketTicket()
+	if err != nil {
+		return nil, fmt.Errorf("Error acquiring websocket ticket: %s", err)
+	}
+
+	host := ticket.Host
+	if len(host) == 0 {
+		host = driver.Host
+	}
+	port := ticket.Port
+	if port == 0 {
+		port = 443
+	}
+
+	websocketURL := fmt.Sprintf("wss://%s:%d/ticket/%s", host, port, ticket.Ticket)
+	log.Printf("[DEBUG] Connecting to websocket url: %s", websocketURL)
+
+	dialer := &websocket.Dialer{
+		Proxy:           http.ProxyFromEnvironment,
+		TLSClientConfig: &tls.Config{InsecureSkipVerify: s.InsecureConnection},
+		Subprotocols:    []string{"binary"},
+	}
+	wsConn, _, err := dialer.Dial(websocketURL, http.Header{})
+	if err != nil {
+		return nil, fmt.Errorf("Error connecting to websocket: %s", err)
+	}
+
+	ccconfig := &vnc.ClientConfig{
+		Auth:      []vnc.ClientAuth{new(vnc.ClientAuthNone)},
+		Exclusive: false,
+	}
+	c, err := vnc.Client(newWebsocketClientConn(wsConn), ccconfig)
+	if err != nil {
+		return nil, fmt.Errorf("Error handshaking with VNC over websocket: %s", err)
+	}
+	return c, nil
+}
+
+func (s *StepVNCConnect) ConnectVNC(state multistep.StateBag) (*vnc.ClientConn, error) {
+	ui := state.Get("ui").(packer.Ui)
+	vncIp := state.Get("vnc_ip").(string)
+	vncPort := state.Get("vnc_port").(int)
+	vncPassword := state.Get("vnc_password")
+
+	ui.Say(fmt.Sprintf("Connecting to VM via VNC (%s:%d)", vncIp, vncPort))
+
+	nc, err := net.Dial("tcp", fmt.Sprintf("%s:%d", vncIp, vncPort))
+	if err != nil {
+		return nil, fmt.Errorf("Error connecting to VNC: %s", err)
+	}
+
+	var auth []vnc.ClientAuth
+	if vncPassword != nil && len(vncPassword.(string)) > 0 {
+		auth = []vnc.ClientAuth{&vnc.PasswordAuth{Password: vncPassword.(string)}}
+	} else {
+		auth = []vnc.ClientAuth{new(vnc.ClientAuthNone)}
+	}
+
+	c, err := vnc.Client(nc, &vnc.ClientConfig{Auth: auth, Exclusive: true})
+	if err != nil {
+		nc.Close()
+		return nil, fmt.Errorf("Error handshaking with VNC: %s", err)
+	}
+	return c, nil
+}
+
+func (s *StepVNCConnect) Cleanup(state multistep.StateBag) {
+	if c, ok := state.GetOk("vnc_conn"); ok {
+		c.(*vnc.ClientConn).Close()
+	}
+}
+
+// websocketClientConn adapts the message-based websocket connection to the
+// net.Conn byte stream expected by the VNC client, carrying reads over
+// message boundaries.
+type websocketClientConn struct {
+	*websocket.Conn
+	reader io.Reader
+}
+
+func newWebsocketClientConn(c *websocket.Conn) *websocketClientConn {
+	return &websocketClientConn{Conn: c}
+}
+
+func (c *websocketClientConn) Read(p []byte) (int, error) {
+	for {
+		if c.reader == nil {
+			_, reader, err := c.NextReader()
+			if err != nil {
+				return 0, err
+			}
+			c.reader = reader
+		}
+		n, err := c.reader.Read(p)
+		if err == io.EOF {
+			// This message is drained, move on to the next one.
+			c.reader = nil
+			if n > 0 {
+				return n, nil
+			}
+			continue
+		}
+		return n, err
+	}
+}
+
+func (c *websocketClientConn) Write(p []byte) (int, error) {
+	if err := c.WriteMessage(websocket.BinaryMessage, p); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+func (c *websocketClientConn) SetDeadline(t time.Time) error {
+	if err := c.SetReadDeadline(t); err != nil {
+		return err
+	}
+	return c.SetWriteDeadline(t)
+}
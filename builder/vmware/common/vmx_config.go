@@ -21,6 +21,13 @@ type VMXConfig struct {
 	// will create ethernet interfaces when provisioning a box. Defaults to
 	// false.
 	VMXRemoveEthernet bool `mapstructure:"vmx_remove_ethernet_interfaces" required:"false"`
+	// Strip machine-specific data from the VMX file after building:
+	// generated MAC addresses, the BIOS and location UUIDs and absolute
+	// paths pointing at files on the build host are removed, so the
+	// exported artifact imports cleanly on other hosts without carrying
+	// identity over from the build machine. Fresh MAC addresses and
+	// UUIDs are generated on the first boot. Defaults to false.
+	VMXStripIdentifiers bool `mapstructure:"vmx_strip_identifiers" required:"false"`
 	// The name that will appear in your vSphere client,
 	// and will be used for the vmx basename. This will override the "displayname"
 	// value in your vmx file. It will also override the "displayname" if you have
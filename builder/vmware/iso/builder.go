@@ -102,6 +102,10 @@ func (b *Builder) Run(ctx context.Context, ui packer.Ui, hook packer.Hook) (pack
 			Checksum:     b.config.ISOChecksum,
 			ChecksumType: b.config.ISOChecksumType,
 		},
+		&vmwcommon.StepValidateNetwork{
+			Network:     b.config.HWConfig.Network,
+			NetworkName: b.config.HWConfig.NetworkName,
+		},
 		&stepCreateDisk{},
 		&stepCreateVMX{},
 		&vmwcommon.StepConfigureVMX{
@@ -117,7 +121,7 @@ func (b *Builder) Run(ctx context.Context, ui packer.Ui, hook packer.Hook) (pack
 			HTTPPortMax: b.config.HTTPPortMax,
 		},
 		&vmwcommon.StepConfigureVNC{
-			Enabled:            !b.config.DisableVNC,
+			Enabled:            !b.config.DisableVNC && !b.config.VNCOverWebsocket,
 			VNCBindAddress:     b.config.VNCBindAddress,
 			VNCPortMin:         b.config.VNCPortMin,
 			VNCPortMax:         b.config.VNCPortMax,
@@ -132,13 +136,20 @@ func (b *Builder) Run(ctx context.Context, ui packer.Ui, hook packer.Hook) (pack
 			DurationBeforeStop: 5 * time.Second,
 			Headless:           b.config.Headless,
 		},
+		&vmwcommon.StepVNCConnect{
+			VNCEnabled:         !b.config.DisableVNC,
+			VNCOverWebsocket:   b.config.VNCOverWebsocket,
+			InsecureConnection: b.config.InsecureConnection,
+			Driver:             driver,
+		},
 		&vmwcommon.StepTypeBootCommand{
-			BootWait:    b.config.BootWait,
-			VNCEnabled:  !b.config.DisableVNC,
-			BootCommand: b.config.FlatBootCommand(),
-			VMName:      b.config.VMName,
-			Ctx:         b.config.ctx,
-			KeyInterval: b.config.VNCConfig.BootKeyInterval,
+			BootWait:         b.config.BootWait,
+			VNCEnabled:       !b.config.DisableVNC,
+			VNCOverWebsocket: b.config.VNCOverWebsocket,
+			BootCommand:      b.config.FlatBootCommand(),
+			VMName:           b.config.VMName,
+			Ctx:              b.config.ctx,
+			KeyInterval:      b.config.VNCConfig.BootKeyInterval,
 		},
 		&communicator.StepConnect{
 			Config:    &b.config.SSHConfig.Comm,
@@ -171,7 +182,8 @@ func (b *Builder) Run(ctx context.Context, ui packer.Ui, hook packer.Hook) (pack
 		},
 		&vmwcommon.StepCleanVMX{
 			RemoveEthernetInterfaces: b.config.VMXConfig.VMXRemoveEthernet,
-			VNCEnabled:               !b.config.DisableVNC,
+			StripIdentifiers:         b.config.VMXConfig.VMXStripIdentifiers,
+			VNCEnabled:               !b.config.DisableVNC && !b.config.VNCOverWebsocket,
 		},
 		&vmwcommon.StepUploadVMX{
 			RemoteType: b.config.RemoteType,
@@ -88,6 +88,15 @@ type Config struct {
 	// sata is chosen for the disk adapter and so Packer attempts to mirror
 	// this logic. This field can be specified as either ide, sata, or scsi.
 	CdromAdapterType string `mapstructure:"cdrom_adapter_type" required:"false"`
+	// Additional ISO files to attach to the virtual machine as extra cdrom
+	// devices alongside the boot ISO, for example driver disks or a
+	// kickstart ISO. Each entry is a path to an ISO on the local filesystem;
+	// the files are attached as-is and are not downloaded or checksummed.
+	// The extra devices are placed on the same adapter as the primary cdrom,
+	// which limits an ide cdrom_adapter_type to two additional ISOs; use
+	// sata to attach more. The devices are removed from the VMX before the
+	// artifact is produced.
+	AttachISOs []string `mapstructure:"attach_isos" required:"false"`
 	// The guest OS type being installed. This will be
 	// set in the VMware VMX. By default this is other. By specifying a more
 	// specific OS type, VMware may perform some optimizations or virtual hardware
@@ -147,7 +156,7 @@ func (c *Config) Prepare(raws ...interface{}) ([]string, error) {
 	errs = packer.MultiErrorAppend(errs, c.DriverConfig.Prepare(&c.ctx)...)
 	errs = packer.MultiErrorAppend(errs,
 		c.OutputConfig.Prepare(&c.ctx, &c.PackerConfig)...)
-	errs = packer.MultiErrorAppend(errs, c.RunConfig.Prepare(&c.ctx)...)
+	errs = packer.MultiErrorAppend(errs, c.RunConfig.Prepare(&c.ctx, &c.DriverConfig)...)
 	errs = packer.MultiErrorAppend(errs, c.ShutdownConfig.Prepare(&c.ctx)...)
 	errs = packer.MultiErrorAppend(errs, c.SSHConfig.Prepare(&c.ctx)...)
 	errs = packer.MultiErrorAppend(errs, c.ToolsConfig.Prepare(&c.ctx)...)
@@ -197,6 +206,13 @@ func (c *Config) Prepare(raws ...interface{}) ([]string, error) {
 		c.GuestOSType = "other"
 	}
 
+	for _, attachPath := range c.AttachISOs {
+		if _, err := os.Stat(attachPath); err != nil {
+			errs = packer.MultiErrorAppend(
+				errs, fmt.Errorf("attach_isos: %s: %s", attachPath, err))
+		}
+	}
+
 	if c.VMName == "" {
 		c.VMName = fmt.Sprintf("packer-%s", c.PackerBuildName)
 	}
@@ -221,6 +237,11 @@ func (c *Config) Prepare(raws ...interface{}) ([]string, error) {
 		c.HWConfig.Network = "nat"
 	}
 
+	if c.HWConfig.NetworkName != "" && c.RemoteType == "" {
+		errs = packer.MultiErrorAppend(errs,
+			fmt.Errorf("network_name is only valid when RemoteType=esx5"))
+	}
+
 	// Remote configuration validation
 	if c.RemoteType != "" {
 		if c.RemoteHost == "" {
@@ -51,6 +51,7 @@ type FlatConfig struct {
 	CoreCount                 *int              `mapstructure:"cores" required:"false" cty:"cores"`
 	Network                   *string           `mapstructure:"network" required:"false" cty:"network"`
 	NetworkAdapterType        *string           `mapstructure:"network_adapter_type" required:"false" cty:"network_adapter_type"`
+	NetworkName               *string           `mapstructure:"network_name" required:"false" cty:"network_name"`
 	Sound                     *bool             `mapstructure:"sound" required:"false" cty:"sound"`
 	USB                       *bool             `mapstructure:"usb" required:"false" cty:"usb"`
 	Serial                    *string           `mapstructure:"serial" required:"false" cty:"serial"`
@@ -61,6 +62,8 @@ type FlatConfig struct {
 	VNCPortMin                *int              `mapstructure:"vnc_port_min" required:"false" cty:"vnc_port_min"`
 	VNCPortMax                *int              `mapstructure:"vnc_port_max" cty:"vnc_port_max"`
 	VNCDisablePassword        *bool             `mapstructure:"vnc_disable_password" required:"false" cty:"vnc_disable_password"`
+	VNCOverWebsocket          *bool             `mapstructure:"vnc_over_websocket" required:"false" cty:"vnc_over_websocket"`
+	InsecureConnection        *bool             `mapstructure:"insecure_connection" required:"false" cty:"insecure_connection"`
 	ShutdownCommand           *string           `mapstructure:"shutdown_command" required:"false" cty:"shutdown_command"`
 	ShutdownTimeout           *string           `mapstructure:"shutdown_timeout" required:"false" cty:"shutdown_timeout"`
 	Type                      *string           `mapstructure:"communicator" cty:"communicator"`
@@ -111,6 +114,7 @@ type FlatConfig struct {
 	VMXData                   map[string]string `mapstructure:"vmx_data" required:"false" cty:"vmx_data"`
 	VMXDataPost               map[string]string `mapstructure:"vmx_data_post" required:"false" cty:"vmx_data_post"`
 	VMXRemoveEthernet         *bool             `mapstructure:"vmx_remove_ethernet_interfaces" required:"false" cty:"vmx_remove_ethernet_interfaces"`
+	VMXStripIdentifiers       *bool             `mapstructure:"vmx_strip_identifiers" required:"false" cty:"vmx_strip_identifiers"`
 	VMXDisplayName            *string           `mapstructure:"display_name" required:"false" cty:"display_name"`
 	Format                    *string           `mapstructure:"format" required:"false" cty:"format"`
 	OVFToolOptions            []string          `mapstructure:"ovftool_options" required:"false" cty:"ovftool_options"`
@@ -123,6 +127,7 @@ type FlatConfig struct {
 	DiskSize                  *uint             `mapstructure:"disk_size" required:"false" cty:"disk_size"`
 	DiskTypeId                *string           `mapstructure:"disk_type_id" required:"false" cty:"disk_type_id"`
 	CdromAdapterType          *string           `mapstructure:"cdrom_adapter_type" required:"false" cty:"cdrom_adapter_type"`
+	AttachISOs                []string          `mapstructure:"attach_isos" required:"false" cty:"attach_isos"`
 	GuestOSType               *string           `mapstructure:"guest_os_type" required:"false" cty:"guest_os_type"`
 	Version                   *string           `mapstructure:"version" required:"false" cty:"version"`
 	VMName                    *string           `mapstructure:"vm_name" required:"false" cty:"vm_name"`
@@ -184,6 +189,7 @@ func (*FlatConfig) HCL2Spec() map[string]hcldec.Spec {
 		"cores":                          &hcldec.AttrSpec{Name: "cores", Type: cty.Number, Required: false},
 		"network":                        &hcldec.AttrSpec{Name: "network", Type: cty.String, Required: false},
 		"network_adapter_type":           &hcldec.AttrSpec{Name: "network_adapter_type", Type: cty.String, Required: false},
+		"network_name":                   &hcldec.AttrSpec{Name: "network_name", Type: cty.String, Required: false},
 		"sound":                          &hcldec.AttrSpec{Name: "sound", Type: cty.Bool, Required: false},
 		"usb":                            &hcldec.AttrSpec{Name: "usb", Type: cty.Bool, Required: false},
 		"serial":                         &hcldec.AttrSpec{Name: "serial", Type: cty.String, Required: false},
@@ -194,6 +200,8 @@ func (*FlatConfig) HCL2Spec() map[string]hcldec.Spec {
 		"vnc_port_min":                   &hcldec.AttrSpec{Name: "vnc_port_min", Type: cty.Number, Required: false},
 		"vnc_port_max":                   &hcldec.AttrSpec{Name: "vnc_port_max", Type: cty.Number, Required: false},
 		"vnc_disable_password":           &hcldec.AttrSpec{Name: "vnc_disable_password", Type: cty.Bool, Required: false},
+		"vnc_over_websocket":             &hcldec.AttrSpec{Name: "vnc_over_websocket", Type: cty.Bool, Required: false},
+		"insecure_connection":            &hcldec.AttrSpec{Name: "insecure_connection", Type: cty.Bool, Required: false},
 		"shutdown_command":               &hcldec.AttrSpec{Name: "shutdown_command", Type: cty.String, Required: false},
 		"shutdown_timeout":               &hcldec.AttrSpec{Name: "shutdown_timeout", Type: cty.String, Required: false},
 		"communicator":                   &hcldec.AttrSpec{Name: "communicator", Type: cty.String, Required: false},
@@ -244,6 +252,7 @@ func (*FlatConfig) HCL2Spec() map[string]hcldec.Spec {
 		"vmx_data":                       &hcldec.AttrSpec{Name: "vmx_data", Type: cty.Map(cty.String), Required: false},
 		"vmx_data_post":                  &hcldec.AttrSpec{Name: "vmx_data_post", Type: cty.Map(cty.String), Required: false},
 		"vmx_remove_ethernet_interfaces": &hcldec.AttrSpec{Name: "vmx_remove_ethernet_interfaces", Type: cty.Bool, Required: false},
+		"vmx_strip_identifiers":          &hcldec.AttrSpec{Name: "vmx_strip_identifiers", Type: cty.Bool, Required: false},
 		"display_name":                   &hcldec.AttrSpec{Name: "display_name", Type: cty.String, Required: false},
 		"format":                         &hcldec.AttrSpec{Name: "format", Type: cty.String, Required: false},
 		"ovftool_options":                &hcldec.AttrSpec{Name: "ovftool_options", Type: cty.List(cty.String), Required: false},
@@ -256,6 +265,7 @@ func (*FlatConfig) HCL2Spec() map[string]hcldec.Spec {
 		"disk_size":                      &hcldec.AttrSpec{Name: "disk_size", Type: cty.Number, Required: false},
 		"disk_type_id":                   &hcldec.AttrSpec{Name: "disk_type_id", Type: cty.String, Required: false},
 		"cdrom_adapter_type":             &hcldec.AttrSpec{Name: "cdrom_adapter_type", Type: cty.String, Required: false},
+		"attach_isos":                    &hcldec.AttrSpec{Name: "attach_isos", Type: cty.List(cty.String), Required: false},
 		"guest_os_type":                  &hcldec.AttrSpec{Name: "guest_os_type", Type: cty.String, Required: false},
 		"version":                        &hcldec.AttrSpec{Name: "version", Type: cty.String, Required: false},
 		"vm_name":                        &hcldec.AttrSpec{Name: "vm_name", Type: cty.String, Required: false},
@@ -436,6 +436,47 @@ func (s *stepCreateVMX) Run(ctx context.Context, state multistep.StateBag) multi
 		vmxData["cpuid.corespersocket"] = strconv.Itoa(config.HWConfig.CoreCount)
 	}
 
+	// Connect the VM to the port group that the validation step resolved,
+	// for remote ESXi builds.
+	if networkName, ok := state.GetOk("network_name"); ok && networkName.(string) != "" {
+		vmxData["ethernet0.networkname"] = networkName.(string)
+	}
+
+	// Attach any additional ISOs as extra cdrom devices on the same adapter
+	// as the primary cdrom, in the slots that follow it. They are registered
+	// as temporary devices so that step_clean_vmx removes them afterwards.
+	if len(config.AttachISOs) > 0 {
+		var slots []string
+		if cdromAdapterType == "ide" {
+			// The primary cdrom lives on ide bus 0, so the extra devices
+			// can only go on the second ide channel.
+			slots = []string{"ide1:0", "ide1:1"}
+		} else {
+			primary, _ := strconv.Atoi(templateData.CDROMType_PrimarySecondary)
+			for i := range config.AttachISOs {
+				slots = append(slots, fmt.Sprintf("%s0:%d", cdromAdapterType, primary+1+i))
+			}
+		}
+
+		if len(config.AttachISOs) > len(slots) {
+			err := fmt.Errorf("Only %d additional ISOs can be attached with an "+
+				"ide cdrom adapter; set cdrom_adapter_type to sata to attach more", len(slots))
+			state.Put("error", err)
+			ui.Error(err.Error())
+			return multistep.ActionHalt
+		}
+
+		tmpBuildDevices := state.Get("temporaryDevices").([]string)
+		for i, attachPath := range config.AttachISOs {
+			device := slots[i]
+			vmxData[device+".present"] = "TRUE"
+			vmxData[device+".filename"] = filepath.FromSlash(attachPath)
+			vmxData[device+".devicetype"] = "cdrom-image"
+			tmpBuildDevices = append(tmpBuildDevices, device)
+		}
+		state.Put("temporaryDevices", tmpBuildDevices)
+	}
+
 	/// Write the vmxData to the vmxPath
 	vmxPath := filepath.Join(vmxDir, config.VMName+".vmx")
 	if err := vmwcommon.WriteVMX(vmxPath, vmxData); err != nil {
@@ -97,6 +97,9 @@ func (b *Builder) Run(ctx context.Context, ui packer.Ui, hook packer.Hook) (pack
 			VMName:    b.config.VMName,
 			Linked:    b.config.Linked,
 		},
+		&vmwcommon.StepSetHardwareVersion{
+			HardwareVersion: b.config.VMXHardwareVersion,
+		},
 		&vmwcommon.StepConfigureVMX{
 			CustomData:  b.config.VMXData,
 			VMName:      b.config.VMName,
@@ -113,7 +116,7 @@ func (b *Builder) Run(ctx context.Context, ui packer.Ui, hook packer.Hook) (pack
 			RemoteType: b.config.RemoteType,
 		},
 		&vmwcommon.StepConfigureVNC{
-			Enabled:            !b.config.DisableVNC,
+			Enabled:            !b.config.DisableVNC && !b.config.VNCOverWebsocket,
 			VNCBindAddress:     b.config.VNCBindAddress,
 			VNCPortMin:         b.config.VNCPortMin,
 			VNCPortMax:         b.config.VNCPortMax,
@@ -128,13 +131,20 @@ func (b *Builder) Run(ctx context.Context, ui packer.Ui, hook packer.Hook) (pack
 			DurationBeforeStop: 5 * time.Second,
 			Headless:           b.config.Headless,
 		},
+		&vmwcommon.StepVNCConnect{
+			VNCEnabled:         !b.config.DisableVNC,
+			VNCOverWebsocket:   b.config.VNCOverWebsocket,
+			InsecureConnection: b.config.InsecureConnection,
+			Driver:             driver,
+		},
 		&vmwcommon.StepTypeBootCommand{
-			BootWait:    b.config.BootWait,
-			VNCEnabled:  !b.config.DisableVNC,
-			BootCommand: b.config.FlatBootCommand(),
-			VMName:      b.config.VMName,
-			Ctx:         b.config.ctx,
-			KeyInterval: b.config.VNCConfig.BootKeyInterval,
+			BootWait:         b.config.BootWait,
+			VNCEnabled:       !b.config.DisableVNC,
+			VNCOverWebsocket: b.config.VNCOverWebsocket,
+			BootCommand:      b.config.FlatBootCommand(),
+			VMName:           b.config.VMName,
+			Ctx:              b.config.ctx,
+			KeyInterval:      b.config.VNCConfig.BootKeyInterval,
 		},
 		&communicator.StepConnect{
 			Config:    &b.config.SSHConfig.Comm,
@@ -167,7 +177,8 @@ func (b *Builder) Run(ctx context.Context, ui packer.Ui, hook packer.Hook) (pack
 		},
 		&vmwcommon.StepCleanVMX{
 			RemoveEthernetInterfaces: b.config.VMXConfig.VMXRemoveEthernet,
-			VNCEnabled:               !b.config.DisableVNC,
+			StripIdentifiers:         b.config.VMXConfig.VMXStripIdentifiers,
+			VNCEnabled:               !b.config.DisableVNC && !b.config.VNCOverWebsocket,
 		},
 		&vmwcommon.StepUploadVMX{
 			RemoteType: b.config.RemoteType,
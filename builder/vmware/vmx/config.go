@@ -51,6 +51,14 @@ type Config struct {
 	// machine, without the file extension. By default this is packer-BUILDNAME,
 	// where "BUILDNAME" is the name of the build.
 	VMName string `mapstructure:"vm_name" required:"false"`
+	// The [virtual hardware
+	// version](http://kb.vmware.com/selfservice/microsites/search.do?language=en_US&cmd=displayKC&externalId=1003746)
+	// for the cloned virtual machine, e.g. 14 for ESXi 6.7. The clone is
+	// upgraded to this version after cloning; requesting a version lower
+	// than that of the source virtual machine fails the build, as VMware
+	// only supports upgrading virtual hardware. By default the version of
+	// the source virtual machine is kept.
+	VMXHardwareVersion int `mapstructure:"vmx_hardware_version" required:"false"`
 
 	ctx interpolate.Context
 }
@@ -81,7 +89,7 @@ func (c *Config) Prepare(raws ...interface{}) ([]string, error) {
 	errs = packer.MultiErrorAppend(errs, c.DriverConfig.Prepare(&c.ctx)...)
 	errs = packer.MultiErrorAppend(errs, c.HTTPConfig.Prepare(&c.ctx)...)
 	errs = packer.MultiErrorAppend(errs, c.OutputConfig.Prepare(&c.ctx, &c.PackerConfig)...)
-	errs = packer.MultiErrorAppend(errs, c.RunConfig.Prepare(&c.ctx)...)
+	errs = packer.MultiErrorAppend(errs, c.RunConfig.Prepare(&c.ctx, &c.DriverConfig)...)
 	errs = packer.MultiErrorAppend(errs, c.ShutdownConfig.Prepare(&c.ctx)...)
 	errs = packer.MultiErrorAppend(errs, c.SSHConfig.Prepare(&c.ctx)...)
 	errs = packer.MultiErrorAppend(errs, c.ToolsConfig.Prepare(&c.ctx)...)
@@ -112,6 +120,11 @@ func (c *Config) Prepare(raws ...interface{}) ([]string, error) {
 		}
 	}
 
+	if c.VMXHardwareVersion < 0 {
+		errs = packer.MultiErrorAppend(errs,
+			fmt.Errorf("vmx_hardware_version must be a positive number"))
+	}
+
 	err = c.DriverConfig.Validate(c.SkipExport)
 	if err != nil {
 		errs = packer.MultiErrorAppend(errs, err)
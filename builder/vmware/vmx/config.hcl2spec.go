@@ -45,6 +45,8 @@ type FlatConfig struct {
 	VNCPortMin                *int              `mapstructure:"vnc_port_min" required:"false" cty:"vnc_port_min"`
 	VNCPortMax                *int              `mapstructure:"vnc_port_max" cty:"vnc_port_max"`
 	VNCDisablePassword        *bool             `mapstructure:"vnc_disable_password" required:"false" cty:"vnc_disable_password"`
+	VNCOverWebsocket          *bool             `mapstructure:"vnc_over_websocket" required:"false" cty:"vnc_over_websocket"`
+	InsecureConnection        *bool             `mapstructure:"insecure_connection" required:"false" cty:"insecure_connection"`
 	ShutdownCommand           *string           `mapstructure:"shutdown_command" required:"false" cty:"shutdown_command"`
 	ShutdownTimeout           *string           `mapstructure:"shutdown_timeout" required:"false" cty:"shutdown_timeout"`
 	Type                      *string           `mapstructure:"communicator" cty:"communicator"`
@@ -95,6 +97,7 @@ type FlatConfig struct {
 	VMXData                   map[string]string `mapstructure:"vmx_data" required:"false" cty:"vmx_data"`
 	VMXDataPost               map[string]string `mapstructure:"vmx_data_post" required:"false" cty:"vmx_data_post"`
 	VMXRemoveEthernet         *bool             `mapstructure:"vmx_remove_ethernet_interfaces" required:"false" cty:"vmx_remove_ethernet_interfaces"`
+	VMXStripIdentifiers       *bool             `mapstructure:"vmx_strip_identifiers" required:"false" cty:"vmx_strip_identifiers"`
 	VMXDisplayName            *string           `mapstructure:"display_name" required:"false" cty:"display_name"`
 	Format                    *string           `mapstructure:"format" required:"false" cty:"format"`
 	OVFToolOptions            []string          `mapstructure:"ovftool_options" required:"false" cty:"ovftool_options"`
@@ -104,6 +107,7 @@ type FlatConfig struct {
 	Linked                    *bool             `mapstructure:"linked" required:"false" cty:"linked"`
 	SourcePath                *string           `mapstructure:"source_path" required:"true" cty:"source_path"`
 	VMName                    *string           `mapstructure:"vm_name" required:"false" cty:"vm_name"`
+	VMXHardwareVersion        *int              `mapstructure:"vmx_hardware_version" required:"false" cty:"vmx_hardware_version"`
 }
 
 // FlatMapstructure returns a new FlatConfig.
@@ -154,6 +158,8 @@ func (*FlatConfig) HCL2Spec() map[string]hcldec.Spec {
 		"vnc_port_min":                   &hcldec.AttrSpec{Name: "vnc_port_min", Type: cty.Number, Required: false},
 		"vnc_port_max":                   &hcldec.AttrSpec{Name: "vnc_port_max", Type: cty.Number, Required: false},
 		"vnc_disable_password":           &hcldec.AttrSpec{Name: "vnc_disable_password", Type: cty.Bool, Required: false},
+		"vnc_over_websocket":             &hcldec.AttrSpec{Name: "vnc_over_websocket", Type: cty.Bool, Required: false},
+		"insecure_connection":            &hcldec.AttrSpec{Name: "insecure_connection", Type: cty.Bool, Required: false},
 		"shutdown_command":               &hcldec.AttrSpec{Name: "shutdown_command", Type: cty.String, Required: false},
 		"shutdown_timeout":               &hcldec.AttrSpec{Name: "shutdown_timeout", Type: cty.String, Required: false},
 		"communicator":                   &hcldec.AttrSpec{Name: "communicator", Type: cty.String, Required: false},
@@ -204,6 +210,7 @@ func (*FlatConfig) HCL2Spec() map[string]hcldec.Spec {
 		"vmx_data":                       &hcldec.AttrSpec{Name: "vmx_data", Type: cty.Map(cty.String), Required: false},
 		"vmx_data_post":                  &hcldec.AttrSpec{Name: "vmx_data_post", Type: cty.Map(cty.String), Required: false},
 		"vmx_remove_ethernet_interfaces": &hcldec.AttrSpec{Name: "vmx_remove_ethernet_interfaces", Type: cty.Bool, Required: false},
+		"vmx_strip_identifiers":          &hcldec.AttrSpec{Name: "vmx_strip_identifiers", Type: cty.Bool, Required: false},
 		"display_name":                   &hcldec.AttrSpec{Name: "display_name", Type: cty.String, Required: false},
 		"format":                         &hcldec.AttrSpec{Name: "format", Type: cty.String, Required: false},
 		"ovftool_options":                &hcldec.AttrSpec{Name: "ovftool_options", Type: cty.List(cty.String), Required: false},
@@ -213,6 +220,7 @@ func (*FlatConfig) HCL2Spec() map[string]hcldec.Spec {
 		"linked":                         &hcldec.AttrSpec{Name: "linked", Type: cty.Bool, Required: false},
 		"source_path":                    &hcldec.AttrSpec{Name: "source_path", Type: cty.String, Required: false},
 		"vm_name":                        &hcldec.AttrSpec{Name: "vm_name", Type: cty.String, Required: false},
+		"vmx_hardware_version":           &hcldec.AttrSpec{Name: "vmx_hardware_version", Type: cty.Number, Required: false},
 	}
 	return s
 }
@@ -6,9 +6,12 @@ import (
 	"fmt"
 	"log"
 	"math"
+	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/hcl/v2/hclparse"
@@ -129,6 +132,8 @@ func (m *Meta) GetConfigFromJSON(cla *MetaArgs) (packer.BuildGetter, int) {
 }
 
 func (c *BuildCommand) RunContext(buildCtx context.Context, cla *BuildArgs) int {
+	commandStart := time.Now().UTC()
+
 	packerStarter, ret := c.GetConfig(&cla.MetaArgs)
 	if ret != 0 {
 		return ret
@@ -143,10 +148,39 @@ func (c *BuildCommand) RunContext(buildCtx context.Context, cla *BuildArgs) int
 	// builds.
 	ret = writeDiags(c.Ui, nil, diags)
 
+	// Order the builds so that a build declared with 'depends_on' always
+	// comes after the builds it depends on, and make sure every dependency
+	// is actually part of this run.
+	builds, err := orderBuilds(builds)
+	if err != nil {
+		c.Ui.Error(err.Error())
+		return 1
+	}
+
 	if cla.Debug {
 		c.Ui.Say("Debug mode enabled. Builds will not be parallelized.")
 	}
 
+	if cla.DryRun {
+		c.Ui.Say("Dry-run mode enabled. Builders that support it will only " +
+			"describe the API calls they would make; builders that do not " +
+			"support dry-run mode will perform a real build.")
+	}
+
+	if cla.TestScript != "" {
+		c.Ui.Say("Boot verification enabled. Builders that support it will " +
+			"boot a throwaway machine from the artifact and run the check " +
+			"script before finalizing the build; builders that do not " +
+			"support it skip verification.")
+	}
+
+	if cla.BuildLogDir != "" {
+		if err := os.MkdirAll(cla.BuildLogDir, 0755); err != nil {
+			c.Ui.Error(fmt.Sprintf("Failed to create build log directory: %s", err))
+			return 1
+		}
+	}
+
 	// Compile all the UIs for the builds
 	colors := [5]packer.UiColor{
 		packer.UiColorGreen,
@@ -156,6 +190,23 @@ func (c *BuildCommand) RunContext(buildCtx context.Context, cla *BuildArgs) int
 		packer.UiColorBlue,
 	}
 	buildUis := make(map[packer.Build]packer.Ui)
+
+	// Pad every build's output prefix to the width of the longest build
+	// name, so parallel output lines up in columns.
+	targetWidth := 0
+	if len(builds) > 1 {
+		for i := range builds {
+			if l := len(builds[i].Name()); l > targetWidth {
+				targetWidth = l
+			}
+		}
+	}
+
+	// With -grouped-ui, everything a build says is buffered and printed
+	// as one contiguous block once all builds are done, instead of
+	// interleaving with the other builds.
+	var groupedUis []*packer.GroupedUi
+
 	for i := range builds {
 		ui := c.Ui
 		if cla.Color {
@@ -171,6 +222,11 @@ func (c *BuildCommand) RunContext(buildCtx context.Context, cla *BuildArgs) int
 				}
 			}
 		}
+		if cla.GroupedUi {
+			grouped := &packer.GroupedUi{Ui: ui}
+			groupedUis = append(groupedUis, grouped)
+			ui = grouped
+		}
 		// Now add timestamps if requested
 		if cla.TimestampUi {
 			ui = &packer.TimestampedUi{
@@ -178,6 +234,23 @@ func (c *BuildCommand) RunContext(buildCtx context.Context, cla *BuildArgs) int
 			}
 		}
 
+		// Tee this build's output into its own timestamped log file, so
+		// parallel builds can be read back separately even when their
+		// console output interleaves.
+		if cla.BuildLogDir != "" {
+			logName := strings.Replace(builds[i].Name(), string(os.PathSeparator), "_", -1)
+			f, err := os.Create(filepath.Join(cla.BuildLogDir, logName+".log"))
+			if err != nil {
+				c.Ui.Error(fmt.Sprintf("Failed to create build log file: %s", err))
+				return 1
+			}
+			defer f.Close()
+			ui = &packer.BuildLogUi{
+				Ui:     ui,
+				Writer: f,
+			}
+		}
+
 		buildUis[builds[i]] = ui
 	}
 
@@ -186,18 +259,35 @@ func (c *BuildCommand) RunContext(buildCtx context.Context, cla *BuildArgs) int
 	log.Printf("On error: %v", cla.OnError)
 
 	// Set the debug and force mode and prepare all the builds
+	var buildWarnings = struct {
+		sync.RWMutex
+		m map[string][]string
+	}{m: make(map[string][]string)}
 	for i := range builds {
 		b := builds[i]
 		log.Printf("Preparing build: %s", b.Name())
 		b.SetDebug(cla.Debug)
+		b.SetDryRun(cla.DryRun)
+		b.SetTestScript(cla.TestScript)
 		b.SetForce(cla.Force)
 		b.SetOnError(cla.OnError)
+		b.SetSecretScan(cla.SecretScan)
+		b.SetQuietProvisioners(cla.QuietProvisioners)
+		b.SetUiTargetWidth(targetWidth)
+
+		if len(buildDependencies(b)) > 0 {
+			// Dependent builds are prepared right before they run, once
+			// the artifact IDs of their dependencies are known.
+			log.Printf("Deferring prepare of dependent build: %s", b.Name())
+			continue
+		}
 
 		warnings, err := b.Prepare()
 		if err != nil {
 			c.Ui.Error(err.Error())
 			return 1
 		}
+		buildWarnings.m[b.Name()] = warnings
 		if len(warnings) > 0 {
 			ui := buildUis[b]
 			ui.Say(fmt.Sprintf("Warnings for build '%s':\n", b.Name()))
@@ -208,6 +298,16 @@ func (c *BuildCommand) RunContext(buildCtx context.Context, cla *BuildArgs) int
 		}
 	}
 
+	// With -strict, warnings (e.g. deprecated options) are treated as
+	// errors and no build is started.
+	if cla.Strict {
+		if count := countWarnings(buildWarnings.m); count > 0 {
+			c.Ui.Error(fmt.Sprintf(
+				"\n%d warning(s) were produced and -strict was set, not starting any builds.", count))
+			return 1
+		}
+	}
+
 	// Run all the builds in parallel and wait for them to complete
 	var wg sync.WaitGroup
 	var artifacts = struct {
@@ -219,6 +319,30 @@ func (c *BuildCommand) RunContext(buildCtx context.Context, cla *BuildArgs) int
 		sync.RWMutex
 		m map[string]error
 	}{m: make(map[string]error)}
+	var timings = struct {
+		sync.RWMutex
+		m map[string][2]time.Time
+	}{m: make(map[string][2]time.Time)}
+	// Optional advisory locking so concurrent packer processes don't run
+	// the same build at the same time.
+	var locker packer.BuildLocker
+	if cla.LockDir != "" {
+		locker = &packer.FileBuildLocker{Dir: cla.LockDir}
+	}
+
+	// A build's channel is closed once it is done (successfully or not) so
+	// that builds depending on it know when they may start. The channels
+	// are keyed by slice index rather than name, since names are not
+	// guaranteed to be unique; dependency names are resolved through
+	// depIndex, which only dependency-carrying (JSON) builds consult.
+	doneCh := make([]chan struct{}, len(builds))
+	for i := range builds {
+		doneCh[i] = make(chan struct{})
+	}
+	depIndex := make(map[string]int, len(builds))
+	for i := range builds {
+		depIndex[builds[i].Name()] = i
+	}
 	limitParallel := semaphore.NewWeighted(cla.ParallelBuilds)
 	for i := range builds {
 		if err := buildCtx.Err(); err != nil {
@@ -226,6 +350,7 @@ func (c *BuildCommand) RunContext(buildCtx context.Context, cla *BuildArgs) int
 			break
 		}
 
+		i := i
 		b := builds[i]
 		name := b.Name()
 		ui := buildUis[b]
@@ -244,9 +369,106 @@ func (c *BuildCommand) RunContext(buildCtx context.Context, cla *BuildArgs) int
 			defer wg.Done()
 
 			defer limitParallel.Release(1)
+			defer close(doneCh[i])
+
+			// Wait for the builds this one depends on to finish. The
+			// topological ordering above guarantees they have already been
+			// started, so this cannot deadlock.
+			deps := buildDependencies(b)
+			for _, dep := range deps {
+				select {
+				case <-doneCh[depIndex[dep]]:
+				case <-buildCtx.Done():
+					return
+				}
+			}
+
+			if len(deps) > 0 {
+				var failed []string
+				errors.RLock()
+				for _, dep := range deps {
+					if errors.m[dep] != nil {
+						failed = append(failed, dep)
+					}
+				}
+				errors.RUnlock()
+				if len(failed) > 0 {
+					err := fmt.Errorf(
+						"dependency '%s' did not complete successfully",
+						strings.Join(failed, "', '"))
+					ui.Error(fmt.Sprintf("Build '%s' not started: %s", name, err))
+					errors.Lock()
+					errors.m[name] = err
+					errors.Unlock()
+					return
+				}
+
+				// Expose the artifact ID of each dependency as a user
+				// variable and prepare the build, which was deferred until
+				// now so that those variables could be interpolated.
+				if cb, ok := b.(*packer.CoreBuild); ok {
+					vars := make(map[string]string, len(cb.Variables)+len(deps))
+					for k, v := range cb.Variables {
+						vars[k] = v
+					}
+					artifacts.RLock()
+					for _, dep := range deps {
+						if as := artifacts.m[dep]; len(as) > 0 {
+							vars["artifact."+dep] = as[len(as)-1].Id()
+						}
+					}
+					artifacts.RUnlock()
+					cb.Variables = vars
+				}
+
+				warnings, err := b.Prepare()
+				if err != nil {
+					ui.Error(fmt.Sprintf("Build '%s' failed to prepare: %s", name, err))
+					errors.Lock()
+					errors.m[name] = err
+					errors.Unlock()
+					return
+				}
+				buildWarnings.Lock()
+				buildWarnings.m[name] = warnings
+				buildWarnings.Unlock()
+				for _, warning := range warnings {
+					ui.Say(fmt.Sprintf("* %s", warning))
+				}
+				if cla.Strict && len(warnings) > 0 {
+					err := fmt.Errorf(
+						"preparing produced %d warning(s) and -strict was set", len(warnings))
+					ui.Error(fmt.Sprintf("Build '%s' not started: %s", name, err))
+					errors.Lock()
+					errors.m[name] = err
+					errors.Unlock()
+					return
+				}
+			}
+
+			if locker != nil {
+				unlock, err := locker.Lock(name)
+				if err != nil {
+					ui.Error(fmt.Sprintf("Build '%s' not started: %s", name, err))
+					errors.Lock()
+					errors.m[name] = err
+					errors.Unlock()
+					return
+				}
+				defer func() {
+					if err := unlock(); err != nil {
+						ui.Error(fmt.Sprintf(
+							"Failed to release lock of build '%s': %s", name, err))
+					}
+				}()
+			}
 
 			log.Printf("Starting build run: %s", name)
+			buildStart := time.Now().UTC()
 			runArtifacts, err := b.Run(buildCtx, ui)
+			timings.Lock()
+			timings.m[name] = [2]time.Time{buildStart, time.Now().UTC()}
+			timings.Unlock()
 
 			if err != nil {
 				ui.Error(fmt.Sprintf("Build '%s' errored: %s", name, err))
@@ -280,11 +502,38 @@ func (c *BuildCommand) RunContext(buildCtx context.Context, cla *BuildArgs) int
 	log.Printf("Waiting on builds to complete...")
 	wg.Wait()
 
+	// Print the buffered output of each build as one contiguous block,
+	// in the order the builds were started.
+	for _, grouped := range groupedUis {
+		grouped.Flush()
+	}
+
 	if err := buildCtx.Err(); err != nil {
 		c.Ui.Say("Cleanly cancelled builds after being interrupted.")
 		return 1
 	}
 
+	// Summarize the warnings of all builds in one block so they are not
+	// lost in the verbose build output above.
+	if count := countWarnings(buildWarnings.m); count > 0 {
+		c.Ui.Machine("warning-count", strconv.FormatInt(int64(count), 10))
+
+		c.Ui.Say("\n==> Some builds produced warnings:")
+		for name, warnings := range buildWarnings.m {
+			// Create a UI for the machine readable stuff to be targeted
+			ui := &packer.TargetedUI{
+				Target: name,
+				Ui:     c.Ui,
+			}
+
+			for _, warning := range warnings {
+				ui.Machine("warning", warning)
+
+				c.Ui.Say(fmt.Sprintf("--> %s: %s", name, warning))
+			}
+		}
+	}
+
 	if len(errors.m) > 0 {
 		c.Ui.Machine("error-count", strconv.FormatInt(int64(len(errors.m)), 10))
 
@@ -355,9 +604,108 @@ func (c *BuildCommand) RunContext(buildCtx context.Context, cla *BuildArgs) int
 		ret = 1
 	}
 
+	if cla.Report != "" {
+		err := writeBuildReport(cla.Report, commandStart, builds,
+			buildWarnings.m, timings.m, artifacts.m, errors.m)
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Failed to write build report: %s", err))
+			ret = 1
+		}
+	}
+
 	return ret
 }
 
+// countWarnings returns the total number of warnings over all builds.
+func countWarnings(warnings map[string][]string) int {
+	count := 0
+	for _, w := range warnings {
+		count += len(w)
+	}
+	return count
+}
+
+// buildDependencies returns the names of the builds that must complete
+// before the given build may start. Only builds from JSON templates carry
+// dependency information.
+func buildDependencies(b packer.Build) []string {
+	if cb, ok := b.(*packer.CoreBuild); ok {
+		return cb.Dependencies
+	}
+	return nil
+}
+
+// orderBuilds sorts the builds topologically so that every build comes
+// after the builds it depends on. It errors if a dependency is not part of
+// this run (for example because of -only/-except) or if the dependencies
+// form a cycle.
+//
+// Build names are not guaranteed to be unique (HCL sources of the same
+// type within one build block share their name), so the sort is keyed by
+// slice index; names are only used to resolve declared dependencies, which
+// JSON templates require to be unique.
+func orderBuilds(builds []packer.Build) ([]packer.Build, error) {
+	hasDependencies := false
+	for _, b := range builds {
+		if len(buildDependencies(b)) > 0 {
+			hasDependencies = true
+			break
+		}
+	}
+	if !hasDependencies {
+		return builds, nil
+	}
+
+	byName := make(map[string]int, len(builds))
+	for i, b := range builds {
+		byName[b.Name()] = i
+	}
+
+	const (
+		unseen = iota
+		visiting
+		done
+	)
+	state := make([]int, len(builds))
+	ordered := make([]packer.Build, 0, len(builds))
+
+	var visit func(i int) error
+	visit = func(i int) error {
+		switch state[i] {
+		case done:
+			return nil
+		case visiting:
+			return fmt.Errorf(
+				"dependency cycle between builds involving '%s'", builds[i].Name())
+		}
+		state[i] = visiting
+
+		for _, dep := range buildDependencies(builds[i]) {
+			di, ok := byName[dep]
+			if !ok {
+				return fmt.Errorf(
+					"build '%s' depends on build '%s' which is not part of this run",
+					builds[i].Name(), dep)
+			}
+			if err := visit(di); err != nil {
+				return err
+			}
+		}
+
+		state[i] = done
+		ordered = append(ordered, builds[i])
+		return nil
+	}
+
+	for i := range builds {
+		if err := visit(i); err != nil {
+			return nil, err
+		}
+	}
+
+	return ordered, nil
+}
+
 func (*BuildCommand) Help() string {
 	helpText := `
 Usage: packer build [options] TEMPLATE
@@ -367,17 +715,25 @@ Usage: packer build [options] TEMPLATE
 
 Options:
 
+  -build-log-dir=path           Write each build's output to its own timestamped log file in the given directory, with sensitive variables scrubbed.
   -color=false                  Disable color output. (Default: color)
   -debug                        Debug mode enabled for builds.
+  -dry-run                      Builders that support it describe the principal API calls the build would make and stop without creating anything.
   -except=foo,bar,baz           Run all builds and post-procesors other than these.
   -only=foo,bar,baz             Build only the specified builds.
   -force                        Force a build to continue if artifacts exist, deletes existing artifacts.
+  -grouped-ui                   Buffer the output of each build and print it as one contiguous block at the end of the run, instead of interleaving parallel builds.
+  -lock-dir=path                Take an advisory lock file per build name in the given directory, failing the build if the lock is already held by another packer process.
   -machine-readable             Produce machine-readable output.
   -on-error=[cleanup|abort|ask] If the build fails do: clean up (default), abort, or ask.
   -parallel-builds=1            Number of builds to run in parallel. 1 disables parallelization. 0 means no limit (Default: 0)
+  -quiet-provisioners           Suppress provisioner output on the console, keeping it in the packer log, and print a per-provisioner summary instead.
+  -report=path.json             Write a machine-readable report of the run (timings, artifacts, warnings, errors) to the given file.
+  -secret-scan                  Fail the build if leaked credentials (AWS keys, private keys) are found in build output or file-based artifacts.
+  -strict                       Treat warnings, such as deprecated template options, as errors and do not start any build.
   -timestamp-ui                 Enable prefixing of each ui output with an RFC3339 timestamp.
   -var 'key=value'              Variable for templates, can be used multiple times.
-  -var-file=path                JSON file containing user variables.
+  -var-file=path                JSON or YAML file containing user variables.
 `
 
 	return strings.TrimSpace(helpText)
@@ -398,9 +754,13 @@ func (*BuildCommand) AutocompleteFlags() complete.Flags {
 		"-except":           complete.PredictNothing,
 		"-only":             complete.PredictNothing,
 		"-force":            complete.PredictNothing,
+		"-grouped-ui":       complete.PredictNothing,
+		"-lock-dir":         complete.PredictNothing,
 		"-machine-readable": complete.PredictNothing,
 		"-on-error":         complete.PredictNothing,
 		"-parallel":         complete.PredictNothing,
+		"-report":           complete.PredictNothing,
+		"-strict":           complete.PredictNothing,
 		"-timestamp-ui":     complete.PredictNothing,
 		"-var":              complete.PredictNothing,
 		"-var-file":         complete.PredictNothing,
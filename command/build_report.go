@@ -0,0 +1,110 @@
+package command
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"time"
+
+	"github.com/hashicorp/packer/packer"
+	packerVersion "github.com/hashicorp/packer/version"
+)
+
+// buildReport is the machine readable summary written by `packer build
+// -report=path.json`. Unlike telemetry it is purely local: nothing in it
+// is ever sent anywhere.
+type buildReport struct {
+	PackerVersion string             `json:"packer_version"`
+	StartTime     time.Time          `json:"start_time"`
+	EndTime       time.Time          `json:"end_time"`
+	Builds        []buildReportBuild `json:"builds"`
+	// One span per component run (builder, provisioner, post-processor),
+	// with timings, so performance regressions can be tracked per step.
+	Spans []buildReportSpan `json:"spans"`
+}
+
+type buildReportBuild struct {
+	Name            string                `json:"name"`
+	StartTime       time.Time             `json:"start_time"`
+	EndTime         time.Time             `json:"end_time"`
+	DurationSeconds float64               `json:"duration_seconds"`
+	Error           string                `json:"error,omitempty"`
+	Warnings        []string              `json:"warnings,omitempty"`
+	Artifacts       []buildReportArtifact `json:"artifacts"`
+}
+
+type buildReportArtifact struct {
+	BuilderID string   `json:"builder_id"`
+	Id        string   `json:"id"`
+	String    string   `json:"string"`
+	Files     []string `json:"files,omitempty"`
+}
+
+type buildReportSpan struct {
+	Name            string    `json:"name"`
+	Type            string    `json:"type"`
+	StartTime       time.Time `json:"start_time"`
+	EndTime         time.Time `json:"end_time"`
+	DurationSeconds float64   `json:"duration_seconds"`
+	Error           string    `json:"error,omitempty"`
+}
+
+func writeBuildReport(
+	path string,
+	startTime time.Time,
+	builds []packer.Build,
+	warnings map[string][]string,
+	timings map[string][2]time.Time,
+	artifacts map[string][]packer.Artifact,
+	errors map[string]error,
+) error {
+	report := buildReport{
+		PackerVersion: packerVersion.FormattedVersion(),
+		StartTime:     startTime,
+		EndTime:       time.Now().UTC(),
+	}
+
+	for _, b := range builds {
+		name := b.Name()
+		rb := buildReportBuild{
+			Name:     name,
+			Warnings: warnings[name],
+		}
+		if t, ok := timings[name]; ok {
+			rb.StartTime = t[0]
+			rb.EndTime = t[1]
+			rb.DurationSeconds = t[1].Sub(t[0]).Seconds()
+		}
+		if err := errors[name]; err != nil {
+			rb.Error = err.Error()
+		}
+		for _, a := range artifacts[name] {
+			if a == nil {
+				continue
+			}
+			rb.Artifacts = append(rb.Artifacts, buildReportArtifact{
+				BuilderID: a.BuilderId(),
+				Id:        a.Id(),
+				String:    a.String(),
+				Files:     a.Files(),
+			})
+		}
+		report.Builds = append(report.Builds, rb)
+	}
+
+	for _, span := range packer.CheckpointReporter.Spans() {
+		report.Spans = append(report.Spans, buildReportSpan{
+			Name:            span.Name,
+			Type:            span.Type,
+			StartTime:       span.StartTime,
+			EndTime:         span.EndTime,
+			DurationSeconds: span.EndTime.Sub(span.StartTime).Seconds(),
+			Error:           span.Error,
+		})
+	}
+
+	out, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, append(out, '\n'), 0644)
+}
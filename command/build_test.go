@@ -2,6 +2,7 @@ package command
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"math"
@@ -251,6 +252,47 @@ func TestBuildOnlyFileCommaFlags(t *testing.T) {
 	}
 }
 
+func TestBuildReport(t *testing.T) {
+	c := &BuildCommand{
+		Meta: testMetaFile(t),
+	}
+
+	args := []string{
+		"-parallel-builds=1",
+		"-only=chocolate",
+		"-report=report.json",
+		filepath.Join(testFixture("build-only"), "template.json"),
+	}
+
+	defer cleanup("report.json")
+
+	if code := c.Run(args); code != 0 {
+		fatalCommand(t, c.Meta)
+	}
+
+	raw, err := ioutil.ReadFile("report.json")
+	if err != nil {
+		t.Fatalf("Expected to find report.json: %s", err)
+	}
+
+	var report map[string]interface{}
+	if err := json.Unmarshal(raw, &report); err != nil {
+		t.Fatalf("Expected report.json to be valid JSON: %s", err)
+	}
+
+	builds, ok := report["builds"].([]interface{})
+	if !ok || len(builds) != 1 {
+		t.Fatalf("Expected report to contain one build, got %v", report["builds"])
+	}
+	build := builds[0].(map[string]interface{})
+	if build["name"] != "chocolate" {
+		t.Errorf("Expected build name chocolate, got %v", build["name"])
+	}
+	if _, ok := build["duration_seconds"]; !ok {
+		t.Error("Expected build to have a duration")
+	}
+}
+
 func TestBuildStdin(t *testing.T) {
 	c := &BuildCommand{
 		Meta: testMetaFile(t),
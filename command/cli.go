@@ -57,9 +57,17 @@ type MetaArgs struct {
 func (ba *BuildArgs) AddFlagSets(flags *flag.FlagSet) {
 	flags.BoolVar(&ba.Color, "color", true, "")
 	flags.BoolVar(&ba.Debug, "debug", false, "")
+	flags.BoolVar(&ba.DryRun, "dry-run", false, "")
 	flags.BoolVar(&ba.Force, "force", false, "")
 	flags.BoolVar(&ba.TimestampUi, "timestamp-ui", false, "")
+	flags.BoolVar(&ba.GroupedUi, "grouped-ui", false, "")
 	flags.BoolVar(&ba.MachineReadable, "machine-readable", false, "")
+	flags.BoolVar(&ba.SecretScan, "secret-scan", false, "")
+	flags.BoolVar(&ba.QuietProvisioners, "quiet-provisioners", false, "")
+	flags.BoolVar(&ba.Strict, "strict", false, "")
+	flags.StringVar(&ba.Report, "report", "", "")
+	flags.StringVar(&ba.LockDir, "lock-dir", "", "")
+	flags.StringVar(&ba.BuildLogDir, "build-log-dir", "", "")
 
 	flags.Int64Var(&ba.ParallelBuilds, "parallel-builds", 0, "")
 
@@ -73,8 +81,20 @@ func (ba *BuildArgs) AddFlagSets(flags *flag.FlagSet) {
 type BuildArgs struct {
 	MetaArgs
 	Color, Debug, Force, TimestampUi, MachineReadable bool
+	GroupedUi                                         bool
+	DryRun                                            bool
+	SecretScan                                        bool
+	QuietProvisioners                                 bool
+	Strict                                            bool
+	Report                                            string
+	LockDir                                           string
+	BuildLogDir                                       string
 	ParallelBuilds                                    int64
 	OnError                                           string
+
+	// TestScript is the path of the check script set with `packer test
+	// -script`. The build command itself exposes no flag for it.
+	TestScript string
 }
 
 // ConsoleArgs represents a parsed cli line for a `packer console`
@@ -92,6 +112,19 @@ type FixArgs struct {
 	Validate bool
 }
 
+func (fa *FmtArgs) AddFlagSets(flags *flag.FlagSet) {
+	flags.BoolVar(&fa.Check, "check", false, "")
+	flags.BoolVar(&fa.Write, "write", false, "")
+
+	fa.MetaArgs.AddFlagSets(flags)
+}
+
+// FmtArgs represents a parsed cli line for a `packer fmt`
+type FmtArgs struct {
+	MetaArgs
+	Check, Write bool
+}
+
 func (va *ValidateArgs) AddFlagSets(flags *flag.FlagSet) {
 	flags.BoolVar(&va.SyntaxOnly, "syntax-only", false, "check syntax only")
 
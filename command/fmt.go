@@ -0,0 +1,313 @@
+package command
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/packer/fix"
+
+	"github.com/posener/complete"
+)
+
+type FmtCommand struct {
+	Meta
+}
+
+// The canonical ordering of the well known root level template keys. Keys
+// not listed here (such as underscore prefixed comments) are printed first,
+// sorted alphabetically.
+var fmtRootKeyOrder = []string{
+	"description",
+	"min_packer_version",
+	"required_features",
+	"variables",
+	"sensitive-variables",
+	"builders",
+	"provisioners",
+	"post-processors",
+}
+
+// Fixers that only rename deprecated keys or values without otherwise
+// changing the meaning of the template. These are safe to apply while
+// formatting; everything else stays the job of `packer fix`.
+var fmtFixerOrder = []string{
+	"virtualbox-rename",
+	"vmware-rename",
+	"manifest-filename",
+	"galaxy-command",
+}
+
+var fmtInterpolationRe = regexp.MustCompile(`{{\s*(.*?)\s*}}`)
+
+func (c *FmtCommand) Run(args []string) int {
+	ctx, cleanup := handleTermInterrupt(c.Ui)
+	defer cleanup()
+
+	cfg, ret := c.ParseArgs(args)
+	if ret != 0 {
+		return ret
+	}
+
+	return c.RunContext(ctx, cfg)
+}
+
+func (c *FmtCommand) ParseArgs(args []string) (*FmtArgs, int) {
+	var cfg FmtArgs
+	flags := c.Meta.FlagSet("fmt", FlagSetNone)
+	flags.Usage = func() { c.Ui.Say(c.Help()) }
+	cfg.AddFlagSets(flags)
+	if err := flags.Parse(args); err != nil {
+		return &cfg, 1
+	}
+
+	args = flags.Args()
+	if len(args) != 1 {
+		flags.Usage()
+		return &cfg, 1
+	}
+	cfg.Path = args[0]
+	return &cfg, 0
+}
+
+func (c *FmtCommand) RunContext(ctx context.Context, cla *FmtArgs) int {
+	if cfgType, _ := ConfigType(cla.Path); cfgType != "json" {
+		c.Ui.Error("packer fmt only supports JSON templates")
+		return 1
+	}
+
+	original, err := ioutil.ReadFile(cla.Path)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error opening template: %s", err))
+		return 1
+	}
+
+	// Decode the JSON into a generic map structure. UseNumber keeps
+	// numeric literals exactly as they were written.
+	var templateData map[string]interface{}
+	decoder := json.NewDecoder(bytes.NewReader(original))
+	decoder.UseNumber()
+	if err := decoder.Decode(&templateData); err != nil {
+		c.Ui.Error(fmt.Sprintf("Error parsing template: %s", err))
+		return 1
+	}
+
+	input := templateData
+	for _, name := range fmtFixerOrder {
+		var err error
+		fixer, ok := fix.Fixers[name]
+		if !ok {
+			panic("fixer not found: " + name)
+		}
+
+		log.Printf("Running fixer: %s", name)
+		input, err = fixer.Fix(input)
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Error fixing: %s", err))
+			return 1
+		}
+	}
+
+	formatted, err := fmtTemplate(input)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error formatting template: %s", err))
+		return 1
+	}
+
+	if cla.Check {
+		if !bytes.Equal(original, formatted) {
+			c.Ui.Say(cla.Path)
+			return 3
+		}
+		return 0
+	}
+
+	if cla.Write {
+		if err := ioutil.WriteFile(cla.Path, formatted, 0644); err != nil {
+			c.Ui.Error(fmt.Sprintf("Error writing template: %s", err))
+			return 1
+		}
+		return 0
+	}
+
+	c.Ui.Say(strings.TrimSuffix(string(formatted), "\n"))
+	return 0
+}
+
+// fmtTemplate renders templateData in its canonical form: well known root
+// keys in a fixed order, the type and name of builders, provisioners and
+// post-processors first, every other key sorted alphabetically, two space
+// indentation and no padding inside interpolation braces.
+func fmtTemplate(templateData map[string]interface{}) ([]byte, error) {
+	var compact bytes.Buffer
+	compact.WriteByte('{')
+	for i, k := range fmtRootKeys(templateData) {
+		if i > 0 {
+			compact.WriteByte(',')
+		}
+		if err := fmtWriteValue(&compact, k, false); err != nil {
+			return nil, err
+		}
+		compact.WriteByte(':')
+		component := k == "builders" || k == "provisioners" || k == "post-processors"
+		if err := fmtWriteValue(&compact, templateData[k], component); err != nil {
+			return nil, err
+		}
+	}
+	compact.WriteByte('}')
+
+	var indented bytes.Buffer
+	if err := json.Indent(&indented, compact.Bytes(), "", "  "); err != nil {
+		return nil, err
+	}
+	indented.WriteByte('\n')
+	return indented.Bytes(), nil
+}
+
+// fmtRootKeys returns the root level keys in their canonical order.
+func fmtRootKeys(templateData map[string]interface{}) []string {
+	known := make(map[string]bool)
+	for _, k := range fmtRootKeyOrder {
+		known[k] = true
+	}
+
+	var keys []string
+	for k := range templateData {
+		if !known[k] {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, k := range fmtRootKeyOrder {
+		if _, ok := templateData[k]; ok {
+			keys = append(keys, k)
+		}
+	}
+	return keys
+}
+
+// fmtWriteValue writes v to buf as compact JSON. component marks objects
+// that configure a builder, provisioner or post-processor, whose type and
+// name keys are hoisted to the front.
+func fmtWriteValue(buf *bytes.Buffer, v interface{}, component bool) error {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			if component && (k == "type" || k == "name") {
+				continue
+			}
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		if component {
+			if _, ok := v["name"]; ok {
+				keys = append([]string{"name"}, keys...)
+			}
+			if _, ok := v["type"]; ok {
+				keys = append([]string{"type"}, keys...)
+			}
+		}
+
+		buf.WriteByte('{')
+		for i, k := range keys {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := fmtWriteValue(buf, k, false); err != nil {
+				return err
+			}
+			buf.WriteByte(':')
+			if err := fmtWriteValue(buf, v[k], false); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte('}')
+		return nil
+	case []map[string]interface{}:
+		// The fixers decode component sections into this shape.
+		elems := make([]interface{}, len(v))
+		for i, e := range v {
+			elems[i] = e
+		}
+		return fmtWriteValue(buf, elems, component)
+	case []interface{}:
+		buf.WriteByte('[')
+		for i, e := range v {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := fmtWriteValue(buf, e, component); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(']')
+		return nil
+	case string:
+		v = fmtInterpolationRe.ReplaceAllString(v, "{{$1}}")
+		encoder := json.NewEncoder(buf)
+		encoder.SetEscapeHTML(false)
+		if err := encoder.Encode(v); err != nil {
+			return err
+		}
+		// Encode terminates the value with a newline that the compact
+		// buffer must not contain.
+		buf.Truncate(buf.Len() - 1)
+		return nil
+	default:
+		b, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		buf.Write(b)
+		return nil
+	}
+}
+
+func (*FmtCommand) Help() string {
+	helpText := `
+Usage: packer fmt [options] TEMPLATE
+
+  Rewrites the JSON template to a canonical format: well known root level
+  keys in a fixed order, the type and name of builders, provisioners and
+  post-processors first, every other key sorted alphabetically, two space
+  indentation and no padding inside interpolation braces. Deprecated keys
+  that only need renaming are renamed along the way.
+
+  By default the formatted template is printed to standard out. If the
+  template cannot be parsed, the command exits with a non-zero exit status
+  and error messages appear on standard error.
+
+Options:
+
+  -check              Check if the template is formatted. The template is
+                      not printed; the exit status is 3 if it needs
+                      formatting.
+  -write              Overwrite the template with its formatted version
+                      instead of printing it.
+`
+
+	return strings.TrimSpace(helpText)
+}
+
+func (c *FmtCommand) Synopsis() string {
+	return "formats a JSON template to a canonical format"
+}
+
+func (c *FmtCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *FmtCommand) AutocompleteFlags() complete.Flags {
+	return complete.Flags{
+		"-check": complete.PredictNothing,
+		"-write": complete.PredictNothing,
+	}
+}
@@ -0,0 +1,62 @@
+package command
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/packer/packer"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFmt(t *testing.T) {
+	s := &strings.Builder{}
+	ui := &packer.BasicUi{
+		Writer: s,
+	}
+	c := &FmtCommand{
+		Meta: testMeta(t),
+	}
+
+	c.Ui = ui
+
+	args := []string{filepath.Join(testFixture("fmt"), "unformatted.json")}
+	if code := c.Run(args); code != 0 {
+		fatalCommand(t, c.Meta)
+	}
+
+	expected, err := ioutil.ReadFile(filepath.Join(testFixture("fmt"), "formatted.json"))
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	assert.Equal(t, strings.TrimSpace(string(expected)), strings.TrimSpace(s.String()))
+}
+
+func TestFmt_check(t *testing.T) {
+	c := &FmtCommand{
+		Meta: testMeta(t),
+	}
+
+	args := []string{
+		"-check",
+		filepath.Join(testFixture("fmt"), "unformatted.json"),
+	}
+	if code := c.Run(args); code != 3 {
+		fatalCommand(t, c.Meta)
+	}
+}
+
+func TestFmt_checkFormatted(t *testing.T) {
+	c := &FmtCommand{
+		Meta: testMeta(t),
+	}
+
+	args := []string{
+		"-check",
+		filepath.Join(testFixture("fmt"), "formatted.json"),
+	}
+	if code := c.Run(args); code != 0 {
+		fatalCommand(t, c.Meta)
+	}
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/hashicorp/packer/packer/plugin"
 
 	alicloudecsbuilder "github.com/hashicorp/packer/builder/alicloud/ecs"
+	amazonamifrominstancebuilder "github.com/hashicorp/packer/builder/amazon/amifrominstance"
 	amazonchrootbuilder "github.com/hashicorp/packer/builder/amazon/chroot"
 	amazonebsbuilder "github.com/hashicorp/packer/builder/amazon/ebs"
 	amazonebssurrogatebuilder "github.com/hashicorp/packer/builder/amazon/ebssurrogate"
@@ -25,6 +26,7 @@ import (
 	cloudstackbuilder "github.com/hashicorp/packer/builder/cloudstack"
 	digitaloceanbuilder "github.com/hashicorp/packer/builder/digitalocean"
 	dockerbuilder "github.com/hashicorp/packer/builder/docker"
+	exoscalebuilder "github.com/hashicorp/packer/builder/exoscale"
 	filebuilder "github.com/hashicorp/packer/builder/file"
 	googlecomputebuilder "github.com/hashicorp/packer/builder/googlecompute"
 	hcloudbuilder "github.com/hashicorp/packer/builder/hcloud"
@@ -74,9 +76,11 @@ import (
 	dockersavepostprocessor "github.com/hashicorp/packer/post-processor/docker-save"
 	dockertagpostprocessor "github.com/hashicorp/packer/post-processor/docker-tag"
 	exoscaleimportpostprocessor "github.com/hashicorp/packer/post-processor/exoscale-import"
+	exoscalereplicatepostprocessor "github.com/hashicorp/packer/post-processor/exoscale-replicate"
 	googlecomputeexportpostprocessor "github.com/hashicorp/packer/post-processor/googlecompute-export"
 	googlecomputeimportpostprocessor "github.com/hashicorp/packer/post-processor/googlecompute-import"
 	manifestpostprocessor "github.com/hashicorp/packer/post-processor/manifest"
+	registrypushpostprocessor "github.com/hashicorp/packer/post-processor/registry-push"
 	shelllocalpostprocessor "github.com/hashicorp/packer/post-processor/shell-local"
 	ucloudimportpostprocessor "github.com/hashicorp/packer/post-processor/ucloud-import"
 	vagrantpostprocessor "github.com/hashicorp/packer/post-processor/vagrant"
@@ -93,6 +97,7 @@ import (
 	convergeprovisioner "github.com/hashicorp/packer/provisioner/converge"
 	fileprovisioner "github.com/hashicorp/packer/provisioner/file"
 	inspecprovisioner "github.com/hashicorp/packer/provisioner/inspec"
+	linuxrestartprovisioner "github.com/hashicorp/packer/provisioner/linux-restart"
 	powershellprovisioner "github.com/hashicorp/packer/provisioner/powershell"
 	puppetmasterlessprovisioner "github.com/hashicorp/packer/provisioner/puppet-masterless"
 	puppetserverprovisioner "github.com/hashicorp/packer/provisioner/puppet-server"
@@ -109,56 +114,58 @@ type PluginCommand struct {
 }
 
 var Builders = map[string]packer.Builder{
-	"alicloud-ecs":        new(alicloudecsbuilder.Builder),
-	"amazon-chroot":       new(amazonchrootbuilder.Builder),
-	"amazon-ebs":          new(amazonebsbuilder.Builder),
-	"amazon-ebssurrogate": new(amazonebssurrogatebuilder.Builder),
-	"amazon-ebsvolume":    new(amazonebsvolumebuilder.Builder),
-	"amazon-instance":     new(amazoninstancebuilder.Builder),
-	"azure-arm":           new(azurearmbuilder.Builder),
-	"azure-chroot":        new(azurechrootbuilder.Builder),
-	"azure-dtl":           new(azuredtlbuilder.Builder),
-	"cloudstack":          new(cloudstackbuilder.Builder),
-	"digitalocean":        new(digitaloceanbuilder.Builder),
-	"docker":              new(dockerbuilder.Builder),
-	"file":                new(filebuilder.Builder),
-	"googlecompute":       new(googlecomputebuilder.Builder),
-	"hcloud":              new(hcloudbuilder.Builder),
-	"hyperone":            new(hyperonebuilder.Builder),
-	"hyperv-iso":          new(hypervisobuilder.Builder),
-	"hyperv-vmcx":         new(hypervvmcxbuilder.Builder),
-	"jdcloud":             new(jdcloudbuilder.Builder),
-	"linode":              new(linodebuilder.Builder),
-	"lxc":                 new(lxcbuilder.Builder),
-	"lxd":                 new(lxdbuilder.Builder),
-	"ncloud":              new(ncloudbuilder.Builder),
-	"null":                new(nullbuilder.Builder),
-	"oneandone":           new(oneandonebuilder.Builder),
-	"openstack":           new(openstackbuilder.Builder),
-	"oracle-classic":      new(oracleclassicbuilder.Builder),
-	"oracle-oci":          new(oracleocibuilder.Builder),
-	"osc-bsu":             new(oscbsubuilder.Builder),
-	"osc-bsusurrogate":    new(oscbsusurrogatebuilder.Builder),
-	"osc-bsuvolume":       new(oscbsuvolumebuilder.Builder),
-	"osc-chroot":          new(oscchrootbuilder.Builder),
-	"parallels-iso":       new(parallelsisobuilder.Builder),
-	"parallels-pvm":       new(parallelspvmbuilder.Builder),
-	"profitbricks":        new(profitbricksbuilder.Builder),
-	"proxmox":             new(proxmoxbuilder.Builder),
-	"qemu":                new(qemubuilder.Builder),
-	"scaleway":            new(scalewaybuilder.Builder),
-	"tencentcloud-cvm":    new(tencentcloudcvmbuilder.Builder),
-	"triton":              new(tritonbuilder.Builder),
-	"ucloud-uhost":        new(uclouduhostbuilder.Builder),
-	"vagrant":             new(vagrantbuilder.Builder),
-	"virtualbox-iso":      new(virtualboxisobuilder.Builder),
-	"virtualbox-ovf":      new(virtualboxovfbuilder.Builder),
-	"virtualbox-vm":       new(virtualboxvmbuilder.Builder),
-	"vmware-iso":          new(vmwareisobuilder.Builder),
-	"vmware-vmx":          new(vmwarevmxbuilder.Builder),
-	"vsphere-clone":       new(vsphereclonebuilder.Builder),
-	"vsphere-iso":         new(vsphereisobuilder.Builder),
-	"yandex":              new(yandexbuilder.Builder),
+	"alicloud-ecs":             new(alicloudecsbuilder.Builder),
+	"amazon-ami-from-instance": new(amazonamifrominstancebuilder.Builder),
+	"amazon-chroot":            new(amazonchrootbuilder.Builder),
+	"amazon-ebs":               new(amazonebsbuilder.Builder),
+	"amazon-ebssurrogate":      new(amazonebssurrogatebuilder.Builder),
+	"amazon-ebsvolume":         new(amazonebsvolumebuilder.Builder),
+	"amazon-instance":          new(amazoninstancebuilder.Builder),
+	"azure-arm":                new(azurearmbuilder.Builder),
+	"azure-chroot":             new(azurechrootbuilder.Builder),
+	"azure-dtl":                new(azuredtlbuilder.Builder),
+	"cloudstack":               new(cloudstackbuilder.Builder),
+	"digitalocean":             new(digitaloceanbuilder.Builder),
+	"docker":                   new(dockerbuilder.Builder),
+	"exoscale":                 new(exoscalebuilder.Builder),
+	"file":                     new(filebuilder.Builder),
+	"googlecompute":            new(googlecomputebuilder.Builder),
+	"hcloud":                   new(hcloudbuilder.Builder),
+	"hyperone":                 new(hyperonebuilder.Builder),
+	"hyperv-iso":               new(hypervisobuilder.Builder),
+	"hyperv-vmcx":              new(hypervvmcxbuilder.Builder),
+	"jdcloud":                  new(jdcloudbuilder.Builder),
+	"linode":                   new(linodebuilder.Builder),
+	"lxc":                      new(lxcbuilder.Builder),
+	"lxd":                      new(lxdbuilder.Builder),
+	"ncloud":                   new(ncloudbuilder.Builder),
+	"null":                     new(nullbuilder.Builder),
+	"oneandone":                new(oneandonebuilder.Builder),
+	"openstack":                new(openstackbuilder.Builder),
+	"oracle-classic":           new(oracleclassicbuilder.Builder),
+	"oracle-oci":               new(oracleocibuilder.Builder),
+	"osc-bsu":                  new(oscbsubuilder.Builder),
+	"osc-bsusurrogate":         new(oscbsusurrogatebuilder.Builder),
+	"osc-bsuvolume":            new(oscbsuvolumebuilder.Builder),
+	"osc-chroot":               new(oscchrootbuilder.Builder),
+	"parallels-iso":            new(parallelsisobuilder.Builder),
+	"parallels-pvm":            new(parallelspvmbuilder.Builder),
+	"profitbricks":             new(profitbricksbuilder.Builder),
+	"proxmox":                  new(proxmoxbuilder.Builder),
+	"qemu":                     new(qemubuilder.Builder),
+	"scaleway":                 new(scalewaybuilder.Builder),
+	"tencentcloud-cvm":         new(tencentcloudcvmbuilder.Builder),
+	"triton":                   new(tritonbuilder.Builder),
+	"ucloud-uhost":             new(uclouduhostbuilder.Builder),
+	"vagrant":                  new(vagrantbuilder.Builder),
+	"virtualbox-iso":           new(virtualboxisobuilder.Builder),
+	"virtualbox-ovf":           new(virtualboxovfbuilder.Builder),
+	"virtualbox-vm":            new(virtualboxvmbuilder.Builder),
+	"vmware-iso":               new(vmwareisobuilder.Builder),
+	"vmware-vmx":               new(vmwarevmxbuilder.Builder),
+	"vsphere-clone":            new(vsphereclonebuilder.Builder),
+	"vsphere-iso":              new(vsphereisobuilder.Builder),
+	"yandex":                   new(yandexbuilder.Builder),
 }
 
 var Provisioners = map[string]packer.Provisioner{
@@ -171,6 +178,7 @@ var Provisioners = map[string]packer.Provisioner{
 	"converge":          new(convergeprovisioner.Provisioner),
 	"file":              new(fileprovisioner.Provisioner),
 	"inspec":            new(inspecprovisioner.Provisioner),
+	"linux-restart":     new(linuxrestartprovisioner.Provisioner),
 	"powershell":        new(powershellprovisioner.Provisioner),
 	"puppet-masterless": new(puppetmasterlessprovisioner.Provisioner),
 	"puppet-server":     new(puppetserverprovisioner.Provisioner),
@@ -194,9 +202,11 @@ var PostProcessors = map[string]packer.PostProcessor{
 	"docker-save":          new(dockersavepostprocessor.PostProcessor),
 	"docker-tag":           new(dockertagpostprocessor.PostProcessor),
 	"exoscale-import":      new(exoscaleimportpostprocessor.PostProcessor),
+	"exoscale-replicate":   new(exoscalereplicatepostprocessor.PostProcessor),
 	"googlecompute-export": new(googlecomputeexportpostprocessor.PostProcessor),
 	"googlecompute-import": new(googlecomputeimportpostprocessor.PostProcessor),
 	"manifest":             new(manifestpostprocessor.PostProcessor),
+	"registry-push":        new(registrypushpostprocessor.PostProcessor),
 	"shell-local":          new(shelllocalpostprocessor.PostProcessor),
 	"ucloud-import":        new(ucloudimportpostprocessor.PostProcessor),
 	"vagrant":              new(vagrantpostprocessor.PostProcessor),
@@ -28,10 +28,21 @@ func handleTermInterrupt(ui packer.Ui) (context.Context, func()) {
 				// triggered first
 				return
 			}
-			ui.Error(fmt.Sprintf("Cancelling build after receiving %s", sig))
+			ui.Error(fmt.Sprintf("Cancelling build after receiving %s. Interrupt again to exit immediately (this skips cleanup!)", sig))
 			cancelCtx()
 		case <-ctx.Done():
+			return
 		}
+		// The context is cancelled and cleanup steps are running. A second
+		// signal aborts the process right away, skipping the remaining
+		// teardown. When the run finishes normally instead, cleanup closes
+		// sigCh and the nil receive below ends the goroutine.
+		sig := <-sigCh
+		if sig == nil {
+			return
+		}
+		ui.Error(fmt.Sprintf("Aborting immediately after receiving a second %s; cleanup steps were skipped", sig))
+		os.Exit(1)
 	}()
 	return ctx, cleanup
 }
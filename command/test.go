@@ -0,0 +1,93 @@
+package command
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// TestCommand is a BuildCommand that additionally boots a throwaway machine
+// from every created artifact and runs a check script on it before the
+// artifact is finalized.
+type TestCommand struct {
+	BuildCommand
+}
+
+func (c *TestCommand) Run(args []string) int {
+	ctx, cleanup := handleTermInterrupt(c.Ui)
+	defer cleanup()
+
+	cfg, ret := c.ParseArgs(args)
+	if ret != 0 {
+		return ret
+	}
+
+	return c.RunContext(ctx, cfg)
+}
+
+func (c *TestCommand) ParseArgs(args []string) (*BuildArgs, int) {
+	var cfg BuildArgs
+	flags := c.Meta.FlagSet("test", FlagSetBuildFilter|FlagSetVars)
+	flags.Usage = func() { c.Ui.Say(c.Help()) }
+	cfg.AddFlagSets(flags)
+	flags.StringVar(&cfg.TestScript, "script", "", "")
+	if err := flags.Parse(args); err != nil {
+		return &cfg, 1
+	}
+
+	if cfg.TestScript == "" {
+		c.Ui.Error("A check script must be specified with -script.")
+		flags.Usage()
+		return &cfg, 1
+	}
+	if _, err := os.Stat(cfg.TestScript); err != nil {
+		c.Ui.Error(fmt.Sprintf("Bad check script '%s': %s", cfg.TestScript, err))
+		return &cfg, 1
+	}
+
+	// Test builds are never parallelized; booting the verification
+	// machines in parallel on top of the builds themselves makes failures
+	// hard to attribute.
+	cfg.ParallelBuilds = 1
+
+	args = flags.Args()
+	if len(args) != 1 {
+		flags.Usage()
+		return &cfg, 1
+	}
+	cfg.Path = args[0]
+	return &cfg, 0
+}
+
+func (*TestCommand) Help() string {
+	helpText := `
+Usage: packer test -script=check.sh [options] TEMPLATE
+
+  Will execute the builds defined in the template, like "packer build", and
+  additionally smoke-test the produced images: after an artifact is created,
+  a throwaway machine is booted from it, the check script is run on it over
+  the configured communicator and the artifact is only finalized if the
+  script exits successfully. Builders that do not support boot verification
+  skip it.
+
+Options:
+
+  -script=check.sh              Path of the check script to run on the machine booted from the artifact. Required.
+  -color=false                  Disable color output. (Default: color)
+  -debug                        Debug mode enabled for builds.
+  -except=foo,bar,baz           Run all builds other than these.
+  -only=foo,bar,baz             Build only the specified builds.
+  -force                        Force a build to continue if artifacts exist, deletes existing artifacts.
+  -machine-readable             Produce machine-readable output.
+  -on-error=[cleanup|abort|ask] If the build fails do: clean up (default), abort, or ask.
+  -timestamp-ui                 Enable prefixing of each ui output with an RFC3339 timestamp.
+  -var 'key=value'              Variable for templates, can be used multiple times.
+  -var-file=path                JSON or YAML file containing user variables.
+`
+
+	return strings.TrimSpace(helpText)
+}
+
+func (*TestCommand) Synopsis() string {
+	return "build image(s) from template and boot-verify them"
+}
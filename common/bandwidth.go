@@ -0,0 +1,142 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/dustin/go-humanize"
+	"golang.org/x/time/rate"
+)
+
+// BandwidthLimitEnv is the environment variable holding a process-wide
+// transfer rate cap, e.g. "10MB". It is applied on top of any per-build
+// bandwidth_limit so parallel builds cannot saturate the uplink together.
+const BandwidthLimitEnv = "PACKER_BANDWIDTH_LIMIT"
+
+var (
+	globalBandwidthOnce    sync.Once
+	globalBandwidthLimiter *rate.Limiter
+)
+
+func globalBandwidth() *rate.Limiter {
+	globalBandwidthOnce.Do(func() {
+		v := os.Getenv(BandwidthLimitEnv)
+		if v == "" {
+			return
+		}
+		limit, err := ParseBandwidth(v)
+		if err != nil {
+			log.Printf("Ignoring invalid %s %q: %s", BandwidthLimitEnv, v, err)
+			return
+		}
+		globalBandwidthLimiter = newBandwidthLimiter(limit)
+	})
+	return globalBandwidthLimiter
+}
+
+// ParseBandwidth converts a human readable rate such as "512KB" or
+// "10MB/s" into bytes per second.
+func ParseBandwidth(v string) (int64, error) {
+	v = strings.TrimSuffix(strings.TrimSpace(v), "/s")
+	bytes, err := humanize.ParseBytes(v)
+	if err != nil {
+		return 0, err
+	}
+	if bytes == 0 {
+		return 0, fmt.Errorf("bandwidth must be positive")
+	}
+	return int64(bytes), nil
+}
+
+func newBandwidthLimiter(bytesPerSecond int64) *rate.Limiter {
+	return rate.NewLimiter(rate.Limit(bytesPerSecond), int(bytesPerSecond))
+}
+
+// LimitReader throttles reads from r to bytesPerSecond, plus the
+// process-wide PACKER_BANDWIDTH_LIMIT cap when one is set. A
+// bytesPerSecond of 0 applies only the process-wide cap; without either,
+// r is returned untouched.
+func LimitReader(r io.Reader, bytesPerSecond int64) io.Reader {
+	limiters := bandwidthLimiters(bytesPerSecond)
+	if len(limiters) == 0 {
+		return r
+	}
+	return &limitedReader{r: r, limiters: limiters}
+}
+
+// LimitWriter is the Write side counterpart of LimitReader.
+func LimitWriter(w io.Writer, bytesPerSecond int64) io.Writer {
+	limiters := bandwidthLimiters(bytesPerSecond)
+	if len(limiters) == 0 {
+		return w
+	}
+	return &limitedWriter{w: w, limiters: limiters}
+}
+
+func bandwidthLimiters(bytesPerSecond int64) []*rate.Limiter {
+	var limiters []*rate.Limiter
+	if bytesPerSecond > 0 {
+		limiters = append(limiters, newBandwidthLimiter(bytesPerSecond))
+	}
+	if l := globalBandwidth(); l != nil {
+		limiters = append(limiters, l)
+	}
+	return limiters
+}
+
+type limitedReader struct {
+	r        io.Reader
+	limiters []*rate.Limiter
+}
+
+func (l *limitedReader) Read(p []byte) (int, error) {
+	p = p[:bandwidthChunk(len(p), l.limiters)]
+	n, err := l.r.Read(p)
+	bandwidthWait(l.limiters, n)
+	return n, err
+}
+
+type limitedWriter struct {
+	w        io.Writer
+	limiters []*rate.Limiter
+}
+
+func (l *limitedWriter) Write(p []byte) (int, error) {
+	written := 0
+	for len(p) > 0 {
+		chunk := p[:bandwidthChunk(len(p), l.limiters)]
+		bandwidthWait(l.limiters, len(chunk))
+		n, err := l.w.Write(chunk)
+		written += n
+		if err != nil {
+			return written, err
+		}
+		p = p[len(chunk):]
+	}
+	return written, nil
+}
+
+// bandwidthChunk caps a transfer to the smallest limiter burst so a
+// single large buffer cannot overdraw a token bucket.
+func bandwidthChunk(max int, limiters []*rate.Limiter) int {
+	for _, l := range limiters {
+		if b := l.Burst(); b < max {
+			max = b
+		}
+	}
+	return max
+}
+
+func bandwidthWait(limiters []*rate.Limiter, n int) {
+	if n <= 0 {
+		return
+	}
+	for _, l := range limiters {
+		l.WaitN(context.Background(), n)
+	}
+}
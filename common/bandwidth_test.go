@@ -0,0 +1,65 @@
+package common
+
+import (
+	"bytes"
+	"io/ioutil"
+	"strings"
+	"testing"
+)
+
+func TestParseBandwidth(t *testing.T) {
+	cases := map[string]int64{
+		"512":    512,
+		"512KB":  512000,
+		"2MB":    2000000,
+		"2MiB":   2097152,
+		"10MB/s": 10000000,
+	}
+	for input, expected := range cases {
+		limit, err := ParseBandwidth(input)
+		if err != nil {
+			t.Errorf("%s: err: %s", input, err)
+		}
+		if limit != expected {
+			t.Errorf("%s: expected %d, got %d", input, expected, limit)
+		}
+	}
+
+	for _, input := range []string{"", "fast", "0"} {
+		if _, err := ParseBandwidth(input); err == nil {
+			t.Errorf("%s: should have errored", input)
+		}
+	}
+}
+
+func TestLimitReader(t *testing.T) {
+	// Without a limit the reader is returned untouched.
+	r := strings.NewReader("hello")
+	if limited := LimitReader(r, 0); limited != r {
+		t.Fatal("reader should be untouched without a limit")
+	}
+
+	// With a generous limit, all data still comes through.
+	data, err := ioutil.ReadAll(LimitReader(strings.NewReader("hello"), 1000000))
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if string(data) != "hello" {
+		t.Fatalf("bad data: %q", data)
+	}
+}
+
+func TestLimitWriter(t *testing.T) {
+	var buf bytes.Buffer
+	if limited := LimitWriter(&buf, 0); limited != &buf {
+		t.Fatal("writer should be untouched without a limit")
+	}
+
+	n, err := LimitWriter(&buf, 1000000).Write([]byte("hello"))
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if n != 5 || buf.String() != "hello" {
+		t.Fatalf("bad data: %q", buf.String())
+	}
+}
@@ -146,6 +146,12 @@ func (d *pcXTDriver) Flush() error {
 func (d *pcXTDriver) SendKey(key rune, action KeyAction) error {
 	keyShift := unicode.IsUpper(key) || strings.ContainsRune(shiftedChars, key)
 
+	if _, ok := d.scancodeMap[key]; !ok {
+		return fmt.Errorf("could not find scancode for character %q; "+
+			"the PC XT keyboard can only type printable US keyboard "+
+			"characters", key)
+	}
+
 	var sc []string
 
 	if action&(KeyOn|KeyPress) != 0 {
@@ -104,6 +104,19 @@ func Test_pcxtSpecial(t *testing.T) {
 	assert.Equal(t, expected, codes)
 }
 
+func Test_pcxtUnknownKey(t *testing.T) {
+	in := "é"
+	sendCodes := func(c []string) error {
+		return nil
+	}
+	d := NewPCXTDriver(sendCodes, -1, time.Duration(0))
+	seq, err := GenerateExpressionSequence(in)
+	assert.NoError(t, err)
+	err = seq.Do(context.Background(), d)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "could not find scancode")
+}
+
 func Test_flushes(t *testing.T) {
 	in := "abc123<wait>098"
 	expected := [][]string{
@@ -32,6 +32,8 @@ type FloppyConfig struct {
 	// or if you just want to organize it's contents as a hierarchy. Wildcard
 	// characters (\\*, ?, and \[\]) are allowed. The maximum summary size of
 	// all files in the listed directories are the same as in `floppy_files`.
+	// If a listed directory contains a `.packerignore` file, files matching
+	// its patterns (such as VCS metadata) are left off the floppy.
 	FloppyDirectories []string `mapstructure:"floppy_dirs"`
 	FloppyLabel       string   `mapstructure:"floppy_label"`
 }
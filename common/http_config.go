@@ -21,7 +21,9 @@ type HTTPConfig struct {
 	// virtual machine. This is useful for hosting kickstart files and so on.
 	// By default this is an empty string, which means no HTTP server will be
 	// started. The address and port of the HTTP server will be available as
-	// variables in `boot_command`. This is covered in more detail below.
+	// variables in `boot_command`. This is covered in more detail below. If
+	// the directory contains a `.packerignore` file, files matching its
+	// patterns (such as VCS metadata) are not served.
 	HTTPDir string `mapstructure:"http_directory"`
 	// These are the minimum and maximum port to use for the HTTP server
 	// started to serve the `http_directory`. Because Packer often runs in
@@ -7,8 +7,11 @@ type PackerConfig struct {
 	PackerBuildName     string            `mapstructure:"packer_build_name"`
 	PackerBuilderType   string            `mapstructure:"packer_builder_type"`
 	PackerDebug         bool              `mapstructure:"packer_debug"`
+	PackerDryRun        bool              `mapstructure:"packer_dry_run"`
+	PackerEnv           map[string]string `mapstructure:"packer_env"`
 	PackerForce         bool              `mapstructure:"packer_force"`
 	PackerOnError       string            `mapstructure:"packer_on_error"`
+	PackerTestScript    string            `mapstructure:"packer_test_script"`
 	PackerUserVars      map[string]string `mapstructure:"packer_user_variables"`
 	PackerSensitiveVars []string          `mapstructure:"packer_sensitive_variables"`
 }
@@ -0,0 +1,84 @@
+// Package packerignore implements the .packerignore file, which lists
+// patterns for files that are left out when Packer ships a directory into
+// a build - for example VCS metadata or editor droppings that have no
+// business ending up inside an image.
+//
+// The format is a deliberately small subset of .gitignore: one pattern
+// per line, blank lines and lines starting with # are skipped, and
+// patterns use the syntax of path.Match. A pattern without a slash is
+// matched against every path element, so ".git" excludes a .git
+// directory at any depth. A pattern containing a slash is anchored to
+// the directory holding the .packerignore file. A trailing slash is
+// allowed and ignored. Negation and ** are not supported.
+package packerignore
+
+import (
+	"bufio"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// FileName is the name of the ignore file read from the root of a
+// directory that is about to be shipped into a build.
+const FileName = ".packerignore"
+
+// Read returns the patterns of the .packerignore file in dir, or nil if
+// dir has no such file.
+func Read(dir string) ([]string, error) {
+	f, err := os.Open(filepath.Join(dir, FileName))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+
+	var patterns []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		patterns = append(patterns, line)
+	}
+
+	return patterns, scanner.Err()
+}
+
+// Matches reports whether relPath, a path relative to the directory
+// holding the .packerignore file, is excluded by any of the given
+// patterns. Both slash and native separators are accepted in relPath.
+func Matches(patterns []string, relPath string) bool {
+	relPath = filepath.ToSlash(relPath)
+	elems := strings.Split(relPath, "/")
+
+	for _, pattern := range patterns {
+		pattern = strings.TrimSuffix(pattern, "/")
+
+		if strings.Contains(pattern, "/") {
+			// Anchored: match the relative path itself and every
+			// parent, so "vendor/cache" also excludes everything
+			// below it.
+			for i := 1; i <= len(elems); i++ {
+				if ok, _ := path.Match(pattern, strings.Join(elems[:i], "/")); ok {
+					return true
+				}
+			}
+			continue
+		}
+
+		// Unanchored: match every path element, so ".git" excludes a
+		// .git directory (and thus its contents) at any depth.
+		for _, elem := range elems {
+			if ok, _ := path.Match(pattern, elem); ok {
+				return true
+			}
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,71 @@
+package packerignore
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestRead(t *testing.T) {
+	dir, err := ioutil.TempDir("", "packerignore")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	defer os.RemoveAll(dir)
+
+	// No .packerignore file at all.
+	patterns, err := Read(dir)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if patterns != nil {
+		t.Fatalf("expected no patterns, got %#v", patterns)
+	}
+
+	contents := "# VCS metadata\n.git\n\n*.tmp\nvendor/cache/\n"
+	if err := ioutil.WriteFile(filepath.Join(dir, FileName), []byte(contents), 0644); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	patterns, err = Read(dir)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	expected := []string{".git", "*.tmp", "vendor/cache/"}
+	if !reflect.DeepEqual(patterns, expected) {
+		t.Fatalf("bad patterns: %#v", patterns)
+	}
+}
+
+func TestMatches(t *testing.T) {
+	patterns := []string{".git", "*.tmp", "vendor/cache/"}
+
+	cases := []struct {
+		Path     string
+		Expected bool
+	}{
+		{".git", true},
+		{".git/config", true},
+		{"sub/dir/.git/config", true},
+		{"scratch.tmp", true},
+		{"sub/scratch.tmp", true},
+		{"vendor/cache", true},
+		{"vendor/cache/gem.rb", true},
+		{"scripts/setup.sh", false},
+		{"gitconfig", false},
+		// Anchored patterns only match from the root.
+		{"sub/vendor/cache", false},
+	}
+
+	for _, tc := range cases {
+		if matches := Matches(patterns, tc.Path); matches != tc.Expected {
+			t.Errorf("Matches(%q) should be %t", tc.Path, tc.Expected)
+		}
+	}
+
+	if Matches(nil, ".git") {
+		t.Errorf("no patterns should match nothing")
+	}
+}
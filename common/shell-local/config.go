@@ -29,6 +29,13 @@ type Config struct {
 	// can be used to inject the environment_vars into the environment.
 	ExecuteCommand []string `mapstructure:"execute_command"`
 
+	// A map of environment variables to inject before the command(s) are
+	// executed, in addition to environment_vars. Values are interpolated,
+	// so build-time data from the builder (for example the instance
+	// address) can be passed through with the build template function. On
+	// conflict, env wins over environment_vars.
+	Env map[string]string `mapstructure:"env"`
+
 	// The shebang value used when running inline scripts.
 	InlineShebang string `mapstructure:"inline_shebang"`
 
@@ -24,6 +24,7 @@ type FlatConfig struct {
 	EnvVarFormat        *string           `mapstructure:"env_var_format" cty:"env_var_format"`
 	Command             *string           `cty:"command"`
 	ExecuteCommand      []string          `mapstructure:"execute_command" cty:"execute_command"`
+	Env                 map[string]string `mapstructure:"env" cty:"env"`
 	InlineShebang       *string           `mapstructure:"inline_shebang" cty:"inline_shebang"`
 	OnlyOn              []string          `mapstructure:"only_on" cty:"only_on"`
 	TempfileExtension   *string           `mapstructure:"tempfile_extension" cty:"tempfile_extension"`
@@ -57,6 +58,7 @@ func (*FlatConfig) HCL2Spec() map[string]hcldec.Spec {
 		"env_var_format":             &hcldec.AttrSpec{Name: "env_var_format", Type: cty.String, Required: false},
 		"command":                    &hcldec.AttrSpec{Name: "command", Type: cty.String, Required: false},
 		"execute_command":            &hcldec.AttrSpec{Name: "execute_command", Type: cty.List(cty.String), Required: false},
+		"env":                        &hcldec.AttrSpec{Name: "env", Type: cty.Map(cty.String), Required: false},
 		"inline_shebang":             &hcldec.AttrSpec{Name: "inline_shebang", Type: cty.String, Required: false},
 		"only_on":                    &hcldec.AttrSpec{Name: "only_on", Type: cty.List(cty.String), Required: false},
 		"tempfile_extension":         &hcldec.AttrSpec{Name: "tempfile_extension", Type: cty.String, Required: false},
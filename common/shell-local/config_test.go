@@ -14,3 +14,19 @@ func TestConvertToLinuxPath(t *testing.T) {
 		"Should have converted %s to %s -- not %s", winPath, winBashPath, converted)
 
 }
+
+func TestCreateFlattenedEnvVars_env(t *testing.T) {
+	config := &Config{
+		Env: map[string]string{"EXTRA": "value", "FOO": "baz"},
+	}
+	config.Vars = []string{"FOO=bar"}
+	config.EnvVarFormat = "%s='%s' "
+	config.generatedData = make(map[string]interface{})
+
+	flattened, err := createFlattenedEnvVars(config)
+	assert.NoError(t, err)
+	assert.Contains(t, flattened, "EXTRA='value'")
+	// On conflict, env wins over environment_vars.
+	assert.Contains(t, flattened, "FOO='baz'")
+	assert.NotContains(t, flattened, "FOO='bar'")
+}
@@ -195,6 +195,18 @@ func createFlattenedEnvVars(config *Config) (string, error) {
 		envVars[keyValue[0]] = strings.Replace(keyValue[1], "'", `'"'"'`, -1)
 	}
 
+	// Structured env vars; on conflict these win over environment_vars.
+	// Values are interpolated with the builder's generated data, so
+	// build-time information such as the instance address can be passed
+	// through to local orchestration scripts.
+	for key, value := range config.Env {
+		value, err := interpolate.Render(value, &config.ctx)
+		if err != nil {
+			return "", err
+		}
+		envVars[key] = strings.Replace(value, "'", `'"'"'`, -1)
+	}
+
 	// Create a list of env var keys in sorted order
 	var keys []string
 	for k := range envVars {
@@ -10,6 +10,7 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/hashicorp/packer/common/packerignore"
 	"github.com/hashicorp/packer/helper/multistep"
 	"github.com/hashicorp/packer/packer"
 	"github.com/hashicorp/packer/packer/tmp"
@@ -257,10 +258,25 @@ func (s *StepCreateFloppy) Add(dircache directoryCache, src string) error {
 
 	// add a directory and it's subdirectories
 	basedirectory := filepath.Join(src, "..")
+
+	// Honor a .packerignore file in the copied directory, so VCS metadata
+	// and the like do not eat into the 1.44MB the floppy offers.
+	excl, err := packerignore.Read(src)
+	if err != nil {
+		return err
+	}
+
 	visit := func(pathname string, fi os.FileInfo, err error) error {
 		if err != nil {
 			return err
 		}
+		if relSrc, err := filepath.Rel(src, pathname); err == nil &&
+			packerignore.Matches(excl, relSrc) {
+			if fi.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
 		if fi.Mode().IsDir() {
 			base, err := removeBase(basedirectory, pathname)
 			if err != nil {
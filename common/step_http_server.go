@@ -3,10 +3,13 @@ package common
 import (
 	"context"
 	"fmt"
+	"os"
+	"strings"
 
 	"net/http"
 
 	"github.com/hashicorp/packer/common/net"
+	"github.com/hashicorp/packer/common/packerignore"
 	"github.com/hashicorp/packer/helper/multistep"
 	"github.com/hashicorp/packer/packer"
 )
@@ -55,8 +58,17 @@ func (s *StepHTTPServer) Run(ctx context.Context, state multistep.StateBag) mult
 
 	ui.Say(fmt.Sprintf("Starting HTTP server on port %d", s.l.Port))
 
+	// Honor a .packerignore file in the served directory.
+	excl, err := packerignore.Read(s.HTTPDir)
+	if err != nil {
+		err := fmt.Errorf("Error reading %s: %s", packerignore.FileName, err)
+		state.Put("error", err)
+		ui.Error(err.Error())
+		return multistep.ActionHalt
+	}
+
 	// Start the HTTP server and run it in the background
-	fileServer := http.FileServer(http.Dir(s.HTTPDir))
+	fileServer := http.FileServer(&ignoreFileSystem{http.Dir(s.HTTPDir), excl})
 	server := &http.Server{Addr: httpAddr, Handler: fileServer}
 	go server.Serve(s.l)
 
@@ -66,6 +78,22 @@ func (s *StepHTTPServer) Run(ctx context.Context, state multistep.StateBag) mult
 	return multistep.ActionContinue
 }
 
+// ignoreFileSystem serves the underlying file system but answers with "not
+// found" for any path excluded by the .packerignore patterns, so VCS
+// metadata and other build junk never leaves the host. Excluded names may
+// still show up in directory listings; requesting them does not work.
+type ignoreFileSystem struct {
+	fs   http.FileSystem
+	excl []string
+}
+
+func (f *ignoreFileSystem) Open(name string) (http.File, error) {
+	if packerignore.Matches(f.excl, strings.TrimPrefix(name, "/")) {
+		return nil, os.ErrNotExist
+	}
+	return f.fs.Open(name)
+}
+
 func (s *StepHTTPServer) Cleanup(multistep.StateBag) {
 	if s.l != nil {
 		// Close the listener so that the HTTP server stops
@@ -0,0 +1,69 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/hashicorp/packer/helper/multistep"
+	"github.com/hashicorp/packer/packer"
+)
+
+// StepTestScript runs the check script configured with "packer test" on the
+// machine that was booted from the freshly created artifact. The script is
+// uploaded over the communicator, executed, and a non-zero exit code fails
+// the build. Builders that support boot verification put this step right
+// after the connect step for the verification machine.
+type StepTestScript struct {
+	// Script is the local path of the check script.
+	Script string
+}
+
+func (s *StepTestScript) Run(ctx context.Context, state multistep.StateBag) multistep.StepAction {
+	comm := state.Get("communicator").(packer.Communicator)
+	ui := state.Get("ui").(packer.Ui)
+
+	ui.Say(fmt.Sprintf("Running check script: %s", s.Script))
+
+	f, err := os.Open(s.Script)
+	if err != nil {
+		err := fmt.Errorf("Error opening check script: %s", err)
+		state.Put("error", err)
+		ui.Error(err.Error())
+		return multistep.ActionHalt
+	}
+	defer f.Close()
+
+	remotePath := "/tmp/packer-test-script"
+	if err := comm.Upload(remotePath, f, nil); err != nil {
+		err := fmt.Errorf("Error uploading check script: %s", err)
+		state.Put("error", err)
+		ui.Error(err.Error())
+		return multistep.ActionHalt
+	}
+
+	cmd := &packer.RemoteCmd{
+		Command: fmt.Sprintf("chmod +x %s && %s", remotePath, remotePath),
+	}
+	if err := cmd.RunWithUi(ctx, comm, ui); err != nil {
+		err := fmt.Errorf("Error running check script: %s", err)
+		state.Put("error", err)
+		ui.Error(err.Error())
+		return multistep.ActionHalt
+	}
+
+	if cmd.ExitStatus() != 0 {
+		err := fmt.Errorf(
+			"Check script exited with status %d, not finalizing the artifact.",
+			cmd.ExitStatus())
+		state.Put("error", err)
+		ui.Error(err.Error())
+		return multistep.ActionHalt
+	}
+
+	ui.Say("Check script passed.")
+	return multistep.ActionContinue
+}
+
+func (s *StepTestScript) Cleanup(state multistep.StateBag) {
+}
@@ -11,10 +11,12 @@ import (
 	"runtime"
 	"sort"
 	"strings"
+	"sync"
 
 	"github.com/hashicorp/packer/command"
 	"github.com/hashicorp/packer/packer"
 	"github.com/hashicorp/packer/packer/plugin"
+	"github.com/hashicorp/packer/template/interpolate"
 	"github.com/kardianos/osext"
 )
 
@@ -30,9 +32,11 @@ type config struct {
 	RawBuilders                map[string]string         `json:"builders"`
 	RawProvisioners            map[string]string         `json:"provisioners"`
 	RawPostProcessors          map[string]string         `json:"post-processors"`
+	RawTemplateFuncs           map[string]string         `json:"functions"`
 	Builders                   packer.MapOfBuilder       `json:"-"`
 	Provisioners               packer.MapOfProvisioner   `json:"-"`
 	PostProcessors             packer.MapOfPostProcessor `json:"-"`
+	TemplateFuncs              packer.MapOfTemplateFunc  `json:"-"`
 }
 
 // decodeConfig decodes configuration in JSON format from the given io.Reader into
@@ -58,6 +62,7 @@ func (c *config) LoadExternalComponentsFromConfig() {
 	pluginPaths = append(pluginPaths, extractPaths(c.RawProvisioners)...)
 	pluginPaths = append(pluginPaths, extractPaths(c.RawBuilders)...)
 	pluginPaths = append(pluginPaths, extractPaths(c.RawPostProcessors)...)
+	pluginPaths = append(pluginPaths, extractPaths(c.RawTemplateFuncs)...)
 
 	var externallyUsed = make([]string, 0, len(pluginPaths))
 	for _, pluginPath := range pluginPaths {
@@ -111,6 +116,11 @@ func (c *config) loadSingleComponent(path string) (string, error) {
 		c.Provisioners[pluginName] = func() (packer.Provisioner, error) {
 			return c.pluginClient(path).Provisioner()
 		}
+	case strings.HasPrefix(pluginName, "packer-function-"):
+		pluginName = pluginName[len("packer-function-"):]
+		c.TemplateFuncs[pluginName] = func() (packer.TemplateFunc, error) {
+			return c.pluginClient(path).TemplateFunc()
+		}
 	}
 
 	return pluginName, nil
@@ -270,9 +280,55 @@ func (c *config) discoverExternalComponents(path string) error {
 		externallyUsed = nil
 	}
 
+	pluginPaths, err = c.discoverSingle(filepath.Join(path, "packer-function-*"))
+	if err != nil {
+		return err
+	}
+	for pluginName, pluginPath := range pluginPaths {
+		newPath := pluginPath // this needs to be stored in a new variable for the func below
+		c.TemplateFuncs[pluginName] = func() (packer.TemplateFunc, error) {
+			return c.pluginClient(newPath).TemplateFunc()
+		}
+		externallyUsed = append(externallyUsed, pluginName)
+	}
+	if len(externallyUsed) > 0 {
+		sort.Strings(externallyUsed)
+		log.Printf("using external template functions %v", externallyUsed)
+		externallyUsed = nil
+	}
+
 	return nil
 }
 
+// RegisterTemplateFuncs exposes every discovered packer-function-* plugin
+// to interpolation under the plugin's name. The plugin process is started
+// on first use and reused for subsequent evaluations, since a template
+// may call the same function many times. Built-in functions cannot be
+// overridden.
+func (c *config) RegisterTemplateFuncs() {
+	for name, starter := range c.TemplateFuncs {
+		if _, builtin := interpolate.FuncGens[name]; builtin {
+			log.Printf("[WARN] Ignoring template function plugin %q: would shadow a built-in function", name)
+			continue
+		}
+
+		starter := starter
+		var once sync.Once
+		var f packer.TemplateFunc
+		var startErr error
+		interpolate.FuncGens[name] = func(args ...string) (string, error) {
+			once.Do(func() {
+				f, startErr = starter()
+			})
+			if startErr != nil {
+				return "", fmt.Errorf("Error starting template function plugin %q: %s", name, startErr)
+			}
+
+			return f.Eval(args...)
+		}
+	}
+}
+
 func (c *config) discoverSingle(glob string) (map[string]string, error) {
 	matches, err := filepath.Glob(glob)
 	if err != nil {
@@ -136,8 +136,13 @@ type SSH struct {
 	// bastion host. The `~` can be used in path and will be expanded to the
 	// home directory of current user.
 	SSHBastionPrivateKeyFile string `mapstructure:"ssh_bastion_private_key_file"`
-	// `scp` or `sftp` - How to transfer files, Secure copy (default) or SSH
-	// File Transfer Protocol.
+	// `scp`, `sftp` or `tar` - How to transfer files: Secure copy
+	// (default), SSH File Transfer Protocol, or a tar stream. With `tar`,
+	// directory uploads are packed into a single tar stream locally and
+	// unpacked on the remote side with one command instead of one
+	// round-trip per file, which is much faster for trees with many small
+	// files; it requires `tar` on the guest, and single file transfers
+	// still use scp.
 	SSHFileTransferMethod string `mapstructure:"ssh_file_transfer_method"`
 	// A SOCKS proxy host to use for SSH connection
 	SSHProxyHost string `mapstructure:"ssh_proxy_host"`
@@ -467,9 +472,9 @@ func (c *Config) prepareSSH(ctx *interpolate.Context) []error {
 		}
 	}
 
-	if c.SSHFileTransferMethod != "scp" && c.SSHFileTransferMethod != "sftp" {
+	if c.SSHFileTransferMethod != "scp" && c.SSHFileTransferMethod != "sftp" && c.SSHFileTransferMethod != "tar" {
 		errs = append(errs, fmt.Errorf(
-			"ssh_file_transfer_method ('%s') is invalid, valid methods: sftp, scp",
+			"ssh_file_transfer_method ('%s') is invalid, valid methods: sftp, scp, tar",
 			c.SSHFileTransferMethod))
 	}
 
@@ -41,6 +41,22 @@ func TestConfig_badtype(t *testing.T) {
 	}
 }
 
+func TestConfig_ssh_file_transfer_method(t *testing.T) {
+	for _, method := range []string{"scp", "sftp", "tar"} {
+		c := testConfig()
+		c.SSHFileTransferMethod = method
+		if err := c.Prepare(testContext(t)); len(err) > 0 {
+			t.Fatalf("bad: %#v", err)
+		}
+	}
+
+	c := testConfig()
+	c.SSHFileTransferMethod = "foo"
+	if err := c.Prepare(testContext(t)); len(err) != 1 {
+		t.Fatalf("bad: %#v", err)
+	}
+}
+
 func TestConfig_winrm_noport(t *testing.T) {
 	c := &Config{
 		Type: "winrm",
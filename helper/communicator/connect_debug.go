@@ -0,0 +1,87 @@
+package communicator
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/packer/helper/multistep"
+	"github.com/hashicorp/packer/packer"
+)
+
+// debugProbeTimeout bounds each individual probe run while collecting a
+// connection debug bundle, so writing the bundle cannot hang a build that
+// is already being torn down.
+const debugProbeTimeout = 10 * time.Second
+
+// dumpConnectionDebugBundle probes the connection target after the
+// communicator failed to become available within its timeout and writes
+// the findings to a file in the working directory: DNS resolution of the
+// host, a raw TCP dial and, for WinRM over SSL, a TLS handshake. Builders
+// that retrieve console output from their cloud can make it part of the
+// bundle by storing it in the state bag under "console_output".
+func dumpConnectionDebugBundle(state multistep.StateBag, ui packer.Ui, config *Config, host string, port int) {
+	var buf bytes.Buffer
+
+	addr := fmt.Sprintf("%s:%d", host, port)
+	fmt.Fprintf(&buf, "Connection debug bundle collected at %s\n", time.Now().Format(time.RFC3339))
+	fmt.Fprintf(&buf, "Communicator: %s, target: %s\n\n", config.Type, addr)
+
+	if host == "" {
+		fmt.Fprintf(&buf, "No connection address was ever resolved; the machine may never have obtained one.\n")
+	} else {
+		if net.ParseIP(host) != nil {
+			fmt.Fprintf(&buf, "Host %s is an IP address; skipping DNS resolution.\n", host)
+		} else {
+			ctx, cancel := context.WithTimeout(context.Background(), debugProbeTimeout)
+			start := time.Now()
+			addrs, err := net.DefaultResolver.LookupHost(ctx, host)
+			cancel()
+			if err != nil {
+				fmt.Fprintf(&buf, "DNS resolution of %s failed after %s: %s\n", host, time.Since(start), err)
+			} else {
+				fmt.Fprintf(&buf, "DNS resolution of %s: %s (%s)\n", host, strings.Join(addrs, ", "), time.Since(start))
+			}
+		}
+
+		start := time.Now()
+		conn, err := net.DialTimeout("tcp", addr, debugProbeTimeout)
+		if err != nil {
+			fmt.Fprintf(&buf, "TCP dial of %s failed after %s: %s\n", addr, time.Since(start), err)
+		} else {
+			conn.Close()
+			fmt.Fprintf(&buf, "TCP dial of %s succeeded (%s); the port is reachable but the %s handshake never completed.\n", addr, time.Since(start), config.Type)
+
+			if config.Type == "winrm" && config.WinRMUseSSL {
+				tlsConn, err := tls.DialWithDialer(&net.Dialer{Timeout: debugProbeTimeout}, "tcp", addr, &tls.Config{
+					ServerName:         host,
+					InsecureSkipVerify: config.WinRMInsecure,
+				})
+				if err != nil {
+					fmt.Fprintf(&buf, "TLS handshake with %s failed: %s\n", addr, err)
+				} else {
+					tlsConn.Close()
+					fmt.Fprintf(&buf, "TLS handshake with %s succeeded.\n", addr)
+				}
+			}
+		}
+	}
+
+	if consoleOutput, ok := state.GetOk("console_output"); ok {
+		fmt.Fprintf(&buf, "\nConsole output reported by the builder:\n%s\n", consoleOutput)
+	}
+
+	path := fmt.Sprintf("packer-%s-debug-%s.log", config.Type, time.Now().Format("20060102-150405"))
+	if err := ioutil.WriteFile(path, buf.Bytes(), 0644); err != nil {
+		log.Printf("[WARN] Failed to write connection debug bundle: %s", err)
+		return
+	}
+
+	ui.Say(fmt.Sprintf("Wrote a connection debug bundle to %s", path))
+}
@@ -0,0 +1,52 @@
+package communicator
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/packer/packer"
+)
+
+func TestDumpConnectionDebugBundle(t *testing.T) {
+	td, err := ioutil.TempDir("", "packer")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	defer os.RemoveAll(td)
+
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if err := os.Chdir(td); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	defer os.Chdir(wd)
+
+	state := testState(t)
+	state.Put("console_output", "kernel: boot complete")
+	ui := state.Get("ui").(packer.Ui)
+
+	dumpConnectionDebugBundle(state, ui, &Config{Type: "ssh"}, "", 22)
+
+	files, err := filepath.Glob(filepath.Join(td, "packer-ssh-debug-*.log"))
+	if err != nil || len(files) != 1 {
+		t.Fatalf("expected one debug bundle, got %v (err: %s)", files, err)
+	}
+
+	raw, err := ioutil.ReadFile(files[0])
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	contents := string(raw)
+	if !strings.Contains(contents, "No connection address was ever resolved") {
+		t.Fatalf("bad contents: %s", contents)
+	}
+	if !strings.Contains(contents, "kernel: boot complete") {
+		t.Fatalf("console output missing from bundle: %s", contents)
+	}
+}
@@ -68,6 +68,7 @@ func (s *StepConnectSSH) Run(ctx context.Context, state multistep.StateBag) mult
 			state.Put("error", err)
 			ui.Error(err.Error())
 			cancel()
+			dumpConnectionDebugBundle(state, ui, s.Config, s.Config.SSHHost, s.Config.SSHPort)
 			return multistep.ActionHalt
 		case <-ctx.Done():
 			// The step sequence was cancelled, so cancel waiting for SSH
@@ -204,6 +205,7 @@ func (s *StepConnectSSH) waitForSSH(state multistep.StateBag, ctx context.Contex
 			Pty:                    s.Config.SSHPty,
 			DisableAgentForwarding: s.Config.SSHDisableAgentForwarding,
 			UseSftp:                s.Config.SSHFileTransferMethod == "sftp",
+			UseTar:                 s.Config.SSHFileTransferMethod == "tar",
 			KeepAliveInterval:      s.Config.SSHKeepAliveInterval,
 			Timeout:                s.Config.SSHReadWriteTimeout,
 			Tunnels:                tunnels,
@@ -68,6 +68,7 @@ func (s *StepConnectWinRM) Run(ctx context.Context, state multistep.StateBag) mu
 			state.Put("error", err)
 			ui.Error(err.Error())
 			cancel()
+			dumpConnectionDebugBundle(state, ui, s.Config, s.Config.WinRMHost, s.Config.WinRMPort)
 			return multistep.ActionHalt
 		case <-ctx.Done():
 			// The step sequence was cancelled, so cancel waiting for WinRM
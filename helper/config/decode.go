@@ -8,6 +8,7 @@ import (
 	"sort"
 	"strings"
 
+	"github.com/agext/levenshtein"
 	"github.com/hashicorp/go-multierror"
 	"github.com/hashicorp/hcl/v2/hcldec"
 	"github.com/hashicorp/packer/template/interpolate"
@@ -30,6 +31,13 @@ type DecodeOpts struct {
 	InterpolateContext *interpolate.Context
 	InterpolateFilter  *interpolate.RenderFilter
 
+	// MutuallyExclusive lists groups of configuration keys of which at
+	// most one may be set. Decoding fails when several keys of a group
+	// are present in the raw configuration, naming every conflicting key
+	// and where it was set. Since the check runs on the raw keys, it
+	// also covers keys that decode into squashed structs.
+	MutuallyExclusive [][]string
+
 	DecodeHooks []mapstructure.DecodeHookFunc
 }
 
@@ -80,6 +88,13 @@ func Decode(target interface{}, config *DecodeOpts, raws ...interface{}) error {
 	// Detect user variables from the raws and merge them into our context
 	ctxData, raws := DetectContextData(raws...)
 
+	// Remember the values as they were written, so that conflicting keys
+	// can be reported with their original (uninterpolated) expressions.
+	var uninterpolated []interface{}
+	if len(config.MutuallyExclusive) > 0 {
+		uninterpolated = append(uninterpolated, raws...)
+	}
+
 	// Interpolate first
 	if config.Interpolate {
 		ctx, err := DetectContext(raws...)
@@ -110,6 +125,15 @@ func Decode(target interface{}, config *DecodeOpts, raws ...interface{}) error {
 		}
 	}
 
+	// Check for mutually exclusive keys before decoding, so that every
+	// conflict gets reported instead of just the value that happened to
+	// win the decode.
+	if len(config.MutuallyExclusive) > 0 {
+		if err := detectMutuallyExclusive(config.MutuallyExclusive, uninterpolated, raws); err != nil {
+			return err
+		}
+	}
+
 	decodeHookFuncs := DefaultDecodeHookFuncs
 	if len(config.DecodeHooks) != 0 {
 		decodeHookFuncs = config.DecodeHooks
@@ -141,13 +165,22 @@ func Decode(target interface{}, config *DecodeOpts, raws ...interface{}) error {
 	if len(md.Unused) > 0 {
 		var err error
 		sort.Strings(md.Unused)
+		known := knownConfigKeys(target)
 		for _, unused := range md.Unused {
-			if unused != "type" && !strings.HasPrefix(unused, "packer_") {
+			if unused == "type" || strings.HasPrefix(unused, "packer_") {
+				continue
+			}
+			if suggestion := nearestConfigKey(unused, known); suggestion != "" {
+				err = multierror.Append(err, fmt.Errorf(
+					"unknown configuration key: %q; did you mean %q?", unused, suggestion))
+			} else {
 				err = multierror.Append(err, fmt.Errorf(
-					"unknown configuration key: %q; raws is %#v \n\n and ctx data is %#v", unused, raws, ctxData))
+					"unknown configuration key: %q", unused))
 			}
 		}
 		if err != nil {
+			log.Printf("Unknown configuration keys %v; raws is %#v, ctx data is %#v",
+				md.Unused, raws, ctxData)
 			return err
 		}
 	}
@@ -219,6 +252,140 @@ func DetectContext(raws ...interface{}) (*interpolate.Context, error) {
 	}, nil
 }
 
+// knownConfigKeys lists the mapstructure keys the target can decode,
+// descending into squashed structs, so that unknown keys can be matched
+// against them for a suggestion.
+func knownConfigKeys(target interface{}) []string {
+	var keys []string
+	var collect func(t reflect.Type)
+	collect = func(t reflect.Type) {
+		for t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+		if t.Kind() != reflect.Struct {
+			return
+		}
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			name := field.Tag.Get("mapstructure")
+			if idx := strings.Index(name, ","); idx != -1 {
+				if strings.Contains(name[idx:], "squash") {
+					collect(field.Type)
+					continue
+				}
+				name = name[:idx]
+			}
+			if name == "" {
+				name = strings.ToLower(field.Name)
+			}
+			keys = append(keys, name)
+		}
+	}
+	collect(reflect.TypeOf(target))
+	return keys
+}
+
+// nearestConfigKey returns the known key closest to the given one, or
+// the empty string when nothing is close enough; a far-fetched
+// suggestion would be more confusing than none at all.
+func nearestConfigKey(key string, known []string) string {
+	best, bestDistance := "", 0
+	for _, candidate := range known {
+		distance := levenshtein.Distance(key, candidate, nil)
+		if best == "" || distance < bestDistance {
+			best, bestDistance = candidate, distance
+		}
+	}
+	if best == "" || bestDistance > 3 {
+		return ""
+	}
+	return best
+}
+
+// detectMutuallyExclusive reports every group of mutually exclusive keys
+// of which more than one is set in the raw configuration. The rendered
+// values decide whether a key counts as set, so a variable interpolating
+// to an empty string does not trigger a conflict; the original values
+// are used to report where a conflicting key got its value from.
+func detectMutuallyExclusive(groups [][]string, original []interface{}, rendered []interface{}) error {
+	templatePath := ""
+	if ctx, err := DetectContext(rendered...); err == nil {
+		templatePath = ctx.TemplatePath
+	}
+
+	var err error
+	for _, group := range groups {
+		var conflicts []string
+		for _, key := range group {
+			for i, raw := range rendered {
+				if !rawKeyIsSet(raw, key) {
+					continue
+				}
+				conflicts = append(conflicts, key+keyProvenance(original[i], key, templatePath))
+				break
+			}
+		}
+		if len(conflicts) > 1 {
+			err = multierror.Append(err, fmt.Errorf(
+				"only one of %s may be set; got: %s",
+				strings.Join(group, ", "), strings.Join(conflicts, ", ")))
+		}
+	}
+
+	return err
+}
+
+// rawKeyIsSet says whether key carries a meaningful value in the given
+// raw configuration map. Keys that are absent, nil (as unset keys are in
+// HCL2 configurations), false or an empty string/list/map count as unset.
+func rawKeyIsSet(raw interface{}, key string) bool {
+	v, ok := rawLookup(raw, key)
+	if !ok || v == nil {
+		return false
+	}
+	switch rv := reflect.ValueOf(v); rv.Kind() {
+	case reflect.Bool:
+		return rv.Bool()
+	case reflect.String, reflect.Slice, reflect.Map:
+		return rv.Len() > 0
+	}
+	return true
+}
+
+// rawLookup fetches key from a raw configuration map, which may arrive
+// over the RPC boundary as either a map[string]interface{} or a
+// map[interface{}]interface{}.
+func rawLookup(raw interface{}, key string) (interface{}, bool) {
+	switch m := raw.(type) {
+	case map[string]interface{}:
+		v, ok := m[key]
+		return v, ok
+	case map[interface{}]interface{}:
+		v, ok := m[key]
+		return v, ok
+	}
+	return nil, false
+}
+
+// keyProvenance describes where a conflicting key was set: the template
+// it came from and, when the value was written as an interpolation, the
+// original expression, so the variable feeding it can be identified.
+func keyProvenance(original interface{}, key string, templatePath string) string {
+	var parts []string
+	if v, ok := rawLookup(original, key); ok {
+		if s, ok := v.(string); ok && strings.Contains(s, "{{") {
+			parts = append(parts, fmt.Sprintf("from %q", s))
+		}
+	}
+	if templatePath != "" {
+		parts = append(parts, "in "+templatePath)
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return " (set " + strings.Join(parts, " ") + ")"
+}
+
 func uint8ToStringHook(f reflect.Kind, t reflect.Kind, v interface{}) (interface{}, error) {
 	// We need to convert []uint8 to string. We have to do this
 	// because internally Packer uses MsgPack for RPC and the MsgPack
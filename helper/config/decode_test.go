@@ -2,6 +2,7 @@ package config
 
 import (
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 
@@ -133,3 +134,119 @@ func TestDecode(t *testing.T) {
 		}
 	}
 }
+
+func TestDecodeMutuallyExclusive(t *testing.T) {
+	type SourceConfig struct {
+		SourceISO      string `mapstructure:"source_iso"`
+		SourceTemplate string `mapstructure:"source_template"`
+	}
+	type Target struct {
+		SourceConfig `mapstructure:",squash"`
+		UserData     string `mapstructure:"user_data"`
+		UserDataFile string `mapstructure:"user_data_file"`
+	}
+
+	opts := &DecodeOpts{
+		Interpolate: true,
+		MutuallyExclusive: [][]string{
+			{"source_iso", "source_template"},
+			{"user_data", "user_data_file"},
+		},
+	}
+
+	// A single key of each group is fine.
+	var result Target
+	err := Decode(&result, opts, map[string]interface{}{
+		"source_iso": "ubuntu.iso",
+		"user_data":  "#cloud-config",
+	})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	// Conflicts in every group are reported at once, including the keys
+	// living in the squashed struct, together with the template path.
+	err = Decode(&result, opts, map[string]interface{}{
+		"source_iso":           "ubuntu.iso",
+		"source_template":      "ubuntu-template",
+		"user_data":            "#cloud-config",
+		"user_data_file":       "cloud.cfg",
+		"packer_template_path": "/tmp/template.json",
+	})
+	if err == nil {
+		t.Fatal("should have errored")
+	}
+	for _, want := range []string{
+		"source_iso", "source_template",
+		"user_data", "user_data_file",
+		"/tmp/template.json",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Fatalf("error %q should mention %q", err, want)
+		}
+	}
+
+	// A variable interpolating to an empty string does not conflict...
+	err = Decode(&result, opts, map[string]interface{}{
+		"source_iso":      "{{user `iso`}}",
+		"source_template": "{{user `template`}}",
+	}, map[string]interface{}{
+		"packer_user_variables": map[string]string{
+			"iso": "ubuntu.iso",
+		},
+	})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	// ...while one carrying a value is reported with its expression, so
+	// the variable feeding the conflict can be identified.
+	err = Decode(&result, opts, map[string]interface{}{
+		"source_iso":      "{{user `iso`}}",
+		"source_template": "ubuntu-template",
+	}, map[string]interface{}{
+		"packer_user_variables": map[string]string{
+			"iso": "ubuntu.iso",
+		},
+	})
+	if err == nil {
+		t.Fatal("should have errored")
+	}
+	if !strings.Contains(err.Error(), "{{user `iso`}}") {
+		t.Fatalf("error %q should mention the original expression", err)
+	}
+}
+
+func TestDecodeUnknownKeys(t *testing.T) {
+	type Squashed struct {
+		Password string `mapstructure:"ssh_password"`
+	}
+	type Target struct {
+		Squashed `mapstructure:",squash"`
+		Name     string `mapstructure:"name"`
+	}
+
+	// A close miss gets a suggestion, also for keys living in squashed
+	// structs.
+	var result Target
+	err := Decode(&result, nil, map[string]interface{}{
+		"ssh_passwrd": "hunter2",
+	})
+	if err == nil {
+		t.Fatal("should have errored")
+	}
+	if !strings.Contains(err.Error(), `did you mean "ssh_password"`) {
+		t.Fatalf("error should carry a suggestion, got: %s", err)
+	}
+
+	// Far-fetched suggestions are worse than none.
+	err = Decode(&result, nil, map[string]interface{}{
+		"completely_bogus": "x",
+	})
+	if err == nil {
+		t.Fatal("should have errored")
+	}
+	if strings.Contains(err.Error(), "did you mean") {
+		t.Fatalf("should not suggest for distant keys, got: %s", err)
+	}
+}
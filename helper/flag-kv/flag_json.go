@@ -3,11 +3,19 @@ package kvflag
 import (
 	"encoding/json"
 	"fmt"
+	"io/ioutil"
 	"os"
+	"path/filepath"
+	"regexp"
+
+	"github.com/ghodss/yaml"
 )
 
+// envVarRegex matches `env` template calls, e.g. {{env `HOME`}}.
+var envVarRegex = regexp.MustCompile("{{\\s*env\\s+`([^`]*)`\\s*}}")
+
 // FlagJSON is a flag.Value implementation for parsing user variables
-// from the command-line using JSON files.
+// from the command-line using JSON or YAML files.
 type FlagJSON map[string]string
 
 func (v *FlagJSON) String() string {
@@ -15,20 +23,34 @@ func (v *FlagJSON) String() string {
 }
 
 func (v *FlagJSON) Set(raw string) error {
-	f, err := os.Open(raw)
+	contents, err := ioutil.ReadFile(raw)
 	if err != nil {
 		return err
 	}
-	defer f.Close()
 
 	if *v == nil {
 		*v = make(map[string]string)
 	}
 
-	if err := json.NewDecoder(f).Decode(v); err != nil {
+	switch filepath.Ext(raw) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(contents, v)
+	default:
+		err = json.Unmarshal(contents, v)
+	}
+	if err != nil {
 		return fmt.Errorf(
 			"Error reading variables in '%s': %s", raw, err)
 	}
 
+	// Interpolate environment variables, e.g. {{env `HOME`}}, into the
+	// values. Any other template call is left untouched; those are
+	// interpolated later, when the variable is used.
+	for key, value := range *v {
+		(*v)[key] = envVarRegex.ReplaceAllStringFunc(value, func(call string) string {
+			return os.Getenv(envVarRegex.FindStringSubmatch(call)[1])
+		})
+	}
+
 	return nil
 }
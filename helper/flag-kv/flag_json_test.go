@@ -2,6 +2,7 @@ package kvflag
 
 import (
 	"flag"
+	"os"
 	"path/filepath"
 	"reflect"
 	"testing"
@@ -38,8 +39,25 @@ func TestFlagJSON(t *testing.T) {
 			map[string]string{"key": "value"},
 			false,
 		},
+
+		{
+			"basic.yaml",
+			nil,
+			map[string]string{"key": "value", "other": "second value"},
+			false,
+		},
+
+		{
+			"env.json",
+			nil,
+			map[string]string{"key": "from-env", "later": "{{timestamp}}"},
+			false,
+		},
 	}
 
+	os.Setenv("KVFLAG_TEST_ENV", "from-env")
+	defer os.Unsetenv("KVFLAG_TEST_ENV")
+
 	for _, tc := range cases {
 		f := new(FlagJSON)
 		if tc.Initial != nil {
@@ -86,8 +86,9 @@ func realMain() int {
 		go copyOutput(outR, doneCh)
 
 		// Enable checkpoint for panic reporting
-		if config, _ := loadConfig(); config != nil && !config.DisableCheckpoint {
+		if config, _ := loadConfig(); config != nil {
 			packer.CheckpointReporter = packer.NewCheckpointReporter(
+				config.DisableCheckpoint,
 				config.DisableCheckpointSignature,
 			)
 		}
@@ -152,13 +153,15 @@ func wrappedMain() int {
 		return 1
 	}
 
+	// Make plugin-provided template functions available to interpolation.
+	config.RegisterTemplateFuncs()
+
 	// Fire off the checkpoint.
 	go runCheckpoint(config)
-	if !config.DisableCheckpoint {
-		packer.CheckpointReporter = packer.NewCheckpointReporter(
-			config.DisableCheckpointSignature,
-		)
-	}
+	packer.CheckpointReporter = packer.NewCheckpointReporter(
+		config.DisableCheckpoint,
+		config.DisableCheckpointSignature,
+	)
 
 	cacheDir, err := packer.CachePath()
 	if err != nil {
@@ -295,6 +298,7 @@ func loadConfig() (*config, error) {
 	config.Builders = packer.MapOfBuilder{}
 	config.PostProcessors = packer.MapOfPostProcessor{}
 	config.Provisioners = packer.MapOfProvisioner{}
+	config.TemplateFuncs = packer.MapOfTemplateFunc{}
 	if err := config.Discover(); err != nil {
 		return nil, err
 	}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"os"
 	"sync"
 
 	"github.com/hashicorp/packer/helper/common"
@@ -23,6 +24,10 @@ const (
 	// debugging is enabled.
 	DebugConfigKey = "packer_debug"
 
+	// This is the key in configurations that is set to "true" when Packer
+	// dry-run mode is enabled.
+	DryRunConfigKey = "packer_dry_run"
+
 	// This is the key in configurations that is set to "true" when Packer
 	// force build is enabled.
 	ForceConfigKey = "packer_force"
@@ -33,12 +38,20 @@ const (
 	// - "ask" - ask the user
 	OnErrorConfigKey = "packer_on_error"
 
+	// This is the key in configurations that is set to the path of the
+	// check script when the build was started with "packer test".
+	TestScriptConfigKey = "packer_test_script"
+
 	// TemplatePathKey is the path to the template that configured this build
 	TemplatePathKey = "packer_template_path"
 
 	// This key contains a map[string]string of the user variables for
 	// template processing.
 	UserVariablesConfigKey = "packer_user_variables"
+
+	// This key contains a map[string]string of the template-level env,
+	// exported by every shell-based provisioner.
+	EnvConfigKey = "packer_env"
 )
 
 // A Build represents a single job within Packer that is responsible for
@@ -68,6 +81,15 @@ type Build interface {
 	// strictly prohibited.
 	SetDebug(bool)
 
+	// SetDryRun will enable/disable dry-run mode. Dry-run mode is passed
+	// to the various components through the additional key
+	// "packer_dry_run" in their configuration. Builders that support it
+	// describe the principal API mutations the build would perform and
+	// stop without creating anything; builders that do not support it
+	// ignore the key and run normally. This must be called prior to
+	// Prepare.
+	SetDryRun(bool)
+
 	// SetForce will enable/disable forcing a build when artifacts exist.
 	//
 	// When SetForce is set to true, existing artifacts from the build are
@@ -79,6 +101,33 @@ type Build interface {
 	// - "abort" - exit without cleanup
 	// - "ask" - ask the user
 	SetOnError(string)
+
+	// SetSecretScan will enable/disable scanning build output and any
+	// file-based artifacts for leaked credentials (AWS keys, private keys)
+	// once the build has finished. Any finding fails the build.
+	SetSecretScan(bool)
+
+	// SetQuietProvisioners will enable/disable suppressing provisioner
+	// output from the UI. Suppressed output still goes to the Packer log,
+	// and each provisioner is summarized with its elapsed time and the
+	// amount of output that was suppressed. This must be called prior to
+	// Prepare.
+	SetQuietProvisioners(bool)
+
+	// SetTestScript sets the path of the check script configured with
+	// "packer test". The path is passed to the various components through
+	// the additional key "packer_test_script" in their configuration.
+	// Builders that support it boot a throwaway machine from the artifact
+	// they just created, run the script on it over the communicator and
+	// fail the build if the script fails; builders that do not support it
+	// ignore the key. This must be called prior to Prepare.
+	SetTestScript(string)
+
+	// SetUiTargetWidth sets the width the build name is padded to in the
+	// output prefix, so that the output of several parallel builds lines
+	// up in columns. Zero leaves the prefix unpadded. This must be called
+	// prior to Run.
+	SetUiTargetWidth(int)
 }
 
 // A CoreBuild struct represents a single build job, the result of which should
@@ -97,12 +146,26 @@ type CoreBuild struct {
 	TemplatePath       string
 	Variables          map[string]string
 
+	// Env is the template-level env map, exported to every shell-based
+	// provisioner of the build.
+	Env map[string]string
+
+	// Dependencies are the names of the builds this build depends on, as
+	// declared with 'depends_on' in the template. They are consumed by the
+	// build command to order build execution.
+	Dependencies []string
+
 	// Indicates whether the build is already initialized before calling Prepare(..)
 	Prepared bool
 
-	debug         bool
-	force         bool
-	onError       string
+	debug             bool
+	dryRun            bool
+	force             bool
+	onError           string
+	secretScan        bool
+	quietProvisioners bool
+	testScript        string
+	uiTargetWidth     int
 	l             sync.Mutex
 	prepareCalled bool
 }
@@ -155,10 +218,13 @@ func (b *CoreBuild) Prepare() (warn []string, err error) {
 		BuildNameConfigKey:     b.Type,
 		BuilderTypeConfigKey:   b.BuilderType,
 		DebugConfigKey:         b.debug,
+		DryRunConfigKey:        b.dryRun,
 		ForceConfigKey:         b.force,
 		OnErrorConfigKey:       b.onError,
+		TestScriptConfigKey:    b.testScript,
 		TemplatePathKey:        b.TemplatePath,
 		UserVariablesConfigKey: b.Variables,
+		EnvConfigKey:           b.Env,
 	}
 
 	// Prepare the builder
@@ -259,6 +325,7 @@ func (b *CoreBuild) Run(ctx context.Context, originalUi Ui) ([]Artifact, error)
 
 		hooks[HookProvision] = append(hooks[HookProvision], &ProvisionHook{
 			Provisioners: hookedProvisioners,
+			Quiet:        b.quietProvisioners,
 		})
 	}
 
@@ -270,16 +337,31 @@ func (b *CoreBuild) Run(ctx context.Context, originalUi Ui) ([]Artifact, error)
 		}
 		hooks[HookCleanupProvision] = []Hook{&ProvisionHook{
 			Provisioners: []*HookedProvisioner{hookedCleanupProvisioner},
+			Quiet:        b.quietProvisioners,
 		}}
 	}
 
 	hook := &DispatchHook{Mapping: hooks}
 	artifacts := make([]Artifact, 0, 1)
 
+	// Optionally feed everything this build says through a secret
+	// scanner, so provisioning output gets checked along with the
+	// final artifacts.
+	var secretScanner *SecretScanner
+	if b.secretScan {
+		secretScanner = &SecretScanner{}
+		originalUi = &SecretScanUi{
+			Ui:      originalUi,
+			Scanner: secretScanner,
+			Source:  "build output",
+		}
+	}
+
 	// The builder just has a normal Ui, but targeted
 	builderUi := &TargetedUI{
-		Target: b.Name(),
-		Ui:     originalUi,
+		Target:      b.Name(),
+		TargetWidth: b.uiTargetWidth,
+		Ui:          originalUi,
 	}
 
 	log.Printf("Running builder: %s", b.BuilderType)
@@ -305,8 +387,9 @@ PostProcessorRunSeqLoop:
 		priorArtifact := builderArtifact
 		for i, corePP := range ppSeq {
 			ppUi := &TargetedUI{
-				Target: fmt.Sprintf("%s (%s)", b.Name(), corePP.PType),
-				Ui:     originalUi,
+				Target:      fmt.Sprintf("%s (%s)", b.Name(), corePP.PType),
+				TargetWidth: b.uiTargetWidth,
+				Ui:          originalUi,
 			}
 
 			if corePP.PName == corePP.PType {
@@ -390,6 +473,24 @@ PostProcessorRunSeqLoop:
 		}
 	}
 
+	if secretScanner != nil {
+		for _, a := range artifacts {
+			for _, path := range a.Files() {
+				if _, statErr := os.Stat(path); statErr != nil {
+					// Not a local file (e.g. a cloud image ID); nothing
+					// to scan.
+					continue
+				}
+				if scanErr := secretScanner.ScanFile(path); scanErr != nil {
+					errors = append(errors, fmt.Errorf("Error scanning artifact %s for secrets: %s", path, scanErr))
+				}
+			}
+		}
+		for _, finding := range secretScanner.Findings() {
+			errors = append(errors, fmt.Errorf("Secret scan: %s", finding))
+		}
+	}
+
 	if len(errors) > 0 {
 		err = &MultiError{errors}
 	}
@@ -405,6 +506,14 @@ func (b *CoreBuild) SetDebug(val bool) {
 	b.debug = val
 }
 
+func (b *CoreBuild) SetDryRun(val bool) {
+	if b.prepareCalled {
+		panic("prepare has already been called")
+	}
+
+	b.dryRun = val
+}
+
 func (b *CoreBuild) SetForce(val bool) {
 	if b.prepareCalled {
 		panic("prepare has already been called")
@@ -420,3 +529,31 @@ func (b *CoreBuild) SetOnError(val string) {
 
 	b.onError = val
 }
+
+func (b *CoreBuild) SetSecretScan(val bool) {
+	if b.prepareCalled {
+		panic("prepare has already been called")
+	}
+
+	b.secretScan = val
+}
+
+func (b *CoreBuild) SetQuietProvisioners(val bool) {
+	if b.prepareCalled {
+		panic("prepare has already been called")
+	}
+
+	b.quietProvisioners = val
+}
+
+func (b *CoreBuild) SetTestScript(val string) {
+	if b.prepareCalled {
+		panic("prepare has already been called")
+	}
+
+	b.testScript = val
+}
+
+func (b *CoreBuild) SetUiTargetWidth(val int) {
+	b.uiTargetWidth = val
+}
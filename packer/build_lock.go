@@ -0,0 +1,79 @@
+package packer
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// BuildLocker is an advisory lock keyed on build name. It prevents two
+// Packer processes, for example two CI pipelines running the same template,
+// from concurrently running a build with the same name and clobbering each
+// other's images. Implementations must be safe for concurrent use by
+// multiple goroutines.
+type BuildLocker interface {
+	// Lock acquires the lock for the named build. It returns a function
+	// that releases the lock, or an error if the lock is already held by
+	// someone else.
+	Lock(name string) (func() error, error)
+}
+
+// FileBuildLocker implements BuildLocker with lock files in a directory. It
+// works across processes and, when the directory is on a shared file
+// system, across machines. The directory is created if it does not exist.
+//
+// The lock file records the PID of the process holding it. If a Packer
+// process is killed without cleaning up, the stale lock file has to be
+// removed by hand.
+type FileBuildLocker struct {
+	Dir string
+}
+
+func (l *FileBuildLocker) Lock(name string) (func() error, error) {
+	if err := os.MkdirAll(l.Dir, 0755); err != nil {
+		return nil, fmt.Errorf("error creating lock directory: %s", err)
+	}
+
+	path := l.lockPath(name)
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if err != nil {
+		if os.IsExist(err) {
+			holder := "unknown"
+			if contents, rerr := ioutil.ReadFile(path); rerr == nil {
+				holder = strings.TrimSpace(string(contents))
+			}
+			return nil, fmt.Errorf(
+				"build '%s' is locked by another Packer process (pid %s); "+
+					"if that process is gone, remove the lock file %s",
+				name, holder, path)
+		}
+		return nil, err
+	}
+
+	fmt.Fprintf(f, "%d\n", os.Getpid())
+	f.Close()
+
+	return func() error {
+		return os.Remove(path)
+	}, nil
+}
+
+// lockPath returns the lock file for a build, with characters that are
+// unsafe in file names replaced.
+func (l *FileBuildLocker) lockPath(name string) string {
+	safe := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z',
+			r >= 'A' && r <= 'Z',
+			r >= '0' && r <= '9',
+			r == '-', r == '_', r == '.':
+			return r
+		default:
+			return '-'
+		}
+	}, name)
+
+	return filepath.Join(l.Dir, safe+".lock")
+}
@@ -0,0 +1,58 @@
+package packer
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFileBuildLocker(t *testing.T) {
+	dir, err := ioutil.TempDir("", "packer")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	defer os.RemoveAll(dir)
+
+	locker := &FileBuildLocker{Dir: dir}
+
+	unlock, err := locker.Lock("foo")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	// A second lock on the same build must fail.
+	if _, err := locker.Lock("foo"); err == nil {
+		t.Fatal("should have error")
+	}
+
+	// A lock on another build is independent.
+	unlockBar, err := locker.Lock("bar")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if err := unlockBar(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	// After unlocking, the build can be locked again.
+	if err := unlock(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	unlock, err = locker.Lock("foo")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if err := unlock(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+}
+
+func TestFileBuildLocker_lockPath(t *testing.T) {
+	locker := &FileBuildLocker{Dir: "locks"}
+
+	path := locker.lockPath("my build/name")
+	if path != filepath.Join("locks", "my-build-name.lock") {
+		t.Fatalf("bad path: %s", path)
+	}
+}
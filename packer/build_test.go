@@ -42,10 +42,13 @@ func testDefaultPackerConfig() map[string]interface{} {
 		BuildNameConfigKey:     "test",
 		BuilderTypeConfigKey:   "foo",
 		DebugConfigKey:         false,
+		DryRunConfigKey:        false,
 		ForceConfigKey:         false,
 		OnErrorConfigKey:       "cleanup",
+		TestScriptConfigKey:    "",
 		TemplatePathKey:        "",
 		UserVariablesConfigKey: make(map[string]string),
+		EnvConfigKey:           map[string]string(nil),
 	}
 }
 func TestBuild_Name(t *testing.T) {
@@ -172,17 +172,20 @@ func (c *Core) generateCoreBuildProvisioner(rawP *template.Provisioner, rawName
 			config = append(config, override)
 		}
 	}
+	// The timeout only covers the provisioner itself, so wrap it first and
+	// the pause and retry wrappers around it.
+	if rawP.Timeout != 0 {
+		provisioner = &TimeoutProvisioner{
+			Timeout:     rawP.Timeout,
+			Provisioner: provisioner,
+		}
+	}
 	// If we're pausing, we wrap the provisioner in a special pauser.
 	if rawP.PauseBefore != 0 {
 		provisioner = &PausedProvisioner{
 			PauseBefore: rawP.PauseBefore,
 			Provisioner: provisioner,
 		}
-	} else if rawP.Timeout != 0 {
-		provisioner = &TimeoutProvisioner{
-			Timeout:     rawP.Timeout,
-			Provisioner: provisioner,
-		}
 	}
 	if rawP.MaxRetries != 0 {
 		provisioner = &RetriedProvisioner{
@@ -315,6 +318,21 @@ func (c *Core) Build(n string) (Build, error) {
 
 	// TODO hooks one day
 
+	// Interpolate the template-level env now that the variables are known,
+	// so entries may reference user variables. Sensitive variables used
+	// here stay masked through the log secret filter.
+	var env map[string]string
+	if len(c.Template.Env) > 0 {
+		env = make(map[string]string, len(c.Template.Env))
+		for k, v := range c.Template.Env {
+			rendered, err := interpolate.Render(v, c.Context())
+			if err != nil {
+				return nil, fmt.Errorf("Error interpolating env %q: %s", k, err)
+			}
+			env[k] = rendered
+		}
+	}
+
 	return &CoreBuild{
 		Type:               n,
 		Builder:            builder,
@@ -325,6 +343,8 @@ func (c *Core) Build(n string) (Build, error) {
 		CleanupProvisioner: cleanupProvisioner,
 		TemplatePath:       c.Template.Path,
 		Variables:          c.variables,
+		Env:                env,
+		Dependencies:       configBuilder.DependsOn,
 	}, nil
 }
 
@@ -369,6 +389,24 @@ func (c *Core) validate() error {
 		}
 	}
 
+	// Validate that every required feature is supported
+	if len(c.Template.RequiredFeatures) > 0 {
+		var missing []string
+		for _, feature := range c.Template.RequiredFeatures {
+			if !HasFeature(feature) {
+				missing = append(missing, feature)
+			}
+		}
+
+		if len(missing) > 0 {
+			return fmt.Errorf(
+				"This template requires features that this version of Packer "+
+					"does not support: %s. Please upgrade to a Packer version "+
+					"that provides them.",
+				strings.Join(missing, ", "))
+		}
+	}
+
 	// Validate variables are set
 	var err error
 	for n, v := range c.Template.Variables {
@@ -554,5 +592,22 @@ func (c *Core) init() error {
 		c.secrets = append(c.secrets, secret)
 	}
 
-	return nil
+	// Check the fully rendered values against the variable types and
+	// validation rules.
+	var errs error
+	for k, v := range c.Template.Variables {
+		if v.Type == "" && v.Validation == "" {
+			continue
+		}
+		value, ok := ctx.UserVariables[k]
+		if !ok {
+			continue
+		}
+		if verr := v.ValidateValue(value); verr != nil {
+			errs = multierror.Append(errs, fmt.Errorf(
+				"variable %s: %s", k, verr))
+		}
+	}
+
+	return errs
 }
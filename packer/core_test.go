@@ -473,6 +473,16 @@ func TestCoreValidate(t *testing.T) {
 		// Min version good
 		{"validate-min-version.json", map[string]string{"foo": "bar"}, false},
 		{"validate-min-version-high.json", map[string]string{"foo": "bar"}, true},
+
+		// Required features
+		{"validate-req-features.json", nil, false},
+		{"validate-req-features-missing.json", nil, true},
+
+		// Typed variables and validation rules
+		{"validate-typed-variables.json", nil, false},
+		{"validate-typed-variables.json", map[string]string{"count": "3", "region": "us-west-2"}, false},
+		{"validate-typed-variables.json", map[string]string{"count": "lots"}, true},
+		{"validate-typed-variables.json", map[string]string{"region": "eu-west-1"}, true},
 	}
 
 	for _, tc := range cases {
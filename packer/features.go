@@ -0,0 +1,27 @@
+package packer
+
+// coreFeatures is the set of named capabilities implemented by this version
+// of Packer. Templates shared across teams can list any of these in
+// "required_features" next to min_packer_version, so that an older Packer
+// fails upfront with a clear error instead of breaking halfway through a
+// build.
+var coreFeatures = map[string]struct{}{
+	// core
+	"core.graceful-cancel": {},
+
+	// builders
+	"amazon.gp3-volumes":          {},
+	"amazon.vpc-endpoint-check":   {},
+	"cloudstack.resource-tags":    {},
+	"lxd.ephemeral-containers":    {},
+	"triton.user-script-chunking": {},
+	"virtualbox.network-adapters": {},
+	"vmware.network-validation":   {},
+}
+
+// HasFeature reports whether this version of Packer provides the named
+// capability.
+func HasFeature(name string) bool {
+	_, ok := coreFeatures[name]
+	return ok
+}
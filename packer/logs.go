@@ -3,6 +3,7 @@ package packer
 import (
 	"bytes"
 	"io"
+	"strings"
 	"sync"
 )
 
@@ -35,6 +36,19 @@ func (l *secretFilter) Write(p []byte) (n int, err error) {
 	return l.w.Write(p)
 }
 
+// FilterString scrubs all registered sensitive values from message, the
+// same way Write scrubs them from the log stream.
+func (l *secretFilter) FilterString(message string) string {
+	l.m.Lock()
+	defer l.m.Unlock()
+	for s := range l.s {
+		if s != "" {
+			message = strings.Replace(message, s, "<sensitive>", -1)
+		}
+	}
+	return message
+}
+
 func (l *secretFilter) get() (s []string) {
 	l.m.Lock()
 	defer l.m.Unlock()
@@ -72,3 +72,26 @@ func (mob MapOfBuilder) List() []string {
 	}
 	return res
 }
+
+type MapOfTemplateFunc map[string]func() (TemplateFunc, error)
+
+func (motf MapOfTemplateFunc) Has(templateFunc string) bool {
+	_, res := motf[templateFunc]
+	return res
+}
+
+func (motf MapOfTemplateFunc) Start(templateFunc string) (TemplateFunc, error) {
+	t, found := motf[templateFunc]
+	if !found {
+		return nil, fmt.Errorf("Unknown template function %s", templateFunc)
+	}
+	return t()
+}
+
+func (motf MapOfTemplateFunc) List() []string {
+	res := []string{}
+	for k := range motf {
+		res = append(res, k)
+	}
+	return res
+}
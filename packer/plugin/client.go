@@ -172,6 +172,17 @@ func (c *Client) Provisioner() (packer.Provisioner, error) {
 	return &cmdProvisioner{client.Provisioner(), c}, nil
 }
 
+// Returns a template function implementation that is communicating over
+// this client. If the client hasn't been started, this will start it.
+func (c *Client) TemplateFunc() (packer.TemplateFunc, error) {
+	client, err := c.packrpcClient()
+	if err != nil {
+		return nil, err
+	}
+
+	return &cmdTemplateFunc{client.TemplateFunc(), c}, nil
+}
+
 // End the executing subprocess (if it is running) and perform any cleanup
 // tasks necessary such as capturing any remaining logs and so on.
 //
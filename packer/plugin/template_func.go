@@ -0,0 +1,29 @@
+package plugin
+
+import (
+	"log"
+
+	"github.com/hashicorp/packer/packer"
+)
+
+type cmdTemplateFunc struct {
+	f      packer.TemplateFunc
+	client *Client
+}
+
+func (c *cmdTemplateFunc) Eval(args ...string) (string, error) {
+	defer func() {
+		r := recover()
+		c.checkExit(r, nil)
+	}()
+
+	return c.f.Eval(args...)
+}
+
+func (c *cmdTemplateFunc) checkExit(p interface{}, cb func()) {
+	if c.client.Exited() && cb != nil {
+		cb()
+	} else if p != nil && !Killed {
+		log.Panic(p)
+	}
+}
@@ -40,6 +40,11 @@ type ProvisionHook struct {
 	// The provisioners to run as part of the hook. These should already
 	// be prepared (by calling Prepare) at some earlier stage.
 	Provisioners []*HookedProvisioner
+
+	// When Quiet is set, the output of each provisioner is suppressed from
+	// the UI (it is still written to the Packer log) and replaced with a
+	// per-provisioner summary of elapsed time and suppressed output size.
+	Quiet bool
 }
 
 // Provisioners interpolate most of their fields in the prepare stage; this
@@ -127,7 +132,22 @@ func (h *ProvisionHook) Run(ctx context.Context, name string, ui Ui, comm Commun
 		ts := CheckpointReporter.AddSpan(p.TypeName, "provisioner", p.Config)
 
 		cast := CastDataToMap(data)
-		err := p.Provisioner.Provision(ctx, ui, comm, cast)
+
+		pUi := ui
+		var quietUi *QuietUi
+		if h.Quiet {
+			ui.Say(fmt.Sprintf("Running the %s provisioner (output suppressed; see the packer log)", p.TypeName))
+			quietUi = &QuietUi{Ui: ui}
+			pUi = quietUi
+		}
+
+		start := time.Now()
+		err := p.Provisioner.Provision(ctx, pUi, comm, cast)
+
+		if quietUi != nil {
+			ui.Say(fmt.Sprintf("The %s provisioner finished after %s; %d bytes of output were suppressed",
+				p.TypeName, time.Since(start).Round(time.Second), quietUi.SuppressedBytes()))
+		}
 
 		ts.End(err)
 		if err != nil {
@@ -164,6 +184,10 @@ func (p *PausedProvisioner) Provision(ctx context.Context, ui Ui, comm Communica
 	return p.Provisioner.Provision(ctx, ui, comm, generatedData)
 }
 
+// retryBackoff is the time to wait between provisioner retries. It is a
+// variable so tests can shorten it.
+var retryBackoff = 2 * time.Second
+
 // RetriedProvisioner is a Provisioner implementation that retries
 // the provisioner whenever there's an error.
 type RetriedProvisioner struct {
@@ -195,6 +219,14 @@ func (r *RetriedProvisioner) Provision(ctx context.Context, ui Ui, comm Communic
 
 		ui.Say(fmt.Sprintf("Provisioner failed with %q, retrying with %d trie(s) left", err, leftTries))
 
+		// Wait a bit before retrying, to give transient failures (busy
+		// mirrors, flaky package sources, ...) a chance to clear.
+		select {
+		case <-time.After(retryBackoff):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+
 		err := r.Provisioner.Provision(ctx, ui, comm, generatedData)
 		if err == nil {
 			return nil
@@ -254,6 +254,8 @@ func TestRetriedProvisionerPrepare(t *testing.T) {
 }
 
 func TestRetriedProvisionerProvision(t *testing.T) {
+	retryBackoff = 10 * time.Millisecond
+
 	mock := &MockProvisioner{
 		ProvFunc: func(ctx context.Context) error {
 			return errors.New("failed")
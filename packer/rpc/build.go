@@ -89,6 +89,12 @@ func (b *build) SetDebug(val bool) {
 	}
 }
 
+func (b *build) SetDryRun(val bool) {
+	if err := b.client.Call("Build.SetDryRun", val, new(interface{})); err != nil {
+		panic(err)
+	}
+}
+
 func (b *build) SetForce(val bool) {
 	if err := b.client.Call("Build.SetForce", val, new(interface{})); err != nil {
 		panic(err)
@@ -101,6 +107,30 @@ func (b *build) SetOnError(val string) {
 	}
 }
 
+func (b *build) SetSecretScan(val bool) {
+	if err := b.client.Call("Build.SetSecretScan", val, new(interface{})); err != nil {
+		panic(err)
+	}
+}
+
+func (b *build) SetQuietProvisioners(val bool) {
+	if err := b.client.Call("Build.SetQuietProvisioners", val, new(interface{})); err != nil {
+		panic(err)
+	}
+}
+
+func (b *build) SetTestScript(val string) {
+	if err := b.client.Call("Build.SetTestScript", val, new(interface{})); err != nil {
+		panic(err)
+	}
+}
+
+func (b *build) SetUiTargetWidth(val int) {
+	if err := b.client.Call("Build.SetUiTargetWidth", val, new(interface{})); err != nil {
+		panic(err)
+	}
+}
+
 func (b *build) Cancel() {
 	if err := b.client.Call("Build.Cancel", new(interface{}), new(interface{})); err != nil {
 		panic(err)
@@ -155,16 +185,41 @@ func (b *BuildServer) SetDebug(val *bool, reply *interface{}) error {
 	return nil
 }
 
+func (b *BuildServer) SetDryRun(val *bool, reply *interface{}) error {
+	b.build.SetDryRun(*val)
+	return nil
+}
+
 func (b *BuildServer) SetForce(val *bool, reply *interface{}) error {
 	b.build.SetForce(*val)
 	return nil
 }
 
+func (b *BuildServer) SetSecretScan(val *bool, reply *interface{}) error {
+	b.build.SetSecretScan(*val)
+	return nil
+}
+
+func (b *BuildServer) SetQuietProvisioners(val *bool, reply *interface{}) error {
+	b.build.SetQuietProvisioners(*val)
+	return nil
+}
+
 func (b *BuildServer) SetOnError(val *string, reply *interface{}) error {
 	b.build.SetOnError(*val)
 	return nil
 }
 
+func (b *BuildServer) SetTestScript(val *string, reply *interface{}) error {
+	b.build.SetTestScript(*val)
+	return nil
+}
+
+func (b *BuildServer) SetUiTargetWidth(val *int, reply *interface{}) error {
+	b.build.SetUiTargetWidth(*val)
+	return nil
+}
+
 func (b *BuildServer) Cancel(args *interface{}, reply *interface{}) error {
 	if b.contextCancel != nil {
 		b.contextCancel()
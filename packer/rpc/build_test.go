@@ -12,15 +12,20 @@ import (
 var testBuildArtifact = &packer.MockArtifact{}
 
 type testBuild struct {
-	nameCalled       bool
-	prepareCalled    bool
-	prepareWarnings  []string
-	runFn            func(context.Context)
-	runCalled        bool
-	runUi            packer.Ui
-	setDebugCalled   bool
-	setForceCalled   bool
-	setOnErrorCalled bool
+	nameCalled          bool
+	prepareCalled       bool
+	prepareWarnings     []string
+	runFn               func(context.Context)
+	runCalled           bool
+	runUi               packer.Ui
+	setDebugCalled      bool
+	setDryRunCalled     bool
+	setForceCalled      bool
+	setOnErrorCalled    bool
+	setSecretScanCalled bool
+	setQuietCalled      bool
+	setTestScriptCalled bool
+	setUiWidthCalled    bool
 
 	errRunResult bool
 }
@@ -54,6 +59,10 @@ func (b *testBuild) SetDebug(bool) {
 	b.setDebugCalled = true
 }
 
+func (b *testBuild) SetDryRun(bool) {
+	b.setDryRunCalled = true
+}
+
 func (b *testBuild) SetForce(bool) {
 	b.setForceCalled = true
 }
@@ -62,6 +71,22 @@ func (b *testBuild) SetOnError(string) {
 	b.setOnErrorCalled = true
 }
 
+func (b *testBuild) SetSecretScan(bool) {
+	b.setSecretScanCalled = true
+}
+
+func (b *testBuild) SetQuietProvisioners(bool) {
+	b.setQuietCalled = true
+}
+
+func (b *testBuild) SetTestScript(string) {
+	b.setTestScriptCalled = true
+}
+
+func (b *testBuild) SetUiTargetWidth(int) {
+	b.setUiWidthCalled = true
+}
+
 func TestBuild(t *testing.T) {
 	b := new(testBuild)
 	client, server := testClientServer(t)
@@ -127,6 +152,24 @@ func TestBuild(t *testing.T) {
 	if !b.setOnErrorCalled {
 		t.Fatal("should be called")
 	}
+
+	// Test SetSecretScan
+	bClient.SetSecretScan(true)
+	if !b.setSecretScanCalled {
+		t.Fatal("should be called")
+	}
+
+	// Test SetQuietProvisioners
+	bClient.SetQuietProvisioners(true)
+	if !b.setQuietCalled {
+		t.Fatal("should be called")
+	}
+
+	// Test SetUiTargetWidth
+	bClient.SetUiTargetWidth(10)
+	if !b.setUiWidthCalled {
+		t.Fatal("should be called")
+	}
 }
 
 func TestBuild_cancel(t *testing.T) {
@@ -136,6 +136,16 @@ func (c *Client) Provisioner() packer.Provisioner {
 	}
 }
 
+func (c *Client) TemplateFunc() packer.TemplateFunc {
+	return &templateFunc{
+		commonClient: commonClient{
+			endpoint: DefaultTemplateFuncEndpoint,
+			client:   c.client,
+			mux:      c.mux,
+		},
+	}
+}
+
 func (c *Client) Ui() packer.Ui {
 	return &Ui{
 		commonClient: commonClient{
@@ -19,6 +19,7 @@ const (
 	DefaultHookEndpoint                 = "Hook"
 	DefaultPostProcessorEndpoint        = "PostProcessor"
 	DefaultProvisionerEndpoint          = "Provisioner"
+	DefaultTemplateFuncEndpoint         = "TemplateFunc"
 	DefaultUiEndpoint                   = "Ui"
 )
 
@@ -120,6 +121,12 @@ func (s *Server) RegisterProvisioner(p packer.Provisioner) error {
 	})
 }
 
+func (s *Server) RegisterTemplateFunc(f packer.TemplateFunc) error {
+	return s.server.RegisterName(DefaultTemplateFuncEndpoint, &TemplateFuncServer{
+		f: f,
+	})
+}
+
 func (s *Server) RegisterUi(ui packer.Ui) error {
 	return s.server.RegisterName(DefaultUiEndpoint, &UiServer{
 		ui:       ui,
@@ -0,0 +1,49 @@
+package rpc
+
+import (
+	"github.com/hashicorp/packer/packer"
+)
+
+// An implementation of packer.TemplateFunc where the function is actually
+// evaluated over an RPC connection.
+type templateFunc struct {
+	commonClient
+}
+
+// TemplateFuncServer wraps a packer.TemplateFunc implementation and makes
+// it exportable as part of a Golang RPC server.
+type TemplateFuncServer struct {
+	f packer.TemplateFunc
+}
+
+type TemplateFuncEvalArgs struct {
+	Args []string
+}
+
+type TemplateFuncEvalResponse struct {
+	Err    *BasicError
+	Result string
+}
+
+func (t *templateFunc) Eval(args ...string) (string, error) {
+	var response TemplateFuncEvalResponse
+	if err := t.client.Call(t.endpoint+".Eval", &TemplateFuncEvalArgs{Args: args}, &response); err != nil {
+		return "", err
+	}
+
+	if response.Err != nil {
+		return "", response.Err
+	}
+
+	return response.Result, nil
+}
+
+func (s *TemplateFuncServer) Eval(args *TemplateFuncEvalArgs, reply *TemplateFuncEvalResponse) error {
+	result, err := s.f.Eval(args.Args...)
+	*reply = TemplateFuncEvalResponse{
+		Err:    NewBasicError(err),
+		Result: result,
+	}
+
+	return nil
+}
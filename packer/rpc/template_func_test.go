@@ -0,0 +1,49 @@
+package rpc
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/packer/packer"
+)
+
+type testTemplateFunc struct {
+	evalArgs []string
+	evalErr  error
+}
+
+func (f *testTemplateFunc) Eval(args ...string) (string, error) {
+	f.evalArgs = args
+	return strings.Join(args, "-"), f.evalErr
+}
+
+func TestTemplateFunc_Implements(t *testing.T) {
+	var _ packer.TemplateFunc = new(templateFunc)
+}
+
+func TestTemplateFunc_Eval(t *testing.T) {
+	f := new(testTemplateFunc)
+
+	client, server := testClientServer(t)
+	defer client.Close()
+	defer server.Close()
+	server.RegisterTemplateFunc(f)
+	fClient := client.TemplateFunc()
+
+	result, err := fClient.Eval("foo", "bar")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if result != "foo-bar" {
+		t.Fatalf("bad result: %s", result)
+	}
+	if len(f.evalArgs) != 2 {
+		t.Fatalf("bad args: %#v", f.evalArgs)
+	}
+
+	f.evalErr = errors.New("eval error")
+	if _, err := fClient.Eval("foo"); err == nil || err.Error() != "eval error" {
+		t.Fatalf("bad err: %s", err)
+	}
+}
@@ -0,0 +1,133 @@
+package packer
+
+import (
+	"bufio"
+	"fmt"
+	"log"
+	"os"
+	"regexp"
+	"sync"
+)
+
+// A SecretFinding is a single match of a known credential pattern, either
+// in build output or in a produced artifact file.
+type SecretFinding struct {
+	// Where the match was found: "build output" or an artifact file path.
+	Source string
+	// Which pattern matched, e.g. "AWS access key ID".
+	Pattern string
+	// The 1-indexed line the match was found on.
+	Line int
+}
+
+func (f SecretFinding) String() string {
+	return fmt.Sprintf("%s: line %d: possible %s", f.Source, f.Line, f.Pattern)
+}
+
+// secretScanPatterns are the credential shapes the scanner looks for. The
+// patterns are deliberately conservative: every match fails the build, so
+// a false positive is more costly than the occasional miss.
+var secretScanPatterns = []struct {
+	name string
+	re   *regexp.Regexp
+}{
+	{"AWS access key ID", regexp.MustCompile(`\b(?:A3T[A-Z0-9]|AKIA|ASIA|AGPA|AIDA|AROA|ANPA)[A-Z0-9]{16}\b`)},
+	{"AWS secret access key", regexp.MustCompile(`(?i)aws_?secret_?access_?key\W{0,4}[A-Za-z0-9/+=]{40}`)},
+	{"private key", regexp.MustCompile(`-----BEGIN (?:[A-Z0-9]+ )?PRIVATE KEY(?: BLOCK)?-----`)},
+}
+
+// SecretScanner matches text against secretScanPatterns and collects the
+// findings. It is safe for concurrent use.
+type SecretScanner struct {
+	mu       sync.Mutex
+	findings []SecretFinding
+}
+
+// ScanLine checks a single line of text, recording a finding per matched
+// pattern. source says where the line came from and line is its 1-indexed
+// position there.
+func (s *SecretScanner) ScanLine(source string, line int, text string) {
+	for _, pattern := range secretScanPatterns {
+		if !pattern.re.MatchString(text) {
+			continue
+		}
+		s.mu.Lock()
+		s.findings = append(s.findings, SecretFinding{
+			Source:  source,
+			Pattern: pattern.name,
+			Line:    line,
+		})
+		s.mu.Unlock()
+	}
+}
+
+// ScanFile checks a file on disk line by line.
+func (s *SecretScanner) ScanFile(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	line := 0
+	for scanner.Scan() {
+		line++
+		s.ScanLine(path, line, scanner.Text())
+	}
+	if err := scanner.Err(); err != nil {
+		if err == bufio.ErrTooLong {
+			// Binary artifacts can contain arbitrarily long "lines"; scan
+			// what we could rather than failing the build on them.
+			log.Printf("Secret scan of %s stopped at line %d: %s", path, line, err)
+			return nil
+		}
+		return err
+	}
+	return nil
+}
+
+// Findings returns every finding recorded so far.
+func (s *SecretScanner) Findings() []SecretFinding {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	findings := make([]SecretFinding, len(s.findings))
+	copy(findings, s.findings)
+	return findings
+}
+
+// SecretScanUi is a Ui decorator that feeds everything said through a
+// SecretScanner, so that builder and provisioner output can be checked
+// for leaked credentials.
+type SecretScanUi struct {
+	Ui
+	Scanner *SecretScanner
+	Source  string
+
+	l    sync.Mutex
+	line int
+}
+
+func (u *SecretScanUi) scan(message string) {
+	u.l.Lock()
+	u.line++
+	line := u.line
+	u.l.Unlock()
+	u.Scanner.ScanLine(u.Source, line, message)
+}
+
+func (u *SecretScanUi) Say(message string) {
+	u.scan(message)
+	u.Ui.Say(message)
+}
+
+func (u *SecretScanUi) Message(message string) {
+	u.scan(message)
+	u.Ui.Message(message)
+}
+
+func (u *SecretScanUi) Error(message string) {
+	u.scan(message)
+	u.Ui.Error(message)
+}
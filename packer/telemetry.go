@@ -25,30 +25,35 @@ type PackerReport struct {
 }
 
 type CheckpointTelemetry struct {
+	// enabled gates reporting to checkpoint. Spans are collected either
+	// way, so `packer build -report` can expose component timings without
+	// anything being sent anywhere.
+	enabled       bool
 	spans         []*TelemetrySpan
 	signatureFile string
 	startTime     time.Time
 }
 
-func NewCheckpointReporter(disableSignature bool) *CheckpointTelemetry {
+func NewCheckpointReporter(disableCheckpoint bool, disableSignature bool) *CheckpointTelemetry {
 	if disabled := os.Getenv("CHECKPOINT_DISABLE"); disabled != "" {
-		return nil
-	}
-
-	configDir, err := ConfigDir()
-	if err != nil {
-		log.Printf("[WARN] (telemetry) setup error: %s", err)
-		return nil
+		disableCheckpoint = true
 	}
 
 	signatureFile := ""
-	if disableSignature {
-		log.Printf("[INFO] (telemetry) Checkpoint signature disabled")
-	} else {
-		signatureFile = filepath.Join(configDir, "checkpoint_signature")
+	if !disableCheckpoint {
+		configDir, err := ConfigDir()
+		if err != nil {
+			log.Printf("[WARN] (telemetry) setup error: %s", err)
+			disableCheckpoint = true
+		} else if disableSignature {
+			log.Printf("[INFO] (telemetry) Checkpoint signature disabled")
+		} else {
+			signatureFile = filepath.Join(configDir, "checkpoint_signature")
+		}
 	}
 
 	return &CheckpointTelemetry{
+		enabled:       !disableCheckpoint,
 		signatureFile: signatureFile,
 		startTime:     time.Now().UTC(),
 	}
@@ -71,7 +76,7 @@ func (c *CheckpointTelemetry) baseParams(prefix string) *checkpoint.ReportParams
 }
 
 func (c *CheckpointTelemetry) ReportPanic(m string) error {
-	if c == nil {
+	if c == nil || !c.enabled {
 		return nil
 	}
 	panicParams := c.baseParams(TelemetryPanicVersion)
@@ -103,7 +108,7 @@ func (c *CheckpointTelemetry) AddSpan(name, pluginType string, options interface
 }
 
 func (c *CheckpointTelemetry) Finalize(command string, errCode int, err error) error {
-	if c == nil {
+	if c == nil || !c.enabled {
 		return nil
 	}
 
@@ -129,6 +134,14 @@ func (c *CheckpointTelemetry) Finalize(command string, errCode int, err error) e
 	return checkpoint.Report(ctx, params)
 }
 
+// Spans returns the spans collected so far.
+func (c *CheckpointTelemetry) Spans() []*TelemetrySpan {
+	if c == nil {
+		return nil
+	}
+	return c.spans
+}
+
 type TelemetrySpan struct {
 	EndTime   time.Time `json:"end_time"`
 	Error     string    `json:"error"`
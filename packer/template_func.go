@@ -0,0 +1,13 @@
+package packer
+
+// A TemplateFunc is a custom template function exposed to interpolation
+// in templates, so organizations can add things like internal inventory
+// or IPAM lookups without forking Packer. Implementations are typically
+// served over RPC from a plugin binary named packer-function-NAME, which
+// makes the function available in templates as {{NAME ...}}.
+type TemplateFunc interface {
+	// Eval is called once for every use of the function in a template.
+	// The arguments and the result are strings, which is what templates
+	// can express. Returning an error fails the interpolation.
+	Eval(args ...string) (string, error)
+}
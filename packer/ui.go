@@ -132,6 +132,11 @@ type TargetedUI struct {
 	Target string
 	Ui     Ui
 	*uiProgressBar
+
+	// TargetWidth, when larger than the length of Target, pads the
+	// target name to that width so the output of several targets lines
+	// up in columns. Zero leaves the prefix unpadded.
+	TargetWidth int
 }
 
 var _ Ui = new(TargetedUI)
@@ -163,10 +168,15 @@ func (u *TargetedUI) prefixLines(arrow bool, message string) string {
 		arrowText = strings.Repeat(" ", len(arrowText))
 	}
 
+	target := u.Target
+	if pad := u.TargetWidth - len(target); pad > 0 {
+		target = target + strings.Repeat(" ", pad)
+	}
+
 	var result bytes.Buffer
 
 	for _, line := range strings.Split(message, "\n") {
-		result.WriteString(fmt.Sprintf("%s %s: %s\n", arrowText, u.Target, line))
+		result.WriteString(fmt.Sprintf("%s %s: %s\n", arrowText, target, line))
 	}
 
 	return strings.TrimRightFunc(result.String(), unicode.IsSpace)
@@ -433,3 +443,157 @@ func (u *SafeUi) Machine(t string, args ...string) {
 	u.Ui.Machine(t, args...)
 	<-u.Sem
 }
+
+// QuietUi is a UI that wraps another UI implementation and suppresses
+// informational output (Say and Message), keeping errors and machine
+// readable output visible. Suppressed lines are copied to the standard
+// logger so they still end up in the Packer log when one is enabled, and
+// the amount of suppressed output is tracked so that callers can print a
+// short summary instead.
+type QuietUi struct {
+	Ui Ui
+	*uiProgressBar
+
+	suppressedLock  sync.Mutex
+	suppressedBytes int64
+}
+
+var _ Ui = new(QuietUi)
+
+func (u *QuietUi) Ask(query string) (string, error) {
+	return u.Ui.Ask(query)
+}
+
+func (u *QuietUi) Say(message string) {
+	u.suppress(message)
+}
+
+func (u *QuietUi) Message(message string) {
+	u.suppress(message)
+}
+
+func (u *QuietUi) Error(message string) {
+	u.Ui.Error(message)
+}
+
+func (u *QuietUi) Machine(t string, args ...string) {
+	u.Ui.Machine(t, args...)
+}
+
+func (u *QuietUi) suppress(message string) {
+	u.suppressedLock.Lock()
+	u.suppressedBytes += int64(len(message)) + 1
+	u.suppressedLock.Unlock()
+	log.Printf("(suppressed): %s", message)
+}
+
+// SuppressedBytes returns how many bytes of output have been suppressed
+// so far.
+func (u *QuietUi) SuppressedBytes() int64 {
+	u.suppressedLock.Lock()
+	defer u.suppressedLock.Unlock()
+	return u.suppressedBytes
+}
+
+// BuildLogUi is a UI that wraps another UI implementation and copies
+// everything said through it to a log writer, prefixing each line with an
+// RFC3339 timestamp. Sensitive variables are scrubbed before writing, the
+// same way they are scrubbed from the Packer log. Output on the wrapped UI
+// is unchanged, so this can be used to tee per-build logs into files while
+// the console keeps its usual formatting.
+type BuildLogUi struct {
+	Ui     Ui
+	Writer io.Writer
+	*uiProgressBar
+
+	logLock sync.Mutex
+}
+
+var _ Ui = new(BuildLogUi)
+
+func (u *BuildLogUi) Ask(query string) (string, error) {
+	u.log(query)
+	return u.Ui.Ask(query)
+}
+
+func (u *BuildLogUi) Say(message string) {
+	u.log(message)
+	u.Ui.Say(message)
+}
+
+func (u *BuildLogUi) Message(message string) {
+	u.log(message)
+	u.Ui.Message(message)
+}
+
+func (u *BuildLogUi) Error(message string) {
+	u.log(message)
+	u.Ui.Error(message)
+}
+
+func (u *BuildLogUi) Machine(t string, args ...string) {
+	u.Ui.Machine(t, args...)
+}
+
+func (u *BuildLogUi) log(message string) {
+	message = LogSecretFilter.FilterString(message)
+	u.logLock.Lock()
+	defer u.logLock.Unlock()
+	fmt.Fprintf(u.Writer, "%s: %s\n", time.Now().Format(time.RFC3339), message)
+}
+
+// GroupedUi is a UI that wraps another UI implementation and buffers
+// everything said through it instead of writing it out right away, so
+// that the output of parallel builds can be printed contiguously per
+// build rather than interleaved. Nothing reaches the wrapped UI until
+// Flush is called. Ask flushes first, so a prompt is never shown without
+// the output that led up to it.
+type GroupedUi struct {
+	Ui Ui
+	*uiProgressBar
+
+	bufLock sync.Mutex
+	buf     []func()
+}
+
+var _ Ui = new(GroupedUi)
+
+func (u *GroupedUi) Ask(query string) (string, error) {
+	u.Flush()
+	return u.Ui.Ask(query)
+}
+
+func (u *GroupedUi) Say(message string) {
+	u.buffer(func() { u.Ui.Say(message) })
+}
+
+func (u *GroupedUi) Message(message string) {
+	u.buffer(func() { u.Ui.Message(message) })
+}
+
+func (u *GroupedUi) Error(message string) {
+	u.buffer(func() { u.Ui.Error(message) })
+}
+
+func (u *GroupedUi) Machine(t string, args ...string) {
+	u.buffer(func() { u.Ui.Machine(t, args...) })
+}
+
+func (u *GroupedUi) buffer(output func()) {
+	u.bufLock.Lock()
+	defer u.bufLock.Unlock()
+	u.buf = append(u.buf, output)
+}
+
+// Flush writes everything buffered so far to the wrapped UI, in the
+// order it was said.
+func (u *GroupedUi) Flush() {
+	u.bufLock.Lock()
+	buffered := u.buf
+	u.buf = nil
+	u.bufLock.Unlock()
+
+	for _, output := range buffered {
+		output()
+	}
+}
@@ -144,6 +144,71 @@ func TestTargetedUI(t *testing.T) {
 	}
 }
 
+func TestTargetedUI_targetWidth(t *testing.T) {
+	bufferUi := testUi()
+	targetedUi := &TargetedUI{
+		Target:      "foo",
+		TargetWidth: 6,
+		Ui:          bufferUi,
+	}
+
+	var actual, expected string
+	targetedUi.Say("foo")
+	actual = readWriter(bufferUi)
+	expected = "==> foo   : foo\n"
+	if actual != expected {
+		t.Fatalf("bad: %#v", actual)
+	}
+
+	// A target longer than the width is left alone.
+	targetedUi.Target = "foobarbaz"
+	targetedUi.Say("foo")
+	actual = readWriter(bufferUi)
+	expected = "==> foobarbaz: foo\n"
+	if actual != expected {
+		t.Fatalf("bad: %#v", actual)
+	}
+}
+
+func TestGroupedUi(t *testing.T) {
+	bufferUi := testUi()
+	groupedUi := &GroupedUi{Ui: bufferUi}
+
+	groupedUi.Say("foo")
+	groupedUi.Message("bar")
+	groupedUi.Error("baz")
+
+	// Nothing reaches the wrapped UI before Flush.
+	if actual := readWriter(bufferUi); actual != "" {
+		t.Fatalf("bad: %#v", actual)
+	}
+	if actual := readErrorWriter(bufferUi); actual != "" {
+		t.Fatalf("bad: %#v", actual)
+	}
+
+	groupedUi.Flush()
+	if actual := readWriter(bufferUi); actual != "foo\nbar\n" {
+		t.Fatalf("bad: %#v", actual)
+	}
+	if actual := readErrorWriter(bufferUi); actual != "baz\n" {
+		t.Fatalf("bad: %#v", actual)
+	}
+
+	// Flushing again replays nothing.
+	groupedUi.Flush()
+	if actual := readWriter(bufferUi); actual != "" {
+		t.Fatalf("bad: %#v", actual)
+	}
+}
+
+func TestGroupedUi_ImplUi(t *testing.T) {
+	var raw interface{}
+	raw = &GroupedUi{}
+	if _, ok := raw.(Ui); !ok {
+		t.Fatalf("GroupedUi must implement Ui")
+	}
+}
+
 func TestColoredUi_ImplUi(t *testing.T) {
 	var raw interface{}
 	raw = &ColoredUi{}
@@ -22,6 +22,8 @@ type FlatConfig struct {
 	DecodeAuthZMessages   *bool                             `mapstructure:"decode_authorization_messages" required:"false" cty:"decode_authorization_messages"`
 	InsecureSkipTLSVerify *bool                             `mapstructure:"insecure_skip_tls_verify" required:"false" cty:"insecure_skip_tls_verify"`
 	MaxRetries            *int                              `mapstructure:"max_retries" required:"false" cty:"max_retries"`
+	RetryMode             *string                           `mapstructure:"retry_mode" required:"false" cty:"retry_mode"`
+	APITimeout            *string                           `mapstructure:"api_timeout" required:"false" cty:"api_timeout"`
 	MFACode               *string                           `mapstructure:"mfa_code" required:"false" cty:"mfa_code"`
 	ProfileName           *string                           `mapstructure:"profile" required:"false" cty:"profile"`
 	RawRegion             *string                           `mapstructure:"region" required:"true" cty:"region"`
@@ -71,6 +73,8 @@ func (*FlatConfig) HCL2Spec() map[string]hcldec.Spec {
 		"decode_authorization_messages": &hcldec.AttrSpec{Name: "decode_authorization_messages", Type: cty.Bool, Required: false},
 		"insecure_skip_tls_verify":      &hcldec.AttrSpec{Name: "insecure_skip_tls_verify", Type: cty.Bool, Required: false},
 		"max_retries":                   &hcldec.AttrSpec{Name: "max_retries", Type: cty.Number, Required: false},
+		"retry_mode":                    &hcldec.AttrSpec{Name: "retry_mode", Type: cty.String, Required: false},
+		"api_timeout":                   &hcldec.AttrSpec{Name: "api_timeout", Type: cty.String, Required: false},
 		"mfa_code":                      &hcldec.AttrSpec{Name: "mfa_code", Type: cty.String, Required: false},
 		"profile":                       &hcldec.AttrSpec{Name: "profile", Type: cty.String, Required: false},
 		"region":                        &hcldec.AttrSpec{Name: "region", Type: cty.String, Required: false},
@@ -8,6 +8,7 @@ import (
 	"crypto/sha1"
 	"crypto/sha256"
 	"crypto/sha512"
+	"encoding/json"
 	"fmt"
 	"hash"
 	"io"
@@ -26,8 +27,15 @@ type Config struct {
 
 	Keep          bool     `mapstructure:"keep_input_artifact"`
 	ChecksumTypes []string `mapstructure:"checksum_types"`
-	OutputPath    string   `mapstructure:"output"`
-	ctx           interpolate.Context
+	// The layout of each checksum line. "tsv" (the default) writes the
+	// historical "<checksum>\t<file>" pair. "sums" writes the coreutils
+	// "<checksum>  <file>" layout, so an output named SHA256SUMS can be
+	// verified with sha256sum -c. "json" writes one JSON object per line
+	// with the algorithm, checksum and file name, which downstream
+	// signing tools such as cosign can consume as a payload.
+	Format     string `mapstructure:"format"`
+	OutputPath string `mapstructure:"output"`
+	ctx        interpolate.Context
 }
 
 type PostProcessor struct {
@@ -79,6 +87,16 @@ func (p *PostProcessor) Configure(raws ...interface{}) error {
 		}
 	}
 
+	if p.config.Format == "" {
+		p.config.Format = "tsv"
+	}
+	switch p.config.Format {
+	case "tsv", "sums", "json":
+	default:
+		errs = packer.MultiErrorAppend(errs,
+			fmt.Errorf("Unrecognized format: %s", p.config.Format))
+	}
+
 	if p.config.OutputPath == "" {
 		p.config.OutputPath = "packer_{{.BuildName}}_{{.BuilderType}}_{{.ChecksumType}}.checksum"
 	}
@@ -121,6 +139,13 @@ func (p *PostProcessor) PostProcess(ctx context.Context, ui packer.Ui, artifact
 		p.config.ctx.Data = generatedData
 
 		for _, art := range files {
+			// Expose the artifact file being checksummed so the output
+			// path can be templated per artifact, e.g. for sidecar
+			// files next to each output.
+			generatedData["ArtifactPath"] = art
+			generatedData["ArtifactFile"] = filepath.Base(art)
+			p.config.ctx.Data = generatedData
+
 			checksumFile, err := interpolate.Render(p.config.OutputPath, &p.config.ctx)
 			if err != nil {
 				return nil, false, true, err
@@ -148,7 +173,7 @@ func (p *PostProcessor) PostProcess(ctx context.Context, ui packer.Ui, artifact
 				return nil, false, true, fmt.Errorf("unable to compute %s hash for %s", ct, art)
 			}
 			fr.Close()
-			fw.WriteString(fmt.Sprintf("%x\t%s\n", h.Sum(nil), filepath.Base(art)))
+			fw.WriteString(checksumLine(p.config.Format, ct, fmt.Sprintf("%x", h.Sum(nil)), filepath.Base(art)))
 			fw.Close()
 			h.Reset()
 		}
@@ -158,3 +183,19 @@ func (p *PostProcessor) PostProcess(ctx context.Context, ui packer.Ui, artifact
 	// delete the very artifact we're checksumming.
 	return newartifact, true, true, nil
 }
+
+func checksumLine(format, checksumType, checksum, file string) string {
+	switch format {
+	case "sums":
+		return fmt.Sprintf("%s  %s\n", checksum, file)
+	case "json":
+		line, _ := json.Marshal(map[string]string{
+			"algorithm": checksumType,
+			"checksum":  checksum,
+			"file":      file,
+		})
+		return string(line) + "\n"
+	default:
+		return fmt.Sprintf("%s\t%s\n", checksum, file)
+	}
+}
@@ -3,6 +3,7 @@ package checksum
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"os"
@@ -42,6 +43,91 @@ func TestChecksumSHA1(t *testing.T) {
 	defer f.Close()
 }
 
+func TestChecksumSumsFormat(t *testing.T) {
+	const config = `
+	{
+	    "post-processors": [
+	        {
+	            "type": "checksum",
+	            "checksum_types": ["sha256"],
+	            "format": "sums",
+	            "output": "SHA256SUMS"
+	        }
+	    ]
+	}
+	`
+	artifact := testChecksum(t, config)
+	defer artifact.Destroy()
+
+	f, err := os.Open("SHA256SUMS")
+	if err != nil {
+		t.Errorf("Unable to read checksum file: %s", err)
+	}
+	expected := "c0535e4be2b79ffd93291305436bf889314e4a3faec05ecffcbb7df31ad9e51a  package.txt\n"
+	if buf, _ := ioutil.ReadAll(f); !bytes.Equal(buf, []byte(expected)) {
+		t.Errorf("Failed to compute checksum: %s\n%s", buf, expected)
+	}
+
+	defer f.Close()
+}
+
+func TestChecksumJSONFormat(t *testing.T) {
+	const config = `
+	{
+	    "post-processors": [
+	        {
+	            "type": "checksum",
+	            "checksum_types": ["sha256"],
+	            "format": "json",
+	            "output": "checksums.json"
+	        }
+	    ]
+	}
+	`
+	artifact := testChecksum(t, config)
+	defer artifact.Destroy()
+
+	f, err := os.Open("checksums.json")
+	if err != nil {
+		t.Errorf("Unable to read checksum file: %s", err)
+	}
+	defer f.Close()
+
+	var entry map[string]string
+	if err := json.NewDecoder(f).Decode(&entry); err != nil {
+		t.Fatalf("Unable to decode checksum file: %s", err)
+	}
+	if entry["algorithm"] != "sha256" {
+		t.Errorf("Expected sha256 algorithm, got %s", entry["algorithm"])
+	}
+	if entry["file"] != "package.txt" {
+		t.Errorf("Expected package.txt file, got %s", entry["file"])
+	}
+	if entry["checksum"] != "c0535e4be2b79ffd93291305436bf889314e4a3faec05ecffcbb7df31ad9e51a" {
+		t.Errorf("Failed to compute checksum: %s", entry["checksum"])
+	}
+}
+
+func TestChecksumPerArtifactOutput(t *testing.T) {
+	const config = `
+	{
+	    "post-processors": [
+	        {
+	            "type": "checksum",
+	            "checksum_types": ["sha256"],
+	            "output": "{{.ArtifactFile}}.{{.ChecksumType}}"
+	        }
+	    ]
+	}
+	`
+	artifact := testChecksum(t, config)
+	defer artifact.Destroy()
+
+	if _, err := os.Stat("package.txt.sha256"); err != nil {
+		t.Errorf("Expected sidecar checksum file next to artifact: %s", err)
+	}
+}
+
 // Test Helpers
 
 func setup(t *testing.T) (packer.Ui, packer.Artifact, error) {
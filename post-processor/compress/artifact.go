@@ -9,6 +9,10 @@ const BuilderId = "packer.post-processor.compress"
 
 type Artifact struct {
 	Path string
+
+	// With split_size set the output is chunked; files then holds every
+	// chunk, in order.
+	files []string
 }
 
 func (a *Artifact) BuilderId() string {
@@ -20,6 +24,9 @@ func (*Artifact) Id() string {
 }
 
 func (a *Artifact) Files() []string {
+	if len(a.files) > 0 {
+		return a.files
+	}
 	return []string{a.Path}
 }
 
@@ -32,5 +39,14 @@ func (*Artifact) State(name string) interface{} {
 }
 
 func (a *Artifact) Destroy() error {
+	if len(a.files) > 0 {
+		var err error
+		for _, f := range a.files {
+			if removeErr := os.Remove(f); removeErr != nil {
+				err = removeErr
+			}
+		}
+		return err
+	}
 	return os.Remove(a.Path)
 }
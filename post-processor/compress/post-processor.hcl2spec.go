@@ -19,6 +19,7 @@ type FlatConfig struct {
 	OutputPath          *string           `mapstructure:"output" cty:"output"`
 	Format              *string           `mapstructure:"format" cty:"format"`
 	CompressionLevel    *int              `mapstructure:"compression_level" cty:"compression_level"`
+	SplitSize           *string           `mapstructure:"split_size" cty:"split_size"`
 	Archive             *string           `cty:"archive"`
 	Algorithm           *string           `cty:"algorithm"`
 }
@@ -45,6 +46,7 @@ func (*FlatConfig) HCL2Spec() map[string]hcldec.Spec {
 		"output":                     &hcldec.AttrSpec{Name: "output", Type: cty.String, Required: false},
 		"format":                     &hcldec.AttrSpec{Name: "format", Type: cty.String, Required: false},
 		"compression_level":          &hcldec.AttrSpec{Name: "compression_level", Type: cty.Number, Required: false},
+		"split_size":                 &hcldec.AttrSpec{Name: "split_size", Type: cty.String, Required: false},
 		"archive":                    &hcldec.AttrSpec{Name: "archive", Type: cty.String, Required: false},
 		"algorithm":                  &hcldec.AttrSpec{Name: "algorithm", Type: cty.String, Required: false},
 	}
@@ -1,6 +1,7 @@
 package compress
 
 import (
+	"bytes"
 	"compress/gzip"
 	"context"
 	"fmt"
@@ -45,6 +46,16 @@ func TestDetectFilename(t *testing.T) {
 		t.Error("Expected to find lz4 algorithm setting")
 	}
 
+	// Test .zst
+	zstFilename := Config{OutputPath: "test.zst"}
+	zstFilename.detectFromFilename()
+	if zstFilename.Archive != "" {
+		t.Error("Expected to find empty archive setting")
+	}
+	if zstFilename.Algorithm != "zstd" {
+		t.Error("Expected to find zstd algorithm setting")
+	}
+
 	// Test .archive.compress with some.extra.dots...
 	lotsOfDots := Config{OutputPath: "test.blah.bloo.blee.tar.lz4"}
 	lotsOfDots.detectFromFilename()
@@ -151,6 +162,80 @@ func TestCompressOptions(t *testing.T) {
 	}
 }
 
+func TestParseSplitSize(t *testing.T) {
+	cases := map[string]int64{
+		"1048576": 1048576,
+		"10K":     10 * 1024,
+		"500M":    500 * 1024 * 1024,
+		"2G":      2 * 1024 * 1024 * 1024,
+		"2g":      2 * 1024 * 1024 * 1024,
+	}
+	for in, expected := range cases {
+		actual, err := parseSplitSize(in)
+		if err != nil {
+			t.Errorf("Unexpected error parsing %q: %s", in, err)
+		}
+		if actual != expected {
+			t.Errorf("Expected %q to parse to %d, got %d", in, expected, actual)
+		}
+	}
+
+	for _, in := range []string{"", "gigabyte", "-5M", "0"} {
+		if _, err := parseSplitSize(in); err == nil {
+			t.Errorf("Expected error parsing %q", in)
+		}
+	}
+}
+
+func TestSplitCompress(t *testing.T) {
+	const config = `
+	{
+	    "post-processors": [
+	        {
+	            "type": "compress",
+	            "output": "package.gz",
+	            "split_size": "5"
+	        }
+	    ]
+	}
+	`
+
+	artifact := testArchive(t, config)
+	defer artifact.Destroy()
+
+	files := artifact.Files()
+	if len(files) < 2 {
+		t.Fatalf("Expected multiple chunks, got %v", files)
+	}
+
+	// Reassembling the chunks must give back the original stream.
+	var buf bytes.Buffer
+	for i, f := range files {
+		expected := fmt.Sprintf("package.gz.part%03d", i)
+		if f != expected {
+			t.Errorf("Expected chunk %d to be named %s, got %s", i, expected, f)
+		}
+		data, err := ioutil.ReadFile(f)
+		if err != nil {
+			t.Fatalf("Unable to read chunk %s: %s", f, err)
+		}
+		if i < len(files)-1 && len(data) != 5 {
+			t.Errorf("Expected chunk %s to be 5 bytes, got %d", f, len(data))
+		}
+		buf.Write(data)
+	}
+
+	gzipReader, err := gzip.NewReader(&buf)
+	if err != nil {
+		t.Fatalf("Unable to read reassembled archive: %s", err)
+	}
+	data, _ := ioutil.ReadAll(gzipReader)
+
+	if string(data) != expectedFileContents {
+		t.Errorf("Expected:\n%s\nFound:\n%s\n", expectedFileContents, data)
+	}
+}
+
 func TestCompressInterpolation(t *testing.T) {
 	const config = `
 	{
@@ -48,6 +48,7 @@ type Config struct {
 	TemplateUsername        string `mapstructure:"template_username"`
 	TemplateDisablePassword bool   `mapstructure:"template_disable_password"`
 	TemplateDisableSSHKey   bool   `mapstructure:"template_disable_sshkey"`
+	TemplateSKSCompatible   bool   `mapstructure:"template_sks_compatible"`
 }
 
 func init() {
@@ -96,6 +97,22 @@ func (p *PostProcessor) Configure(raws ...interface{}) error {
 		}
 	}
 
+	if p.config.TemplateSKSCompatible {
+		// SKS node templates are bootstrapped via cloud-init and log in
+		// exclusively with SSH keys, so the template must have a default
+		// username and SSH key installation enabled, and must not rely on
+		// the password reset feature.
+		if p.config.TemplateUsername == "" {
+			errs = packer.MultiErrorAppend(errs, fmt.Errorf(
+				"template_username must be set when template_sks_compatible is enabled"))
+		}
+		if p.config.TemplateDisableSSHKey {
+			errs = packer.MultiErrorAppend(errs, fmt.Errorf(
+				"template_disable_sshkey conflicts with template_sks_compatible"))
+		}
+		p.config.TemplateDisablePassword = true
+	}
+
 	if len(errs.Errors) > 0 {
 		return errs
 	}
@@ -28,6 +28,7 @@ type FlatConfig struct {
 	TemplateUsername        *string           `mapstructure:"template_username" cty:"template_username"`
 	TemplateDisablePassword *bool             `mapstructure:"template_disable_password" cty:"template_disable_password"`
 	TemplateDisableSSHKey   *bool             `mapstructure:"template_disable_sshkey" cty:"template_disable_sshkey"`
+	TemplateSKSCompatible   *bool             `mapstructure:"template_sks_compatible" cty:"template_sks_compatible"`
 }
 
 // FlatMapstructure returns a new FlatConfig.
@@ -61,6 +62,7 @@ func (*FlatConfig) HCL2Spec() map[string]hcldec.Spec {
 		"template_username":          &hcldec.AttrSpec{Name: "template_username", Type: cty.String, Required: false},
 		"template_disable_password":  &hcldec.AttrSpec{Name: "template_disable_password", Type: cty.Bool, Required: false},
 		"template_disable_sshkey":    &hcldec.AttrSpec{Name: "template_disable_sshkey", Type: cty.Bool, Required: false},
+		"template_sks_compatible":    &hcldec.AttrSpec{Name: "template_sks_compatible", Type: cty.Bool, Required: false},
 	}
 	return s
 }
@@ -0,0 +1,44 @@
+package exoscalereplicate
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const BuilderId = "packer.post-processor.exoscale-replicate"
+
+type Artifact struct {
+	// ids maps each zone name to the ID of the template registered there.
+	ids map[string]string
+}
+
+func (a *Artifact) BuilderId() string {
+	return BuilderId
+}
+
+func (a *Artifact) Id() string {
+	parts := make([]string, 0, len(a.ids))
+	for zone, id := range a.ids {
+		parts = append(parts, fmt.Sprintf("%s:%s", zone, id))
+	}
+	sort.Strings(parts)
+
+	return strings.Join(parts, ",")
+}
+
+func (a *Artifact) Files() []string {
+	return nil
+}
+
+func (a *Artifact) String() string {
+	return fmt.Sprintf("Replicated template: %s", a.Id())
+}
+
+func (a *Artifact) State(name string) interface{} {
+	return nil
+}
+
+func (a *Artifact) Destroy() error {
+	return nil
+}
@@ -0,0 +1,237 @@
+//go:generate mapstructure-to-hcl2 -type Config
+
+package exoscalereplicate
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/exoscale/egoscale"
+	"github.com/hashicorp/hcl/v2/hcldec"
+	"github.com/hashicorp/packer/common"
+	"github.com/hashicorp/packer/helper/config"
+	"github.com/hashicorp/packer/packer"
+	exoscaleimport "github.com/hashicorp/packer/post-processor/exoscale-import"
+	"github.com/hashicorp/packer/version"
+)
+
+var (
+	defaultTemplateZone = "ch-gva-2"
+	defaultAPIEndpoint  = "https://api.exoscale.com/compute"
+)
+
+type Config struct {
+	common.PackerConfig `mapstructure:",squash"`
+
+	APIEndpoint   string   `mapstructure:"api_endpoint"`
+	APIKey        string   `mapstructure:"api_key"`
+	APISecret     string   `mapstructure:"api_secret"`
+	TemplateZone  string   `mapstructure:"template_zone"`
+	TemplateZones []string `mapstructure:"template_zones"`
+}
+
+func init() {
+	egoscale.UserAgent = "Packer-Exoscale/" + version.FormattedVersion() + " " + egoscale.UserAgent
+}
+
+type PostProcessor struct {
+	config Config
+}
+
+func (p *PostProcessor) ConfigSpec() hcldec.ObjectSpec { return p.config.FlatMapstructure().HCL2Spec() }
+
+func (p *PostProcessor) Configure(raws ...interface{}) error {
+	p.config.TemplateZone = defaultTemplateZone
+	p.config.APIEndpoint = defaultAPIEndpoint
+
+	if err := config.Decode(&p.config, nil, raws...); err != nil {
+		return err
+	}
+
+	if p.config.APIKey == "" {
+		p.config.APIKey = os.Getenv("EXOSCALE_API_KEY")
+	}
+
+	if p.config.APISecret == "" {
+		p.config.APISecret = os.Getenv("EXOSCALE_API_SECRET")
+	}
+
+	requiredArgs := map[string]*string{
+		"api_key":       &p.config.APIKey,
+		"api_secret":    &p.config.APISecret,
+		"api_endpoint":  &p.config.APIEndpoint,
+		"template_zone": &p.config.TemplateZone,
+	}
+
+	errs := new(packer.MultiError)
+	for k, v := range requiredArgs {
+		if *v == "" {
+			errs = packer.MultiErrorAppend(
+				errs, fmt.Errorf("%s must be set", k))
+		}
+	}
+
+	if len(p.config.TemplateZones) == 0 {
+		errs = packer.MultiErrorAppend(
+			errs, fmt.Errorf("template_zones must be set"))
+	}
+	for _, zone := range p.config.TemplateZones {
+		if zone == p.config.TemplateZone {
+			errs = packer.MultiErrorAppend(errs, fmt.Errorf(
+				"template_zones must not contain the source template_zone (%s)", zone))
+		}
+	}
+
+	if len(errs.Errors) > 0 {
+		return errs
+	}
+
+	packer.LogSecretFilter.Set(p.config.APIKey, p.config.APISecret)
+
+	return nil
+}
+
+func (p *PostProcessor) PostProcess(ctx context.Context, ui packer.Ui, a packer.Artifact) (packer.Artifact, bool, bool, error) {
+	if a.BuilderId() != exoscaleimport.BuilderId {
+		err := fmt.Errorf(
+			"Unknown artifact type: %s\nCan only replicate templates imported by the exoscale-import post-processor.",
+			a.BuilderId())
+		return nil, false, false, err
+	}
+
+	templateID, err := egoscale.ParseUUID(a.Id())
+	if err != nil {
+		return nil, false, false, fmt.Errorf("invalid template ID %q: %s", a.Id(), err)
+	}
+
+	exo := egoscale.NewClient(p.config.APIEndpoint, p.config.APIKey, p.config.APISecret)
+	exo.RetryStrategy = egoscale.FibonacciRetryStrategy
+
+	source, err := p.sourceTemplate(ctx, exo, templateID)
+	if err != nil {
+		return nil, false, false, fmt.Errorf("unable to look up source template: %s", err)
+	}
+
+	// Register the template into every target zone in parallel. The image
+	// is served from SOS, so each registration is an independent API job.
+	var (
+		wg   sync.WaitGroup
+		l    sync.Mutex
+		ids  = map[string]string{}
+		errs = make([]error, 0)
+	)
+	for _, zone := range p.config.TemplateZones {
+		wg.Add(1)
+		go func(zone string) {
+			defer wg.Done()
+
+			id, err := p.replicate(ctx, ui, exo, source, zone)
+
+			l.Lock()
+			defer l.Unlock()
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s: %s", zone, err))
+				return
+			}
+			ids[zone] = id
+		}(zone)
+	}
+	wg.Wait()
+
+	if len(errs) > 0 {
+		return nil, false, false, &packer.MultiError{Errors: errs}
+	}
+
+	// The input artifact is the template in the source zone; keep it, the
+	// replicas do not replace it.
+	return &Artifact{ids}, true, false, nil
+}
+
+// sourceTemplate looks up the imported template in the source zone, to
+// recover the registration parameters (URL, checksum, details) that the
+// replicas are created from.
+func (p *PostProcessor) sourceTemplate(ctx context.Context, exo *egoscale.Client, id *egoscale.UUID) (*egoscale.Template, error) {
+	zone := egoscale.Zone{Name: p.config.TemplateZone}
+	if resp, err := exo.GetWithContext(ctx, &zone); err != nil {
+		return nil, fmt.Errorf("template zone lookup failed: %s", err)
+	} else {
+		zone.ID = resp.(*egoscale.Zone).ID
+	}
+
+	resp, err := exo.RequestWithContext(ctx, &egoscale.ListTemplates{
+		ID:             id,
+		ZoneID:         zone.ID,
+		TemplateFilter: "self",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	templates := resp.(*egoscale.ListTemplatesResponse)
+	if templates.Count != 1 {
+		return nil, fmt.Errorf("template %s not found in zone %s", id, p.config.TemplateZone)
+	}
+
+	return &templates.Template[0], nil
+}
+
+// replicate registers the source template into a single target zone and
+// returns the ID of the new template.
+func (p *PostProcessor) replicate(ctx context.Context, ui packer.Ui, exo *egoscale.Client, source *egoscale.Template, zoneName string) (string, error) {
+	var regErr error
+
+	zone := egoscale.Zone{Name: zoneName}
+	if resp, err := exo.GetWithContext(ctx, &zone); err != nil {
+		return "", fmt.Errorf("zone lookup failed: %s", err)
+	} else {
+		zone.ID = resp.(*egoscale.Zone).ID
+	}
+
+	passwordEnabled := source.PasswordEnabled
+	sshkeyEnabled := source.SSHKeyEnabled
+
+	req := egoscale.RegisterCustomTemplate{
+		URL:             source.URL,
+		ZoneID:          zone.ID,
+		Name:            source.Name,
+		Displaytext:     source.DisplayText,
+		PasswordEnabled: &passwordEnabled,
+		SSHKeyEnabled:   &sshkeyEnabled,
+		Details:         source.Details,
+		Checksum:        source.Checksum,
+	}
+
+	res := make([]egoscale.Template, 0)
+
+	exo.AsyncRequestWithContext(ctx, req, func(jobRes *egoscale.AsyncJobResult, err error) bool {
+		if err != nil {
+			regErr = fmt.Errorf("request failed: %s", err)
+			return false
+		} else if jobRes.JobStatus == egoscale.Pending {
+			// Job is not completed yet
+			ui.Message(fmt.Sprintf("template registration in progress (%s)", zoneName))
+			return true
+		}
+
+		if err := jobRes.Result(&res); err != nil {
+			regErr = err
+			return false
+		}
+
+		if len(res) != 1 {
+			regErr = fmt.Errorf("unexpected response from API (expected 1 item, got %d)", len(res))
+			return false
+		}
+
+		return false
+	})
+	if regErr != nil {
+		return "", regErr
+	}
+
+	ui.Message(fmt.Sprintf("Template registered in zone %s: %s", zoneName, res[0].ID))
+
+	return res[0].ID.String(), nil
+}
@@ -5,8 +5,9 @@ import "fmt"
 const BuilderId = "packer.post-processor.manifest"
 
 type ArtifactFile struct {
-	Name string `json:"name"`
-	Size int64  `json:"size"`
+	Name   string `json:"name"`
+	Size   int64  `json:"size"`
+	SHA256 string `json:"sha256,omitempty"`
 }
 
 type Artifact struct {
@@ -17,6 +18,7 @@ type Artifact struct {
 	ArtifactId    string            `json:"artifact_id"`
 	PackerRunUUID string            `json:"packer_run_uuid"`
 	CustomData    map[string]string `json:"custom_data"`
+	Fingerprint   string            `json:"fingerprint,omitempty"`
 }
 
 func (a *Artifact) BuilderId() string {
@@ -5,12 +5,16 @@ package manifest
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"log"
 	"os"
 	"path/filepath"
+	"sort"
 	"time"
 
 	"github.com/hashicorp/hcl/v2/hcldec"
@@ -35,7 +39,14 @@ type Config struct {
 	// engine](https://packer.io/docs/templates/engine.html). Therefore, you
 	// may use user variables and template functions in this field.
 	CustomData map[string]string `mapstructure:"custom_data"`
-	ctx        interpolate.Context
+	// Compute a SHA256 digest for every file the artifact produced and a
+	// combined `fingerprint` for the build, derived from the file digests,
+	// the artifact ID, the builder type and the user variables in effect.
+	// Two builds with identical inputs and outputs share the same
+	// fingerprint, which lets downstream tooling detect identical rebuilds.
+	// This defaults to false since hashing large images can be slow.
+	Fingerprint bool `mapstructure:"fingerprint"`
+	ctx         interpolate.Context
 }
 
 type PostProcessor struct {
@@ -99,6 +110,11 @@ func (p *PostProcessor) PostProcess(ctx context.Context, ui packer.Ui, source pa
 		if fi, err = os.Stat(name); err == nil {
 			af.Size = fi.Size()
 		}
+		if p.config.Fingerprint {
+			if af.SHA256, err = fileSHA256(name); err != nil {
+				return nil, false, false, fmt.Errorf("Error hashing %s: %s", name, err)
+			}
+		}
 		if p.config.StripPath {
 			af.Name = filepath.Base(name)
 		} else {
@@ -114,6 +130,9 @@ func (p *PostProcessor) PostProcess(ctx context.Context, ui packer.Ui, source pa
 	if p.config.StripTime {
 		artifact.BuildTime = 0
 	}
+	if p.config.Fingerprint {
+		artifact.Fingerprint = buildFingerprint(artifact, p.config.PackerUserVars)
+	}
 	// Since each post-processor runs in a different process we need a way to
 	// coordinate between various post-processors in a single packer run. We do
 	// this by setting a UUID per run and tracking this in the manifest file.
@@ -175,6 +194,45 @@ func (p *PostProcessor) PostProcess(ctx context.Context, ui packer.Ui, source pa
 	return source, true, true, nil
 }
 
+func fileSHA256(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// buildFingerprint derives a stable digest for the build from the inputs
+// that determine its output: the builder type, the user variables in effect
+// (the template itself is not visible to post-processor plugins), the cloud
+// artifact ID and the digests of every produced file. File names are left
+// out so strip_path does not change the fingerprint.
+func buildFingerprint(artifact *Artifact, userVars map[string]string) string {
+	hash := sha256.New()
+	fmt.Fprintln(hash, artifact.BuilderType)
+	fmt.Fprintln(hash, artifact.ArtifactId)
+
+	keys := make([]string, 0, len(userVars))
+	for k := range userVars {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		fmt.Fprintf(hash, "%s=%s\n", k, userVars[k])
+	}
+
+	for _, af := range artifact.ArtifactFiles {
+		fmt.Fprintln(hash, af.SHA256)
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
 func createInterpolatedCustomData(config *Config, customData string) (string, error) {
 	interpolatedCmd, err := interpolate.Render(customData, &config.ctx)
 	if err != nil {
@@ -20,6 +20,7 @@ type FlatConfig struct {
 	StripPath           *bool             `mapstructure:"strip_path" cty:"strip_path"`
 	StripTime           *bool             `mapstructure:"strip_time" cty:"strip_time"`
 	CustomData          map[string]string `mapstructure:"custom_data" cty:"custom_data"`
+	Fingerprint         *bool             `mapstructure:"fingerprint" cty:"fingerprint"`
 }
 
 // FlatMapstructure returns a new FlatConfig.
@@ -45,6 +46,7 @@ func (*FlatConfig) HCL2Spec() map[string]hcldec.Spec {
 		"strip_path":                 &hcldec.AttrSpec{Name: "strip_path", Type: cty.Bool, Required: false},
 		"strip_time":                 &hcldec.AttrSpec{Name: "strip_time", Type: cty.Bool, Required: false},
 		"custom_data":                &hcldec.AttrSpec{Name: "custom_data", Type: cty.Map(cty.String), Required: false},
+		"fingerprint":                &hcldec.AttrSpec{Name: "fingerprint", Type: cty.Bool, Required: false},
 	}
 	return s
 }
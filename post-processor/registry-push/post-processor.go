@@ -0,0 +1,264 @@
+//go:generate mapstructure-to-hcl2 -type Config
+//go:generate struct-markdown
+
+package registrypush
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+
+	"github.com/hashicorp/hcl/v2/hcldec"
+	"github.com/hashicorp/packer/common"
+	"github.com/hashicorp/packer/helper/config"
+	"github.com/hashicorp/packer/packer"
+	"github.com/hashicorp/packer/template/interpolate"
+)
+
+type Config struct {
+	common.PackerConfig `mapstructure:",squash"`
+
+	// The HTTP(S) endpoint the artifact manifest is pushed to, e.g.
+	// `https://registry.example.com/v1/images`.
+	Endpoint string `mapstructure:"endpoint" required:"true"`
+	// The HTTP method used to push the manifest. Either `POST` or `PUT`.
+	// Defaults to `POST`.
+	Method string `mapstructure:"method"`
+	// A bearer token sent in the `Authorization` header. May not be
+	// combined with username.
+	AuthToken string `mapstructure:"auth_token"`
+	// The username for HTTP basic authentication.
+	Username string `mapstructure:"username"`
+	// The password for HTTP basic authentication.
+	Password string `mapstructure:"password"`
+	// Additional headers to send with the request, for example a custom
+	// API key header.
+	Headers map[string]string `mapstructure:"headers"`
+	// A version string recorded in the pushed manifest so the registry
+	// can order builds of the same image. This is a [template
+	// engine](https://packer.io/docs/templates/engine.html); you may use
+	// user variables and template functions in this field.
+	Version string `mapstructure:"version"`
+	// Arbitrary data to add to the pushed manifest. This is a [template
+	// engine](https://packer.io/docs/templates/engine.html). Therefore, you
+	// may use user variables and template functions in this field.
+	CustomData map[string]string `mapstructure:"custom_data"`
+	// The time to wait for the registry to accept the manifest before
+	// failing the push. Defaults to `30s`.
+	Timeout time.Duration `mapstructure:"timeout"`
+	// Skip TLS certificate verification when talking to the registry.
+	// Defaults to false.
+	InsecureSkipTLSVerify bool `mapstructure:"insecure_skip_tls_verify"`
+
+	ctx interpolate.Context
+}
+
+type PostProcessor struct {
+	config Config
+}
+
+// Manifest is the document pushed to the registry for every artifact.
+type Manifest struct {
+	BuilderType   string            `json:"builder_type"`
+	BuildName     string            `json:"build_name"`
+	BuildTime     int64             `json:"build_time"`
+	ArtifactId    string            `json:"artifact_id"`
+	Description   string            `json:"description"`
+	Files         []ManifestFile    `json:"files"`
+	Version       string            `json:"version,omitempty"`
+	CustomData    map[string]string `json:"custom_data,omitempty"`
+	PackerRunUUID string            `json:"packer_run_uuid"`
+}
+
+type ManifestFile struct {
+	Name   string `json:"name"`
+	Size   int64  `json:"size"`
+	SHA256 string `json:"sha256,omitempty"`
+}
+
+func (p *PostProcessor) ConfigSpec() hcldec.ObjectSpec { return p.config.FlatMapstructure().HCL2Spec() }
+
+func (p *PostProcessor) Configure(raws ...interface{}) error {
+	err := config.Decode(&p.config, &config.DecodeOpts{
+		Interpolate:        true,
+		InterpolateContext: &p.config.ctx,
+		InterpolateFilter: &interpolate.RenderFilter{
+			Exclude: []string{
+				"version",
+				"custom_data",
+			},
+		},
+	}, raws...)
+	if err != nil {
+		return err
+	}
+
+	if p.config.Method == "" {
+		p.config.Method = "POST"
+	}
+
+	if p.config.Timeout == 0 {
+		p.config.Timeout = 30 * time.Second
+	}
+
+	var errs *packer.MultiError
+
+	if p.config.Endpoint == "" {
+		errs = packer.MultiErrorAppend(errs,
+			fmt.Errorf("endpoint must be specified"))
+	} else if u, err := url.Parse(p.config.Endpoint); err != nil {
+		errs = packer.MultiErrorAppend(errs,
+			fmt.Errorf("endpoint is invalid: %s", err))
+	} else if u.Scheme != "http" && u.Scheme != "https" {
+		errs = packer.MultiErrorAppend(errs,
+			fmt.Errorf("endpoint must be an http or https URL"))
+	}
+
+	if p.config.Method != "POST" && p.config.Method != "PUT" {
+		errs = packer.MultiErrorAppend(errs,
+			fmt.Errorf("method must be either POST or PUT"))
+	}
+
+	if p.config.AuthToken != "" && p.config.Username != "" {
+		errs = packer.MultiErrorAppend(errs,
+			fmt.Errorf("only one of auth_token and username may be specified"))
+	}
+
+	if errs != nil && len(errs.Errors) > 0 {
+		return errs
+	}
+
+	return nil
+}
+
+func (p *PostProcessor) PostProcess(ctx context.Context, ui packer.Ui, source packer.Artifact) (packer.Artifact, bool, bool, error) {
+	generatedData := source.State("generated_data")
+	if generatedData == nil {
+		// Make sure it's not a nil map so we can assign to it later.
+		generatedData = make(map[string]interface{})
+	}
+	p.config.ctx.Data = generatedData
+
+	version, err := interpolate.Render(p.config.Version, &p.config.ctx)
+	if err != nil {
+		return nil, false, false, fmt.Errorf("Error interpolating version: %s", err)
+	}
+
+	customData := make(map[string]string, len(p.config.CustomData))
+	for key, data := range p.config.CustomData {
+		interpolatedData, err := interpolate.Render(data, &p.config.ctx)
+		if err != nil {
+			return nil, false, false, fmt.Errorf("Error interpolating custom data: %s", err)
+		}
+		customData[key] = interpolatedData
+	}
+
+	manifest := &Manifest{
+		BuilderType:   p.config.PackerBuilderType,
+		BuildName:     p.config.PackerBuildName,
+		BuildTime:     time.Now().Unix(),
+		ArtifactId:    source.Id(),
+		Description:   source.String(),
+		Version:       version,
+		CustomData:    customData,
+		PackerRunUUID: os.Getenv("PACKER_RUN_UUID"),
+	}
+
+	for _, name := range source.Files() {
+		mf := ManifestFile{Name: name}
+		if fi, err := os.Stat(name); err == nil {
+			mf.Size = fi.Size()
+		}
+		if sum, err := fileSHA256(name); err == nil {
+			mf.SHA256 = sum
+		}
+		manifest.Files = append(manifest.Files, mf)
+	}
+
+	body, err := json.Marshal(manifest)
+	if err != nil {
+		return nil, false, false, fmt.Errorf("Unable to marshal manifest JSON: %s", err)
+	}
+
+	ui.Say(fmt.Sprintf("Pushing artifact manifest for %s to %s...",
+		source.Id(), p.config.Endpoint))
+
+	if err := p.push(ctx, body); err != nil {
+		return nil, false, false, err
+	}
+
+	ui.Message("Artifact manifest pushed successfully")
+
+	// The push should never delete the artifacts it is set to record, so it
+	// forcibly sets "keep" to true.
+	return source, true, true, nil
+}
+
+func (p *PostProcessor) push(ctx context.Context, body []byte) error {
+	ctx, cancel := context.WithTimeout(ctx, p.config.Timeout)
+	defer cancel()
+
+	req, err := http.NewRequest(p.config.Method, p.config.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("Unable to create registry request: %s", err)
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Content-Type", "application/json")
+
+	if p.config.AuthToken != "" {
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", p.config.AuthToken))
+	} else if p.config.Username != "" {
+		req.SetBasicAuth(p.config.Username, p.config.Password)
+	}
+
+	for key, value := range p.config.Headers {
+		req.Header.Set(key, value)
+	}
+
+	client := &http.Client{}
+	if p.config.InsecureSkipTLSVerify {
+		client.Transport = &http.Transport{
+			TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+		}
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("Error pushing manifest to registry: %s", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		// Include the start of the response body in the error so a
+		// rejection reason from the registry is surfaced to the user.
+		snippet, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("Registry rejected the manifest with status %s: %s",
+			resp.Status, bytes.TrimSpace(snippet))
+	}
+
+	return nil
+}
+
+func fileSHA256(name string) (string, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
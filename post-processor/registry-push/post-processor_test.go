@@ -0,0 +1,158 @@
+package registrypush
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/packer/packer"
+)
+
+func testConfig() map[string]interface{} {
+	return map[string]interface{}{
+		"endpoint": "https://registry.example.com/v1/images",
+	}
+}
+
+func TestPostProcessor_ImplementsPostProcessor(t *testing.T) {
+	var _ packer.PostProcessor = new(PostProcessor)
+}
+
+func TestPostProcessorConfigure(t *testing.T) {
+	var p PostProcessor
+	if err := p.Configure(testConfig()); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if p.config.Method != "POST" {
+		t.Fatalf("bad method default: %s", p.config.Method)
+	}
+	if p.config.Timeout == 0 {
+		t.Fatal("should default the timeout")
+	}
+}
+
+func TestPostProcessorConfigure_endpoint(t *testing.T) {
+	var p PostProcessor
+
+	// No endpoint
+	raw := testConfig()
+	delete(raw, "endpoint")
+	if err := p.Configure(raw); err == nil {
+		t.Fatal("should error")
+	}
+
+	// Not an HTTP URL
+	raw = testConfig()
+	raw["endpoint"] = "ftp://registry.example.com"
+	if err := p.Configure(raw); err == nil {
+		t.Fatal("should error")
+	}
+}
+
+func TestPostProcessorConfigure_method(t *testing.T) {
+	var p PostProcessor
+
+	raw := testConfig()
+	raw["method"] = "PUT"
+	if err := p.Configure(raw); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	raw["method"] = "DELETE"
+	if err := p.Configure(raw); err == nil {
+		t.Fatal("should error")
+	}
+}
+
+func TestPostProcessorConfigure_auth(t *testing.T) {
+	var p PostProcessor
+
+	raw := testConfig()
+	raw["auth_token"] = "tok"
+	raw["username"] = "user"
+	if err := p.Configure(raw); err == nil {
+		t.Fatal("should error")
+	}
+}
+
+func TestPostProcessorPostProcess(t *testing.T) {
+	var received Manifest
+	var authHeader string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" {
+			t.Errorf("bad method: %s", r.Method)
+		}
+		authHeader = r.Header.Get("Authorization")
+		body, _ := ioutil.ReadAll(r.Body)
+		if err := json.Unmarshal(body, &received); err != nil {
+			t.Errorf("bad body: %s", err)
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	var p PostProcessor
+	if err := p.Configure(map[string]interface{}{
+		"endpoint":   server.URL,
+		"auth_token": "tok",
+		"version":    "1.2.3",
+	}); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	artifact := &packer.MockArtifact{
+		IdValue: "ami-12345",
+	}
+
+	result, keep, forceOverride, err := p.PostProcess(context.Background(), testUi(), artifact)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if result != artifact {
+		t.Fatal("should return the source artifact")
+	}
+	if !keep || !forceOverride {
+		t.Fatal("should keep the artifact")
+	}
+
+	if authHeader != "Bearer tok" {
+		t.Fatalf("bad auth header: %s", authHeader)
+	}
+	if received.ArtifactId != "ami-12345" {
+		t.Fatalf("bad artifact id: %s", received.ArtifactId)
+	}
+	if received.Version != "1.2.3" {
+		t.Fatalf("bad version: %s", received.Version)
+	}
+}
+
+func TestPostProcessorPostProcess_rejected(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "image already exists", http.StatusConflict)
+	}))
+	defer server.Close()
+
+	var p PostProcessor
+	if err := p.Configure(map[string]interface{}{
+		"endpoint": server.URL,
+	}); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	_, _, _, err := p.PostProcess(context.Background(), testUi(), new(packer.MockArtifact))
+	if err == nil {
+		t.Fatal("should error")
+	}
+}
+
+func testUi() *packer.BasicUi {
+	return &packer.BasicUi{
+		Reader: new(bytes.Buffer),
+		Writer: new(bytes.Buffer),
+	}
+}
@@ -69,6 +69,21 @@ type Config struct {
 
 	// The command to run ansible-galaxy
 	GalaxyCommand string `mapstructure:"galaxy_command"`
+
+	// If true, ansible is installed into a disposable virtualenv on the
+	// target machine and removed again after the playbooks ran, so the
+	// provisioning toolchain does not end up in the image.
+	UseVirtualenv bool `mapstructure:"use_virtualenv"`
+
+	// The command used to create the virtualenv
+	VirtualenvCommand string `mapstructure:"virtualenv_command"`
+
+	// The ansible version pip installs into the virtualenv
+	AnsibleVersion string `mapstructure:"ansible_version"`
+
+	// An optional local directory of python wheels that is uploaded to the
+	// target and used as the only package source of the pip install
+	WheelhouseDir string `mapstructure:"wheelhouse_directory"`
 }
 
 type Provisioner struct {
@@ -76,6 +91,7 @@ type Provisioner struct {
 
 	playbookFiles []string
 	generatedData map[string]interface{}
+	venvDir       string
 }
 
 func (p *Provisioner) ConfigSpec() hcldec.ObjectSpec { return p.config.FlatMapstructure().HCL2Spec() }
@@ -102,6 +118,9 @@ func (p *Provisioner) Prepare(raws ...interface{}) error {
 	if p.config.GalaxyCommand == "" {
 		p.config.GalaxyCommand = "ansible-galaxy"
 	}
+	if p.config.VirtualenvCommand == "" {
+		p.config.VirtualenvCommand = "python3 -m venv"
+	}
 
 	if p.config.StagingDir == "" {
 		p.config.StagingDir = filepath.ToSlash(filepath.Join(DefaultStagingDir, uuid.TimeOrderedUUID()))
@@ -186,6 +205,24 @@ func (p *Provisioner) Prepare(raws ...interface{}) error {
 		}
 	}
 
+	if !p.config.UseVirtualenv {
+		if p.config.AnsibleVersion != "" {
+			errs = packer.MultiErrorAppend(errs,
+				fmt.Errorf("ansible_version requires use_virtualenv to be true"))
+		}
+		if p.config.WheelhouseDir != "" {
+			errs = packer.MultiErrorAppend(errs,
+				fmt.Errorf("wheelhouse_directory requires use_virtualenv to be true"))
+		}
+	}
+
+	// Check that the wheelhouse directory exists, if configured
+	if len(p.config.WheelhouseDir) > 0 {
+		if err := validateDirConfig(p.config.WheelhouseDir, "wheelhouse_directory"); err != nil {
+			errs = packer.MultiErrorAppend(errs, err)
+		}
+	}
+
 	if errs != nil && len(errs.Errors) > 0 {
 		return errs
 	}
@@ -303,10 +340,22 @@ func (p *Provisioner) Provision(ctx context.Context, ui packer.Ui, comm packer.C
 		}
 	}
 
+	if p.config.UseVirtualenv {
+		if err := p.provisionVirtualenv(ui, comm); err != nil {
+			return fmt.Errorf("Error creating Ansible virtualenv: %s", err)
+		}
+	}
+
 	if err := p.executeAnsible(ui, comm); err != nil {
 		return fmt.Errorf("Error executing Ansible: %s", err)
 	}
 
+	if p.config.UseVirtualenv {
+		if err := p.cleanVirtualenv(ui, comm); err != nil {
+			return fmt.Errorf("Error removing Ansible virtualenv: %s", err)
+		}
+	}
+
 	if p.config.CleanStagingDir {
 		ui.Message("Removing staging directory...")
 		if err := p.removeDir(ui, comm, p.config.StagingDir); err != nil {
@@ -354,14 +403,83 @@ func (p *Provisioner) provisionPlaybookFile(ui packer.Ui, comm packer.Communicat
 	return nil
 }
 
+func (p *Provisioner) provisionVirtualenv(ui packer.Ui, comm packer.Communicator) error {
+	ctx := context.TODO()
+	p.venvDir = filepath.ToSlash(filepath.Join(p.config.StagingDir, "venv"))
+
+	ui.Message("Creating Ansible virtualenv...")
+	command := fmt.Sprintf("%s %s", p.config.VirtualenvCommand, p.venvDir)
+	cmd := &packer.RemoteCmd{
+		Command: command,
+	}
+	if err := cmd.RunWithUi(ctx, comm, ui); err != nil {
+		return err
+	}
+	if cmd.ExitStatus() != 0 {
+		return fmt.Errorf("Non-zero exit status: %d", cmd.ExitStatus())
+	}
+
+	pipArgs := ""
+	if p.config.WheelhouseDir != "" {
+		wheelhouse := filepath.ToSlash(filepath.Join(p.config.StagingDir, "wheelhouse"))
+		ui.Message("Uploading wheelhouse directory...")
+		if err := p.uploadDir(ui, comm, wheelhouse, p.config.WheelhouseDir); err != nil {
+			return fmt.Errorf("Error uploading wheelhouse directory: %s", err)
+		}
+		pipArgs = fmt.Sprintf(" --no-index --find-links %s", wheelhouse)
+	}
+
+	spec := "ansible"
+	if p.config.AnsibleVersion != "" {
+		spec = fmt.Sprintf("ansible==%s", p.config.AnsibleVersion)
+	}
+	ui.Message(fmt.Sprintf("Installing %s into the virtualenv...", spec))
+	command = fmt.Sprintf("%s/bin/pip install --disable-pip-version-check%s %s",
+		p.venvDir, pipArgs, spec)
+	cmd = &packer.RemoteCmd{
+		Command: command,
+	}
+	if err := cmd.RunWithUi(ctx, comm, ui); err != nil {
+		return err
+	}
+	if cmd.ExitStatus() != 0 {
+		return fmt.Errorf("Non-zero exit status: %d", cmd.ExitStatus())
+	}
+	return nil
+}
+
+func (p *Provisioner) cleanVirtualenv(ui packer.Ui, comm packer.Communicator) error {
+	ui.Message("Removing Ansible virtualenv...")
+	if err := p.removeDir(ui, comm, p.venvDir); err != nil {
+		return err
+	}
+	if p.config.WheelhouseDir != "" {
+		wheelhouse := filepath.ToSlash(filepath.Join(p.config.StagingDir, "wheelhouse"))
+		if err := p.removeDir(ui, comm, wheelhouse); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// venvPathPrefix returns a PATH override that makes the commands of the
+// disposable virtualenv win over the system ones, or an empty string when no
+// virtualenv is used.
+func (p *Provisioner) venvPathPrefix() string {
+	if p.venvDir == "" {
+		return ""
+	}
+	return fmt.Sprintf("PATH=\"%s/bin:$PATH\" ", p.venvDir)
+}
+
 func (p *Provisioner) executeGalaxy(ui packer.Ui, comm packer.Communicator) error {
 	ctx := context.TODO()
 	rolesDir := filepath.ToSlash(filepath.Join(p.config.StagingDir, "roles"))
 	galaxyFile := filepath.ToSlash(filepath.Join(p.config.StagingDir, filepath.Base(p.config.GalaxyFile)))
 
 	// ansible-galaxy install -r requirements.yml -p roles/
-	command := fmt.Sprintf("cd %s && %s install -r %s -p %s",
-		p.config.StagingDir, p.config.GalaxyCommand, galaxyFile, rolesDir)
+	command := fmt.Sprintf("cd %s && %s%s install -r %s -p %s",
+		p.config.StagingDir, p.venvPathPrefix(), p.config.GalaxyCommand, galaxyFile, rolesDir)
 	ui.Message(fmt.Sprintf("Executing Ansible Galaxy: %s", command))
 	cmd := &packer.RemoteCmd{
 		Command: command,
@@ -412,8 +530,8 @@ func (p *Provisioner) executeAnsiblePlaybook(
 	ui packer.Ui, comm packer.Communicator, playbookFile, extraArgs, inventory string,
 ) error {
 	ctx := context.TODO()
-	command := fmt.Sprintf("cd %s && %s %s%s -c local -i %s",
-		p.config.StagingDir, p.config.Command, playbookFile, extraArgs, inventory,
+	command := fmt.Sprintf("cd %s && %s%s %s%s -c local -i %s",
+		p.config.StagingDir, p.venvPathPrefix(), p.config.Command, playbookFile, extraArgs, inventory,
 	)
 	ui.Message(fmt.Sprintf("Executing Ansible: %s", command))
 	cmd := &packer.RemoteCmd{
@@ -31,6 +31,10 @@ type FlatConfig struct {
 	InventoryGroups     []string          `mapstructure:"inventory_groups" cty:"inventory_groups"`
 	GalaxyFile          *string           `mapstructure:"galaxy_file" cty:"galaxy_file"`
 	GalaxyCommand       *string           `mapstructure:"galaxy_command" cty:"galaxy_command"`
+	UseVirtualenv       *bool             `mapstructure:"use_virtualenv" cty:"use_virtualenv"`
+	VirtualenvCommand   *string           `mapstructure:"virtualenv_command" cty:"virtualenv_command"`
+	AnsibleVersion      *string           `mapstructure:"ansible_version" cty:"ansible_version"`
+	WheelhouseDir       *string           `mapstructure:"wheelhouse_directory" cty:"wheelhouse_directory"`
 }
 
 // FlatMapstructure returns a new FlatConfig.
@@ -67,6 +71,10 @@ func (*FlatConfig) HCL2Spec() map[string]hcldec.Spec {
 		"inventory_groups":           &hcldec.AttrSpec{Name: "inventory_groups", Type: cty.List(cty.String), Required: false},
 		"galaxy_file":                &hcldec.AttrSpec{Name: "galaxy_file", Type: cty.String, Required: false},
 		"galaxy_command":             &hcldec.AttrSpec{Name: "galaxy_command", Type: cty.String, Required: false},
+		"use_virtualenv":             &hcldec.AttrSpec{Name: "use_virtualenv", Type: cty.Bool, Required: false},
+		"virtualenv_command":         &hcldec.AttrSpec{Name: "virtualenv_command", Type: cty.String, Required: false},
+		"ansible_version":            &hcldec.AttrSpec{Name: "ansible_version", Type: cty.String, Required: false},
+		"wheelhouse_directory":       &hcldec.AttrSpec{Name: "wheelhouse_directory", Type: cty.String, Required: false},
 	}
 	return s
 }
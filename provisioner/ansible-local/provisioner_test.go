@@ -315,6 +315,56 @@ func TestProvisionerPrepare_CleanStagingDir(t *testing.T) {
 	}
 }
 
+func TestProvisionerPrepare_Virtualenv(t *testing.T) {
+	var p Provisioner
+	config := testConfig()
+
+	playbook_file, err := ioutil.TempFile("", "playbook")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	defer os.Remove(playbook_file.Name())
+
+	config["playbook_file"] = playbook_file.Name()
+	config["ansible_version"] = "2.9.6"
+
+	err = p.Prepare(config)
+	if err == nil {
+		t.Fatal("ansible_version without use_virtualenv should be an error")
+	}
+
+	p = Provisioner{}
+	config["use_virtualenv"] = true
+	err = p.Prepare(config)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if p.config.VirtualenvCommand != "python3 -m venv" {
+		t.Fatalf("unexpected virtualenv_command: %s", p.config.VirtualenvCommand)
+	}
+
+	wheelhouse_dir, err := ioutil.TempDir("", "wheelhouse")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	defer os.RemoveAll(wheelhouse_dir)
+
+	p = Provisioner{}
+	config["wheelhouse_directory"] = wheelhouse_dir
+	err = p.Prepare(config)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	p = Provisioner{}
+	config["wheelhouse_directory"] = "i-should-not-exist"
+	err = p.Prepare(config)
+	if err == nil {
+		t.Fatal("should have error")
+	}
+}
+
 func TestProvisionerProvisionDocker_PlaybookFiles(t *testing.T) {
 	testProvisionerProvisionDockerWithPlaybookFiles(t, playbookFilesDockerTemplate)
 }
@@ -55,6 +55,7 @@ type Config struct {
 	EnvironmentsPath           string   `mapstructure:"environments_path"`
 	ExecuteCommand             string   `mapstructure:"execute_command"`
 	InstallCommand             string   `mapstructure:"install_command"`
+	InstallPackagePath         string   `mapstructure:"install_package_path"`
 	RemoteCookbookPaths        []string `mapstructure:"remote_cookbook_paths"`
 	Json                       map[string]interface{}
 	PreventSudo                bool     `mapstructure:"prevent_sudo"`
@@ -143,6 +144,12 @@ func (p *Provisioner) Prepare(raws ...interface{}) error {
 		p.config.InstallCommand = p.guestOSTypeConfig.installCommand
 	}
 
+	if p.config.InstallPackagePath != "" {
+		if p.config.InstallCommand != p.guestOSTypeConfig.installCommand {
+			return fmt.Errorf("Cannot specify both install_command and install_package_path")
+		}
+	}
+
 	if p.config.RunList == nil {
 		p.config.RunList = make([]string, 0)
 	}
@@ -158,6 +165,19 @@ func (p *Provisioner) Prepare(raws ...interface{}) error {
 	}
 
 	var errs *packer.MultiError
+	if p.config.InstallPackagePath != "" {
+		fi, err := os.Stat(p.config.InstallPackagePath)
+		if err != nil {
+			errs = packer.MultiErrorAppend(
+				errs, fmt.Errorf("Bad install package path: %s", err))
+		} else if fi.IsDir() {
+			errs = packer.MultiErrorAppend(
+				errs, fmt.Errorf("Install package path must be a file: %s", p.config.InstallPackagePath))
+		} else if _, err := provisioner.PackageInstallCommand(p.config.InstallPackagePath, true); err != nil {
+			errs = packer.MultiErrorAppend(errs, err)
+		}
+	}
+
 	if p.config.ConfigTemplate != "" {
 		fi, err := os.Stat(p.config.ConfigTemplate)
 		if err != nil {
@@ -475,6 +495,10 @@ func (p *Provisioner) executeChef(ui packer.Ui, comm packer.Communicator, config
 }
 
 func (p *Provisioner) installChef(ui packer.Ui, comm packer.Communicator, version string) error {
+	if p.config.InstallPackagePath != "" {
+		return p.installChefFromPackage(ui, comm)
+	}
+
 	ui.Message("Installing Chef...")
 	ctx := context.TODO()
 
@@ -500,6 +524,50 @@ func (p *Provisioner) installChef(ui packer.Ui, comm packer.Communicator, versio
 	return nil
 }
 
+// installChefFromPackage uploads a vendored Chef installer package to the
+// staging directory and installs it with the guest's package manager, so
+// that builds in networks without internet egress never reach out to
+// omnitruck.
+func (p *Provisioner) installChefFromPackage(ui packer.Ui, comm packer.Communicator) error {
+	ui.Message(fmt.Sprintf(
+		"Installing Chef from local package %s...", p.config.InstallPackagePath))
+	ctx := context.TODO()
+
+	if err := p.createDir(ui, comm, p.config.StagingDir); err != nil {
+		return err
+	}
+
+	remotePath := fmt.Sprintf("%s/%s",
+		p.config.StagingDir, filepath.Base(p.config.InstallPackagePath))
+
+	f, err := os.Open(p.config.InstallPackagePath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if err := comm.Upload(remotePath, f, nil); err != nil {
+		return err
+	}
+
+	command, err := provisioner.PackageInstallCommand(remotePath, !p.config.PreventSudo)
+	if err != nil {
+		return err
+	}
+
+	cmd := &packer.RemoteCmd{Command: command}
+	if err := cmd.RunWithUi(ctx, comm, ui); err != nil {
+		return err
+	}
+
+	if cmd.ExitStatus() != 0 {
+		return fmt.Errorf(
+			"Package install exited with non-zero exit status %d", cmd.ExitStatus())
+	}
+
+	return nil
+}
+
 func (p *Provisioner) deepJsonFix(key string, current interface{}) (interface{}, error) {
 	if current == nil {
 		return nil, nil
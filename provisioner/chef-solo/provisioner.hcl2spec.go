@@ -26,6 +26,7 @@ type FlatConfig struct {
 	EnvironmentsPath           *string                `mapstructure:"environments_path" cty:"environments_path"`
 	ExecuteCommand             *string                `mapstructure:"execute_command" cty:"execute_command"`
 	InstallCommand             *string                `mapstructure:"install_command" cty:"install_command"`
+	InstallPackagePath         *string                `mapstructure:"install_package_path" cty:"install_package_path"`
 	RemoteCookbookPaths        []string               `mapstructure:"remote_cookbook_paths" cty:"remote_cookbook_paths"`
 	Json                       map[string]interface{} `cty:"json"`
 	PreventSudo                *bool                  `mapstructure:"prevent_sudo" cty:"prevent_sudo"`
@@ -65,6 +66,7 @@ func (*FlatConfig) HCL2Spec() map[string]hcldec.Spec {
 		"environments_path":              &hcldec.AttrSpec{Name: "environments_path", Type: cty.String, Required: false},
 		"execute_command":                &hcldec.AttrSpec{Name: "execute_command", Type: cty.String, Required: false},
 		"install_command":                &hcldec.AttrSpec{Name: "install_command", Type: cty.String, Required: false},
+		"install_package_path":           &hcldec.AttrSpec{Name: "install_package_path", Type: cty.String, Required: false},
 		"remote_cookbook_paths":          &hcldec.AttrSpec{Name: "remote_cookbook_paths", Type: cty.List(cty.String), Required: false},
 		"json":                           &hcldec.AttrSpec{Name: "json", Type: cty.Map(cty.String), Required: false},
 		"prevent_sudo":                   &hcldec.AttrSpec{Name: "prevent_sudo", Type: cty.Bool, Required: false},
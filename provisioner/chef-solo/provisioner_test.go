@@ -132,6 +132,59 @@ func TestProvisionerPrepare_configTemplate(t *testing.T) {
 	}
 }
 
+func TestProvisionerPrepare_installPackagePath(t *testing.T) {
+	var err error
+	var p Provisioner
+
+	// Test with a package file
+	tf, err := ioutil.TempFile("", "packer-chef-*.deb")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	defer os.Remove(tf.Name())
+
+	config := testConfig()
+	config["install_package_path"] = tf.Name()
+	err = p.Prepare(config)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	// Test with a non-existent file
+	config = testConfig()
+	config["install_package_path"] = "/i/do/not/exist.deb"
+	p = Provisioner{}
+	err = p.Prepare(config)
+	if err == nil {
+		t.Fatal("should have err")
+	}
+
+	// Test with an unknown package format
+	tf2, err := ioutil.TempFile("", "packer-chef-*.tgz")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	defer os.Remove(tf2.Name())
+
+	config = testConfig()
+	config["install_package_path"] = tf2.Name()
+	p = Provisioner{}
+	err = p.Prepare(config)
+	if err == nil {
+		t.Fatal("should have err")
+	}
+
+	// Test that a custom install_command conflicts
+	config = testConfig()
+	config["install_package_path"] = tf.Name()
+	config["install_command"] = "/opt/install.sh"
+	p = Provisioner{}
+	err = p.Prepare(config)
+	if err == nil {
+		t.Fatal("should have err")
+	}
+}
+
 func TestProvisionerPrepare_cookbookPaths(t *testing.T) {
 	var p Provisioner
 
@@ -13,6 +13,7 @@ import (
 
 	"github.com/hashicorp/hcl/v2/hcldec"
 	"github.com/hashicorp/packer/common"
+	"github.com/hashicorp/packer/common/packerignore"
 	"github.com/hashicorp/packer/helper/config"
 	"github.com/hashicorp/packer/packer"
 	"github.com/hashicorp/packer/template/interpolate"
@@ -34,6 +35,13 @@ type Config struct {
 	// False if the sources have to exist.
 	Generated bool
 
+	// Maximum transfer rate, in bytes per second, e.g. "512KB" or "2MB".
+	// The PACKER_BANDWIDTH_LIMIT environment variable additionally caps
+	// all transfers of a Packer run. Unlimited by default.
+	BandwidthLimit string `mapstructure:"bandwidth_limit"`
+
+	bandwidthLimit int64
+
 	ctx interpolate.Context
 }
 
@@ -88,6 +96,15 @@ func (p *Provisioner) Prepare(raws ...interface{}) error {
 			errors.New("Destination must be specified."))
 	}
 
+	if p.config.BandwidthLimit != "" {
+		limit, err := common.ParseBandwidth(p.config.BandwidthLimit)
+		if err != nil {
+			errs = packer.MultiErrorAppend(errs,
+				fmt.Errorf("Bad bandwidth_limit '%s': %s", p.config.BandwidthLimit, err))
+		}
+		p.config.bandwidthLimit = limit
+	}
+
 	if errs != nil && len(errs.Errors) > 0 {
 		return errs
 	}
@@ -146,7 +163,7 @@ func (p *Provisioner) ProvisionDownload(ui packer.Ui, comm packer.Communicator)
 		defer f.Close()
 
 		// Create MultiWriter for the current progress
-		pf := io.MultiWriter(f)
+		pf := common.LimitWriter(io.MultiWriter(f), p.config.bandwidthLimit)
 
 		// Download the file
 		if err = comm.Download(src, pf); err != nil {
@@ -178,7 +195,14 @@ func (p *Provisioner) ProvisionUpload(ui packer.Ui, comm packer.Communicator) er
 
 		// If we're uploading a directory, short circuit and do that
 		if info.IsDir() {
-			return comm.UploadDir(p.config.Destination, src, nil)
+			excl, err := packerignore.Read(src)
+			if err != nil {
+				return fmt.Errorf("Error reading %s: %s", packerignore.FileName, err)
+			}
+			if len(excl) > 0 {
+				ui.Say(fmt.Sprintf("Excluding %d %s pattern(s)", len(excl), packerignore.FileName))
+			}
+			return comm.UploadDir(p.config.Destination, src, excl)
 		}
 
 		// We're uploading a file...
@@ -199,9 +223,10 @@ func (p *Provisioner) ProvisionUpload(ui packer.Ui, comm packer.Communicator) er
 
 		pf := ui.TrackProgress(filepath.Base(src), 0, info.Size(), f)
 		defer pf.Close()
+		body := common.LimitReader(pf, p.config.bandwidthLimit)
 
 		// Upload the file
-		if err = comm.Upload(dst, pf, &fi); err != nil {
+		if err = comm.Upload(dst, body, &fi); err != nil {
 			if strings.Contains(err.Error(), "Error restoring file") {
 				ui.Error(fmt.Sprintf("Upload failed: %s; this can occur when "+
 					"your file destination is a folder without a trailing "+
@@ -21,6 +21,7 @@ type FlatConfig struct {
 	Destination         *string           `cty:"destination"`
 	Direction           *string           `cty:"direction"`
 	Generated           *bool             `cty:"generated"`
+	BandwidthLimit      *string           `mapstructure:"bandwidth_limit" cty:"bandwidth_limit"`
 }
 
 // FlatMapstructure returns a new FlatConfig.
@@ -47,6 +48,7 @@ func (*FlatConfig) HCL2Spec() map[string]hcldec.Spec {
 		"destination":                &hcldec.AttrSpec{Name: "destination", Type: cty.String, Required: false},
 		"direction":                  &hcldec.AttrSpec{Name: "direction", Type: cty.String, Required: false},
 		"generated":                  &hcldec.AttrSpec{Name: "generated", Type: cty.Bool, Required: false},
+		"bandwidth_limit":            &hcldec.AttrSpec{Name: "bandwidth_limit", Type: cty.String, Required: false},
 	}
 	return s
 }
@@ -55,6 +55,31 @@ func TestProvisionerPrepare_InvalidSource(t *testing.T) {
 	}
 }
 
+func TestProvisionerPrepare_BandwidthLimit(t *testing.T) {
+	var p Provisioner
+	tf, err := ioutil.TempFile("", "packer")
+	if err != nil {
+		t.Fatalf("error tempfile: %s", err)
+	}
+	defer os.Remove(tf.Name())
+
+	config := testConfig()
+	config["source"] = tf.Name()
+	config["bandwidth_limit"] = "512KB"
+
+	if err := p.Prepare(config); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if p.config.bandwidthLimit != 512000 {
+		t.Fatalf("bad limit: %d", p.config.bandwidthLimit)
+	}
+
+	config["bandwidth_limit"] = "very fast"
+	if err := p.Prepare(config); err == nil {
+		t.Fatal("should reject an unparseable bandwidth_limit")
+	}
+}
+
 func TestProvisionerPrepare_ValidSource(t *testing.T) {
 	var p Provisioner
 
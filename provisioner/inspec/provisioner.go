@@ -9,6 +9,7 @@ import (
 	"crypto/rand"
 	"crypto/rsa"
 	"crypto/x509"
+	"encoding/json"
 	"encoding/pem"
 	"errors"
 	"fmt"
@@ -19,6 +20,7 @@ import (
 	"os"
 	"os/exec"
 	"os/user"
+	"path/filepath"
 	"regexp"
 	"strconv"
 	"strings"
@@ -37,6 +39,9 @@ import (
 
 var SupportedBackends = map[string]bool{"docker": true, "local": true, "ssh": true, "winrm": true}
 
+// Reporters that write to the screen rather than to a file.
+var screenReporters = map[string]bool{"cli": true, "progress": true, "progress-bar": true}
+
 type Config struct {
 	common.PackerConfig `mapstructure:",squash"`
 	ctx                 interpolate.Context
@@ -59,6 +64,20 @@ type Config struct {
 	LocalPort            int      `mapstructure:"local_port"`
 	SSHHostKeyFile       string   `mapstructure:"ssh_host_key_file"`
 	SSHAuthorizedKeyFile string   `mapstructure:"ssh_authorized_key_file"`
+
+	// Reporters to pass to inspec via --reporter. Screen reporters such as
+	// cli and progress are passed through as-is; file reporters (junit,
+	// json, ...) are written into ResultsDir. When empty, inspec's own
+	// default reporter is used.
+	Reporter []string `mapstructure:"reporter"`
+	// The local directory where file-based reporter results are written.
+	// It is created if it does not exist. Defaults to "inspec-results".
+	ResultsDir string `mapstructure:"results_dir"`
+	// The minimum percentage (0-100) of controls that must pass for the
+	// build to succeed. When set, failing controls no longer abort the
+	// build outright: the JSON results are evaluated against the
+	// threshold instead.
+	PassThreshold float64 `mapstructure:"pass_threshold"`
 }
 
 type Provisioner struct {
@@ -136,6 +155,20 @@ func (p *Provisioner) Prepare(raws ...interface{}) error {
 		errs = packer.MultiErrorAppend(errs, fmt.Errorf("local_port: %d must be a valid port", p.config.LocalPort))
 	}
 
+	if p.config.ResultsDir == "" {
+		p.config.ResultsDir = "inspec-results"
+	}
+
+	for _, reporter := range p.config.Reporter {
+		if strings.ContainsAny(reporter, ": ") {
+			errs = packer.MultiErrorAppend(errs, fmt.Errorf("reporter: %q must be a plain reporter name; output paths are derived from results_dir", reporter))
+		}
+	}
+
+	if p.config.PassThreshold < 0 || p.config.PassThreshold > 100 {
+		errs = packer.MultiErrorAppend(errs, fmt.Errorf("pass_threshold: %v must be between 0 and 100", p.config.PassThreshold))
+	}
+
 	if len(p.config.AttributesDirectory) > 0 {
 		err = validateDirectoryConfig(p.config.AttributesDirectory, "attrs")
 		if err != nil {
@@ -344,6 +377,13 @@ func (p *Provisioner) executeInspec(ui packer.Ui, comm packer.Communicator, priv
 
 	args = append(args, "--input-file")
 	args = append(args, p.config.AttributesFiles...)
+
+	reporterArgs, jsonResults, err := p.reporterArgs()
+	if err != nil {
+		return err
+	}
+	args = append(args, reporterArgs...)
+
 	args = append(args, p.config.ExtraArguments...)
 
 	if len(p.config.InspecEnvVars) > 0 {
@@ -396,13 +436,134 @@ func (p *Provisioner) executeInspec(ui packer.Ui, comm packer.Communicator, priv
 	}
 	wg.Wait()
 	err = cmd.Wait()
-	if err != nil {
+	if err != nil && !(p.config.PassThreshold > 0 && isControlFailureExit(err)) {
 		return fmt.Errorf("Non-zero exit status: %s", err)
 	}
 
+	if p.config.PassThreshold > 0 {
+		passed, total, err := countControls(jsonResults)
+		if err != nil {
+			return fmt.Errorf("Error reading Inspec results: %s", err)
+		}
+		pct := 100.0
+		if total > 0 {
+			pct = float64(passed) / float64(total) * 100
+		}
+		ui.Say(fmt.Sprintf("Inspec: %d/%d controls passed (%.1f%%, threshold %.1f%%)", passed, total, pct, p.config.PassThreshold))
+		if pct < p.config.PassThreshold {
+			return fmt.Errorf("Inspec: %.1f%% of controls passed, below the pass_threshold of %.1f%%", pct, p.config.PassThreshold)
+		}
+	}
+
 	return nil
 }
 
+// reporterArgs translates the reporter config into inspec --reporter
+// arguments, giving file-based reporters an output path under the results
+// directory. It returns the path of the JSON results when a json reporter
+// is in play; one is added implicitly when pass_threshold needs it.
+func (p *Provisioner) reporterArgs() ([]string, string, error) {
+	reporters := p.config.Reporter
+	if p.config.PassThreshold > 0 {
+		hasJson := false
+		for _, r := range reporters {
+			if r == "json" {
+				hasJson = true
+			}
+		}
+		if !hasJson {
+			if len(reporters) == 0 {
+				// Keep the build output readable when json is only
+				// added for the threshold check.
+				reporters = append(reporters, "cli")
+			}
+			reporters = append(reporters, "json")
+		}
+	}
+
+	if len(reporters) == 0 {
+		return nil, "", nil
+	}
+
+	if err := os.MkdirAll(p.config.ResultsDir, 0755); err != nil {
+		return nil, "", fmt.Errorf("Error creating results directory: %s", err)
+	}
+
+	jsonResults := ""
+	args := []string{"--reporter"}
+	for _, r := range reporters {
+		if screenReporters[r] {
+			args = append(args, r)
+			continue
+		}
+		ext := "json"
+		switch {
+		case strings.HasPrefix(r, "junit"):
+			ext = "xml"
+		case strings.HasPrefix(r, "html"):
+			ext = "html"
+		case r == "yaml":
+			ext = "yml"
+		}
+		out := filepath.Join(p.config.ResultsDir, fmt.Sprintf("inspec-%s.%s", r, ext))
+		if r == "json" {
+			jsonResults = out
+		}
+		args = append(args, fmt.Sprintf("%s:%s", r, out))
+	}
+	return args, jsonResults, nil
+}
+
+// isControlFailureExit reports whether err is inspec exiting because
+// controls failed (100) or were skipped (101), as opposed to inspec
+// itself erroring out.
+func isControlFailureExit(err error) bool {
+	exitErr, ok := err.(*exec.ExitError)
+	if !ok {
+		return false
+	}
+	code := exitErr.ExitCode()
+	return code == 100 || code == 101
+}
+
+// countControls parses a JSON reporter results file and counts the
+// controls in it. A control passes when none of its results failed.
+func countControls(path string) (passed, total int, err error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	var results struct {
+		Profiles []struct {
+			Controls []struct {
+				Results []struct {
+					Status string `json:"status"`
+				} `json:"results"`
+			} `json:"controls"`
+		} `json:"profiles"`
+	}
+	if err := json.Unmarshal(data, &results); err != nil {
+		return 0, 0, err
+	}
+
+	for _, profile := range results.Profiles {
+		for _, control := range profile.Controls {
+			total++
+			ok := true
+			for _, result := range control.Results {
+				if result.Status == "failed" {
+					ok = false
+				}
+			}
+			if ok {
+				passed++
+			}
+		}
+	}
+	return passed, total, nil
+}
+
 func validateFileConfig(name string, config string, req bool) error {
 	if req {
 		if name == "" {
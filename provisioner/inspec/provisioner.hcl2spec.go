@@ -27,6 +27,9 @@ type FlatConfig struct {
 	LocalPort            *int              `mapstructure:"local_port" cty:"local_port"`
 	SSHHostKeyFile       *string           `mapstructure:"ssh_host_key_file" cty:"ssh_host_key_file"`
 	SSHAuthorizedKeyFile *string           `mapstructure:"ssh_authorized_key_file" cty:"ssh_authorized_key_file"`
+	Reporter             []string          `mapstructure:"reporter" cty:"reporter"`
+	ResultsDir           *string           `mapstructure:"results_dir" cty:"results_dir"`
+	PassThreshold        *float64          `mapstructure:"pass_threshold" cty:"pass_threshold"`
 }
 
 // FlatMapstructure returns a new FlatConfig.
@@ -59,6 +62,9 @@ func (*FlatConfig) HCL2Spec() map[string]hcldec.Spec {
 		"local_port":                 &hcldec.AttrSpec{Name: "local_port", Type: cty.Number, Required: false},
 		"ssh_host_key_file":          &hcldec.AttrSpec{Name: "ssh_host_key_file", Type: cty.String, Required: false},
 		"ssh_authorized_key_file":    &hcldec.AttrSpec{Name: "ssh_authorized_key_file", Type: cty.String, Required: false},
+		"reporter":                   &hcldec.AttrSpec{Name: "reporter", Type: cty.List(cty.String), Required: false},
+		"results_dir":                &hcldec.AttrSpec{Name: "results_dir", Type: cty.String, Required: false},
+		"pass_threshold":             &hcldec.AttrSpec{Name: "pass_threshold", Type: cty.Number, Required: false},
 	}
 	return s
 }
@@ -14,7 +14,8 @@ import (
 )
 
 // Be sure to remove the InSpec stub file in each test with:
-//   defer os.Remove(config["command"].(string))
+//
+//	defer os.Remove(config["command"].(string))
 func testConfig(t *testing.T) map[string]interface{} {
 	m := make(map[string]interface{})
 	wd, err := os.Getwd()
@@ -291,3 +292,81 @@ func TestInspecGetVersionError(t *testing.T) {
 		t.Fatal("Error message should include command name")
 	}
 }
+
+func TestProvisionerPrepare_PassThreshold(t *testing.T) {
+	var p Provisioner
+	config := testConfig(t)
+	defer os.Remove(config["command"].(string))
+
+	profile_file, err := ioutil.TempFile("", "test")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	defer os.Remove(profile_file.Name())
+	config["profile"] = profile_file.Name()
+
+	config["pass_threshold"] = 150
+	err = p.Prepare(config)
+	if err == nil {
+		t.Fatal("should have error")
+	}
+
+	config["pass_threshold"] = 75
+	err = p.Prepare(config)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+}
+
+func TestProvisionerPrepare_Reporter(t *testing.T) {
+	var p Provisioner
+	config := testConfig(t)
+	defer os.Remove(config["command"].(string))
+
+	profile_file, err := ioutil.TempFile("", "test")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	defer os.Remove(profile_file.Name())
+	config["profile"] = profile_file.Name()
+
+	config["reporter"] = []string{"junit:/tmp/results.xml"}
+	err = p.Prepare(config)
+	if err == nil {
+		t.Fatal("should have error")
+	}
+
+	config["reporter"] = []string{"cli", "junit"}
+	err = p.Prepare(config)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+}
+
+func TestCountControls(t *testing.T) {
+	results_file, err := ioutil.TempFile("", "results")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	defer os.Remove(results_file.Name())
+
+	results := `{"profiles": [{"controls": [
+		{"id": "one", "results": [{"status": "passed"}, {"status": "passed"}]},
+		{"id": "two", "results": [{"status": "passed"}, {"status": "failed"}]},
+		{"id": "three", "results": [{"status": "skipped"}]}
+	]}]}`
+	if err := ioutil.WriteFile(results_file.Name(), []byte(results), 0644); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	passed, total, err := countControls(results_file.Name())
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if total != 3 {
+		t.Fatalf("expected 3 controls, got %d", total)
+	}
+	if passed != 2 {
+		t.Fatalf("expected 2 passed controls, got %d", passed)
+	}
+}
@@ -0,0 +1,224 @@
+//go:generate mapstructure-to-hcl2 -type Config
+
+package restart
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/hcl/v2/hcldec"
+	"github.com/hashicorp/packer/common"
+	"github.com/hashicorp/packer/helper/config"
+	"github.com/hashicorp/packer/packer"
+	"github.com/hashicorp/packer/template/interpolate"
+)
+
+var DefaultRestartCommand = "sudo shutdown -r now"
+var retryableSleep = 5 * time.Second
+
+// The kernel generates a new boot_id on every boot, which makes it a
+// reliable way to tell a completed restart from a connection that came
+// back before the machine went down.
+var BootIDCommand = "cat /proc/sys/kernel/random/boot_id"
+
+type Config struct {
+	common.PackerConfig `mapstructure:",squash"`
+
+	// The command used to restart the guest machine
+	RestartCommand string `mapstructure:"restart_command"`
+
+	// An optional command run once the machine is back up. The output of
+	// this command will be displayed to the user
+	RestartCheckCommand string `mapstructure:"restart_check_command"`
+
+	// The timeout for waiting for the machine to restart
+	RestartTimeout time.Duration `mapstructure:"restart_timeout"`
+
+	// Whether to require the kernel boot_id to change before considering
+	// the machine restarted. Enabled by default.
+	CheckBootID config.Trilean `mapstructure:"check_boot_id"`
+
+	ctx interpolate.Context
+}
+
+type Provisioner struct {
+	config     Config
+	comm       packer.Communicator
+	ui         packer.Ui
+	bootID     string
+	cancel     chan struct{}
+	cancelLock sync.Mutex
+}
+
+func (p *Provisioner) ConfigSpec() hcldec.ObjectSpec { return p.config.FlatMapstructure().HCL2Spec() }
+
+func (p *Provisioner) Prepare(raws ...interface{}) error {
+	err := config.Decode(&p.config, &config.DecodeOpts{
+		Interpolate:        true,
+		InterpolateContext: &p.config.ctx,
+		InterpolateFilter: &interpolate.RenderFilter{
+			Exclude: []string{
+				"execute_command",
+			},
+		},
+	}, raws...)
+	if err != nil {
+		return err
+	}
+
+	if p.config.RestartCommand == "" {
+		p.config.RestartCommand = DefaultRestartCommand
+	}
+
+	if p.config.RestartTimeout == 0 {
+		p.config.RestartTimeout = 5 * time.Minute
+	}
+
+	if p.config.CheckBootID == config.TriUnset {
+		p.config.CheckBootID = config.TriTrue
+	}
+
+	return nil
+}
+
+func (p *Provisioner) Provision(ctx context.Context, ui packer.Ui, comm packer.Communicator, _ map[string]interface{}) error {
+	p.cancelLock.Lock()
+	p.cancel = make(chan struct{})
+	p.cancelLock.Unlock()
+
+	ui.Say("Restarting Machine")
+	p.comm = comm
+	p.ui = ui
+
+	if p.config.CheckBootID.True() {
+		bootID, err := readBootID(ctx, p)
+		if err != nil {
+			// Not every guest exposes /proc; fall back to waiting for the
+			// connection only rather than failing the build.
+			log.Printf("Could not read boot_id, skipping boot_id check: %s", err)
+		}
+		p.bootID = bootID
+	}
+
+	cmd := &packer.RemoteCmd{Command: p.config.RestartCommand}
+	if err := cmd.RunWithUi(ctx, comm, ui); err != nil {
+		return err
+	}
+
+	if cmd.ExitStatus() != 0 {
+		return fmt.Errorf("Restart command exited with non-zero exit status: %d", cmd.ExitStatus())
+	}
+
+	return waitForRestart(ctx, p, comm)
+}
+
+var waitForRestart = func(ctx context.Context, p *Provisioner, comm packer.Communicator) error {
+	ui := p.ui
+	ui.Say("Waiting for machine to restart...")
+	waitDone := make(chan bool, 1)
+	timeout := time.After(p.config.RestartTimeout)
+	var err error
+
+	p.comm = comm
+
+	go func() {
+		log.Printf("Waiting for machine to become available...")
+		err = waitForCommunicator(ctx, p)
+		waitDone <- true
+	}()
+
+	log.Printf("Waiting for machine to reboot with timeout: %s", p.config.RestartTimeout)
+
+WaitLoop:
+	for {
+		// Wait for either SSH to become available again, a timeout to
+		// occur, or an interrupt to come through.
+		select {
+		case <-waitDone:
+			if err != nil {
+				ui.Error(fmt.Sprintf("Error waiting for machine to restart: %s", err))
+				return err
+			}
+
+			ui.Say("Machine successfully restarted, moving on")
+			close(p.cancel)
+			break WaitLoop
+		case <-timeout:
+			err := fmt.Errorf("Timeout waiting for machine to restart.")
+			ui.Error(err.Error())
+			close(p.cancel)
+			return err
+		case <-p.cancel:
+			close(waitDone)
+			return fmt.Errorf("Interrupt detected, quitting waiting for machine to restart")
+		}
+	}
+	return nil
+}
+
+var waitForCommunicator = func(ctx context.Context, p *Provisioner) error {
+	for {
+		select {
+		case <-ctx.Done():
+			log.Println("Communicator wait canceled, exiting loop")
+			return fmt.Errorf("Communicator wait canceled")
+		case <-time.After(retryableSleep):
+		}
+
+		bootID, err := readBootID(ctx, p)
+		if err != nil {
+			// Still down, or SSH not back yet
+			log.Printf("Communication connection err: %s", err)
+			continue
+		}
+
+		if p.bootID != "" && bootID == p.bootID {
+			// The connection came back before the machine went down; the
+			// restart has not happened yet.
+			log.Printf("Machine reports the same boot_id, restart still pending...")
+			continue
+		}
+		log.Printf("Connected to machine")
+
+		if p.config.RestartCheckCommand != "" {
+			cmdRestartCheck := &packer.RemoteCmd{Command: p.config.RestartCheckCommand}
+			log.Printf("Checking that communicator is connected with: '%s'",
+				cmdRestartCheck.Command)
+			if err := cmdRestartCheck.RunWithUi(ctx, p.comm, p.ui); err != nil {
+				log.Printf("Communication connection err: %s", err)
+				continue
+			}
+			if cmdRestartCheck.ExitStatus() != 0 {
+				log.Printf("Restart check returned non-zero exit status: %d; retrying...",
+					cmdRestartCheck.ExitStatus())
+				continue
+			}
+		}
+		break
+	}
+
+	return nil
+}
+
+// readBootID returns the current boot_id of the guest, trimmed of
+// whitespace.
+func readBootID(ctx context.Context, p *Provisioner) (string, error) {
+	var buf bytes.Buffer
+	cmd := &packer.RemoteCmd{
+		Command: BootIDCommand,
+		Stdout:  &buf,
+	}
+	if err := p.comm.Start(ctx, cmd); err != nil {
+		return "", err
+	}
+	cmd.Wait()
+	if cmd.ExitStatus() != 0 {
+		return "", fmt.Errorf("Boot ID command exited with non-zero exit status: %d", cmd.ExitStatus())
+	}
+	return strings.TrimSpace(buf.String()), nil
+}
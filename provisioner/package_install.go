@@ -0,0 +1,30 @@
+package provisioner
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// PackageInstallCommand returns the guest command that installs a package
+// file already uploaded to remotePath, based on its extension. It is used
+// by provisioners offering an offline install mode, where a vendored
+// installer package is uploaded instead of being downloaded from the
+// internet.
+func PackageInstallCommand(remotePath string, sudo bool) (string, error) {
+	prefix := ""
+	if sudo {
+		prefix = "sudo "
+	}
+	switch strings.ToLower(filepath.Ext(remotePath)) {
+	case ".deb":
+		return fmt.Sprintf("%sdpkg -i '%s'", prefix, remotePath), nil
+	case ".rpm":
+		return fmt.Sprintf("%srpm -Uvh '%s'", prefix, remotePath), nil
+	case ".msi":
+		return fmt.Sprintf(`msiexec /qn /i "%s"`, remotePath), nil
+	default:
+		return "", fmt.Errorf(
+			"Don't know how to install %s; expected a .deb, .rpm or .msi package", remotePath)
+	}
+}
@@ -417,6 +417,12 @@ func (p *Provisioner) createFlattenedEnvVars(elevated bool) (flattened string) {
 		envVars["PACKER_HTTP_PORT"] = httpPort.(string)
 	}
 
+	// Template-level env, overridable by this provisioner's
+	// environment_vars
+	for k, v := range p.config.PackerEnv {
+		envVars[k] = psEscape.Replace(v)
+	}
+
 	// interpolate environment variables
 	p.config.ctx.Data = p.generatedData
 
@@ -55,6 +55,12 @@ type Config struct {
 	// machine.
 	ManifestDir string `mapstructure:"manifest_dir"`
 
+	// A local puppet-agent installer package (.deb, .rpm or .msi) that is
+	// uploaded and installed with the guest's package manager before
+	// running Puppet, for hosts without internet egress. By default Puppet
+	// is assumed to already be installed on the guest.
+	InstallPackagePath string `mapstructure:"install_package_path"`
+
 	// If true, `sudo` will NOT be used to execute Puppet.
 	PreventSudo bool `mapstructure:"prevent_sudo"`
 
@@ -205,6 +211,19 @@ func (p *Provisioner) Prepare(raws ...interface{}) error {
 
 	// Validation
 	var errs *packer.MultiError
+	if p.config.InstallPackagePath != "" {
+		info, err := os.Stat(p.config.InstallPackagePath)
+		if err != nil {
+			errs = packer.MultiErrorAppend(errs,
+				fmt.Errorf("install_package_path is invalid: %s", err))
+		} else if info.IsDir() {
+			errs = packer.MultiErrorAppend(errs,
+				fmt.Errorf("install_package_path must point to a file"))
+		} else if _, err := provisioner.PackageInstallCommand(p.config.InstallPackagePath, true); err != nil {
+			errs = packer.MultiErrorAppend(errs, err)
+		}
+	}
+
 	if p.config.HieraConfigPath != "" {
 		info, err := os.Stat(p.config.HieraConfigPath)
 		if err != nil {
@@ -265,6 +284,13 @@ func (p *Provisioner) Provision(ctx context.Context, ui packer.Ui, comm packer.C
 		return fmt.Errorf("Error creating staging directory: %s", err)
 	}
 
+	// Install Puppet from a vendored package if requested
+	if p.config.InstallPackagePath != "" {
+		if err := p.installFromPackage(ui, comm); err != nil {
+			return fmt.Errorf("Error installing Puppet: %s", err)
+		}
+	}
+
 	// Upload hiera config if set
 	remoteHieraConfigPath := ""
 	if p.config.HieraConfigPath != "" {
@@ -365,6 +391,45 @@ func (p *Provisioner) Provision(ctx context.Context, ui packer.Ui, comm packer.C
 	return nil
 }
 
+// installFromPackage uploads the configured puppet-agent installer
+// package to the staging directory and installs it with the guest's
+// package manager, so air-gapped builds never reach out to puppetlabs.
+func (p *Provisioner) installFromPackage(ui packer.Ui, comm packer.Communicator) error {
+	ui.Message(fmt.Sprintf(
+		"Installing Puppet from local package %s...", p.config.InstallPackagePath))
+
+	remotePath := fmt.Sprintf("%s/%s",
+		p.config.StagingDir, filepath.Base(p.config.InstallPackagePath))
+
+	f, err := os.Open(p.config.InstallPackagePath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if err := comm.Upload(remotePath, f, nil); err != nil {
+		return err
+	}
+
+	command, err := provisioner.PackageInstallCommand(remotePath, !p.config.PreventSudo)
+	if err != nil {
+		return err
+	}
+
+	cmd := &packer.RemoteCmd{Command: command}
+	ctx := context.TODO()
+	if err := cmd.RunWithUi(ctx, comm, ui); err != nil {
+		return err
+	}
+
+	if cmd.ExitStatus() != 0 {
+		return fmt.Errorf(
+			"Package install exited with non-zero exit status %d", cmd.ExitStatus())
+	}
+
+	return nil
+}
+
 func (p *Provisioner) uploadHieraConfig(ui packer.Ui, comm packer.Communicator) (string, error) {
 	ui.Message("Uploading hiera configuration...")
 	f, err := os.Open(p.config.HieraConfigPath)
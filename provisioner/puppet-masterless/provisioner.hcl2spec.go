@@ -26,6 +26,7 @@ type FlatConfig struct {
 	ModulePaths         []string          `mapstructure:"module_paths" cty:"module_paths"`
 	ManifestFile        *string           `mapstructure:"manifest_file" cty:"manifest_file"`
 	ManifestDir         *string           `mapstructure:"manifest_dir" cty:"manifest_dir"`
+	InstallPackagePath  *string           `mapstructure:"install_package_path" cty:"install_package_path"`
 	PreventSudo         *bool             `mapstructure:"prevent_sudo" cty:"prevent_sudo"`
 	PuppetBinDir        *string           `mapstructure:"puppet_bin_dir" cty:"puppet_bin_dir"`
 	StagingDir          *string           `mapstructure:"staging_directory" cty:"staging_directory"`
@@ -63,6 +64,7 @@ func (*FlatConfig) HCL2Spec() map[string]hcldec.Spec {
 		"module_paths":               &hcldec.AttrSpec{Name: "module_paths", Type: cty.List(cty.String), Required: false},
 		"manifest_file":              &hcldec.AttrSpec{Name: "manifest_file", Type: cty.String, Required: false},
 		"manifest_dir":               &hcldec.AttrSpec{Name: "manifest_dir", Type: cty.String, Required: false},
+		"install_package_path":       &hcldec.AttrSpec{Name: "install_package_path", Type: cty.String, Required: false},
 		"prevent_sudo":               &hcldec.AttrSpec{Name: "prevent_sudo", Type: cty.Bool, Required: false},
 		"puppet_bin_dir":             &hcldec.AttrSpec{Name: "puppet_bin_dir", Type: cty.String, Required: false},
 		"staging_directory":          &hcldec.AttrSpec{Name: "staging_directory", Type: cty.String, Required: false},
@@ -429,6 +429,12 @@ func (p *Provisioner) escapeEnvVars() ([]string, map[string]string) {
 		envVars["PACKER_HTTP_PORT"] = httpPort.(string)
 	}
 
+	// Template-level env, overridable by this provisioner's
+	// environment_vars
+	for k, v := range p.config.PackerEnv {
+		envVars[k] = strings.Replace(v, "'", `'"'"'`, -1)
+	}
+
 	// Split vars into key/value components
 	for _, envVar := range p.config.Vars {
 		keyValue := strings.SplitN(envVar, "=", 2)
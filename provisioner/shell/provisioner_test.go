@@ -288,6 +288,32 @@ func TestProvisioner_createFlattenedEnvVars(t *testing.T) {
 	}
 }
 
+func TestProvisioner_createFlattenedEnvVars_packerEnv(t *testing.T) {
+	config := testConfig()
+
+	p := new(Provisioner)
+	p.generatedData = generatedData()
+	p.Prepare(config)
+
+	// Defaults provided by Packer
+	p.config.PackerBuildName = "vmware"
+	p.config.PackerBuilderType = "iso"
+
+	// Template-level env is exported...
+	p.config.PackerEnv = map[string]string{"HTTP_PROXY": "http://proxy:3128"}
+	expected := `HTTP_PROXY='http://proxy:3128' PACKER_BUILDER_TYPE='iso' PACKER_BUILD_NAME='vmware' `
+	if flattened := p.createFlattenedEnvVars(); flattened != expected {
+		t.Fatalf("expected flattened env vars to be: %s, got %s.", expected, flattened)
+	}
+
+	// ...but this provisioner's environment_vars win.
+	p.config.Vars = []string{"HTTP_PROXY=direct"}
+	expected = `HTTP_PROXY='direct' PACKER_BUILDER_TYPE='iso' PACKER_BUILD_NAME='vmware' `
+	if flattened := p.createFlattenedEnvVars(); flattened != expected {
+		t.Fatalf("expected flattened env vars to be: %s, got %s.", expected, flattened)
+	}
+}
+
 func TestProvisioner_createFlattenedEnvVars_withEnvVarFormat(t *testing.T) {
 	var flattenedEnvVars string
 	config := testConfig()
@@ -252,6 +252,12 @@ func (p *Provisioner) createFlattenedEnvVars() (flattened string) {
 		envVars["PACKER_HTTP_PORT"] = httpPort.(string)
 	}
 
+	// Template-level env, overridable by this provisioner's
+	// environment_vars
+	for k, v := range p.config.PackerEnv {
+		envVars[k] = v
+	}
+
 	// Split vars into key/value components
 	for _, envVar := range p.config.Vars {
 		keyValue := strings.SplitN(envVar, "=", 2)
@@ -2,9 +2,12 @@ package interpolate
 
 import (
 	"bytes"
+	"fmt"
+	"reflect"
 	"regexp"
 	"strings"
 	"text/template"
+	"text/template/parse"
 
 	"github.com/google/uuid"
 )
@@ -96,6 +99,93 @@ func Validate(v string, ctx *Context) error {
 	return (&I{Value: v}).Validate(ctx)
 }
 
+// ValidateTemplateFields validates that the template is syntactically
+// valid and that every top-level `.Field` reference in it exists on
+// ctx.Data. It never executes the template, so template functions with
+// side effects are not run. This allows typo'd placeholders to be
+// reported when a configuration is prepared, instead of failing - or
+// worse, rendering as literal text - in the middle of a build.
+func ValidateTemplateFields(v string, ctx *Context) error {
+	tpl, err := (&I{Value: v}).template(ctx)
+	if err != nil {
+		return err
+	}
+
+	if ctx == nil || ctx.Data == nil {
+		return nil
+	}
+	dataType := reflect.TypeOf(ctx.Data)
+	for dataType.Kind() == reflect.Ptr {
+		dataType = dataType.Elem()
+	}
+	if dataType.Kind() != reflect.Struct {
+		return nil
+	}
+
+	var walk func(n parse.Node) error
+	walk = func(n parse.Node) error {
+		switch n := n.(type) {
+		case *parse.ListNode:
+			if n == nil {
+				return nil
+			}
+			for _, child := range n.Nodes {
+				if err := walk(child); err != nil {
+					return err
+				}
+			}
+		case *parse.ActionNode:
+			return walk(n.Pipe)
+		case *parse.PipeNode:
+			for _, cmd := range n.Cmds {
+				if err := walk(cmd); err != nil {
+					return err
+				}
+			}
+		case *parse.CommandNode:
+			for _, arg := range n.Args {
+				if err := walk(arg); err != nil {
+					return err
+				}
+			}
+		case *parse.BranchNode:
+			if err := walk(n.Pipe); err != nil {
+				return err
+			}
+			if err := walk(n.List); err != nil {
+				return err
+			}
+			return walk(n.ElseList)
+		case *parse.IfNode:
+			return walk(&n.BranchNode)
+		case *parse.RangeNode:
+			return walk(&n.BranchNode)
+		case *parse.WithNode:
+			return walk(&n.BranchNode)
+		case *parse.FieldNode:
+			// Only the first identifier can be checked against the data
+			// struct; deeper identifiers may index maps whose keys are
+			// not known until the template is executed.
+			if _, ok := dataType.FieldByName(n.Ident[0]); !ok {
+				return fmt.Errorf(
+					"unknown placeholder %q; available fields are: %s",
+					n.String(), strings.Join(fieldNames(dataType), ", "))
+			}
+		}
+		return nil
+	}
+
+	return walk(tpl.Tree.Root)
+}
+
+func fieldNames(t reflect.Type) []string {
+	names := make([]string, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		names = append(names, "."+t.Field(i).Name)
+	}
+	return names
+}
+
 // I stands for "interpolation" and is the main interpolation struct
 // in order to render values.
 type I struct {
@@ -30,3 +30,32 @@ func TestIRender(t *testing.T) {
 		}
 	}
 }
+
+func TestValidateTemplateFields(t *testing.T) {
+	type data struct {
+		SourceAMI string
+	}
+	ctx := &Context{Data: &data{}}
+
+	for _, valid := range []string{
+		"plain",
+		"{{ .SourceAMI }}",
+		"{{ timestamp }}",
+	} {
+		if err := ValidateTemplateFields(valid, ctx); err != nil {
+			t.Fatalf("%q should validate, got: %s", valid, err)
+		}
+	}
+
+	if err := ValidateTemplateFields("{{ .SourceAmi }}", ctx); err == nil {
+		t.Fatal("expected an error for an unknown placeholder")
+	}
+	if err := ValidateTemplateFields("{{ bogus_func }}", ctx); err == nil {
+		t.Fatal("expected an error for an unknown function")
+	}
+
+	// Without data, only the syntax can be checked.
+	if err := ValidateTemplateFields("{{ .Whatever }}", &Context{}); err != nil {
+		t.Fatalf("expected no error without data, got: %s", err)
+	}
+}
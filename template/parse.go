@@ -8,6 +8,7 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"regexp"
 	"sort"
 	"strings"
 
@@ -20,8 +21,9 @@ import (
 // This is what is decoded directly from the file, and then it is turned
 // into a Template object thereafter.
 type rawTemplate struct {
-	MinVersion  string `mapstructure:"min_packer_version" json:"min_packer_version,omitempty"`
-	Description string `json:"description,omitempty"`
+	MinVersion       string   `mapstructure:"min_packer_version" json:"min_packer_version,omitempty"`
+	RequiredFeatures []string `mapstructure:"required_features" json:"required_features,omitempty"`
+	Description      string   `json:"description,omitempty"`
 
 	Builders           []interface{}          `mapstructure:"builders" json:"builders,omitempty"`
 	Comments           []map[string]string    `json:"comments,omitempty"`
@@ -31,6 +33,7 @@ type rawTemplate struct {
 	CleanupProvisioner interface{}            `mapstructure:"error-cleanup-provisioner" json:"error-cleanup-provisioner,omitempty"`
 	Variables          map[string]interface{} `json:"variables,omitempty"`
 	SensitiveVariables []string               `mapstructure:"sensitive-variables" json:"sensitive-variables,omitempty"`
+	Env                map[string]string      `json:"env,omitempty"`
 
 	RawContents []byte `json:"-"`
 }
@@ -95,6 +98,8 @@ func (r *rawTemplate) Template() (*Template, error) {
 	// Copy some literals
 	result.Description = r.Description
 	result.MinVersion = r.MinVersion
+	result.RequiredFeatures = r.RequiredFeatures
+	result.Env = r.Env
 	result.RawContents = r.RawContents
 
 	// Gather the comments
@@ -120,8 +125,15 @@ func (r *rawTemplate) Template() (*Template, error) {
 		// Variable is required if the value is exactly nil
 		v.Required = rawV == nil
 
-		// Weak decode the default if we have one
-		if err := r.decoder(&v.Default, nil).Decode(rawV); err != nil {
+		if m, ok := rawV.(map[string]interface{}); ok {
+			// The object form carries a type, validation rule and default
+			if err := r.decodeVariable(&v, m); err != nil {
+				errs = multierror.Append(errs, fmt.Errorf(
+					"variable %s: %s", k, err))
+				continue
+			}
+		} else if err := r.decoder(&v.Default, nil).Decode(rawV); err != nil {
+			// Weak decode the default if we have one
 			errs = multierror.Append(errs, fmt.Errorf(
 				"variable %s: %s", k, err))
 			continue
@@ -153,6 +165,7 @@ func (r *rawTemplate) Template() (*Template, error) {
 
 		delete(b.Config, "name")
 		delete(b.Config, "type")
+		delete(b.Config, "depends_on")
 
 		if len(b.Config) == 0 {
 			b.Config = nil
@@ -270,6 +283,69 @@ func (r *rawTemplate) Template() (*Template, error) {
 	return &result, nil
 }
 
+// decodeVariable decodes the object form of a variable definition, which
+// carries a type, default value and validation rule in addition to what the
+// plain string form can express.
+func (r *rawTemplate) decodeVariable(v *Variable, raw map[string]interface{}) error {
+	var def struct {
+		Type         string
+		Default      interface{}
+		Required     bool
+		Validation   string
+		ErrorMessage string `mapstructure:"error_message"`
+	}
+	var md mapstructure.Metadata
+	if err := r.decoder(&def, &md).Decode(raw); err != nil {
+		return err
+	}
+	if len(md.Unused) > 0 {
+		sort.Strings(md.Unused)
+		return fmt.Errorf("unknown keys: %s", strings.Join(md.Unused, ", "))
+	}
+
+	switch def.Type {
+	case "", VariableTypeString, VariableTypeNumber, VariableTypeBool,
+		VariableTypeList, VariableTypeMap:
+	default:
+		return fmt.Errorf("unknown type: %s", def.Type)
+	}
+
+	if def.Validation != "" {
+		if _, err := regexp.Compile(def.Validation); err != nil {
+			return fmt.Errorf(
+				"validation is not a valid regular expression: %s", err)
+		}
+	}
+
+	v.Type = def.Type
+	v.Validation = def.Validation
+	v.ErrorMessage = def.ErrorMessage
+	v.Required = def.Required || def.Default == nil
+
+	if def.Default != nil {
+		switch def.Default.(type) {
+		case []interface{}, map[string]interface{}:
+			// Lists and maps are carried in their JSON form
+			b, err := json.Marshal(def.Default)
+			if err != nil {
+				return err
+			}
+			v.Default = string(b)
+		default:
+			if err := mapstructure.WeakDecode(def.Default, &v.Default); err != nil {
+				return err
+			}
+		}
+
+		// The default must obey the rules of the variable itself
+		if err := v.ValidateValue(v.Default); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (r *rawTemplate) decoder(
 	result interface{},
 	md *mapstructure.Metadata) *mapstructure.Decoder {
@@ -64,6 +64,23 @@ func TestParse(t *testing.T) {
 			nil,
 			true,
 		},
+		{
+			"parse-builder-depends-on.json",
+			&Template{
+				Builders: map[string]*Builder{
+					"base": {
+						Name: "base",
+						Type: "base",
+					},
+					"app": {
+						Name:      "app",
+						Type:      "app",
+						DependsOn: []string{"base"},
+					},
+				},
+			},
+			false,
+		},
 
 		/*
 		 * Provisioners
@@ -183,6 +200,22 @@ func TestParse(t *testing.T) {
 			true,
 		},
 
+		{
+			"parse-env.json",
+			&Template{
+				Env: map[string]string{
+					"HTTP_PROXY": "http://proxy:3128",
+				},
+				Builders: map[string]*Builder{
+					"something": {
+						Name: "something",
+						Type: "something",
+					},
+				},
+			},
+			false,
+		},
+
 		{
 			"parse-variable-default.json",
 			&Template{
@@ -209,6 +242,43 @@ func TestParse(t *testing.T) {
 			false,
 		},
 
+		{
+			"parse-variable-typed.json",
+			&Template{
+				Variables: map[string]*Variable{
+					"count": {
+						Key:     "count",
+						Type:    "number",
+						Default: "2",
+					},
+					"zones": {
+						Key:     "zones",
+						Type:    "list",
+						Default: `["a","b"]`,
+					},
+					"region": {
+						Key:          "region",
+						Default:      "us-east-1",
+						Validation:   "us-(east|west)-[12]",
+						ErrorMessage: "region must be a US region",
+					},
+				},
+			},
+			false,
+		},
+
+		{
+			"parse-variable-bad-type.json",
+			nil,
+			true,
+		},
+
+		{
+			"parse-variable-bad-default.json",
+			nil,
+			true,
+		},
+
 		{
 			"parse-pp-basic.json",
 			&Template{
@@ -6,6 +6,10 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 
 	multierror "github.com/hashicorp/go-multierror"
@@ -21,9 +25,18 @@ type Template struct {
 	Description string
 	MinVersion  string
 
+	// RequiredFeatures lists named Packer capabilities the template needs,
+	// so running it on a version that lacks them fails with a clear error.
+	RequiredFeatures []string
+
 	Comments           map[string]string
 	Variables          map[string]*Variable
 	SensitiveVariables []*Variable
+
+	// Env is a map of environment variables exported to every shell-based
+	// provisioner of the template, so they do not have to be repeated in
+	// each provisioner's environment_vars.
+	Env                map[string]string
 	Builders           map[string]*Builder
 	Provisioners       []*Provisioner
 	CleanupProvisioner *Provisioner
@@ -38,6 +51,7 @@ func (t *Template) Raw() (*rawTemplate, error) {
 	var out rawTemplate
 
 	out.MinVersion = t.MinVersion
+	out.RequiredFeatures = t.RequiredFeatures
 	out.Description = t.Description
 
 	for k, v := range t.Comments {
@@ -60,6 +74,8 @@ func (t *Template) Raw() (*rawTemplate, error) {
 		out.SensitiveVariables = append(out.SensitiveVariables, v.Key)
 	}
 
+	out.Env = t.Env
+
 	for k, v := range t.Variables {
 		if out.Variables == nil {
 			out.Variables = make(map[string]interface{})
@@ -76,6 +92,13 @@ type Builder struct {
 	Name   string                 `json:"name,omitempty"`
 	Type   string                 `json:"type"`
 	Config map[string]interface{} `json:"config,omitempty"`
+
+	// DependsOn lists the names of builds whose artifacts this build
+	// consumes. Dependent builds only start once every listed build has
+	// finished successfully, and the artifact ID of each dependency is
+	// made available to this build as the user variable
+	// "artifact.<name>".
+	DependsOn []string `mapstructure:"depends_on" json:"depends_on,omitempty"`
 }
 
 // MarshalJSON conducts the necessary flattening of the Builder struct
@@ -176,14 +199,53 @@ type Push struct {
 	VCS     bool
 }
 
+// The valid values of Variable.Type. An empty type behaves like
+// VariableTypeString.
+const (
+	VariableTypeString = "string"
+	VariableTypeNumber = "number"
+	VariableTypeBool   = "bool"
+	VariableTypeList   = "list"
+	VariableTypeMap    = "map"
+)
+
 // Variable represents a variable within the template
 type Variable struct {
 	Key      string
 	Default  string
 	Required bool
+
+	// Type constrains the values of the variable. List and map values are
+	// carried in their JSON form.
+	Type string
+
+	// Validation is a regular expression the whole final value must match.
+	// ErrorMessage, when set, replaces the generic mismatch error.
+	Validation   string
+	ErrorMessage string
 }
 
 func (v *Variable) MarshalJSON() ([]byte, error) {
+	if v.Type != "" || v.Validation != "" {
+		def := map[string]interface{}{
+			"default": v.Default,
+		}
+		if v.Required {
+			def["required"] = true
+			delete(def, "default")
+		}
+		if v.Type != "" {
+			def["type"] = v.Type
+		}
+		if v.Validation != "" {
+			def["validation"] = v.Validation
+		}
+		if v.ErrorMessage != "" {
+			def["error_message"] = v.ErrorMessage
+		}
+		return json.Marshal(def)
+	}
+
 	if v.Required {
 		// We use a nil pointer to coax Go into marshalling it as a JSON null
 		var ret *string
@@ -193,6 +255,53 @@ func (v *Variable) MarshalJSON() ([]byte, error) {
 	return json.Marshal(v.Default)
 }
 
+// ValidateValue checks that value conforms to the type of the variable and
+// to its validation rule, if any. List and map values are expected in their
+// JSON form.
+func (v *Variable) ValidateValue(value string) error {
+	switch v.Type {
+	case "", VariableTypeString:
+	case VariableTypeNumber:
+		if _, err := strconv.ParseFloat(value, 64); err != nil {
+			return fmt.Errorf("value %q is not a number", value)
+		}
+	case VariableTypeBool:
+		if _, err := strconv.ParseBool(value); err != nil {
+			return fmt.Errorf("value %q is not a bool", value)
+		}
+	case VariableTypeList:
+		var l []interface{}
+		if err := json.Unmarshal([]byte(value), &l); err != nil {
+			return fmt.Errorf("value %q is not a JSON list", value)
+		}
+	case VariableTypeMap:
+		var m map[string]interface{}
+		if err := json.Unmarshal([]byte(value), &m); err != nil {
+			return fmt.Errorf("value %q is not a JSON map", value)
+		}
+	default:
+		return fmt.Errorf("unknown type %q", v.Type)
+	}
+
+	if v.Validation != "" {
+		re, err := regexp.Compile("^(?:" + v.Validation + ")$")
+		if err != nil {
+			return fmt.Errorf(
+				"validation is not a valid regular expression: %s", err)
+		}
+		if !re.MatchString(value) {
+			if v.ErrorMessage != "" {
+				return errors.New(v.ErrorMessage)
+			}
+			return fmt.Errorf(
+				"value %q does not match validation %q",
+				value, v.Validation)
+		}
+	}
+
+	return nil
+}
+
 // OnlyExcept is a struct that is meant to be embedded that contains the
 // logic required for "only" and "except" meta-parameters.
 type OnlyExcept struct {
@@ -218,6 +327,29 @@ func (t *Template) Validate() error {
 			"at least one builder must be defined"))
 	}
 
+	// Verify that build dependencies reference builders that exist and
+	// don't form cycles.
+	for _, b := range t.Builders {
+		for _, d := range b.DependsOn {
+			if d == b.Name {
+				err = multierror.Append(err, fmt.Errorf(
+					"builder '%s': cannot depend on itself", b.Name))
+				continue
+			}
+
+			if _, ok := t.Builders[d]; !ok {
+				err = multierror.Append(err, fmt.Errorf(
+					"builder '%s': 'depends_on' build '%s' not found",
+					b.Name, d))
+			}
+		}
+	}
+	if cycle := t.dependencyCycle(); len(cycle) > 0 {
+		err = multierror.Append(err, fmt.Errorf(
+			"dependency cycle between builds: %s",
+			strings.Join(cycle, " -> ")))
+	}
+
 	// Verify that the provisioner overrides target builders that exist
 	for i, p := range t.Provisioners {
 		// Validate only/except
@@ -254,6 +386,55 @@ func (t *Template) Validate() error {
 	return err
 }
 
+// dependencyCycle returns a cycle in the 'depends_on' graph between the
+// builds of the template, or nil if the graph is acyclic. Dependencies on
+// builds that don't exist are ignored; they are reported separately.
+func (t *Template) dependencyCycle() []string {
+	// Builds on the current DFS path, so a revisit means a cycle.
+	onPath := make(map[string]bool)
+	visited := make(map[string]bool)
+
+	var visit func(n string, path []string) []string
+	visit = func(n string, path []string) []string {
+		if onPath[n] {
+			return append(path, n)
+		}
+		if visited[n] {
+			return nil
+		}
+
+		visited[n] = true
+		onPath[n] = true
+		defer delete(onPath, n)
+
+		b := t.Builders[n]
+		if b == nil {
+			return nil
+		}
+		for _, d := range b.DependsOn {
+			if cycle := visit(d, append(path, n)); cycle != nil {
+				return cycle
+			}
+		}
+
+		return nil
+	}
+
+	// Iterate over sorted names so the reported cycle is deterministic.
+	names := make([]string, 0, len(t.Builders))
+	for n := range t.Builders {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	for _, n := range names {
+		if cycle := visit(n, nil); cycle != nil {
+			return cycle
+		}
+	}
+
+	return nil
+}
+
 // Skip says whether or not to skip the build with the given name.
 func (o *OnlyExcept) Skip(n string) bool {
 	if len(o.Only) > 0 {
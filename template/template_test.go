@@ -77,6 +77,21 @@ func TestTemplateValidate(t *testing.T) {
 			"validate-good-pp-except.json",
 			false,
 		},
+
+		{
+			"validate-good-depends-on.json",
+			false,
+		},
+
+		{
+			"validate-bad-depends-on.json",
+			true,
+		},
+
+		{
+			"validate-cycle-depends-on.json",
+			true,
+		},
 	}
 
 	for _, tc := range cases {
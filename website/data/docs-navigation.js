@@ -140,7 +140,7 @@ export default [
   'terminology',
   {
     category: 'commands',
-    content: ['build', 'console', 'fix', 'inspect', 'validate'],
+    content: ['build', 'console', 'fix', 'fmt', 'inspect', 'test', 'validate'],
   },
   {
     category: 'templates',
@@ -161,7 +161,14 @@ export default [
       'alicloud-ecs',
       {
         category: 'amazon',
-        content: ['chroot', 'ebs', 'ebssurrogate', 'ebsvolume', 'instance'],
+        content: [
+          'amifrominstance',
+          'chroot',
+          'ebs',
+          'ebssurrogate',
+          'ebsvolume',
+          'instance',
+        ],
       },
       {
         category: 'azure',
@@ -170,6 +177,7 @@ export default [
       'cloudstack',
       'digitalocean',
       'docker',
+      'exoscale',
       'file',
       'googlecompute',
       'hetzner-cloud',
@@ -221,6 +229,7 @@ export default [
       'converge',
       'file',
       'inspec',
+      'linux-restart',
       'powershell',
       'puppet-masterless',
       'puppet-server',
@@ -247,9 +256,11 @@ export default [
       'docker-save',
       'docker-tag',
       'exoscale-import',
+      'exoscale-replicate',
       'googlecompute-export',
       'googlecompute-import',
       'manifest',
+      'registry-push',
       'shell-local',
       'ucloud-import',
       'vagrant',